@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	auth "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	redisStore "github.com/go-session/redis/v3"
@@ -17,9 +18,11 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -32,6 +35,7 @@ import (
 	"github.com/nexodus-io/nexodus/internal/fflags"
 	"github.com/nexodus-io/nexodus/internal/handlers"
 	"github.com/nexodus-io/nexodus/internal/ipam"
+	"github.com/nexodus-io/nexodus/internal/models"
 	"github.com/nexodus-io/nexodus/internal/routers"
 	"github.com/open-policy-agent/opa/storage/inmem"
 	"go.opentelemetry.io/otel"
@@ -177,6 +181,12 @@ func main() {
 				Usage:   "Address of ipam grpc service",
 				Sources: cli.EnvVars("NEXAPI_IPAM_URL"),
 			},
+			&cli.StringFlag{
+				Name:    "ipam-backend",
+				Value:   "external",
+				Usage:   "IPAM backend: external uses the ipam grpc service at --ipam-address, embedded keeps the ipam state in the apiserver database",
+				Sources: cli.EnvVars("NEXAPI_IPAM_BACKEND"),
+			},
 			&cli.BoolFlag{
 				Name:    "trace-insecure",
 				Value:   false,
@@ -273,6 +283,65 @@ func main() {
 				Required: false,
 				Sources:  cli.EnvVars("NEXAPI_SMTP_FROM"),
 			},
+			&cli.IntFlag{
+				Name:    "quota-devices",
+				Usage:   "The default maximum number of devices of an organization, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_QUOTA_DEVICES"),
+			},
+			&cli.IntFlag{
+				Name:    "quota-vpcs",
+				Usage:   "The default maximum number of VPCs of an organization, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_QUOTA_VPCS"),
+			},
+			&cli.IntFlag{
+				Name:    "quota-reg-keys",
+				Usage:   "The default maximum number of registration keys of an organization, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_QUOTA_REG_KEYS"),
+			},
+			&cli.IntFlag{
+				Name:    "quota-security-groups",
+				Usage:   "The default maximum number of security groups of an organization, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_QUOTA_SECURITY_GROUPS"),
+			},
+			&cli.IntFlag{
+				Name:    "quota-invitations",
+				Usage:   "The default maximum number of pending invitations of an organization, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_QUOTA_INVITATIONS"),
+			},
+			&cli.IntFlag{
+				Name:    "rate-limit-reads",
+				Usage:   "The maximum number of API requests reading resources per minute and client, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_RATE_LIMIT_READS"),
+			},
+			&cli.IntFlag{
+				Name:    "rate-limit-writes",
+				Usage:   "The maximum number of API requests creating, updating or deleting resources per minute and client, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_RATE_LIMIT_WRITES"),
+			},
+			&cli.IntFlag{
+				Name:    "rate-limit-watches",
+				Usage:   "The maximum number of API requests starting a watch of events per minute and client, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_RATE_LIMIT_WATCHES"),
+			},
+			&cli.IntFlag{
+				Name:    "rate-limit-logins",
+				Usage:   "The maximum number of login and token refresh requests per minute and client, 0 is unlimited",
+				Value:   0,
+				Sources: cli.EnvVars("NEXAPI_RATE_LIMIT_LOGINS"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "trusted-proxies",
+				Usage:   "The addresses or CIDRs of the proxies whose X-Forwarded-For header identifies the clients being rate limited",
+				Sources: cli.EnvVars("NEXAPI_TRUSTED_PROXIES"),
+			},
 			&cli.StringFlag{
 				Name:     "ca-cert",
 				Usage:    "Certificate authority cert",
@@ -302,7 +371,13 @@ func main() {
 				wg := &sync.WaitGroup{}
 				signalBus.Start(ctx, wg)
 
-				ipam := ipam.NewIPAM(logger.Sugar(), command.String("ipam-address"))
+				ipam := newIPAM(command, logger, db)
+				// the embedded ipam starts out empty, it is populated from the database once when switching to it
+				if command.String("ipam-backend") == "embedded" {
+					if err := cmd.Populate(ctx, logger, db, ipam); err != nil {
+						log.Fatal(err)
+					}
+				}
 
 				fflags := fflags.NewFFlags(logger.Sugar())
 
@@ -357,6 +432,18 @@ func main() {
 				}
 				api.SmtpServer = smtpServer
 				api.SmtpFrom = command.String("smtp-from")
+				api.DefaultQuotas = models.Quotas{
+					Devices:        command.Int("quota-devices"),
+					Vpcs:           command.Int("quota-vpcs"),
+					RegKeys:        command.Int("quota-reg-keys"),
+					SecurityGroups: command.Int("quota-security-groups"),
+					Invitations:    command.Int("quota-invitations"),
+				}
+				for _, resource := range []string{models.QuotaDevices, models.QuotaVpcs, models.QuotaRegKeys, models.QuotaSecurityGroups, models.QuotaInvitations} {
+					if api.DefaultQuotas.Quota(resource) < 0 {
+						log.Fatalf("invalid --quota-%s value: must not be negative", strings.ReplaceAll(resource, "_", "-"))
+					}
+				}
 
 				scopes := []string{"openid", "profile", "email"}
 				scopes = append(scopes, command.StringSlice("scopes")...)
@@ -405,6 +492,19 @@ func main() {
 					log.Fatal(fmt.Errorf("invalid tls-key: %w", err))
 				}
 
+				var trustedProxies []netip.Prefix
+				for _, proxy := range command.StringSlice("trusted-proxies") {
+					prefix, err := netip.ParsePrefix(proxy)
+					if err != nil {
+						addr, addrErr := netip.ParseAddr(proxy)
+						if addrErr != nil {
+							log.Fatal(fmt.Errorf("invalid trusted-proxies: %w", err))
+						}
+						prefix = netip.PrefixFrom(addr, addr.BitLen())
+					}
+					trustedProxies = append(trustedProxies, prefix)
+				}
+
 				router, err := routers.NewAPIRouter(ctx, routers.APIRouterOptions{
 					Logger:          logger.Sugar(),
 					Api:             api,
@@ -417,6 +517,13 @@ func main() {
 					DeviceFlow:      cliAuth,
 					Store:           store,
 					SessionStore:    sessionStore,
+					RateLimits: routers.RateLimits{
+						Reads:   command.Int("rate-limit-reads"),
+						Writes:  command.Int("rate-limit-writes"),
+						Watches: command.Int("rate-limit-watches"),
+						Logins:  command.Int("rate-limit-logins"),
+					},
+					TrustedProxies: trustedProxies,
 				})
 				if err != nil {
 					log.Fatal(err)
@@ -522,17 +629,58 @@ func main() {
 			{
 				Name:  "rebuild",
 				Usage: "Rebuild the IPAM service using the allocated ips and cidrs in nexodus database",
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "grace-period",
+						Value: cmd.DefaultLeakGracePeriod,
+						Usage: "How long the leaks are left alone before they are checked again and released",
+					},
+				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 
 					withLoggerAndDB(ctx, command, func(logger *zap.Logger, db *gorm.DB, dsn string) {
-						ipam := ipam.NewIPAM(logger.Sugar(), command.String("ipam-address"))
-						if err := cmd.Rebuild(ctx, logger, db, ipam); err != nil {
+						ipam := newIPAM(command, logger, db)
+						if err := cmd.Rebuild(ctx, logger, db, ipam, command.Duration("grace-period")); err != nil {
 							log.Fatal(err)
 						}
 					})
 					return nil
 				},
 			},
+			{
+				Name:  "verify",
+				Usage: "Report the differences between the IPAM service and the allocated ips and cidrs in nexodus database",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "repair",
+						Usage: "Repair the differences",
+					},
+					&cli.DurationFlag{
+						Name:  "grace-period",
+						Value: cmd.DefaultLeakGracePeriod,
+						Usage: "How long a repair leaves the leaks alone before they are checked again and released",
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+
+					withLoggerAndDB(ctx, command, func(logger *zap.Logger, db *gorm.DB, dsn string) {
+						ipam := newIPAM(command, logger, db)
+						report, err := cmd.Verify(ctx, logger, db, ipam, command.Bool("repair"), command.Duration("grace-period"))
+						if err != nil {
+							log.Fatal(err)
+						}
+						out, err := json.MarshalIndent(report, "", "  ")
+						if err != nil {
+							log.Fatal(err)
+						}
+						fmt.Println(string(out))
+						if !report.Consistent() && !report.Repaired {
+							os.Exit(1)
+						}
+					})
+					return nil
+				},
+			},
 			{
 				Name:  "clear",
 				Usage: "Clear the IPAM db",
@@ -620,6 +768,22 @@ func getLogger(command *cli.Command) *zap.Logger {
 	}
 	return logger
 }
+func newIPAM(command *cli.Command, logger *zap.Logger, db *gorm.DB) ipam.IPAM {
+	switch backend := command.String("ipam-backend"); backend {
+	case "external":
+		return ipam.NewIPAM(logger.Sugar(), command.String("ipam-address"))
+	case "embedded":
+		embedded, err := ipam.NewEmbeddedIPAM(logger.Sugar(), db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return embedded
+	default:
+		log.Fatalf("invalid ipam backend %q, must be external or embedded", backend)
+		return nil
+	}
+}
+
 func withLoggerAndDB(ctx context.Context, command *cli.Command, f func(logger *zap.Logger, db *gorm.DB, dsn string)) {
 	logger := getLogger(command)
 	cleanup := initTracer(logger.Sugar(), command.Bool("trace-insecure"), command.String("trace-endpoint"))
@@ -0,0 +1,70 @@
+//go:build linux || darwin || windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+)
+
+func cmdLocalCapture(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+
+	file := command.String("write")
+	if file == "-" && term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("Refusing to write the capture to a terminal, use -w <file> or pipe it to a packet analyzer\n")
+	}
+
+	query := url.Values{}
+	if peer := command.String("peer"); peer != "" {
+		query.Set("peer", peer)
+	}
+	if filter := command.String("filter"); filter != "" {
+		query.Set("filter", filter)
+	}
+	if command.IsSet("snaplen") {
+		query.Set("snaplen", strconv.FormatInt(command.Int("snaplen"), 10))
+	}
+	if command.IsSet("count") {
+		query.Set("count", strconv.FormatInt(command.Int("count"), 10))
+	}
+	path := "/capture"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := nexdRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to capture packets: %w\n", err)
+	}
+	defer resp.Body.Close()
+
+	out := io.Writer(os.Stdout)
+	if file != "-" {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+		fmt.Fprintf(os.Stderr, "Capturing packets to %s, press Ctrl-C to stop\n", file)
+	}
+
+	n, err := io.Copy(out, resp.Body)
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("Failed to capture packets: %w\n", err)
+	}
+	if file != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %d bytes to %s\n", n, file)
+	}
+	return nil
+}
@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"time"
@@ -42,7 +42,7 @@ func keepaliveStatusTableFields(command *cli.Command) []TableField {
 
 // cmdConnStatus check the reachability of the node's peers and sort the return by hostname
 func cmdConnStatus(ctx context.Context, command *cli.Command, family string) error {
-	if err := checkVersion(); err != nil {
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 
@@ -55,7 +55,7 @@ func cmdConnStatus(ctx context.Context, command *cli.Command, family string) err
 		s.Start()
 	}
 
-	result, err := callNexdKeepalives(family)
+	result, err := callNexdKeepalives(ctx, family)
 	if err != nil {
 		// clear spinner on error return
 		fmt.Print("\r \r")
@@ -80,28 +80,11 @@ func cmdConnStatus(ctx context.Context, command *cli.Command, family string) err
 	return nil
 }
 
-// callNexdKeepalives call the Connectivity ctl methods in nexd agent
-func callNexdKeepalives(family string) (api.PingPeersResponse, error) {
+// callNexdKeepalives call the connectivity probe of the nexd agent
+func callNexdKeepalives(ctx context.Context, family string) (api.PingPeersResponse, error) {
 	var result api.PingPeersResponse
-	var keepaliveJson string
-	var err error
-
-	if family == v6 {
-		keepaliveJson, err = callNexd("ConnectivityV6", "")
-		if err != nil {
-			return result, fmt.Errorf("Failed to get nexd connectivity status: %w\n", err)
-		}
-	} else {
-		keepaliveJson, err = callNexd("ConnectivityV4", "")
-		if err != nil {
-			return result, fmt.Errorf("Failed to get nexd connectivity status: %w\n", err)
-		}
+	if err := nexdApi(ctx, http.MethodGet, "/connectivity/"+family, nil, &result); err != nil {
+		return result, fmt.Errorf("Failed to get nexd connectivity status: %w\n", err)
 	}
-
-	err = json.Unmarshal([]byte(keepaliveJson), &result)
-	if err != nil {
-		return result, fmt.Errorf("Failed to get unmarshall connecitivty results: %w\n", err)
-	}
-
 	return result, nil
 }
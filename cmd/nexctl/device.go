@@ -76,6 +76,18 @@ func createDeviceCommand() *cli.Command {
 						Name:     "hostname",
 						Required: false,
 					},
+					&cli.StringFlag{
+						Name:  "vpc-id",
+						Usage: "move the device to another VPC, which assigns it new tunnel IPs and the default security group of the VPC",
+					},
+					&cli.BoolFlag{
+						Name:  "hub",
+						Usage: "make the device a hub of its VPC, which peers with every device when the VPC is not a full mesh",
+					},
+					&cli.StringSliceFlag{
+						Name:  "peering-group",
+						Usage: "set the peering groups of the device, which peers with the devices sharing a group when the VPC topology is peering-groups",
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 
@@ -96,6 +108,19 @@ func createDeviceCommand() *cli.Command {
 						}
 						update.SecurityGroupId = client.PtrString(value)
 					}
+					if command.IsSet("vpc-id") {
+						value, err := getUUID(command, "vpc-id")
+						if err != nil {
+							return err
+						}
+						update.VpcId = client.PtrString(value)
+					}
+					if command.IsSet("hub") {
+						update.Hub = client.PtrBool(command.Bool("hub"))
+					}
+					if command.IsSet("peering-group") {
+						update.PeeringGroups = command.StringSlice("peering-group")
+					}
 					return updateDevice(ctx, command, devID, update)
 				},
 			},
@@ -104,6 +129,16 @@ func createDeviceCommand() *cli.Command {
 				Usage:    "Commands relating to device metadata",
 				Commands: deviceMetadataSubcommands,
 			},
+			{
+				Name:     "routes",
+				Usage:    "Commands relating to the CIDRs advertised by devices",
+				Commands: deviceRoutesSubcommands,
+			},
+			{
+				Name:     "shares",
+				Usage:    "Commands relating to sharing devices into the VPCs of other organizations",
+				Commands: deviceSharesSubcommands,
+			},
 		},
 	}
 }
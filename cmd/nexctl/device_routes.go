@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var deviceRoutesSubcommands []*cli.Command
+
+func init() {
+	deviceRoutesSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the CIDRs advertised by devices and their approval state",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: false,
+				},
+				&cli.BoolFlag{
+					Name:  "pending",
+					Usage: "only list devices with advertised CIDRs that are pending approval",
+					Value: false,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				return listDeviceRoutes(ctx, command, deviceID, command.Bool("pending"))
+			},
+		},
+		{
+			Name:  "approve",
+			Usage: "Approve CIDRs advertised by a device",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "cidr",
+					Usage:    "Advertised `CIDR` to approve",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.DevicesApi.
+					ApproveDeviceRoutes(ctx, deviceID).
+					Routes(client.ModelsDeviceRoutes{Cidrs: command.StringSlice("cidr")}).
+					Execute())
+				show(command, deviceRoutesTableFields(), res)
+				showSuccessfully(command, "approved")
+				return nil
+			},
+		},
+		{
+			Name:  "reject",
+			Usage: "Reject CIDRs advertised by a device",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "cidr",
+					Usage:    "Advertised `CIDR` to reject",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.DevicesApi.
+					RejectDeviceRoutes(ctx, deviceID).
+					Routes(client.ModelsDeviceRoutes{Cidrs: command.StringSlice("cidr")}).
+					Execute())
+				show(command, deviceRoutesTableFields(), res)
+				showSuccessfully(command, "rejected")
+				return nil
+			},
+		},
+	}
+}
+
+// pendingCidrs returns the advertised CIDRs of the device that have been neither approved nor rejected.
+func pendingCidrs(dev client.ModelsDevice) []string {
+	var pending []string
+	for _, cidr := range dev.AdvertiseCidrs {
+		if !slices.Contains(dev.ApprovedCidrs, cidr) && !slices.Contains(dev.RejectedCidrs, cidr) {
+			pending = append(pending, cidr)
+		}
+	}
+	return pending
+}
+
+func deviceRoutesTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "DEVICE ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "HOSTNAME", Field: "Hostname"})
+	fields = append(fields, TableField{Header: "APPROVED", Formatter: func(item interface{}) string {
+		return strings.Join(item.(client.ModelsDevice).ApprovedCidrs, ", ")
+	}})
+	fields = append(fields, TableField{Header: "PENDING", Formatter: func(item interface{}) string {
+		return strings.Join(pendingCidrs(item.(client.ModelsDevice)), ", ")
+	}})
+	fields = append(fields, TableField{Header: "REJECTED", Formatter: func(item interface{}) string {
+		return strings.Join(item.(client.ModelsDevice).RejectedCidrs, ", ")
+	}})
+	return fields
+}
+
+func listDeviceRoutes(ctx context.Context, command *cli.Command, deviceID string, onlyPending bool) error {
+	c := createClient(ctx, command)
+	if deviceID != "" {
+		res := apiResponse(c.DevicesApi.
+			GetDevice(ctx, deviceID).
+			Execute())
+		show(command, deviceRoutesTableFields(), res)
+		return nil
+	}
+	devices := apiResponse(c.DevicesApi.
+		ListDevices(ctx).
+		Execute())
+	var rows []client.ModelsDevice
+	for _, dev := range devices {
+		if len(dev.AdvertiseCidrs) == 0 || (onlyPending && len(pendingCidrs(dev)) == 0) {
+			continue
+		}
+		rows = append(rows, dev)
+	}
+	show(command, deviceRoutesTableFields(), rows)
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var deviceSharesSubcommands []*cli.Command
+
+func init() {
+	deviceSharesSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the VPCs a device is shared into",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.DevicesApi.
+					ListDeviceShares(ctx, deviceID).
+					Execute())
+				show(command, deviceShareTableFields(), res)
+				return nil
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Share a device into a VPC of another organization",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "ID of the VPC to share the device into",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.DevicesApi.
+					CreateDeviceShare(ctx, deviceID).
+					Share(client.ModelsAddDeviceShare{VpcId: client.PtrString(vpcID)}).
+					Execute())
+				show(command, deviceShareTableFields(), res)
+				showSuccessfully(command, "created")
+				return nil
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Revoke the sharing of a device into a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "device-id",
+					Usage:    "Device ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "ID of the VPC the device is shared into",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				deviceID, err := getUUID(command, "device-id")
+				if err != nil {
+					return err
+				}
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.DevicesApi.
+					DeleteDeviceShare(ctx, deviceID, vpcID).
+					Execute())
+				show(command, deviceShareTableFields(), res)
+				showSuccessfully(command, "deleted")
+				return nil
+			},
+		},
+	}
+}
+
+func deviceShareTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "DEVICE ID", Field: "DeviceId"})
+	fields = append(fields, TableField{Header: "VPC ID", Field: "VpcId"})
+	fields = append(fields, TableField{Header: "CREATED AT", Field: "CreatedAt"})
+	return fields
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/urfave/cli/v3"
+)
+
+// cmdLocalDiagnose reports the state of the path to a peer of nexd
+func cmdLocalDiagnose(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+	if command.Args().Len() != 1 {
+		return fmt.Errorf("expected one peer argument: a public key, device id, hostname or tunnel ip")
+	}
+
+	var res api.DiagnosePeerResponse
+	if err := nexdApi(ctx, http.MethodGet, "/peers/"+url.PathEscape(command.Args().First())+"/diagnose", nil, &res); err != nil {
+		return err
+	}
+
+	if command.String("output") != encodeColumn && command.String("output") != encodeNoHeader {
+		show(command, nil, res)
+		return nil
+	}
+	printDiagnoseReport(res)
+	return nil
+}
+
+func printDiagnoseReport(res api.DiagnosePeerResponse) {
+	peer := res.Peer
+	fmt.Printf("Peer:                %s (%s)\n", peer.Hostname, peer.DeviceId)
+	fmt.Printf("Public Key:          %s\n", peer.PublicKey)
+	fmt.Printf("Tunnel IPs:          %s\n", strings.Join(peer.TunnelIPs, ", "))
+	fmt.Printf("Online:              %t\n", peer.Online)
+	fmt.Printf("Relay:               %t\n", peer.Relay)
+	fmt.Printf("Peer Symmetric NAT:  %t\n", peer.SymmetricNat)
+	fmt.Printf("Healthy:             %t\n", peer.Healthy)
+	fmt.Printf("Peering Method:      %s\n", peer.PeeringMethod)
+	fmt.Printf("Configured Endpoint: %s\n", peer.ConfiguredEndpoint)
+	fmt.Printf("Allowed IPs:         %s\n", strings.Join(peer.AllowedIPs, ", "))
+	fmt.Printf("Latest Handshake:    %s\n", peer.HandshakeAge)
+	fmt.Printf("Transmitted:         %d\n", peer.Tx)
+	fmt.Printf("Received:            %d\n", peer.Rx)
+
+	fmt.Printf("\nLocal NAT Type:      %s\n", res.NatType)
+	fmt.Printf("Reflexive Address:   %s\n", res.ReflexiveAddress)
+	fmt.Printf("Relay Required:      %t\n", res.RelayRequired)
+
+	fmt.Printf("\nSTUN Results:\n")
+	for _, stun := range res.StunResults {
+		outcome := stun.Address
+		if stun.Error != "" {
+			outcome = "error: " + stun.Error
+		}
+		fmt.Printf("  %s  %s  %s\n", stun.Time.Format(time.RFC3339), stun.Server, outcome)
+	}
+
+	fmt.Printf("\nEndpoint Candidates:\n")
+	for _, endpoint := range peer.EndpointCandidates {
+		fmt.Printf("  %-20s %s\n", endpoint.Source, endpoint.Address)
+	}
+
+	fmt.Printf("\nPeering History:\n")
+	for _, attempt := range peer.PeeringHistory {
+		fmt.Printf("  %s  %-30s %s\n", attempt.Time.Format(time.RFC3339), attempt.Method, attempt.Endpoint)
+	}
+
+	fmt.Printf("\nRoutes:\n%s\n", res.Routes)
+	fmt.Printf("Firewall:\n%s\n", res.Firewall)
+}
+
+// cmdLocalBugReport writes a redacted tarball with the state and recent logs of nexd
+func cmdLocalBugReport(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+
+	var res api.BugReportResponse
+	if err := nexdApi(ctx, http.MethodGet, "/bugreport", nil, &res); err != nil {
+		return err
+	}
+	res.Files["nexctl-version.txt"] = Version + "\n"
+
+	path := command.String("file")
+	if path == "" {
+		path = fmt.Sprintf("nexd-bugreport-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	}
+	if err := writeBugReport(path, res.Files); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bug report written to %s\n", path)
+	return nil
+}
+
+func writeBugReport(path string, files map[string]string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the bug report: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	dir := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".gz"), ".tar")
+	for _, name := range names {
+		content := []byte(files[name])
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    dir + "/" + name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}); err != nil {
+			return fmt.Errorf("failed to write the bug report: %w", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("failed to write the bug report: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write the bug report: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write the bug report: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,79 @@
+//go:build linux || darwin || windows
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/urfave/cli/v3"
+)
+
+// cmdLocalEvents prints the events of nexd as they happen until interrupted.
+func cmdLocalEvents(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for _, t := range command.StringSlice("type") {
+		query.Add("type", t)
+	}
+	path := "/events"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := nexdRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to watch nexd events: %w\n", err)
+	}
+	defer resp.Body.Close()
+
+	encodeOut := command.String("output")
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if encodeOut == encodeJsonRaw || encodeOut == encodeJsonPretty {
+			fmt.Println(scanner.Text())
+			continue
+		}
+		var event api.NexdEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("Failed to decode nexd event: %w\n", err)
+		}
+		fmt.Println(formatEvent(event))
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+func formatEvent(event api.NexdEvent) string {
+	fields := []string{event.Time.Format(time.RFC3339), event.Type}
+	if event.Hostname != "" {
+		fields = append(fields, "hostname="+event.Hostname)
+	}
+	if event.DeviceId != "" {
+		fields = append(fields, "device="+event.DeviceId)
+	}
+	if event.PublicKey != "" {
+		fields = append(fields, "public-key="+event.PublicKey)
+	}
+	if event.PeeringMethod != "" {
+		fields = append(fields, "peering-method="+event.PeeringMethod)
+	}
+	if event.Status != "" {
+		fields = append(fields, "status="+event.Status)
+	}
+	if event.Message != "" {
+		fields = append(fields, fmt.Sprintf("message=%q", strings.TrimSpace(event.Message)))
+	}
+	return strings.Join(fields, " ")
+}
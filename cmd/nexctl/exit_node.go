@@ -2,53 +2,36 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/api"
 	"github.com/urfave/cli/v3"
 )
 
-type exitNodeOrigin struct {
-	PublicKey           string
-	Endpoint            string
-	AllowedIPs          []string
-	PersistentKeepAlive string
-}
-
 func enableExitNodeClient(ctx context.Context, command *cli.Command) error {
-	if err := checkVersion(); err != nil {
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 
-	result, err := callNexd("EnableExitNodeClient", "")
-	if err != nil {
+	if err := nexdApi(ctx, http.MethodPut, "/exit-node-client", api.ExitNodeClientSetting{Enabled: true}, nil); err != nil {
 		return fmt.Errorf("Failed to enable exit node client: %w\n", err)
 	}
 
-	if result == "null" {
-		fmt.Printf("Successfully enabled exit node client on this device\n")
-		return nil
-	}
-	fmt.Printf("Error encountered  while enabing exit node client: %s\n", result)
-
+	fmt.Printf("Successfully enabled exit node client on this device\n")
 	return nil
 }
 
 func disableExitNodeClient(ctx context.Context, command *cli.Command) error {
-	if err := checkVersion(); err != nil {
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 
-	result, err := callNexd("DisableExitNodeClient", "")
-	if err != nil {
+	if err := nexdApi(ctx, http.MethodPut, "/exit-node-client", api.ExitNodeClientSetting{Enabled: false}, nil); err != nil {
 		return fmt.Errorf("Failed to disable exit node client: %w\n", err)
 	}
 
-	if result == "null" {
-		fmt.Printf("Successfully disabled exit node client on this device\n")
-		return nil
-	}
-	fmt.Printf("Error encountered while enabing exit node client: %s\n", result)
-
+	fmt.Printf("Successfully disabled exit node client on this device\n")
 	return nil
 }
 
@@ -59,22 +42,15 @@ func exitNodeTableFields(command *cli.Command) []TableField {
 	return fields
 }
 func listExitNodes(ctx context.Context, command *cli.Command, encodeOut string) error {
-	var err error
-	var exitNodes []exitNodeOrigin
-	if err = checkVersion(); err != nil {
+	var exitNodes []api.ExitNode
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 
-	result, err := callNexd("ListExitNodes", "")
-	if err != nil {
+	if err := nexdApi(ctx, http.MethodGet, "/exit-nodes", nil, &exitNodes); err != nil {
 		return fmt.Errorf("Failed to list exit nodes: %w\n", err)
 	}
 
-	err = json.Unmarshal([]byte(result), &exitNodes)
-	if err != nil {
-		return fmt.Errorf("Failed to marshall exit node results: %w\n", err)
-	}
-
 	show(command, exitNodeTableFields(command), exitNodes)
 	return nil
 }
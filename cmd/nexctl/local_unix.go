@@ -5,12 +5,13 @@ package main
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/rpc/jsonrpc"
-	"path/filepath"
+	"net/http"
+	"os"
 
 	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/state/fstore"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
 )
 
 func init() {
@@ -44,6 +45,89 @@ func init() {
 				Usage:  "Display the nexd status",
 				Action: cmdLocalStatus,
 			},
+			{
+				Name:  "events",
+				Usage: "Watch the peer and status events of nexd",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "type",
+						Usage:    "Only show events of this `type`: tunnel-up, tunnel-down, tunnel-ip-changed, peer-added, peer-removed, peer-up, peer-down, peering-method-changed, exit-node-changed, status-changed or subnet-changed",
+						Required: false,
+					},
+				},
+				Action: cmdLocalEvents,
+			},
+			{
+				Name:  "capture",
+				Usage: "Capture the decrypted packets of the nexd tunnel in the pcapng format, also in userspace mode",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "write",
+						Aliases: []string{"w"},
+						Usage:   "Write the packets to this `file`, - for the standard output",
+						Value:   "-",
+					},
+					&cli.StringFlag{
+						Name:  "peer",
+						Usage: "Only capture the packets from or to the allowed IPs of this `peer` (public key, device id, hostname or tunnel ip)",
+					},
+					&cli.StringFlag{
+						Name:  "filter",
+						Usage: "Only capture the packets matching this `expression`, a subset of the tcpdump filter syntax: [src|dst] host|net|port, ip, ip6, tcp, udp, icmp and icmp6 combined with and, or, not and parentheses",
+					},
+					&cli.IntFlag{
+						Name:  "snaplen",
+						Usage: "Truncate the captured packets to this many bytes",
+					},
+					&cli.IntFlag{
+						Name:    "count",
+						Aliases: []string{"c"},
+						Usage:   "Stop after capturing this many packets",
+					},
+				},
+				Action: cmdLocalCapture,
+			},
+			{
+				Name:      "diagnose",
+				Usage:     "Diagnose the connection to a peer",
+				ArgsUsage: "<public key | device id | hostname | tunnel ip>",
+				Action:    cmdLocalDiagnose,
+			},
+			{
+				Name:  "bugreport",
+				Usage: "Collect the nexd state, peer diagnostics and recent logs into a redacted tarball",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "file",
+						Usage:    "Path of the tarball to write (default: nexd-bugreport-<timestamp>.tar.gz)",
+						Required: false,
+					},
+				},
+				Action: cmdLocalBugReport,
+			},
+			{
+				Name:   "reload",
+				Usage:  "Reload the nexd configuration file",
+				Action: cmdLocalReload,
+			},
+			{
+				Name:  "state",
+				Usage: "Commands relating to the nexd state",
+				Commands: []*cli.Command{
+					{
+						Name:  "rekey",
+						Usage: "Re-encrypt the nexd state with the key of a key provider, nexd must then be started with the same --state-key-provider",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "key-provider",
+								Usage:    "none, passphrase, passphrase-file:<path>, key-file:<path>, systemd-creds[:<name>] or keyring[:<description>]",
+								Required: true,
+							},
+						},
+						Action: cmdLocalStateRekey,
+					},
+				},
+			},
 			{
 				Name:  "get",
 				Usage: "Get a value from the local nexd instance",
@@ -60,21 +144,19 @@ func init() {
 							},
 						},
 						Action: func(ctx context.Context, command *cli.Command) error {
-							var result string
-							var err error
-							if err := checkVersion(); err != nil {
+							if err := checkVersion(ctx); err != nil {
 								return err
 							}
-							if command.Bool("ipv6") {
-								result, err = callNexd("GetTunnelIPv6", "")
-							} else {
-								result, err = callNexd("GetTunnelIPv4", "")
-							}
-							if err != nil {
+							var status api.StatusResponse
+							if err := nexdApi(ctx, http.MethodGet, "/status", nil, &status); err != nil {
 								fmt.Printf("%s\n", err)
 								return err
 							}
-							fmt.Printf("%s\n", result)
+							if command.Bool("ipv6") {
+								fmt.Printf("%s\n", status.TunnelIPv6)
+							} else {
+								fmt.Printf("%s\n", status.TunnelIPv4)
+							}
 							return nil
 						},
 					},
@@ -82,15 +164,19 @@ func init() {
 						Name:  "debug",
 						Usage: "Get the debug logging status",
 						Action: func(ctx context.Context, command *cli.Command) error {
-							if err := checkVersion(); err != nil {
+							if err := checkVersion(ctx); err != nil {
 								return err
 							}
-							result, err := callNexd("GetDebug", "")
-							if err != nil {
+							var setting api.DebugSetting
+							if err := nexdApi(ctx, http.MethodGet, "/debug", nil, &setting); err != nil {
 								fmt.Printf("%s\n", err)
 								return err
 							}
-							fmt.Printf("%s\n", result)
+							if setting.Enabled {
+								fmt.Printf("on\n")
+							} else {
+								fmt.Printf("off\n")
+							}
 							return nil
 						},
 					},
@@ -108,32 +194,20 @@ func init() {
 								Name:  "on",
 								Usage: "Turn debug logging on",
 								Action: func(ctx context.Context, command *cli.Command) error {
-									if err := checkVersion(); err != nil {
-										return err
-									}
-									result, err := callNexd("SetDebugOn", "")
-									if err != nil {
-										fmt.Printf("%s\n", err)
+									if err := checkVersion(ctx); err != nil {
 										return err
 									}
-									fmt.Printf("%s\n", result)
-									return nil
+									return setDebug(ctx, true)
 								},
 							},
 							{
 								Name:  "off",
 								Usage: "Turn debug logging off",
 								Action: func(ctx context.Context, command *cli.Command) error {
-									if err := checkVersion(); err != nil {
+									if err := checkVersion(ctx); err != nil {
 										return err
 									}
-									result, err := callNexd("SetDebugOff", "")
-									if err != nil {
-										fmt.Printf("%s\n", err)
-										return err
-									}
-									fmt.Printf("%s\n", result)
-									return nil
+									return setDebug(ctx, false)
 								},
 							},
 						},
@@ -148,15 +222,17 @@ func init() {
 						Name:  "list",
 						Usage: "List the nexd proxy rules",
 						Action: func(ctx context.Context, command *cli.Command) error {
-							if err := checkVersion(); err != nil {
+							if err := checkVersion(ctx); err != nil {
 								return err
 							}
-							result, err := callNexd("ProxyList", "")
-							if err != nil {
+							var rules []api.ProxyRule
+							if err := nexdApi(ctx, http.MethodGet, "/proxy-rules", nil, &rules); err != nil {
 								fmt.Printf("%s\n", err)
 								return err
 							}
-							fmt.Printf("%s", result)
+							for _, rule := range rules {
+								fmt.Printf("--%s %s\n", rule.Type, rule.Rule)
+							}
 							return nil
 						},
 					},
@@ -166,12 +242,12 @@ func init() {
 						Flags: []cli.Flag{
 							&cli.StringSliceFlag{
 								Name:     "ingress",
-								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 								Required: false,
 							},
 							&cli.StringSliceFlag{
 								Name:     "egress",
-								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 								Required: false,
 							},
 						},
@@ -185,12 +261,12 @@ func init() {
 						Flags: []cli.Flag{
 							&cli.StringSliceFlag{
 								Name:     "ingress",
-								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 								Required: false,
 							},
 							&cli.StringSliceFlag{
 								Name:     "egress",
-								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 								Required: false,
 							},
 						},
@@ -274,35 +350,14 @@ func init() {
 	})
 }
 
-func callNexd(method string, arg string) (string, error) {
-	conn, err := net.Dial("unix", api.UnixSocketPath)
-	if err != nil {
-		conn, err = net.Dial("unix", filepath.Base(api.UnixSocketPath))
-		if err != nil {
-			return "", fmt.Errorf("Failed to connect to nexd: %w\n", err)
-		}
-	}
-	defer conn.Close()
-
-	client := jsonrpc.NewClient(conn)
-
-	var result string
-	err = client.Call("NexdCtl."+method, arg, &result)
-	if err != nil {
-		return "", fmt.Errorf("Failed to execute method (%s): %w\n", method, err)
-	}
-
-	return result, nil
-}
-
-func checkVersion() error {
-	result, err := callNexd("Version", "")
-	if err != nil {
+func checkVersion(ctx context.Context) error {
+	var version api.VersionResponse
+	if err := nexdApi(ctx, http.MethodGet, "/version", nil, &version); err != nil {
 		return fmt.Errorf("Failed to get nexd version: %w\n", err)
 	}
 
-	if Version != result {
-		errMsg := fmt.Sprintf("Version mismatch: nexctl(%s) nexd(%s)\n", Version, result)
+	if Version != version.Version {
+		errMsg := fmt.Sprintf("Version mismatch: nexctl(%s) nexd(%s)\n", Version, version.Version)
 		return fmt.Errorf("%s", errMsg)
 	}
 
@@ -312,31 +367,110 @@ func checkVersion() error {
 func cmdLocalVersion(ctx context.Context, command *cli.Command) error {
 	fmt.Printf("nexctl version: %s\n", Version)
 
-	result, err := callNexd("Version", "")
+	var version api.VersionResponse
+	err := nexdApi(ctx, http.MethodGet, "/version", nil, &version)
 	if err == nil {
-		fmt.Printf("nexd version: %s\n", result)
+		fmt.Printf("nexd version: %s\n", version.Version)
 	}
 
 	return err
 }
 
 func cmdLocalStatus(ctx context.Context, command *cli.Command) error {
-	if err := checkVersion(); err != nil {
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 
-	result, err := callNexd("Status", "")
-	if err != nil {
+	var status api.StatusResponse
+	if err := nexdApi(ctx, http.MethodGet, "/status", nil, &status); err != nil {
+		return err
+	}
+
+	fmt.Printf("Status: %s\n%s", status.Status, status.Message)
+
+	return nil
+}
+
+func cmdLocalReload(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+
+	if err := nexdApi(ctx, http.MethodPost, "/reload", nil, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Configuration reloaded\n")
+
+	return nil
+}
+
+func cmdLocalStateRekey(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+
+	req := api.StateRekeyRequest{KeyProvider: command.String("key-provider")}
+	if req.KeyProvider == "passphrase" {
+		// the passphrase is read here, nexd only sees its own environment
+		req.Passphrase = os.Getenv(fstore.PassphraseEnv)
+		if req.Passphrase == "" {
+			passphrase, err := readNewPassphrase()
+			if err != nil {
+				return err
+			}
+			req.Passphrase = passphrase
+		}
+	}
+	if err := nexdApi(ctx, http.MethodPost, "/state/rekey", req, nil); err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", result)
+	fmt.Printf("The state was re-keyed, start nexd with --state-key-provider %s from now on\n", req.KeyProvider)
 
 	return nil
 }
 
+func readNewPassphrase() (string, error) {
+	fmt.Fprint(os.Stderr, "New passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+	fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+	confirmation, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return "", fmt.Errorf("the passphrase is empty")
+	}
+	if string(passphrase) != string(confirmation) {
+		return "", fmt.Errorf("the passphrases do not match")
+	}
+	return string(passphrase), nil
+}
+
+func setDebug(ctx context.Context, enabled bool) error {
+	if err := checkVersion(ctx); err != nil {
+		return err
+	}
+	if err := nexdApi(ctx, http.MethodPut, "/debug", api.DebugSetting{Enabled: enabled}, nil); err != nil {
+		fmt.Printf("%s\n", err)
+		return err
+	}
+	if enabled {
+		fmt.Printf("Debug logging enabled\n")
+	} else {
+		fmt.Printf("Debug logging disabled\n")
+	}
+	return nil
+}
+
 func proxyAddRemove(ctx context.Context, command *cli.Command, add bool) error {
-	if err := checkVersion(); err != nil {
+	if err := checkVersion(ctx); err != nil {
 		return err
 	}
 	ingress := command.StringSlice("ingress")
@@ -345,34 +479,26 @@ func proxyAddRemove(ctx context.Context, command *cli.Command, add bool) error {
 		return fmt.Errorf("No rules provided")
 	}
 
-	var method string
-	addStr := "adding"
-	if add {
-		method = "ProxyAddIngress"
-	} else {
-		method = "ProxyRemoveIngress"
-		addStr = "removing"
+	path := "/proxy-rules"
+	addStr, addedStr := "adding", "Added"
+	if !add {
+		path = "/proxy-rules/remove"
+		addStr, addedStr = "removing", "Removed"
 	}
-	for _, rule := range ingress {
-		result, err := callNexd(method, rule)
-		if err != nil {
-			fmt.Printf("Error %s ingress rule (%s): %s\n", addStr, rule, err)
+	for _, rule := range append(proxyRules("ingress", ingress), proxyRules("egress", egress)...) {
+		if err := nexdApi(ctx, http.MethodPost, path, rule, nil); err != nil {
+			fmt.Printf("Error %s %s rule (%s): %s\n", addStr, rule.Type, rule.Rule, err)
 			continue
 		}
-		fmt.Printf("%s", result)
-	}
-	if add {
-		method = "ProxyAddEgress"
-	} else {
-		method = "ProxyRemoveEgress"
-	}
-	for _, rule := range egress {
-		result, err := callNexd(method, rule)
-		if err != nil {
-			fmt.Printf("Error %s egress rule (%s): %s\n", addStr, rule, err)
-			continue
-		}
-		fmt.Printf("%s", result)
+		fmt.Printf("%s %s proxy rule: %s\n", addedStr, rule.Type, rule.Rule)
 	}
 	return nil
 }
+
+func proxyRules(proxyType string, rules []string) []api.ProxyRule {
+	var result []api.ProxyRule
+	for _, rule := range rules {
+		result = append(result, api.ProxyRule{Type: proxyType, Rule: rule})
+	}
+	return result
+}
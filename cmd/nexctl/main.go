@@ -328,6 +328,8 @@ func apiResponse[T any](resp T, httpResp *http.Response, err error) T {
 				}
 				message += fmt.Sprintf(", status: %d", status)
 				Fatalf(message)
+			case client.ModelsQuotaExceededError:
+				Fatalf("error: %s: %s quota: %d, status: %d", err.GetError(), err.GetResource(), err.GetQuota(), status)
 			case client.ModelsValidationError:
 				message := fmt.Sprintf("error: %s", err.GetError())
 				if err.GetField() != "" {
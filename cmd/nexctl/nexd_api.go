@@ -0,0 +1,83 @@
+//go:build linux || darwin || windows
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+)
+
+// nexdHttpClient talks to the control API of nexd over its unix socket.
+var nexdHttpClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "unix", api.UnixSocketPath)
+			if err != nil {
+				conn, err = d.DialContext(ctx, "unix", filepath.Base(api.UnixSocketPath))
+				if err != nil {
+					return nil, fmt.Errorf("Failed to connect to nexd: %w\n", err)
+				}
+			}
+			return conn, nil
+		},
+	},
+}
+
+// nexdRequest sends a request to the control API of nexd and returns the response when it succeeded.
+// The caller must close the body of the response.
+func nexdRequest(ctx context.Context, method string, path string, in any) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "http://nexd"+api.CtlApiPrefix+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := nexdHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var apiErr api.CtlApiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Error == "" {
+			return nil, fmt.Errorf("nexd returned %s", resp.Status)
+		}
+		return nil, fmt.Errorf("%s", apiErr.Error)
+	}
+	return resp, nil
+}
+
+// nexdApi calls the control API of nexd, sending in as the request body and decoding the response into out
+// when they are not nil.
+func nexdApi(ctx context.Context, method string, path string, in any, out any) error {
+	resp, err := nexdRequest(ctx, method, path, in)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("Failed to decode the nexd response: %w\n", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/urfave/cli/v3"
 )
@@ -85,6 +86,24 @@ func createOrganizationCommand() *cli.Command {
 					return createOrganization(ctx, command, name, description)
 				},
 			},
+			{
+				Name:  "usage",
+				Usage: "Show the resources of an organization and their quotas",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "organization-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					organizationID, err := getUUID(command, "organization-id")
+					if err != nil {
+						return err
+					}
+
+					return showOrganizationUsage(ctx, command, organizationID)
+				},
+			},
 			{
 				Name:  "delete",
 				Usage: "Delete a organization",
@@ -123,6 +142,41 @@ func listOrganizations(ctx context.Context, command *cli.Command) error {
 	return nil
 }
 
+func orgUsageTableFields() []TableField {
+	usage := func(header string, get func(*client.ModelsOrganizationUsage) client.ModelsResourceUsage) TableField {
+		return TableField{Header: header, Formatter: func(item interface{}) string {
+			var ru client.ModelsResourceUsage
+			switch usage := item.(type) {
+			case *client.ModelsOrganizationUsage:
+				ru = get(usage)
+			case client.ModelsOrganizationUsage:
+				ru = get(&usage)
+			}
+			if ru.GetQuota() == 0 {
+				return fmt.Sprintf("%d/unlimited", ru.GetUsed())
+			}
+			return fmt.Sprintf("%d/%d", ru.GetUsed(), ru.GetQuota())
+		}}
+	}
+	var fields []TableField
+	fields = append(fields, TableField{Header: "ORGANIZATION ID", Field: "OrganizationId"})
+	fields = append(fields, usage("DEVICES", (*client.ModelsOrganizationUsage).GetDevices))
+	fields = append(fields, usage("VPCS", (*client.ModelsOrganizationUsage).GetVpcs))
+	fields = append(fields, usage("REG KEYS", (*client.ModelsOrganizationUsage).GetRegKeys))
+	fields = append(fields, usage("SECURITY GROUPS", (*client.ModelsOrganizationUsage).GetSecurityGroups))
+	fields = append(fields, usage("INVITATIONS", (*client.ModelsOrganizationUsage).GetInvitations))
+	return fields
+}
+
+func showOrganizationUsage(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.OrganizationsApi.
+		GetOrganizationUsage(ctx, id).
+		Execute())
+	show(command, orgUsageTableFields(), res)
+	return nil
+}
+
 func createOrganization(ctx context.Context, command *cli.Command, name, description string) error {
 	c := createClient(ctx, command)
 	res := apiResponse(c.OrganizationsApi.
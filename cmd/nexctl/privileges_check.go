@@ -22,11 +22,11 @@ func hasPrivileges() error {
 	switch osType := getOSType(); osType {
 	case "linux":
 		if !isLinuxRoot() {
-			return fmt.Errorf("'nexctl nexd' commands must be run with sudo on Linux")
+			return fmt.Errorf("'nexctl nexd' commands must be run with sudo on Linux, or by a member of a group nexd grants access with --ctl-read-group or --ctl-admin-group")
 		}
 	case "darwin":
 		if !isDarwinRoot() {
-			return fmt.Errorf("'nexctl nexd' commands must be run with sudo on macOS")
+			return fmt.Errorf("'nexctl nexd' commands must be run with sudo on macOS, or by a member of a group nexd grants access with --ctl-read-group or --ctl-admin-group")
 		}
 	case "windows":
 		if !isWindowsAdmin() {
@@ -40,6 +40,11 @@ func createRegKeyCommand() *cli.Command {
 						Name:     "security-group-id",
 						Required: false,
 					},
+					&cli.StringFlag{
+						Name:     "subnet-id",
+						Usage:    "The subnet of the vpc the devices registered with the key join",
+						Required: false,
+					},
 					&cli.StringFlag{
 						Name:     "description",
 						Required: false,
@@ -56,6 +61,11 @@ func createRegKeyCommand() *cli.Command {
 						Name:     "settings",
 						Required: false,
 					},
+					&cli.StringSliceFlag{
+						Name:     "auto-approve-cidr",
+						Usage:    "Approve CIDRs advertised by devices registered with the key that fall within this `CIDR`",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					settings := map[string]interface{}{}
@@ -69,12 +79,14 @@ func createRegKeyCommand() *cli.Command {
 					}
 
 					return createRegKey(ctx, command, client.ModelsAddRegKey{
-						VpcId:           client.PtrOptionalString(command.String("vpc-id")),
-						Description:     client.PtrOptionalString(command.String("description")),
-						ExpiresAt:       client.PtrOptionalString(getExpiration(command, "expiration")),
-						SingleUse:       client.PtrBool(command.Bool("single-use")),
-						SecurityGroupId: client.PtrOptionalString(command.String("security-group-id")),
-						Settings:        settings,
+						VpcId:            client.PtrOptionalString(command.String("vpc-id")),
+						Description:      client.PtrOptionalString(command.String("description")),
+						ExpiresAt:        client.PtrOptionalString(getExpiration(command, "expiration")),
+						SingleUse:        client.PtrBool(command.Bool("single-use")),
+						SecurityGroupId:  client.PtrOptionalString(command.String("security-group-id")),
+						SubnetId:         client.PtrOptionalString(command.String("subnet-id")),
+						Settings:         settings,
+						AutoApproveCidrs: command.StringSlice("auto-approve-cidr"),
 					})
 				},
 			},
@@ -102,6 +114,11 @@ func createRegKeyCommand() *cli.Command {
 						Name:     "settings",
 						Required: false,
 					},
+					&cli.StringSliceFlag{
+						Name:     "auto-approve-cidr",
+						Usage:    "Approve CIDRs advertised by devices registered with the key that fall within this `CIDR`",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					settings := map[string]interface{}{}
@@ -115,10 +132,11 @@ func createRegKeyCommand() *cli.Command {
 					}
 
 					return updateRegKey(ctx, command, command.String("reg-key-id"), client.ModelsUpdateRegKey{
-						Description:     client.PtrOptionalString(command.String("description")),
-						ExpiresAt:       client.PtrOptionalString(getExpiration(command, "expiration")),
-						SecurityGroupId: client.PtrOptionalString(command.String("security-group-id")),
-						Settings:        settings,
+						Description:      client.PtrOptionalString(command.String("description")),
+						ExpiresAt:        client.PtrOptionalString(getExpiration(command, "expiration")),
+						SecurityGroupId:  client.PtrOptionalString(command.String("security-group-id")),
+						Settings:         settings,
+						AutoApproveCidrs: command.StringSlice("auto-approve-cidr"),
 					})
 				},
 			},
@@ -165,6 +183,7 @@ func regTokenTableFields(command *cli.Command) []TableField {
 		fields = append(fields, TableField{Header: "EXPIRES AT", Field: "ExpiresAt"})
 		// fields = append(fields, TableField{Header: "BEARER TOKEN", Field: "BearerToken"})
 		fields = append(fields, TableField{Header: "SETTINGS", Field: "Settings"})
+		fields = append(fields, TableField{Header: "AUTO APPROVE CIDRS", Field: "AutoApproveCidrs"})
 	}
 	return fields
 }
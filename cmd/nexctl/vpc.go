@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/urfave/cli/v3"
+	"strings"
 )
 
 func createVpcCommand() *cli.Command {
@@ -39,6 +40,11 @@ func createVpcCommand() *cli.Command {
 						Name:     "ipv6-cidr",
 						Required: false,
 					},
+					&cli.StringFlag{
+						Name:     "topology",
+						Usage:    "which devices peer with each other: full-mesh, hub-and-spoke or peering-groups",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					return createVPC(ctx, command, client.ModelsAddVPC{
@@ -47,6 +53,7 @@ func createVpcCommand() *cli.Command {
 						Description:    client.PtrOptionalString(command.String("description")),
 						OrganizationId: client.PtrOptionalString(command.String("organization-id")),
 						PrivateCidr:    client.PtrBool(!(command.String("ipv4-cidr") == "" && command.String("ipv6-cidr") == "")),
+						Topology:       client.PtrOptionalString(command.String("topology")),
 					})
 				},
 			},
@@ -62,6 +69,15 @@ func createVpcCommand() *cli.Command {
 						Name:     "description",
 						Required: false,
 					},
+					&cli.StringFlag{
+						Name:     "topology",
+						Usage:    "which devices peer with each other: full-mesh, hub-and-spoke or peering-groups",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:  "secondary-cidr",
+						Usage: "set the secondary CIDRs of the vpc, devices are assigned addresses from them once the primary CIDR is exhausted",
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					id, err := getUUID(command, "vpc-id")
@@ -69,8 +85,15 @@ func createVpcCommand() *cli.Command {
 						return err
 					}
 
-					update := client.ModelsUpdateVPC{
-						Description: client.PtrString(command.String("description")),
+					update := client.ModelsUpdateVPC{}
+					if command.IsSet("description") {
+						update.Description = client.PtrString(command.String("description"))
+					}
+					if command.IsSet("topology") {
+						update.Topology = client.PtrString(command.String("topology"))
+					}
+					if command.IsSet("secondary-cidr") {
+						update.SecondaryCidrs = command.StringSlice("secondary-cidr")
 					}
 					return updateVPC(ctx, command, id, update)
 				},
@@ -97,6 +120,26 @@ func createVpcCommand() *cli.Command {
 				Usage:    "Commands relating to device metadata across the vpc",
 				Commands: vpcMetadataSubcommands,
 			},
+			{
+				Name:     "peering",
+				Usage:    "Commands relating to the peering of vpcs with other vpcs",
+				Commands: vpcPeeringsSubcommands,
+			},
+			{
+				Name:     "ip-reservation",
+				Usage:    "Commands relating to the addresses of a vpc reserved for devices",
+				Commands: vpcIPReservationsSubcommands,
+			},
+			{
+				Name:     "excluded-range",
+				Usage:    "Commands relating to the addresses of a vpc kept out of its pool",
+				Commands: vpcExcludedRangesSubcommands,
+			},
+			{
+				Name:     "subnet",
+				Usage:    "Commands relating to the named slices of a vpc the devices join",
+				Commands: vpcSubnetsSubcommands,
+			},
 		},
 	}
 }
@@ -113,7 +156,7 @@ func updateVPC(ctx context.Context, command *cli.Command, idStr string, update c
 		Update(update).
 		Execute())
 
-	show(command, invitationsTableFields(), res)
+	show(command, vpcTableFields(), res)
 	showSuccessfully(command, "updated")
 	return nil
 }
@@ -124,6 +167,11 @@ func vpcTableFields() []TableField {
 	fields = append(fields, TableField{Header: "ORGANIZATION ID", Field: "OrganizationId"})
 	fields = append(fields, TableField{Header: "IPV4 CIDR", Field: "Ipv4Cidr"})
 	fields = append(fields, TableField{Header: "IPV6 CIDR", Field: "Ipv6Cidr"})
+	fields = append(fields, TableField{Header: "SECONDARY CIDRS", Formatter: func(item interface{}) string {
+		vpc := item.(client.ModelsVPC)
+		return strings.Join(vpc.SecondaryCidrs, ", ")
+	}})
+	fields = append(fields, TableField{Header: "TOPOLOGY", Field: "Topology"})
 	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
 	return fields
 }
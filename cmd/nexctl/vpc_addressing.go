@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var vpcIPReservationsSubcommands []*cli.Command
+var vpcExcludedRangesSubcommands []*cli.Command
+
+func init() {
+	vpcIPReservationsSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the IP reservations of a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					ListIPReservations(ctx, vpcID).
+					Execute())
+				show(command, ipReservationTableFields(), res)
+				return nil
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Reserve an address of a VPC for a device, a registration key or a hostname",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "address",
+					Usage:    "the IPv4 or IPv6 `ADDRESS` of the VPC to reserve",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "device-id",
+					Usage: "reserve the address for the device with this ID",
+				},
+				&cli.StringFlag{
+					Name:  "reg-key-id",
+					Usage: "reserve the address for the device registered with this registration key ID",
+				},
+				&cli.StringFlag{
+					Name:  "hostname",
+					Usage: "reserve the address for the device with this hostname",
+				},
+				&cli.StringFlag{
+					Name:  "description",
+					Usage: "description of the reservation",
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				reservation := client.ModelsAddIPReservation{
+					Address:     client.PtrString(command.String("address")),
+					Description: client.PtrString(command.String("description")),
+				}
+				if command.IsSet("device-id") {
+					deviceID, err := getUUID(command, "device-id")
+					if err != nil {
+						return err
+					}
+					reservation.DeviceId = client.PtrString(deviceID)
+				}
+				if command.IsSet("reg-key-id") {
+					regKeyID, err := getUUID(command, "reg-key-id")
+					if err != nil {
+						return err
+					}
+					reservation.RegKeyId = client.PtrString(regKeyID)
+				}
+				if command.IsSet("hostname") {
+					reservation.Hostname = client.PtrString(command.String("hostname"))
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					CreateIPReservation(ctx, vpcID).
+					Reservation(reservation).
+					Execute())
+				show(command, ipReservationTableFields(), res)
+				showSuccessfully(command, "created")
+				return nil
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete an IP reservation of a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "reservation-id",
+					Usage:    "IP Reservation ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				reservationID, err := getUUID(command, "reservation-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					DeleteIPReservation(ctx, vpcID, reservationID).
+					Execute())
+				show(command, ipReservationTableFields(), res)
+				showSuccessfully(command, "deleted")
+				return nil
+			},
+		},
+	}
+
+	vpcExcludedRangesSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the excluded ranges of a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					ListExcludedRanges(ctx, vpcID).
+					Execute())
+				show(command, excludedRangeTableFields(), res)
+				return nil
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Keep a range of addresses of a VPC out of its pool",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "cidr",
+					Usage:    "the `CIDR` of the addresses to exclude",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "description",
+					Usage: "description of the excluded range",
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					CreateExcludedRange(ctx, vpcID).
+					ExcludedRange(client.ModelsAddExcludedRange{
+						Cidr:        client.PtrString(command.String("cidr")),
+						Description: client.PtrString(command.String("description")),
+					}).
+					Execute())
+				show(command, excludedRangeTableFields(), res)
+				showSuccessfully(command, "created")
+				return nil
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete an excluded range of a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "range-id",
+					Usage:    "Excluded Range ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				rangeID, err := getUUID(command, "range-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					DeleteExcludedRange(ctx, vpcID, rangeID).
+					Execute())
+				show(command, excludedRangeTableFields(), res)
+				showSuccessfully(command, "deleted")
+				return nil
+			},
+		},
+	}
+}
+
+func ipReservationTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "RESERVATION ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "ADDRESS", Field: "Address"})
+	fields = append(fields, TableField{Header: "DEVICE ID", Field: "DeviceId"})
+	fields = append(fields, TableField{Header: "REG KEY ID", Field: "RegKeyId"})
+	fields = append(fields, TableField{Header: "HOSTNAME", Field: "Hostname"})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	return fields
+}
+
+func excludedRangeTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "RANGE ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "CIDR", Field: "Cidr"})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	return fields
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var vpcPeeringsSubcommands []*cli.Command
+
+func init() {
+	vpcPeeringsSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the VPC peerings",
+			Action: func(ctx context.Context, command *cli.Command) error {
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					ListVpcPeerings(ctx).
+					Execute())
+				show(command, vpcPeeringTableFields(), res)
+				return nil
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Request the peering of a VPC with another VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "ID of the VPC requesting the peering",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "peer-vpc-id",
+					Usage:    "ID of the VPC to peer with, an owner of it accepts the peering",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:  "device",
+					Usage: "ID of a `DEVICE` of the VPC to export to the peer VPC",
+				},
+				&cli.StringSliceFlag{
+					Name:  "cidr",
+					Usage: "`CIDR` of the VPC to export to the peer VPC, with the devices and approved routes within it",
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				peerVpcID, err := getUUID(command, "peer-vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					CreateVpcPeering(ctx).
+					Peering(client.ModelsAddVpcPeering{
+						RequesterVpcId: client.PtrString(vpcID),
+						AccepterVpcId:  client.PtrString(peerVpcID),
+						Devices:        command.StringSlice("device"),
+						Cidrs:          command.StringSlice("cidr"),
+					}).
+					Execute())
+				show(command, vpcPeeringTableFields(), res)
+				showSuccessfully(command, "created")
+				return nil
+			},
+		},
+		{
+			Name:  "accept",
+			Usage: "Accept the peering of a VPC you own with another VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "peering-id",
+					Usage:    "VPC Peering ID",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:  "device",
+					Usage: "ID of a `DEVICE` of the VPC to export to the requester VPC",
+				},
+				&cli.StringSliceFlag{
+					Name:  "cidr",
+					Usage: "`CIDR` of the VPC to export to the requester VPC, with the devices and approved routes within it",
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				peeringID, err := getUUID(command, "peering-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					AcceptVpcPeering(ctx, peeringID).
+					Peering(client.ModelsAcceptVpcPeering{
+						Devices: command.StringSlice("device"),
+						Cidrs:   command.StringSlice("cidr"),
+					}).
+					Execute())
+				show(command, vpcPeeringTableFields(), res)
+				showSuccessfully(command, "accepted")
+				return nil
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a VPC peering",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "peering-id",
+					Usage:    "VPC Peering ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				peeringID, err := getUUID(command, "peering-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					DeleteVpcPeering(ctx, peeringID).
+					Execute())
+				show(command, vpcPeeringTableFields(), res)
+				showSuccessfully(command, "deleted")
+				return nil
+			},
+		},
+	}
+}
+
+func vpcPeeringTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "PEERING ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "REQUESTER VPC ID", Field: "RequesterVpcId"})
+	fields = append(fields, TableField{Header: "ACCEPTER VPC ID", Field: "AccepterVpcId"})
+	fields = append(fields, TableField{Header: "STATUS", Field: "Status"})
+	return fields
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var vpcSubnetsSubcommands []*cli.Command
+
+func init() {
+	settingsFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "description",
+			Usage: "description of the subnet",
+		},
+		&cli.StringFlag{
+			Name:  "security-group-id",
+			Usage: "the security group of the devices joining the subnet, instead of the default one of the vpc",
+		},
+		&cli.StringFlag{
+			Name:  "relay-device-id",
+			Usage: "the relay the devices of the subnet prefer over the other relays of the vpc",
+		},
+		&cli.StringSliceFlag{
+			Name:  "dns-server",
+			Usage: "a DNS server nexd passes to the hooks of the devices of the subnet",
+		},
+		&cli.StringSliceFlag{
+			Name:  "dns-search-domain",
+			Usage: "a DNS search domain nexd passes to the hooks of the devices of the subnet",
+		},
+	}
+
+	vpcSubnetsSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the subnets of a VPC",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					ListSubnets(ctx, vpcID).
+					Execute())
+				show(command, subnetTableFields(), res)
+				return nil
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Add a named slice of the CIDRs of a VPC, the devices joining it are assigned addresses from it",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "name",
+					Usage:    "the `NAME` devices join the subnet with",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "ipv4-cidr",
+					Usage: "a slice of an IPv4 CIDR of the vpc",
+				},
+				&cli.StringFlag{
+					Name:  "ipv6-cidr",
+					Usage: "a slice of an IPv6 CIDR of the vpc",
+				},
+			}, settingsFlags...),
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				subnet := client.ModelsAddSubnet{
+					Name:             client.PtrString(command.String("name")),
+					Description:      client.PtrOptionalString(command.String("description")),
+					Ipv4Cidr:         client.PtrOptionalString(command.String("ipv4-cidr")),
+					Ipv6Cidr:         client.PtrOptionalString(command.String("ipv6-cidr")),
+					DnsServers:       command.StringSlice("dns-server"),
+					DnsSearchDomains: command.StringSlice("dns-search-domain"),
+				}
+				if command.IsSet("security-group-id") {
+					securityGroupID, err := getUUID(command, "security-group-id")
+					if err != nil {
+						return err
+					}
+					subnet.SecurityGroupId = client.PtrString(securityGroupID)
+				}
+				if command.IsSet("relay-device-id") {
+					relayDeviceID, err := getUUID(command, "relay-device-id")
+					if err != nil {
+						return err
+					}
+					subnet.RelayDeviceId = client.PtrString(relayDeviceID)
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					CreateSubnet(ctx, vpcID).
+					Subnet(subnet).
+					Execute())
+				show(command, subnetTableFields(), res)
+				showSuccessfully(command, "created")
+				return nil
+			},
+		},
+		{
+			Name:  "update",
+			Usage: "Update the settings of a subnet of a VPC",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "subnet-id",
+					Usage:    "Subnet ID",
+					Required: true,
+				},
+			}, settingsFlags...),
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				subnetID, err := getUUID(command, "subnet-id")
+				if err != nil {
+					return err
+				}
+				update := client.ModelsUpdateSubnet{}
+				if command.IsSet("description") {
+					update.Description = client.PtrString(command.String("description"))
+				}
+				if command.IsSet("security-group-id") {
+					securityGroupID, err := getUUID(command, "security-group-id")
+					if err != nil {
+						return err
+					}
+					update.SecurityGroupId = client.PtrString(securityGroupID)
+				}
+				if command.IsSet("relay-device-id") {
+					relayDeviceID, err := getUUID(command, "relay-device-id")
+					if err != nil {
+						return err
+					}
+					update.RelayDeviceId = client.PtrString(relayDeviceID)
+				}
+				if command.IsSet("dns-server") {
+					update.DnsServers = command.StringSlice("dns-server")
+				}
+				if command.IsSet("dns-search-domain") {
+					update.DnsSearchDomains = command.StringSlice("dns-search-domain")
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					UpdateSubnet(ctx, vpcID, subnetID).
+					Update(update).
+					Execute())
+				show(command, subnetTableFields(), res)
+				showSuccessfully(command, "updated")
+				return nil
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a subnet of a VPC that no device or registration key uses",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Usage:    "VPC ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "subnet-id",
+					Usage:    "Subnet ID",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				subnetID, err := getUUID(command, "subnet-id")
+				if err != nil {
+					return err
+				}
+				c := createClient(ctx, command)
+				res := apiResponse(c.VPCApi.
+					DeleteSubnet(ctx, vpcID, subnetID).
+					Execute())
+				show(command, subnetTableFields(), res)
+				showSuccessfully(command, "deleted")
+				return nil
+			},
+		},
+	}
+}
+
+func subnetTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "SUBNET ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "NAME", Field: "Name"})
+	fields = append(fields, TableField{Header: "IPV4 CIDR", Field: "Ipv4Cidr"})
+	fields = append(fields, TableField{Header: "IPV6 CIDR", Field: "Ipv6Cidr"})
+	fields = append(fields, TableField{Header: "SECURITY GROUP ID", Field: "SecurityGroupId"})
+	fields = append(fields, TableField{Header: "RELAY DEVICE ID", Field: "RelayDeviceId"})
+	fields = append(fields, TableField{Header: "DNS SERVERS", Formatter: func(item interface{}) string {
+		subnet := item.(client.ModelsSubnet)
+		return strings.Join(subnet.DnsServers, ", ")
+	}})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	return fields
+}
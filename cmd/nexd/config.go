@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/nexodus-io/nexodus/internal/nexodus"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v3"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// reloadableSettings are the settings that are applied to a running nexd when the config file is reloaded.
+// Changes to any other setting require a restart of nexd.
+var reloadableSettings = []string{"log-level", "ingress", "egress", "advertise-cidr", "exit-node", "exit-node-client"}
+
+// nexdConfig tracks the settings that were taken from the --config file.
+//
+// The config file uses the flag names as keys. Top level keys set the global flags, and a table
+// named after a subcommand (proxy, router, relay, relayderp) sets the flags of that subcommand.
+// Flags given on the command line or through environment variables take precedence over the file.
+type nexdConfig struct {
+	mu   sync.Mutex
+	path string
+	// values holds the flag values that were set from the config file
+	values map[string]string
+	// commandLine records whether a flag was set on the command line or through an environment variable
+	commandLine map[string]bool
+}
+
+// apply sets the flags of command that were not given on the command line from the config file.
+// It is used as the Before hook of the nexd commands, so that the flag actions validate the values
+// from the file just like they validate command line flags.
+func (cfg *nexdConfig) apply(command *cli.Command) error {
+	path := command.String("config")
+	if path == "" {
+		return nil
+	}
+	if cfg.path != "" && cfg.path != path {
+		return fmt.Errorf("the --config flag may only be given once")
+	}
+	cfg.path = path
+	if cfg.values == nil {
+		cfg.values = map[string]string{}
+		cfg.commandLine = map[string]bool{}
+	}
+
+	settings, err := readConfigSettings(path, command)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range append(mapKeys(settings), reloadableSettings...) {
+		if _, seen := cfg.commandLine[name]; seen || lookupFlag(command, name) == nil {
+			continue
+		}
+		cfg.commandLine[name] = command.IsSet(name)
+	}
+
+	for _, name := range mapKeys(settings) {
+		if _, applied := cfg.values[name]; applied || cfg.commandLine[name] {
+			continue
+		}
+		if err := command.Set(name, settings[name]); err != nil {
+			return fmt.Errorf("invalid %s setting in config file %s: %w", name, path, err)
+		}
+		cfg.values[name] = settings[name]
+	}
+	return nil
+}
+
+// reload re-reads the config file and applies the changes to the reloadable settings to the running nexd.
+func (cfg *nexdConfig) reload(command *cli.Command, nex *nexodus.Nexodus, logger *zap.Logger, logLevel *zap.AtomicLevel, mode nexdMode) error {
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	settings, err := readConfigSettings(cfg.path, command)
+	if err != nil {
+		return err
+	}
+	for name := range settings {
+		if cfg.commandLine[name] {
+			logger.Warn("Ignoring config file setting that was given on the command line", zap.String("setting", name))
+			delete(settings, name)
+		}
+	}
+
+	changed := map[string]bool{}
+	for name := range settings {
+		if settings[name] != cfg.values[name] {
+			changed[name] = true
+		}
+	}
+	for name := range cfg.values {
+		if _, ok := settings[name]; !ok {
+			changed[name] = true
+		}
+	}
+
+	// validate everything before applying any of the changes
+	level := zapcore.InfoLevel
+	if value, ok := settings["log-level"]; ok {
+		if level, err = zapcore.ParseLevel(value); err != nil {
+			return fmt.Errorf("invalid log-level setting in config file %s: %w", cfg.path, err)
+		}
+	}
+	var proxyRules []nexodus.ProxyRule
+	if mode == nexdModeProxy {
+		if proxyRules, err = cfg.proxyRules(command, settings); err != nil {
+			return fmt.Errorf("invalid proxy rule in config file %s: %w", cfg.path, err)
+		}
+	}
+	var advertiseCidrs []string
+	exitNode := false
+	if mode == nexdModeRouter {
+		advertiseCidrs = cfg.list(command, settings, "advertise-cidr")
+		for _, cidr := range advertiseCidrs {
+			if err := nexodus.ValidateCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid advertise-cidr setting in config file %s: %w", cfg.path, err)
+			}
+		}
+		if command.IsSet("child-prefix") {
+			advertiseCidrs = append(advertiseCidrs, command.StringSlice("child-prefix")...)
+		}
+		if exitNode, err = cfg.bool(command, settings, "exit-node"); err != nil {
+			return fmt.Errorf("invalid exit-node setting in config file %s: %w", cfg.path, err)
+		}
+		if exitNode && !slices.Contains(advertiseCidrs, "0.0.0.0/0") {
+			advertiseCidrs = append(advertiseCidrs, "0.0.0.0/0")
+		}
+	}
+	exitNodeClient, err := cfg.bool(command, settings, "exit-node-client")
+	if err != nil {
+		return fmt.Errorf("invalid exit-node-client setting in config file %s: %w", cfg.path, err)
+	}
+
+	for _, name := range mapKeys(changed) {
+		if !slices.Contains(reloadableSettings, name) {
+			logger.Warn("Config file setting changed, restart nexd to apply it", zap.String("setting", name))
+		}
+	}
+
+	if changed["log-level"] {
+		logLevel.SetLevel(level)
+		logger.Info("Log level changed", zap.String("level", level.String()))
+	}
+	if mode == nexdModeProxy && (changed["ingress"] || changed["egress"]) {
+		if err := nex.SetConfiguredProxyRules(proxyRules); err != nil {
+			return err
+		}
+	}
+	if mode == nexdModeRouter && (changed["advertise-cidr"] || changed["exit-node"]) {
+		if exitNode {
+			if err := nex.SetExitNodeOrigin(true); err != nil {
+				return err
+			}
+		}
+		if err := nex.SetAdvertiseCidrs(advertiseCidrs); err != nil {
+			return err
+		}
+		if !exitNode {
+			if err := nex.SetExitNodeOrigin(false); err != nil {
+				return err
+			}
+		}
+	}
+	if changed["exit-node-client"] {
+		if err := nex.SetExitNodeClient(exitNodeClient); err != nil {
+			return err
+		}
+	}
+
+	cfg.values = settings
+	logger.Info("Reloaded config file", zap.String("path", cfg.path))
+	return nil
+}
+
+// list returns the value of a list flag, taken from the config file settings unless it was given on the command line.
+func (cfg *nexdConfig) list(command *cli.Command, settings map[string]string, name string) []string {
+	if cfg.commandLine[name] {
+		return command.StringSlice(name)
+	}
+	if settings[name] == "" {
+		return nil
+	}
+	return strings.Split(settings[name], ",")
+}
+
+// bool returns the value of a bool flag, taken from the config file settings unless it was given on the command line.
+func (cfg *nexdConfig) bool(command *cli.Command, settings map[string]string, name string) (bool, error) {
+	if cfg.commandLine[name] {
+		return command.Bool(name), nil
+	}
+	if settings[name] == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(settings[name])
+}
+
+func (cfg *nexdConfig) proxyRules(command *cli.Command, settings map[string]string) ([]nexodus.ProxyRule, error) {
+	var rules []nexodus.ProxyRule
+	for _, proxyType := range []nexodus.ProxyType{nexodus.ProxyTypeEgress, nexodus.ProxyTypeIngress} {
+		for _, value := range cfg.list(command, settings, proxyType.String()) {
+			rule, err := nexodus.ParseProxyRule(value, proxyType)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// readConfigSettings reads the config file and returns the flag values that apply to command, keyed by flag name.
+func readConfigSettings(path string, command *cli.Command) (map[string]string, error) {
+	config, err := loadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := configSettings(config, command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// loadConfigFile reads a config file, which is parsed as TOML if it has a .toml extension and as YAML otherwise.
+func loadConfigFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	config := map[string]any{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(data, &config)
+	} else {
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// configSettings validates every key of the config against the flags of nexd and returns the
+// flag values that apply to command: the top level settings and the table of the running subcommand.
+func configSettings(config map[string]any, command *cli.Command) (map[string]string, error) {
+	root := command.Root()
+	settings := map[string]string{}
+	for key, value := range config {
+		if section, ok := value.(map[string]any); ok {
+			subcommand := root.Command(key)
+			if subcommand == nil || len(subcommand.Flags) == 0 {
+				return nil, fmt.Errorf("unknown section: %s", key)
+			}
+			for name, value := range section {
+				if !hasFlag(subcommand.Flags, name) {
+					return nil, fmt.Errorf("unknown setting: %s.%s", key, name)
+				}
+				s, err := configValue(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid setting %s.%s: %w", key, name, err)
+				}
+				if subcommand.Name == command.Name {
+					settings[name] = s
+				}
+			}
+			continue
+		}
+		if key == "config" || !hasFlag(root.Flags, key) {
+			return nil, fmt.Errorf("unknown setting: %s", key)
+		}
+		s, err := configValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid setting %s: %w", key, err)
+		}
+		settings[key] = s
+	}
+	return settings, nil
+}
+
+// configValue converts a config file value into the string form accepted by the flag.
+func configValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		var items []string
+		for _, item := range v {
+			s, err := configValue(item)
+			if err != nil {
+				return "", err
+			}
+			if strings.Contains(s, ",") {
+				return "", fmt.Errorf("list items may not contain a comma: %s", s)
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value: %v", value)
+}
+
+func hasFlag(flags []cli.Flag, name string) bool {
+	for _, f := range flags {
+		if slices.Contains(f.Names(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupFlag finds the flag of command or one of its parents with the given name.
+func lookupFlag(command *cli.Command, name string) cli.Flag {
+	for _, c := range command.Lineage() {
+		for _, f := range c.Flags {
+			if slices.Contains(f.Names(), name) {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -30,5 +30,17 @@ func init() {
 			Destination: &api.UnixSocketPath,
 			DefaultText: api.UnixSocketPathExpression,
 			Required:    false,
+		},
+		&cli.StringFlag{
+			Name:     "ctl-read-group",
+			Usage:    "Grant the members of this `group` read-only access to the unix socket, e.g. for 'nexctl nexd status' and 'nexctl nexd peers list'",
+			Sources:  cli.EnvVars("NEXD_CTL_READ_GROUP"),
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "ctl-admin-group",
+			Usage:    "Grant the members of this `group` full access to the unix socket, like root",
+			Sources:  cli.EnvVars("NEXD_CTL_ADMIN_GROUP"),
+			Required: false,
 		})
 }
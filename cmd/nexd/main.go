@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"math"
+	"net"
 	"net/url"
 	"os"
 	"os/signal"
@@ -14,6 +15,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/nexodus-io/nexodus/internal/state/fstore"
 	"github.com/nexodus-io/nexodus/internal/state/kstore"
@@ -33,6 +35,8 @@ const (
 	derpOptions       = "DERP Options"
 	agentOptions      = "Agent Options"
 	nexServiceOptions = "Nexodus Service Options"
+	// the number of log lines included in a bug report
+	logBufferLines = 2000
 )
 
 type nexdMode int
@@ -51,12 +55,20 @@ var Version = "dev"
 // Optionally set at build time using ldflags
 var DefaultServiceURL = "https://try.nexodus.io"
 
-func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logLevel *zap.AtomicLevel, mode nexdMode) error {
+func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logLevel *zap.AtomicLevel, logBuffer *nexodus.LogBuffer, cfg *nexdConfig, mode nexdMode) error {
 
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT)
 	defer cancel()
 	wg := &sync.WaitGroup{}
 
+	if command.IsSet("log-level") {
+		level, err := zapcore.ParseLevel(command.String("log-level"))
+		if err != nil {
+			return fmt.Errorf("invalid '--log-level=%s' flag provided. error: %w", command.String("log-level"), err)
+		}
+		logLevel.SetLevel(level)
+	}
+
 	if mode == nexdModeRelayDerp && !command.Bool("onboard") {
 		derper := nexodus.NewDerper(ctx, command, wg, logger.Sugar())
 		derper.StartDerp()
@@ -147,6 +159,7 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 	relayNode := false
 	relayDerpNode := false
 	var advertiseCidr []string
+	var routerPriority int
 	switch mode {
 	case nexdModeAgent:
 		logger.Info("Starting node agent with wireguard driver")
@@ -157,6 +170,7 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 			logger.Warn("DEPRECATION WARNING: The 'child-prefix' flag is deprecated. In the future, please use 'advertise-cidr' instead.")
 			advertiseCidr = append(advertiseCidr, command.StringSlice("child-prefix")...)
 		}
+		routerPriority = int(command.Int("router-priority"))
 		logger.Info("Starting node agent with wireguard driver and router function")
 	case nexdModeRelay:
 		relayNode = true
@@ -177,6 +191,22 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 		stun.SetServers(stunServers)
 	}
 
+	var hooks []nexodus.Hook
+	for _, value := range command.StringSlice("hook") {
+		hook, err := nexodus.ParseHook(value)
+		if err != nil {
+			return err
+		}
+		hooks = append(hooks, hook)
+	}
+	for _, value := range command.StringSlice("webhook") {
+		hook, err := nexodus.ParseWebhook(value)
+		if err != nil {
+			return err
+		}
+		hooks = append(hooks, hook)
+	}
+
 	stateStore, err := kstore.NewIfInCluster()
 	if err != nil {
 		log.Error(err)
@@ -184,13 +214,19 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 
 	stateDir := command.String("state-dir")
 	if stateStore == nil {
-		stateStore = fstore.New(filepath.Join(stateDir, "state.json"))
+		keyProvider, err := fstore.ParseKeyProvider(command.String("state-key-provider"))
+		if err != nil {
+			return fmt.Errorf("invalid --state-key-provider: %w", err)
+		}
+		stateStore = fstore.NewWithKeyProvider(filepath.Join(stateDir, "state.json"), keyProvider)
 	}
 	defer util.IgnoreError(stateStore.Close)
 
 	options := nexodus.Options{
 		Logger:                  logger.Sugar(),
 		LogLevel:                logLevel,
+		LogBuffer:               logBuffer,
+		MetricsListen:           command.String("metrics-listen"),
 		ApiURL:                  apiURL,
 		RegKey:                  regKey,
 		Username:                command.String("username"),
@@ -199,6 +235,7 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 		RequestedIP:             command.String("request-ip"),
 		UserProvidedLocalIP:     command.String("local-endpoint-ip"),
 		AdvertiseCidrs:          advertiseCidr,
+		RouterPriority:          routerPriority,
 		Relay:                   relayNode,
 		RelayDerp:               relayDerpNode,
 		RelayOnly:               command.Bool("relay-only"),
@@ -206,14 +243,21 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 		NetworkRouterDisableNAT: command.Bool("disable-nat"),
 		ExitNodeClientEnabled:   command.Bool("exit-node-client"),
 		ExitNodeOriginEnabled:   command.Bool("exit-node"),
+		Hooks:                   hooks,
+		HookTimeout:             command.Duration("hook-timeout"),
 		InsecureSkipTlsVerify:   command.Bool("insecure-skip-tls-verify"),
+		LazyPeers:               command.Bool("lazy-peers"),
+		LazyPeerIdleTimeout:     command.Duration("lazy-peer-idle-timeout"),
 		Version:                 Version,
 		UserspaceMode:           userspaceMode,
 		StateStore:              stateStore,
 		StateDir:                stateDir,
 		Context:                 ctx,
+		CtlAdminGroup:           command.String("ctl-admin-group"),
+		CtlReadGroup:            command.String("ctl-read-group"),
 		VpcId:                   parseUUIDFlag(command, "vpc-id"),
 		SecurityGroupId:         parseUUIDFlag(command, "security-group-id"),
+		Subnet:                  command.String("subnet"),
 	}
 
 	if relayDerpNode {
@@ -225,25 +269,24 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 		logger.Fatal(err.Error())
 	}
 
+	var proxyRules []nexodus.ProxyRule
 	for _, egressRule := range command.StringSlice("egress") {
 		rule, err := nexodus.ParseProxyRule(egressRule, nexodus.ProxyTypeEgress)
 		if err != nil {
 			logger.Fatal(fmt.Sprintf("Failed to add egress proxy rule (%s): %v", egressRule, err))
 		}
-		_, err = nex.UserspaceProxyAdd(rule)
-		if err != nil {
-			logger.Fatal(fmt.Sprintf("Failed to add egress proxy rule (%s): %v", egressRule, err))
-		}
+		proxyRules = append(proxyRules, rule)
 	}
 	for _, ingressRule := range command.StringSlice("ingress") {
 		rule, err := nexodus.ParseProxyRule(ingressRule, nexodus.ProxyTypeIngress)
 		if err != nil {
 			logger.Fatal(fmt.Sprintf("Failed to add ingress proxy rule (%s): %v", ingressRule, err))
 		}
-		_, err = nex.UserspaceProxyAdd(rule)
-		if err != nil {
-			logger.Fatal(fmt.Sprintf("Failed to add ingress proxy rule (%s): %v", ingressRule, err))
-		}
+		proxyRules = append(proxyRules, rule)
+	}
+	err = nex.SetConfiguredProxyRules(proxyRules)
+	if err != nil {
+		logger.Fatal(err.Error())
 	}
 	err = nex.LoadProxyRules()
 	if err != nil {
@@ -254,7 +297,25 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 		logger.Fatal(err.Error())
 	}
 
-	<-ctx.Done()
+	reload := make(chan os.Signal, 1)
+	if cfg.path != "" {
+		nex.SetReloadHandler(func() error {
+			return cfg.reload(command, nex, logger, logLevel, mode)
+		})
+		signal.Notify(reload, syscall.SIGHUP)
+		defer signal.Stop(reload)
+	}
+
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-reload:
+			if err := cfg.reload(command, nex, logger, logLevel, mode); err != nil {
+				logger.Error("Failed to reload the config file", zap.Error(err))
+			}
+		}
+	}
 	nex.Stop()
 	wg.Wait()
 
@@ -278,25 +339,30 @@ var additionalPlatformFlags []cli.Flag = nil
 func main() {
 	// set the log level
 	debug := os.Getenv(nexodusLogEnv)
-	var logger *zap.Logger
-	var logLevel *zap.AtomicLevel
-	var err error
+	var logCfg zap.Config
 	if debug != "" {
-		logCfg := zap.NewDevelopmentConfig()
-		logLevel = &logCfg.Level
-		logger, err = logCfg.Build()
-		logger.Info("Debug logging enabled")
+		logCfg = zap.NewDevelopmentConfig()
 	} else {
-		logCfg := zap.NewProductionConfig()
-		logLevel = &logCfg.Level
+		logCfg = zap.NewProductionConfig()
 		logCfg.DisableStacktrace = true
 		logCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		logger, err = logCfg.Build()
 	}
+	logLevel := &logCfg.Level
+	// keep the recent logs in memory for 'nexctl nexd bugreport'
+	logBuffer := nexodus.NewLogBuffer(logBufferLines)
+	logger, err := logCfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, zapcore.NewCore(zapcore.NewJSONEncoder(logCfg.EncoderConfig), logBuffer, logLevel))
+	}))
 	if err != nil {
-		logger.Fatal(err.Error())
+		log.Fatal(err)
+	}
+	if debug != "" {
+		logger.Info("Debug logging enabled")
 	}
 
+	// settings from the --config file, applied by the Before hook of each command
+	cfg := &nexdConfig{}
+
 	// Overwrite usage to capitalize "Show"
 	cli.HelpFlag.(*cli.BoolFlag).Usage = "Show help"
 	// flags are stored in the global flags variable
@@ -316,18 +382,21 @@ func main() {
 			{
 				Name:  "proxy",
 				Usage: "Run nexd as an L4 proxy instead of creating a network interface",
+				Before: func(ctx context.Context, command *cli.Command) error {
+					return cfg.apply(command)
+				},
 				Action: func(ctx context.Context, command *cli.Command) error {
-					return nexdRun(ctx, command, logger, logLevel, nexdModeProxy)
+					return nexdRun(ctx, command, logger, logLevel, logBuffer, cfg, nexdModeProxy)
 				},
 				Flags: []cli.Flag{
 					&cli.StringSliceFlag{
 						Name:     "ingress",
-						Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+						Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 						Required: false,
 					},
 					&cli.StringSliceFlag{
 						Name:     "egress",
-						Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+						Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port[/option]. All fields are required. TCP rules accept the send-proxy-v1, send-proxy-v2 and accept-proxy options.",
 						Required: false,
 					},
 				},
@@ -335,6 +404,9 @@ func main() {
 			{
 				Name:  "router",
 				Usage: "Enable advertise-cidr function of the node agent to enable prefix forwarding.",
+				Before: func(ctx context.Context, command *cli.Command) error {
+					return cfg.apply(command)
+				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					if command.Bool("exit-node") {
 						if runtime.GOOS != nexodus.Linux.String() {
@@ -358,7 +430,7 @@ func main() {
 							}
 						}
 					}
-					return nexdRun(ctx, command, logger, logLevel, nexdModeRouter)
+					return nexdRun(ctx, command, logger, logLevel, logBuffer, cfg, nexdModeRouter)
 				},
 
 				Flags: []cli.Flag{
@@ -410,22 +482,35 @@ func main() {
 						Sources:  cli.EnvVars("NEXD_EXIT_NODE"),
 						Required: false,
 					},
+					&cli.IntFlag{
+						Name:     "router-priority",
+						Usage:    "The `priority` of this node when other nodes advertise the same --advertise-cidr. Peers route the prefix to the online node with the highest priority",
+						Value:    0,
+						Sources:  cli.EnvVars("NEXD_ROUTER_PRIORITY"),
+						Required: false,
+					},
 				},
 			},
 			{
 				Name:  "relay",
 				Usage: "Enable relay and discovery support function for the node agent.",
+				Before: func(ctx context.Context, command *cli.Command) error {
+					return cfg.apply(command)
+				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					if runtime.GOOS != nexodus.Linux.String() {
 						return fmt.Errorf("Relay node is only supported for Linux Operating System")
 					}
 
-					return nexdRun(ctx, command, logger, logLevel, nexdModeRelay)
+					return nexdRun(ctx, command, logger, logLevel, logBuffer, cfg, nexdModeRelay)
 				},
 			},
 			{
 				Name:  "relayderp",
 				Usage: "Enable DERP relay to relay traffic between nexd nodes.",
+				Before: func(ctx context.Context, command *cli.Command) error {
+					return cfg.apply(command)
+				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					if command.String("certmode") == "manual" {
 						if command.String("certdir") == "" {
@@ -440,7 +525,7 @@ func main() {
 							return fmt.Errorf("hostname is required for onboarding.")
 						}
 					}
-					return nexdRun(ctx, command, logger, logLevel, nexdModeRelayDerp)
+					return nexdRun(ctx, command, logger, logLevel, logBuffer, cfg, nexdModeRelayDerp)
 				},
 
 				Flags: []cli.Flag{
@@ -576,6 +661,77 @@ func main() {
 			},
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:       "config",
+				Usage:      "Path to a YAML or TOML config `file` with settings for the flags of nexd. Send SIGHUP or run 'nexctl nexd reload' to reload it",
+				Sources:    cli.EnvVars("NEXD_CONFIG"),
+				Required:   false,
+				Persistent: true,
+			},
+			&cli.StringFlag{
+				Name:       "log-level",
+				Usage:      "Log `level` (debug, info, warn or error)",
+				Sources:    cli.EnvVars(nexodusLogEnv),
+				Required:   false,
+				Persistent: true,
+				Action: func(ctx context.Context, command *cli.Command, level string) error {
+					if _, err := zapcore.ParseLevel(level); err != nil {
+						return fmt.Errorf("invalid '--log-level=%s' flag provided. error: %w", level, err)
+					}
+					return nil
+				},
+			},
+			&cli.StringFlag{
+				Name:       "metrics-listen",
+				Usage:      "Serve prometheus metrics on `address` (e.g. 127.0.0.1:9190), disabled when empty",
+				Sources:    cli.EnvVars("NEXD_METRICS_LISTEN"),
+				Required:   false,
+				Persistent: true,
+				Action: func(ctx context.Context, command *cli.Command, address string) error {
+					if _, _, err := net.SplitHostPort(address); err != nil {
+						return fmt.Errorf("invalid '--metrics-listen=%s' flag provided. error: %w", address, err)
+					}
+					return nil
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:       "hook",
+				Usage:      "Run a command when an event happens, using a `value` in the form: event=command. The event is passed as JSON on the standard input of the command. Use * as the event to run the command on every event",
+				Sources:    cli.EnvVars("NEXD_HOOK"),
+				Required:   false,
+				Persistent: true,
+				Action: func(ctx context.Context, command *cli.Command, hooks []string) error {
+					for _, hook := range hooks {
+						if _, err := nexodus.ParseHook(hook); err != nil {
+							return fmt.Errorf("invalid '--hook=%s' flag provided. error: %w", hook, err)
+						}
+					}
+					return nil
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:       "webhook",
+				Usage:      "POST the event as JSON to a URL when it happens, using a `value` in the form: event=url. Use * as the event to call the URL on every event",
+				Sources:    cli.EnvVars("NEXD_WEBHOOK"),
+				Required:   false,
+				Persistent: true,
+				Action: func(ctx context.Context, command *cli.Command, webhooks []string) error {
+					for _, webhook := range webhooks {
+						if _, err := nexodus.ParseWebhook(webhook); err != nil {
+							return fmt.Errorf("invalid '--webhook=%s' flag provided. error: %w", webhook, err)
+						}
+					}
+					return nil
+				},
+			},
+			&cli.DurationFlag{
+				Name:       "hook-timeout",
+				Value:      30 * time.Second,
+				Usage:      "Maximum `duration` of a hook command or webhook call",
+				Sources:    cli.EnvVars("NEXD_HOOK_TIMEOUT"),
+				Required:   false,
+				Persistent: true,
+			},
 			&cli.IntFlag{
 				Name:       "listen-port",
 				Value:      0,
@@ -631,6 +787,24 @@ func main() {
 				Category:   agentOptions,
 				Persistent: true,
 			},
+			&cli.BoolFlag{
+				Name:       "lazy-peers",
+				Usage:      "Only configure a wireguard peer while there is traffic to or from it, for very large VPCs. Relays and routers are always configured (linux only)",
+				Value:      false,
+				Sources:    cli.EnvVars("NEXD_LAZY_PEERS"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
+			&cli.DurationFlag{
+				Name:       "lazy-peer-idle-timeout",
+				Value:      5 * time.Minute,
+				Usage:      "Remove a lazy peer from the wireguard configuration once there was no traffic to or from it for this `duration`",
+				Sources:    cli.EnvVars("NEXD_LAZY_PEER_IDLE_TIMEOUT"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
 			&cli.StringFlag{
 				Name:       "username",
 				Value:      "",
@@ -667,6 +841,14 @@ func main() {
 				Category:    nexServiceOptions,
 				Persistent:  true,
 			},
+			&cli.StringFlag{
+				Name:       "state-key-provider",
+				Usage:      "Encrypt the state with a key from a provider: none, passphrase (read from $NEXD_STATE_PASSPHRASE), passphrase-file:<path>, key-file:<path>, systemd-creds[:<name>] or keyring[:<description>]",
+				Value:      "none",
+				Sources:    cli.EnvVars("NEXD_STATE_KEY_PROVIDER"),
+				Category:   nexServiceOptions,
+				Persistent: true,
+			},
 			&cli.StringSliceFlag{
 				Name:       "stun-server",
 				Usage:      "stun server to use discover our endpoint address.  At least two are required.",
@@ -682,6 +864,14 @@ func main() {
 				Category:   nexServiceOptions,
 				Persistent: true,
 			},
+			&cli.StringFlag{
+				Name:       "subnet",
+				Usage:      "Name or ID of the subnet of the VPC to join when registering with the nexodus service",
+				Sources:    cli.EnvVars("NEXD_SUBNET"),
+				Required:   false,
+				Category:   nexServiceOptions,
+				Persistent: true,
+			},
 			&cli.StringFlag{
 				Name:       "service-url",
 				Usage:      "URL to the Nexodus service",
@@ -716,6 +906,9 @@ func main() {
 			},
 		},
 		Before: func(ctx context.Context, command *cli.Command) error {
+			if err := cfg.apply(command); err != nil {
+				return err
+			}
 			if command.Bool("network-router") {
 				if runtime.GOOS != nexodus.Linux.String() {
 					return fmt.Errorf("network-router mode is only supported for Linux operating systems")
@@ -732,7 +925,7 @@ func main() {
 			return nil
 		},
 		Action: func(ctx context.Context, command *cli.Command) error {
-			return nexdRun(ctx, command, logger, logLevel, nexdModeAgent)
+			return nexdRun(ctx, command, logger, logLevel, logBuffer, cfg, nexdModeAgent)
 		},
 	}
 
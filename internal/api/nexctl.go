@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 type PingPeersResponse struct {
 	RelayPresent  bool                       `json:"relay-present"`
 	RelayRequired bool                       `json:"relay-required"`
@@ -13,3 +15,152 @@ type KeepaliveStatus struct {
 	Latency     string `json:""`
 	Method      string `json:"method"`
 }
+
+type DiagnosePeerResponse struct {
+	NatType          string       `json:"nat_type"`
+	ReflexiveAddress string       `json:"reflexive_address"`
+	RelayRequired    bool         `json:"relay_required"`
+	StunResults      []StunResult `json:"stun_results"`
+	Peer             DiagnosePeer `json:"peer"`
+	Routes           string       `json:"routes"`
+	Firewall         string       `json:"firewall"`
+}
+
+type StunResult struct {
+	Server  string    `json:"server"`
+	Address string    `json:"address,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+type DiagnosePeer struct {
+	PublicKey          string              `json:"public_key"`
+	DeviceId           string              `json:"device_id"`
+	Hostname           string              `json:"hostname"`
+	TunnelIPs          []string            `json:"tunnel_ips"`
+	Online             bool                `json:"online"`
+	Relay              bool                `json:"relay"`
+	SymmetricNat       bool                `json:"symmetric_nat"`
+	EndpointCandidates []EndpointCandidate `json:"endpoint_candidates"`
+	ConfiguredEndpoint string              `json:"configured_endpoint"`
+	AllowedIPs         []string            `json:"allowed_ips"`
+	PeeringMethod      string              `json:"peering_method"`
+	PeeringTime        time.Time           `json:"peering_time"`
+	PeeringHistory     []PeeringAttempt    `json:"peering_history"`
+	Healthy            bool                `json:"healthy"`
+	LatestHandshake    time.Time           `json:"latest_handshake"`
+	HandshakeAge       string              `json:"handshake_age"`
+	Tx                 int64               `json:"tx"`
+	Rx                 int64               `json:"rx"`
+}
+
+type EndpointCandidate struct {
+	Source  string `json:"source"`
+	Address string `json:"address"`
+}
+
+type PeeringAttempt struct {
+	Method   string    `json:"method"`
+	Endpoint string    `json:"endpoint"`
+	Time     time.Time `json:"time"`
+}
+
+type BugReportResponse struct {
+	// Files maps the name of each file of the bug report to its already redacted content
+	Files map[string]string `json:"files"`
+}
+
+// CtlApiPrefix is the path prefix of the versioned control API that nexd serves over HTTP on its unix socket.
+const CtlApiPrefix = "/v1"
+
+// CtlApiError is the body of the control API responses for failed requests.
+type CtlApiError struct {
+	Error string `json:"error"`
+}
+
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+type StatusResponse struct {
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	TunnelIPv4 string `json:"tunnel_ipv4,omitempty"`
+	TunnelIPv6 string `json:"tunnel_ipv6,omitempty"`
+}
+
+type ListPeersResponse struct {
+	RelayPresent  bool            `json:"relay-present"`
+	RelayRequired bool            `json:"relay-required"`
+	Peers         map[string]Peer `json:"peers"`
+}
+
+type Peer struct {
+	PublicKey       string
+	Endpoint        string
+	AllowedIPs      []string
+	LatestHandshake string
+	Tx              int64
+	Rx              int64
+	Healthy         bool
+}
+
+type ProxyRule struct {
+	// Type is either ingress or egress
+	Type string `json:"type"`
+	// Rule is in the form protocol:port:destination_ip:destination_port[/option...]
+	Rule string `json:"rule"`
+}
+
+type DebugSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// StateRekeyRequest re-encrypts the nexd state with the key of a key provider, in the format of the
+// --state-key-provider flag of nexd. Passphrase overrides the passphrase of the passphrase provider.
+type StateRekeyRequest struct {
+	KeyProvider string `json:"key-provider"`
+	Passphrase  string `json:"passphrase,omitempty"`
+}
+
+type ExitNodeClientSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+type ExitNode struct {
+	PublicKey  string   `json:"public_key"`
+	Endpoint   string   `json:"endpoint"`
+	AllowedIPs []string `json:"allowed_ips,omitempty"`
+}
+
+// The types of the events streamed by the control API
+const (
+	NexdEventTunnelUp             = "tunnel-up"
+	NexdEventTunnelDown           = "tunnel-down"
+	NexdEventTunnelIPChanged      = "tunnel-ip-changed"
+	NexdEventExitNodeChanged      = "exit-node-changed"
+	NexdEventPeerAdded            = "peer-added"
+	NexdEventPeerRemoved          = "peer-removed"
+	NexdEventPeerUp               = "peer-up"
+	NexdEventPeerDown             = "peer-down"
+	NexdEventPeeringMethodChanged = "peering-method-changed"
+	NexdEventStatusChanged        = "status-changed"
+	NexdEventSubnetChanged        = "subnet-changed"
+)
+
+// NexdEvent is a change in the state of nexd, streamed as newline delimited JSON by the events endpoint of the control API.
+type NexdEvent struct {
+	Type             string    `json:"type"`
+	Time             time.Time `json:"time"`
+	PublicKey        string    `json:"public_key,omitempty"`
+	DeviceId         string    `json:"device_id,omitempty"`
+	Hostname         string    `json:"hostname,omitempty"`
+	PeeringMethod    string    `json:"peering_method,omitempty"`
+	TunnelIPv4       string    `json:"tunnel_ipv4,omitempty"`
+	TunnelIPv6       string    `json:"tunnel_ipv6,omitempty"`
+	Status           string    `json:"status,omitempty"`
+	Message          string    `json:"message,omitempty"`
+	Subnet           string    `json:"subnet,omitempty"`
+	DnsServers       []string  `json:"dns_servers,omitempty"`
+	DnsSearchDomains []string  `json:"dns_search_domains,omitempty"`
+}
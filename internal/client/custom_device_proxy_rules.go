@@ -0,0 +1,40 @@
+package client
+
+import (
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// Informer creates a *ListInformer which provides a simpler
+// API to list the proxy rules of a device but which is implemented with the Watch api.  The *ListInformer
+// maintains a local proxy rule cache which gets updated with the Watch events.
+func (r ApiListDeviceProxyRulesRequest) Informer() *ListInformer[ModelsProxyRule] {
+	informer := NewInformer[ModelsProxyRule](&ProxyRuleAdaptor{}, r.gtRevision, ApiWatchRequest{
+		ctx:        r.ctx,
+		ApiService: r.ApiService.client.EventsApi,
+	}, map[string]interface{}{
+		"device-id": r.id,
+	})
+	return informer
+}
+
+type ProxyRuleAdaptor struct{}
+
+func (d ProxyRuleAdaptor) Revision(item ModelsProxyRule) int32 {
+	return item.GetRevision()
+}
+
+func (d ProxyRuleAdaptor) Key(item ModelsProxyRule) string {
+	return item.GetId()
+}
+
+func (d ProxyRuleAdaptor) Kind() string {
+	return "proxy-rule"
+}
+
+func (d ProxyRuleAdaptor) Item(value map[string]interface{}) (ModelsProxyRule, error) {
+	item := ModelsProxyRule{}
+	err := util.JsonUnmarshal(value, &item)
+	return item, err
+}
+
+var _ InformerAdaptor[ModelsProxyRule] = &ProxyRuleAdaptor{}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// Informer creates a *ListInformer of the VPCs of the organization of the VPC, keyed by their ids, which is
+// implemented with the Watch api. The *ListInformer is signaled when the VPC changes.
+func (r ApiGetVPCRequest) Informer() *ListInformer[ModelsVPC] {
+	informer := NewInformer[ModelsVPC](&VPCAdaptor{}, nil, ApiWatchRequest{
+		ctx:        r.ctx,
+		ApiService: r.ApiService.client.EventsApi,
+	}, map[string]interface{}{
+		"vpc-id": r.id,
+	})
+	return informer
+}
+
+type VPCAdaptor struct{}
+
+func (d VPCAdaptor) Revision(item ModelsVPC) int32 {
+	return item.GetRevision()
+}
+
+func (d VPCAdaptor) Key(item ModelsVPC) string {
+	return item.GetId()
+}
+
+func (d VPCAdaptor) Kind() string {
+	return "vpc"
+}
+
+func (d VPCAdaptor) Item(value map[string]interface{}) (ModelsVPC, error) {
+	item := ModelsVPC{}
+	err := util.JsonUnmarshal(value, &item)
+	return item, err
+}
+
+var _ InformerAdaptor[ModelsVPC] = &VPCAdaptor{}
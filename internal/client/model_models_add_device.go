@@ -26,7 +26,9 @@ type ModelsAddDevice struct {
 	Os              *string          `json:"os,omitempty"`
 	PublicKey       *string          `json:"public_key,omitempty"`
 	Relay           *bool            `json:"relay,omitempty"`
+	RouterPriority  *int32           `json:"router_priority,omitempty"`
 	SecurityGroupId *string          `json:"security_group_id,omitempty"`
+	SubnetId        *string          `json:"subnet_id,omitempty"`
 	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
 	VpcId           *string          `json:"vpc_id,omitempty"`
 }
@@ -272,6 +274,38 @@ func (o *ModelsAddDevice) SetRelay(v bool) {
 	o.Relay = &v
 }
 
+// GetRouterPriority returns the RouterPriority field value if set, zero value otherwise.
+func (o *ModelsAddDevice) GetRouterPriority() int32 {
+	if o == nil || IsNil(o.RouterPriority) {
+		var ret int32
+		return ret
+	}
+	return *o.RouterPriority
+}
+
+// GetRouterPriorityOk returns a tuple with the RouterPriority field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddDevice) GetRouterPriorityOk() (*int32, bool) {
+	if o == nil || IsNil(o.RouterPriority) {
+		return nil, false
+	}
+	return o.RouterPriority, true
+}
+
+// HasRouterPriority returns a boolean if a field has been set.
+func (o *ModelsAddDevice) HasRouterPriority() bool {
+	if o != nil && !IsNil(o.RouterPriority) {
+		return true
+	}
+
+	return false
+}
+
+// SetRouterPriority gets a reference to the given int32 and assigns it to the RouterPriority field.
+func (o *ModelsAddDevice) SetRouterPriority(v int32) {
+	o.RouterPriority = &v
+}
+
 // GetSecurityGroupId returns the SecurityGroupId field value if set, zero value otherwise.
 func (o *ModelsAddDevice) GetSecurityGroupId() string {
 	if o == nil || IsNil(o.SecurityGroupId) {
@@ -304,6 +338,38 @@ func (o *ModelsAddDevice) SetSecurityGroupId(v string) {
 	o.SecurityGroupId = &v
 }
 
+// GetSubnetId returns the SubnetId field value if set, zero value otherwise.
+func (o *ModelsAddDevice) GetSubnetId() string {
+	if o == nil || IsNil(o.SubnetId) {
+		var ret string
+		return ret
+	}
+	return *o.SubnetId
+}
+
+// GetSubnetIdOk returns a tuple with the SubnetId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddDevice) GetSubnetIdOk() (*string, bool) {
+	if o == nil || IsNil(o.SubnetId) {
+		return nil, false
+	}
+	return o.SubnetId, true
+}
+
+// HasSubnetId returns a boolean if a field has been set.
+func (o *ModelsAddDevice) HasSubnetId() bool {
+	if o != nil && !IsNil(o.SubnetId) {
+		return true
+	}
+
+	return false
+}
+
+// SetSubnetId gets a reference to the given string and assigns it to the SubnetId field.
+func (o *ModelsAddDevice) SetSubnetId(v string) {
+	o.SubnetId = &v
+}
+
 // GetSymmetricNat returns the SymmetricNat field value if set, zero value otherwise.
 func (o *ModelsAddDevice) GetSymmetricNat() bool {
 	if o == nil || IsNil(o.SymmetricNat) {
@@ -399,9 +465,15 @@ func (o ModelsAddDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Relay) {
 		toSerialize["relay"] = o.Relay
 	}
+	if !IsNil(o.RouterPriority) {
+		toSerialize["router_priority"] = o.RouterPriority
+	}
 	if !IsNil(o.SecurityGroupId) {
 		toSerialize["security_group_id"] = o.SecurityGroupId
 	}
+	if !IsNil(o.SubnetId) {
+		toSerialize["subnet_id"] = o.SubnetId
+	}
 	if !IsNil(o.SymmetricNat) {
 		toSerialize["symmetric_nat"] = o.SymmetricNat
 	}
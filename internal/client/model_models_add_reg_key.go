@@ -19,6 +19,8 @@ var _ MappedNullable = &ModelsAddRegKey{}
 
 // ModelsAddRegKey struct for ModelsAddRegKey
 type ModelsAddRegKey struct {
+	// AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
+	AutoApproveCidrs []string `json:"auto_approve_cidrs,omitempty"`
 	// Description of the registration key.
 	Description *string `json:"description,omitempty"`
 	// ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
@@ -32,7 +34,8 @@ type ModelsAddRegKey struct {
 	// SingleUse only allows the registration key to be used once.
 	SingleUse *bool `json:"single_use,omitempty"`
 	// VpcID is the ID of the VPC the device will join.
-	VpcId *string `json:"vpc_id,omitempty"`
+	SubnetId *string `json:"subnet_id,omitempty"`
+	VpcId    *string `json:"vpc_id,omitempty"`
 }
 
 // NewModelsAddRegKey instantiates a new ModelsAddRegKey object
@@ -52,6 +55,38 @@ func NewModelsAddRegKeyWithDefaults() *ModelsAddRegKey {
 	return &this
 }
 
+// GetAutoApproveCidrs returns the AutoApproveCidrs field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetAutoApproveCidrs() []string {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.AutoApproveCidrs
+}
+
+// GetAutoApproveCidrsOk returns a tuple with the AutoApproveCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetAutoApproveCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		return nil, false
+	}
+	return o.AutoApproveCidrs, true
+}
+
+// HasAutoApproveCidrs returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasAutoApproveCidrs() bool {
+	if o != nil && !IsNil(o.AutoApproveCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetAutoApproveCidrs gets a reference to the given []string and assigns it to the AutoApproveCidrs field.
+func (o *ModelsAddRegKey) SetAutoApproveCidrs(v []string) {
+	o.AutoApproveCidrs = v
+}
+
 // GetDescription returns the Description field value if set, zero value otherwise.
 func (o *ModelsAddRegKey) GetDescription() string {
 	if o == nil || IsNil(o.Description) {
@@ -244,6 +279,38 @@ func (o *ModelsAddRegKey) SetSingleUse(v bool) {
 	o.SingleUse = &v
 }
 
+// GetSubnetId returns the SubnetId field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetSubnetId() string {
+	if o == nil || IsNil(o.SubnetId) {
+		var ret string
+		return ret
+	}
+	return *o.SubnetId
+}
+
+// GetSubnetIdOk returns a tuple with the SubnetId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetSubnetIdOk() (*string, bool) {
+	if o == nil || IsNil(o.SubnetId) {
+		return nil, false
+	}
+	return o.SubnetId, true
+}
+
+// HasSubnetId returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasSubnetId() bool {
+	if o != nil && !IsNil(o.SubnetId) {
+		return true
+	}
+
+	return false
+}
+
+// SetSubnetId gets a reference to the given string and assigns it to the SubnetId field.
+func (o *ModelsAddRegKey) SetSubnetId(v string) {
+	o.SubnetId = &v
+}
+
 // GetVpcId returns the VpcId field value if set, zero value otherwise.
 func (o *ModelsAddRegKey) GetVpcId() string {
 	if o == nil || IsNil(o.VpcId) {
@@ -286,6 +353,9 @@ func (o ModelsAddRegKey) MarshalJSON() ([]byte, error) {
 
 func (o ModelsAddRegKey) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.AutoApproveCidrs) {
+		toSerialize["auto_approve_cidrs"] = o.AutoApproveCidrs
+	}
 	if !IsNil(o.Description) {
 		toSerialize["description"] = o.Description
 	}
@@ -304,6 +374,9 @@ func (o ModelsAddRegKey) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.SingleUse) {
 		toSerialize["single_use"] = o.SingleUse
 	}
+	if !IsNil(o.SubnetId) {
+		toSerialize["subnet_id"] = o.SubnetId
+	}
 	if !IsNil(o.VpcId) {
 		toSerialize["vpc_id"] = o.VpcId
 	}
@@ -24,6 +24,7 @@ type ModelsAddVPC struct {
 	Ipv6Cidr       *string `json:"ipv6_cidr,omitempty"`
 	OrganizationId *string `json:"organization_id,omitempty"`
 	PrivateCidr    *bool   `json:"private_cidr,omitempty"`
+	Topology       *string `json:"topology,omitempty"`
 }
 
 // NewModelsAddVPC instantiates a new ModelsAddVPC object
@@ -203,6 +204,38 @@ func (o *ModelsAddVPC) SetPrivateCidr(v bool) {
 	o.PrivateCidr = &v
 }
 
+// GetTopology returns the Topology field value if set, zero value otherwise.
+func (o *ModelsAddVPC) GetTopology() string {
+	if o == nil || IsNil(o.Topology) {
+		var ret string
+		return ret
+	}
+	return *o.Topology
+}
+
+// GetTopologyOk returns a tuple with the Topology field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddVPC) GetTopologyOk() (*string, bool) {
+	if o == nil || IsNil(o.Topology) {
+		return nil, false
+	}
+	return o.Topology, true
+}
+
+// HasTopology returns a boolean if a field has been set.
+func (o *ModelsAddVPC) HasTopology() bool {
+	if o != nil && !IsNil(o.Topology) {
+		return true
+	}
+
+	return false
+}
+
+// SetTopology gets a reference to the given string and assigns it to the Topology field.
+func (o *ModelsAddVPC) SetTopology(v string) {
+	o.Topology = &v
+}
+
 func (o ModelsAddVPC) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -228,6 +261,9 @@ func (o ModelsAddVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.PrivateCidr) {
 		toSerialize["private_cidr"] = o.PrivateCidr
 	}
+	if !IsNil(o.Topology) {
+		toSerialize["topology"] = o.Topology
+	}
 	return toSerialize, nil
 }
 
@@ -21,23 +21,32 @@ var _ MappedNullable = &ModelsDevice{}
 type ModelsDevice struct {
 	AdvertiseCidrs []string `json:"advertise_cidrs,omitempty"`
 	AllowedIps     []string `json:"allowed_ips,omitempty"`
+	// the advertised CIDRs an organization owner approved, only these are routed by peers
+	ApprovedCidrs []string `json:"approved_cidrs,omitempty"`
 	// the token nexd should use to reconcile device state.
-	BearerToken     *string          `json:"bearer_token,omitempty"`
-	Endpoints       []ModelsEndpoint `json:"endpoints,omitempty"`
-	Hostname        *string          `json:"hostname,omitempty"`
-	Id              *string          `json:"id,omitempty"`
-	Ipv4TunnelIps   []ModelsTunnelIP `json:"ipv4_tunnel_ips,omitempty"`
-	Ipv6TunnelIps   []ModelsTunnelIP `json:"ipv6_tunnel_ips,omitempty"`
-	Online          *bool            `json:"online,omitempty"`
-	OnlineAt        *string          `json:"online_at,omitempty"`
-	Os              *string          `json:"os,omitempty"`
-	OwnerId         *string          `json:"owner_id,omitempty"`
-	PublicKey       *string          `json:"public_key,omitempty"`
-	Relay           *bool            `json:"relay,omitempty"`
-	Revision        *int32           `json:"revision,omitempty"`
-	SecurityGroupId *string          `json:"security_group_id,omitempty"`
-	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
-	VpcId           *string          `json:"vpc_id,omitempty"`
+	BearerToken   *string          `json:"bearer_token,omitempty"`
+	Endpoints     []ModelsEndpoint `json:"endpoints,omitempty"`
+	Hostname      *string          `json:"hostname,omitempty"`
+	Hub           *bool            `json:"hub,omitempty"`
+	Id            *string          `json:"id,omitempty"`
+	Ipv4TunnelIps []ModelsTunnelIP `json:"ipv4_tunnel_ips,omitempty"`
+	Ipv6TunnelIps []ModelsTunnelIP `json:"ipv6_tunnel_ips,omitempty"`
+	Online        *bool            `json:"online,omitempty"`
+	OnlineAt      *string          `json:"online_at,omitempty"`
+	Os            *string          `json:"os,omitempty"`
+	OwnerId       *string          `json:"owner_id,omitempty"`
+	PeeringGroups []string         `json:"peering_groups,omitempty"`
+	PublicKey     *string          `json:"public_key,omitempty"`
+	// the advertised CIDRs an organization owner rejected
+	RejectedCidrs []string `json:"rejected_cidrs,omitempty"`
+	Relay         *bool    `json:"relay,omitempty"`
+	Revision      *int32   `json:"revision,omitempty"`
+	// peers route a prefix advertised by several devices to the online device with the highest priority
+	RouterPriority  *int32  `json:"router_priority,omitempty"`
+	SecurityGroupId *string `json:"security_group_id,omitempty"`
+	SubnetId        *string `json:"subnet_id,omitempty"`
+	SymmetricNat    *bool   `json:"symmetric_nat,omitempty"`
+	VpcId           *string `json:"vpc_id,omitempty"`
 }
 
 // NewModelsDevice instantiates a new ModelsDevice object
@@ -121,6 +130,38 @@ func (o *ModelsDevice) SetAllowedIps(v []string) {
 	o.AllowedIps = v
 }
 
+// GetApprovedCidrs returns the ApprovedCidrs field value if set, zero value otherwise.
+func (o *ModelsDevice) GetApprovedCidrs() []string {
+	if o == nil || IsNil(o.ApprovedCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.ApprovedCidrs
+}
+
+// GetApprovedCidrsOk returns a tuple with the ApprovedCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetApprovedCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.ApprovedCidrs) {
+		return nil, false
+	}
+	return o.ApprovedCidrs, true
+}
+
+// HasApprovedCidrs returns a boolean if a field has been set.
+func (o *ModelsDevice) HasApprovedCidrs() bool {
+	if o != nil && !IsNil(o.ApprovedCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetApprovedCidrs gets a reference to the given []string and assigns it to the ApprovedCidrs field.
+func (o *ModelsDevice) SetApprovedCidrs(v []string) {
+	o.ApprovedCidrs = v
+}
+
 // GetBearerToken returns the BearerToken field value if set, zero value otherwise.
 func (o *ModelsDevice) GetBearerToken() string {
 	if o == nil || IsNil(o.BearerToken) {
@@ -217,6 +258,38 @@ func (o *ModelsDevice) SetHostname(v string) {
 	o.Hostname = &v
 }
 
+// GetHub returns the Hub field value if set, zero value otherwise.
+func (o *ModelsDevice) GetHub() bool {
+	if o == nil || IsNil(o.Hub) {
+		var ret bool
+		return ret
+	}
+	return *o.Hub
+}
+
+// GetHubOk returns a tuple with the Hub field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetHubOk() (*bool, bool) {
+	if o == nil || IsNil(o.Hub) {
+		return nil, false
+	}
+	return o.Hub, true
+}
+
+// HasHub returns a boolean if a field has been set.
+func (o *ModelsDevice) HasHub() bool {
+	if o != nil && !IsNil(o.Hub) {
+		return true
+	}
+
+	return false
+}
+
+// SetHub gets a reference to the given bool and assigns it to the Hub field.
+func (o *ModelsDevice) SetHub(v bool) {
+	o.Hub = &v
+}
+
 // GetId returns the Id field value if set, zero value otherwise.
 func (o *ModelsDevice) GetId() string {
 	if o == nil || IsNil(o.Id) {
@@ -441,6 +514,38 @@ func (o *ModelsDevice) SetOwnerId(v string) {
 	o.OwnerId = &v
 }
 
+// GetPeeringGroups returns the PeeringGroups field value if set, zero value otherwise.
+func (o *ModelsDevice) GetPeeringGroups() []string {
+	if o == nil || IsNil(o.PeeringGroups) {
+		var ret []string
+		return ret
+	}
+	return o.PeeringGroups
+}
+
+// GetPeeringGroupsOk returns a tuple with the PeeringGroups field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetPeeringGroupsOk() ([]string, bool) {
+	if o == nil || IsNil(o.PeeringGroups) {
+		return nil, false
+	}
+	return o.PeeringGroups, true
+}
+
+// HasPeeringGroups returns a boolean if a field has been set.
+func (o *ModelsDevice) HasPeeringGroups() bool {
+	if o != nil && !IsNil(o.PeeringGroups) {
+		return true
+	}
+
+	return false
+}
+
+// SetPeeringGroups gets a reference to the given []string and assigns it to the PeeringGroups field.
+func (o *ModelsDevice) SetPeeringGroups(v []string) {
+	o.PeeringGroups = v
+}
+
 // GetPublicKey returns the PublicKey field value if set, zero value otherwise.
 func (o *ModelsDevice) GetPublicKey() string {
 	if o == nil || IsNil(o.PublicKey) {
@@ -473,6 +578,38 @@ func (o *ModelsDevice) SetPublicKey(v string) {
 	o.PublicKey = &v
 }
 
+// GetRejectedCidrs returns the RejectedCidrs field value if set, zero value otherwise.
+func (o *ModelsDevice) GetRejectedCidrs() []string {
+	if o == nil || IsNil(o.RejectedCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.RejectedCidrs
+}
+
+// GetRejectedCidrsOk returns a tuple with the RejectedCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetRejectedCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.RejectedCidrs) {
+		return nil, false
+	}
+	return o.RejectedCidrs, true
+}
+
+// HasRejectedCidrs returns a boolean if a field has been set.
+func (o *ModelsDevice) HasRejectedCidrs() bool {
+	if o != nil && !IsNil(o.RejectedCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetRejectedCidrs gets a reference to the given []string and assigns it to the RejectedCidrs field.
+func (o *ModelsDevice) SetRejectedCidrs(v []string) {
+	o.RejectedCidrs = v
+}
+
 // GetRelay returns the Relay field value if set, zero value otherwise.
 func (o *ModelsDevice) GetRelay() bool {
 	if o == nil || IsNil(o.Relay) {
@@ -537,6 +674,38 @@ func (o *ModelsDevice) SetRevision(v int32) {
 	o.Revision = &v
 }
 
+// GetRouterPriority returns the RouterPriority field value if set, zero value otherwise.
+func (o *ModelsDevice) GetRouterPriority() int32 {
+	if o == nil || IsNil(o.RouterPriority) {
+		var ret int32
+		return ret
+	}
+	return *o.RouterPriority
+}
+
+// GetRouterPriorityOk returns a tuple with the RouterPriority field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetRouterPriorityOk() (*int32, bool) {
+	if o == nil || IsNil(o.RouterPriority) {
+		return nil, false
+	}
+	return o.RouterPriority, true
+}
+
+// HasRouterPriority returns a boolean if a field has been set.
+func (o *ModelsDevice) HasRouterPriority() bool {
+	if o != nil && !IsNil(o.RouterPriority) {
+		return true
+	}
+
+	return false
+}
+
+// SetRouterPriority gets a reference to the given int32 and assigns it to the RouterPriority field.
+func (o *ModelsDevice) SetRouterPriority(v int32) {
+	o.RouterPriority = &v
+}
+
 // GetSecurityGroupId returns the SecurityGroupId field value if set, zero value otherwise.
 func (o *ModelsDevice) GetSecurityGroupId() string {
 	if o == nil || IsNil(o.SecurityGroupId) {
@@ -569,6 +738,38 @@ func (o *ModelsDevice) SetSecurityGroupId(v string) {
 	o.SecurityGroupId = &v
 }
 
+// GetSubnetId returns the SubnetId field value if set, zero value otherwise.
+func (o *ModelsDevice) GetSubnetId() string {
+	if o == nil || IsNil(o.SubnetId) {
+		var ret string
+		return ret
+	}
+	return *o.SubnetId
+}
+
+// GetSubnetIdOk returns a tuple with the SubnetId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetSubnetIdOk() (*string, bool) {
+	if o == nil || IsNil(o.SubnetId) {
+		return nil, false
+	}
+	return o.SubnetId, true
+}
+
+// HasSubnetId returns a boolean if a field has been set.
+func (o *ModelsDevice) HasSubnetId() bool {
+	if o != nil && !IsNil(o.SubnetId) {
+		return true
+	}
+
+	return false
+}
+
+// SetSubnetId gets a reference to the given string and assigns it to the SubnetId field.
+func (o *ModelsDevice) SetSubnetId(v string) {
+	o.SubnetId = &v
+}
+
 // GetSymmetricNat returns the SymmetricNat field value if set, zero value otherwise.
 func (o *ModelsDevice) GetSymmetricNat() bool {
 	if o == nil || IsNil(o.SymmetricNat) {
@@ -649,6 +850,9 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.AllowedIps) {
 		toSerialize["allowed_ips"] = o.AllowedIps
 	}
+	if !IsNil(o.ApprovedCidrs) {
+		toSerialize["approved_cidrs"] = o.ApprovedCidrs
+	}
 	if !IsNil(o.BearerToken) {
 		toSerialize["bearer_token"] = o.BearerToken
 	}
@@ -658,6 +862,9 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Hostname) {
 		toSerialize["hostname"] = o.Hostname
 	}
+	if !IsNil(o.Hub) {
+		toSerialize["hub"] = o.Hub
+	}
 	if !IsNil(o.Id) {
 		toSerialize["id"] = o.Id
 	}
@@ -679,18 +886,30 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OwnerId) {
 		toSerialize["owner_id"] = o.OwnerId
 	}
+	if !IsNil(o.PeeringGroups) {
+		toSerialize["peering_groups"] = o.PeeringGroups
+	}
 	if !IsNil(o.PublicKey) {
 		toSerialize["public_key"] = o.PublicKey
 	}
+	if !IsNil(o.RejectedCidrs) {
+		toSerialize["rejected_cidrs"] = o.RejectedCidrs
+	}
 	if !IsNil(o.Relay) {
 		toSerialize["relay"] = o.Relay
 	}
 	if !IsNil(o.Revision) {
 		toSerialize["revision"] = o.Revision
 	}
+	if !IsNil(o.RouterPriority) {
+		toSerialize["router_priority"] = o.RouterPriority
+	}
 	if !IsNil(o.SecurityGroupId) {
 		toSerialize["security_group_id"] = o.SecurityGroupId
 	}
+	if !IsNil(o.SubnetId) {
+		toSerialize["subnet_id"] = o.SubnetId
+	}
 	if !IsNil(o.SymmetricNat) {
 		toSerialize["symmetric_nat"] = o.SymmetricNat
 	}
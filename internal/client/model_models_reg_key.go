@@ -19,6 +19,8 @@ var _ MappedNullable = &ModelsRegKey{}
 
 // ModelsRegKey struct for ModelsRegKey
 type ModelsRegKey struct {
+	// AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
+	AutoApproveCidrs []string `json:"auto_approve_cidrs,omitempty"`
 	// BearerToken is the bearer token the client should use to authenticate the device registration request.
 	BearerToken *string `json:"bearer_token,omitempty"`
 	// Description of the registration key.
@@ -37,7 +39,8 @@ type ModelsRegKey struct {
 	// Settings contains general settings for the device.
 	Settings map[string]interface{} `json:"settings,omitempty"`
 	// VpcID is the ID of the VPC the device can join.
-	VpcId *string `json:"vpc_id,omitempty"`
+	SubnetId *string `json:"subnet_id,omitempty"`
+	VpcId    *string `json:"vpc_id,omitempty"`
 }
 
 // NewModelsRegKey instantiates a new ModelsRegKey object
@@ -57,6 +60,38 @@ func NewModelsRegKeyWithDefaults() *ModelsRegKey {
 	return &this
 }
 
+// GetAutoApproveCidrs returns the AutoApproveCidrs field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetAutoApproveCidrs() []string {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.AutoApproveCidrs
+}
+
+// GetAutoApproveCidrsOk returns a tuple with the AutoApproveCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetAutoApproveCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		return nil, false
+	}
+	return o.AutoApproveCidrs, true
+}
+
+// HasAutoApproveCidrs returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasAutoApproveCidrs() bool {
+	if o != nil && !IsNil(o.AutoApproveCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetAutoApproveCidrs gets a reference to the given []string and assigns it to the AutoApproveCidrs field.
+func (o *ModelsRegKey) SetAutoApproveCidrs(v []string) {
+	o.AutoApproveCidrs = v
+}
+
 // GetBearerToken returns the BearerToken field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetBearerToken() string {
 	if o == nil || IsNil(o.BearerToken) {
@@ -345,6 +380,38 @@ func (o *ModelsRegKey) SetSettings(v map[string]interface{}) {
 	o.Settings = v
 }
 
+// GetSubnetId returns the SubnetId field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetSubnetId() string {
+	if o == nil || IsNil(o.SubnetId) {
+		var ret string
+		return ret
+	}
+	return *o.SubnetId
+}
+
+// GetSubnetIdOk returns a tuple with the SubnetId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetSubnetIdOk() (*string, bool) {
+	if o == nil || IsNil(o.SubnetId) {
+		return nil, false
+	}
+	return o.SubnetId, true
+}
+
+// HasSubnetId returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasSubnetId() bool {
+	if o != nil && !IsNil(o.SubnetId) {
+		return true
+	}
+
+	return false
+}
+
+// SetSubnetId gets a reference to the given string and assigns it to the SubnetId field.
+func (o *ModelsRegKey) SetSubnetId(v string) {
+	o.SubnetId = &v
+}
+
 // GetVpcId returns the VpcId field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetVpcId() string {
 	if o == nil || IsNil(o.VpcId) {
@@ -387,6 +454,9 @@ func (o ModelsRegKey) MarshalJSON() ([]byte, error) {
 
 func (o ModelsRegKey) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.AutoApproveCidrs) {
+		toSerialize["auto_approve_cidrs"] = o.AutoApproveCidrs
+	}
 	if !IsNil(o.BearerToken) {
 		toSerialize["bearer_token"] = o.BearerToken
 	}
@@ -414,6 +484,9 @@ func (o ModelsRegKey) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Settings) {
 		toSerialize["settings"] = o.Settings
 	}
+	if !IsNil(o.SubnetId) {
+		toSerialize["subnet_id"] = o.SubnetId
+	}
 	if !IsNil(o.VpcId) {
 		toSerialize["vpc_id"] = o.VpcId
 	}
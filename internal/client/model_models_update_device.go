@@ -22,8 +22,11 @@ type ModelsUpdateDevice struct {
 	AdvertiseCidrs  []string         `json:"advertise_cidrs,omitempty"`
 	Endpoints       []ModelsEndpoint `json:"endpoints,omitempty"`
 	Hostname        *string          `json:"hostname,omitempty"`
+	Hub             *bool            `json:"hub,omitempty"`
+	PeeringGroups   []string         `json:"peering_groups,omitempty"`
 	Relay           *bool            `json:"relay,omitempty"`
 	Revision        *int32           `json:"revision,omitempty"`
+	RouterPriority  *int32           `json:"router_priority,omitempty"`
 	SecurityGroupId *string          `json:"security_group_id,omitempty"`
 	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
 	VpcId           *string          `json:"vpc_id,omitempty"`
@@ -142,6 +145,70 @@ func (o *ModelsUpdateDevice) SetHostname(v string) {
 	o.Hostname = &v
 }
 
+// GetHub returns the Hub field value if set, zero value otherwise.
+func (o *ModelsUpdateDevice) GetHub() bool {
+	if o == nil || IsNil(o.Hub) {
+		var ret bool
+		return ret
+	}
+	return *o.Hub
+}
+
+// GetHubOk returns a tuple with the Hub field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateDevice) GetHubOk() (*bool, bool) {
+	if o == nil || IsNil(o.Hub) {
+		return nil, false
+	}
+	return o.Hub, true
+}
+
+// HasHub returns a boolean if a field has been set.
+func (o *ModelsUpdateDevice) HasHub() bool {
+	if o != nil && !IsNil(o.Hub) {
+		return true
+	}
+
+	return false
+}
+
+// SetHub gets a reference to the given bool and assigns it to the Hub field.
+func (o *ModelsUpdateDevice) SetHub(v bool) {
+	o.Hub = &v
+}
+
+// GetPeeringGroups returns the PeeringGroups field value if set, zero value otherwise.
+func (o *ModelsUpdateDevice) GetPeeringGroups() []string {
+	if o == nil || IsNil(o.PeeringGroups) {
+		var ret []string
+		return ret
+	}
+	return o.PeeringGroups
+}
+
+// GetPeeringGroupsOk returns a tuple with the PeeringGroups field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateDevice) GetPeeringGroupsOk() ([]string, bool) {
+	if o == nil || IsNil(o.PeeringGroups) {
+		return nil, false
+	}
+	return o.PeeringGroups, true
+}
+
+// HasPeeringGroups returns a boolean if a field has been set.
+func (o *ModelsUpdateDevice) HasPeeringGroups() bool {
+	if o != nil && !IsNil(o.PeeringGroups) {
+		return true
+	}
+
+	return false
+}
+
+// SetPeeringGroups gets a reference to the given []string and assigns it to the PeeringGroups field.
+func (o *ModelsUpdateDevice) SetPeeringGroups(v []string) {
+	o.PeeringGroups = v
+}
+
 // GetRelay returns the Relay field value if set, zero value otherwise.
 func (o *ModelsUpdateDevice) GetRelay() bool {
 	if o == nil || IsNil(o.Relay) {
@@ -206,6 +273,38 @@ func (o *ModelsUpdateDevice) SetRevision(v int32) {
 	o.Revision = &v
 }
 
+// GetRouterPriority returns the RouterPriority field value if set, zero value otherwise.
+func (o *ModelsUpdateDevice) GetRouterPriority() int32 {
+	if o == nil || IsNil(o.RouterPriority) {
+		var ret int32
+		return ret
+	}
+	return *o.RouterPriority
+}
+
+// GetRouterPriorityOk returns a tuple with the RouterPriority field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateDevice) GetRouterPriorityOk() (*int32, bool) {
+	if o == nil || IsNil(o.RouterPriority) {
+		return nil, false
+	}
+	return o.RouterPriority, true
+}
+
+// HasRouterPriority returns a boolean if a field has been set.
+func (o *ModelsUpdateDevice) HasRouterPriority() bool {
+	if o != nil && !IsNil(o.RouterPriority) {
+		return true
+	}
+
+	return false
+}
+
+// SetRouterPriority gets a reference to the given int32 and assigns it to the RouterPriority field.
+func (o *ModelsUpdateDevice) SetRouterPriority(v int32) {
+	o.RouterPriority = &v
+}
+
 // GetSecurityGroupId returns the SecurityGroupId field value if set, zero value otherwise.
 func (o *ModelsUpdateDevice) GetSecurityGroupId() string {
 	if o == nil || IsNil(o.SecurityGroupId) {
@@ -321,12 +420,21 @@ func (o ModelsUpdateDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Hostname) {
 		toSerialize["hostname"] = o.Hostname
 	}
+	if !IsNil(o.Hub) {
+		toSerialize["hub"] = o.Hub
+	}
+	if !IsNil(o.PeeringGroups) {
+		toSerialize["peering_groups"] = o.PeeringGroups
+	}
 	if !IsNil(o.Relay) {
 		toSerialize["relay"] = o.Relay
 	}
 	if !IsNil(o.Revision) {
 		toSerialize["revision"] = o.Revision
 	}
+	if !IsNil(o.RouterPriority) {
+		toSerialize["router_priority"] = o.RouterPriority
+	}
 	if !IsNil(o.SecurityGroupId) {
 		toSerialize["security_group_id"] = o.SecurityGroupId
 	}
@@ -19,6 +19,8 @@ var _ MappedNullable = &ModelsUpdateRegKey{}
 
 // ModelsUpdateRegKey struct for ModelsUpdateRegKey
 type ModelsUpdateRegKey struct {
+	// AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
+	AutoApproveCidrs []string `json:"auto_approve_cidrs,omitempty"`
 	// Description of the registration key.
 	Description *string `json:"description,omitempty"`
 	// ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
@@ -46,6 +48,38 @@ func NewModelsUpdateRegKeyWithDefaults() *ModelsUpdateRegKey {
 	return &this
 }
 
+// GetAutoApproveCidrs returns the AutoApproveCidrs field value if set, zero value otherwise.
+func (o *ModelsUpdateRegKey) GetAutoApproveCidrs() []string {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.AutoApproveCidrs
+}
+
+// GetAutoApproveCidrsOk returns a tuple with the AutoApproveCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateRegKey) GetAutoApproveCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.AutoApproveCidrs) {
+		return nil, false
+	}
+	return o.AutoApproveCidrs, true
+}
+
+// HasAutoApproveCidrs returns a boolean if a field has been set.
+func (o *ModelsUpdateRegKey) HasAutoApproveCidrs() bool {
+	if o != nil && !IsNil(o.AutoApproveCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetAutoApproveCidrs gets a reference to the given []string and assigns it to the AutoApproveCidrs field.
+func (o *ModelsUpdateRegKey) SetAutoApproveCidrs(v []string) {
+	o.AutoApproveCidrs = v
+}
+
 // GetDescription returns the Description field value if set, zero value otherwise.
 func (o *ModelsUpdateRegKey) GetDescription() string {
 	if o == nil || IsNil(o.Description) {
@@ -184,6 +218,9 @@ func (o ModelsUpdateRegKey) MarshalJSON() ([]byte, error) {
 
 func (o ModelsUpdateRegKey) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.AutoApproveCidrs) {
+		toSerialize["auto_approve_cidrs"] = o.AutoApproveCidrs
+	}
 	if !IsNil(o.Description) {
 		toSerialize["description"] = o.Description
 	}
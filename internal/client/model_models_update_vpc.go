@@ -19,7 +19,9 @@ var _ MappedNullable = &ModelsUpdateVPC{}
 
 // ModelsUpdateVPC struct for ModelsUpdateVPC
 type ModelsUpdateVPC struct {
-	Description *string `json:"description,omitempty"`
+	Description    *string  `json:"description,omitempty"`
+	SecondaryCidrs []string `json:"secondary_cidrs,omitempty"`
+	Topology       *string  `json:"topology,omitempty"`
 }
 
 // NewModelsUpdateVPC instantiates a new ModelsUpdateVPC object
@@ -71,6 +73,70 @@ func (o *ModelsUpdateVPC) SetDescription(v string) {
 	o.Description = &v
 }
 
+// GetSecondaryCidrs returns the SecondaryCidrs field value if set, zero value otherwise.
+func (o *ModelsUpdateVPC) GetSecondaryCidrs() []string {
+	if o == nil || IsNil(o.SecondaryCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.SecondaryCidrs
+}
+
+// GetSecondaryCidrsOk returns a tuple with the SecondaryCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateVPC) GetSecondaryCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.SecondaryCidrs) {
+		return nil, false
+	}
+	return o.SecondaryCidrs, true
+}
+
+// HasSecondaryCidrs returns a boolean if a field has been set.
+func (o *ModelsUpdateVPC) HasSecondaryCidrs() bool {
+	if o != nil && !IsNil(o.SecondaryCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetSecondaryCidrs gets a reference to the given []string and assigns it to the SecondaryCidrs field.
+func (o *ModelsUpdateVPC) SetSecondaryCidrs(v []string) {
+	o.SecondaryCidrs = v
+}
+
+// GetTopology returns the Topology field value if set, zero value otherwise.
+func (o *ModelsUpdateVPC) GetTopology() string {
+	if o == nil || IsNil(o.Topology) {
+		var ret string
+		return ret
+	}
+	return *o.Topology
+}
+
+// GetTopologyOk returns a tuple with the Topology field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateVPC) GetTopologyOk() (*string, bool) {
+	if o == nil || IsNil(o.Topology) {
+		return nil, false
+	}
+	return o.Topology, true
+}
+
+// HasTopology returns a boolean if a field has been set.
+func (o *ModelsUpdateVPC) HasTopology() bool {
+	if o != nil && !IsNil(o.Topology) {
+		return true
+	}
+
+	return false
+}
+
+// SetTopology gets a reference to the given string and assigns it to the Topology field.
+func (o *ModelsUpdateVPC) SetTopology(v string) {
+	o.Topology = &v
+}
+
 func (o ModelsUpdateVPC) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -84,6 +150,12 @@ func (o ModelsUpdateVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Description) {
 		toSerialize["description"] = o.Description
 	}
+	if !IsNil(o.SecondaryCidrs) {
+		toSerialize["secondary_cidrs"] = o.SecondaryCidrs
+	}
+	if !IsNil(o.Topology) {
+		toSerialize["topology"] = o.Topology
+	}
 	return toSerialize, nil
 }
 
@@ -19,13 +19,15 @@ var _ MappedNullable = &ModelsVPC{}
 
 // ModelsVPC struct for ModelsVPC
 type ModelsVPC struct {
-	Description    *string `json:"description,omitempty"`
-	Id             *string `json:"id,omitempty"`
-	Ipv4Cidr       *string `json:"ipv4_cidr,omitempty"`
-	Ipv6Cidr       *string `json:"ipv6_cidr,omitempty"`
-	OrganizationId *string `json:"organization_id,omitempty"`
-	PrivateCidr    *bool   `json:"private_cidr,omitempty"`
-	Revision       *int32  `json:"revision,omitempty"`
+	Description    *string  `json:"description,omitempty"`
+	Id             *string  `json:"id,omitempty"`
+	Ipv4Cidr       *string  `json:"ipv4_cidr,omitempty"`
+	Ipv6Cidr       *string  `json:"ipv6_cidr,omitempty"`
+	OrganizationId *string  `json:"organization_id,omitempty"`
+	PrivateCidr    *bool    `json:"private_cidr,omitempty"`
+	Revision       *int32   `json:"revision,omitempty"`
+	SecondaryCidrs []string `json:"secondary_cidrs,omitempty"`
+	Topology       *string  `json:"topology,omitempty"`
 }
 
 // NewModelsVPC instantiates a new ModelsVPC object
@@ -269,6 +271,70 @@ func (o *ModelsVPC) SetRevision(v int32) {
 	o.Revision = &v
 }
 
+// GetSecondaryCidrs returns the SecondaryCidrs field value if set, zero value otherwise.
+func (o *ModelsVPC) GetSecondaryCidrs() []string {
+	if o == nil || IsNil(o.SecondaryCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.SecondaryCidrs
+}
+
+// GetSecondaryCidrsOk returns a tuple with the SecondaryCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsVPC) GetSecondaryCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.SecondaryCidrs) {
+		return nil, false
+	}
+	return o.SecondaryCidrs, true
+}
+
+// HasSecondaryCidrs returns a boolean if a field has been set.
+func (o *ModelsVPC) HasSecondaryCidrs() bool {
+	if o != nil && !IsNil(o.SecondaryCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetSecondaryCidrs gets a reference to the given []string and assigns it to the SecondaryCidrs field.
+func (o *ModelsVPC) SetSecondaryCidrs(v []string) {
+	o.SecondaryCidrs = v
+}
+
+// GetTopology returns the Topology field value if set, zero value otherwise.
+func (o *ModelsVPC) GetTopology() string {
+	if o == nil || IsNil(o.Topology) {
+		var ret string
+		return ret
+	}
+	return *o.Topology
+}
+
+// GetTopologyOk returns a tuple with the Topology field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsVPC) GetTopologyOk() (*string, bool) {
+	if o == nil || IsNil(o.Topology) {
+		return nil, false
+	}
+	return o.Topology, true
+}
+
+// HasTopology returns a boolean if a field has been set.
+func (o *ModelsVPC) HasTopology() bool {
+	if o != nil && !IsNil(o.Topology) {
+		return true
+	}
+
+	return false
+}
+
+// SetTopology gets a reference to the given string and assigns it to the Topology field.
+func (o *ModelsVPC) SetTopology(v string) {
+	o.Topology = &v
+}
+
 func (o ModelsVPC) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -300,6 +366,12 @@ func (o ModelsVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Revision) {
 		toSerialize["revision"] = o.Revision
 	}
+	if !IsNil(o.SecondaryCidrs) {
+		toSerialize["secondary_cidrs"] = o.SecondaryCidrs
+	}
+	if !IsNil(o.Topology) {
+		toSerialize["topology"] = o.Topology
+	}
 	return toSerialize, nil
 }
 
@@ -16,6 +16,20 @@ import (
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20231211_0000"
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240221_0000"
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240227_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240301_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240304_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240305_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240306_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240307_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240308_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240309_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240310_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240311_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240312_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240313_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240314_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240315_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240316_0000"
 	"sort"
 
 	"github.com/cenkalti/backoff/v4"
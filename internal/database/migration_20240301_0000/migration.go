@@ -0,0 +1,45 @@
+package migration_20240301_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type ProxyRule struct {
+	migration_20231031_0000.Base
+	DeviceID            uuid.UUID `gorm:"type:uuid;index"`
+	VpcID               uuid.UUID `gorm:"type:uuid;index"`
+	OrganizationID      uuid.UUID `gorm:"type:uuid;index"`
+	Type                string
+	Protocol            string
+	ListenPort          int
+	DestinationHost     string
+	DestinationPort     int
+	SendProxyProtocol   string
+	AcceptProxyProtocol bool
+	Description         string
+	Revision            uint64 `gorm:"type:bigserial;index:"`
+}
+
+func init() {
+	migrationId := "20240301-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&ProxyRule{}),
+		ExecActionIf(`
+			CREATE OR REPLACE FUNCTION proxy_rules_revision_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+			BEGIN
+			NEW.revision := nextval(''proxy_rules_revision_seq'');
+			RETURN NEW;
+			END;'
+		`, `
+			DROP FUNCTION IF EXISTS proxy_rules_revision_trigger
+		`, NotOnSqlLite),
+		ExecActionIf(`
+			CREATE OR REPLACE TRIGGER proxy_rules_revision_trigger BEFORE INSERT OR UPDATE ON proxy_rules
+			FOR EACH ROW EXECUTE PROCEDURE proxy_rules_revision_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS proxy_rules_revision_trigger ON proxy_rules
+		`, NotOnSqlLite),
+	)
+}
@@ -0,0 +1,16 @@
+package migration_20240304_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	RouterPriority int
+}
+
+func init() {
+	migrationId := "20240304-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnsAction(&Device{}),
+	)
+}
@@ -0,0 +1,25 @@
+package migration_20240305_0000
+
+import (
+	"github.com/lib/pq"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	ApprovedCidrs pq.StringArray `gorm:"type:text[]"`
+	RejectedCidrs pq.StringArray `gorm:"type:text[]"`
+}
+
+type RegKey struct {
+	AutoApproveCidrs pq.StringArray `gorm:"type:text[]"`
+}
+
+func init() {
+	migrationId := "20240305-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnsAction(&Device{}),
+		AddTableColumnsAction(&RegKey{}),
+		// CIDRs advertised before the approval workflow existed stay routed.
+		ExecAction(`UPDATE devices SET approved_cidrs = advertise_cidrs`, ``),
+	)
+}
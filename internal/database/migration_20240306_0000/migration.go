@@ -0,0 +1,25 @@
+package migration_20240306_0000
+
+import (
+	"github.com/lib/pq"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type VPC struct {
+	Topology string
+}
+
+type Device struct {
+	Hub           bool
+	PeeringGroups pq.StringArray `gorm:"type:text[]"`
+}
+
+func init() {
+	migrationId := "20240306-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnsAction(&VPC{}),
+		AddTableColumnsAction(&Device{}),
+		// existing VPCs are full meshes.
+		ExecAction(`UPDATE vpcs SET topology = 'full-mesh'`, ``),
+	)
+}
@@ -0,0 +1,29 @@
+package migration_20240307_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type DeviceTombstone struct {
+	DeviceID  uuid.UUID `gorm:"type:uuid;primary_key"`
+	VpcID     uuid.UUID `gorm:"type:uuid;primary_key"`
+	Revision  uint64    `gorm:"type:bigserial;index:"`
+	CreatedAt time.Time
+}
+
+func init() {
+	migrationId := "20240307-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&DeviceTombstone{}),
+		// the tombstones are merged with the devices when watching a VPC, so they share the revision sequence of the devices.
+		ExecActionIf(`
+			CREATE OR REPLACE TRIGGER device_tombstones_revision_trigger BEFORE INSERT OR UPDATE ON device_tombstones
+			FOR EACH ROW EXECUTE PROCEDURE devices_revision_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS device_tombstones_revision_trigger ON device_tombstones
+		`, NotOnSqlLite),
+	)
+}
@@ -0,0 +1,22 @@
+package migration_20240308_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type DeviceShare struct {
+	DeviceID       uuid.UUID `gorm:"type:uuid;primary_key"`
+	VpcID          uuid.UUID `gorm:"type:uuid;primary_key;index"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt      time.Time
+}
+
+func init() {
+	migrationId := "20240308-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&DeviceShare{}),
+	)
+}
@@ -0,0 +1,28 @@
+package migration_20240309_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type VpcPeering struct {
+	migration_20231031_0000.Base
+	RequesterVpcID          uuid.UUID      `gorm:"type:uuid;index"`
+	RequesterOrganizationID uuid.UUID      `gorm:"type:uuid;index"`
+	RequesterDevices        pq.StringArray `gorm:"type:text[]"`
+	RequesterCidrs          pq.StringArray `gorm:"type:text[]"`
+	AccepterVpcID           uuid.UUID      `gorm:"type:uuid;index"`
+	AccepterOrganizationID  uuid.UUID      `gorm:"type:uuid;index"`
+	AccepterDevices         pq.StringArray `gorm:"type:text[]"`
+	AccepterCidrs           pq.StringArray `gorm:"type:text[]"`
+	Status                  string
+}
+
+func init() {
+	migrationId := "20240309-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&VpcPeering{}),
+	)
+}
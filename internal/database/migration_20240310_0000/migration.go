@@ -0,0 +1,34 @@
+package migration_20240310_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type IPReservation struct {
+	migration_20231031_0000.Base
+	VpcID          uuid.UUID  `gorm:"type:uuid;index"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index"`
+	Address        string     `gorm:"index"`
+	DeviceID       *uuid.UUID `gorm:"type:uuid"`
+	RegKeyID       *uuid.UUID `gorm:"type:uuid"`
+	Hostname       string
+	Description    string
+}
+
+type ExcludedRange struct {
+	migration_20231031_0000.Base
+	VpcID          uuid.UUID `gorm:"type:uuid;index"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;index"`
+	Cidr           string
+	Description    string
+}
+
+func init() {
+	migrationId := "20240310-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&IPReservation{}),
+		CreateTableAction(&ExcludedRange{}),
+	)
+}
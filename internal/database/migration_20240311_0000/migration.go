@@ -0,0 +1,30 @@
+package migration_20240311_0000
+
+import (
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type IpamNamespace struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+type IpamPrefix struct {
+	NamespaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Cidr        string    `gorm:"primaryKey"`
+}
+
+type IpamAddress struct {
+	NamespaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Address     string    `gorm:"primaryKey"`
+	Cidr        string    `gorm:"index"`
+}
+
+func init() {
+	migrationId := "20240311-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&IpamNamespace{}),
+		CreateTableAction(&IpamPrefix{}),
+		CreateTableAction(&IpamAddress{}),
+	)
+}
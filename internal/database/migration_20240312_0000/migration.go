@@ -0,0 +1,17 @@
+package migration_20240312_0000
+
+import (
+	"github.com/lib/pq"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type VPC struct {
+	SecondaryCidrs pq.StringArray `gorm:"type:text[]"`
+}
+
+func init() {
+	migrationId := "20240312-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnsAction(&VPC{}),
+	)
+}
@@ -0,0 +1,39 @@
+package migration_20240313_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Subnet struct {
+	migration_20231031_0000.Base
+	VpcID            uuid.UUID `gorm:"type:uuid;index"`
+	OrganizationID   uuid.UUID `gorm:"type:uuid;index"`
+	Name             string    `gorm:"index"`
+	Description      string
+	Ipv4Cidr         string
+	Ipv6Cidr         string
+	SecurityGroupId  *uuid.UUID     `gorm:"type:uuid"`
+	RelayDeviceId    *uuid.UUID     `gorm:"type:uuid"`
+	DnsServers       pq.StringArray `gorm:"type:text[]"`
+	DnsSearchDomains pq.StringArray `gorm:"type:text[]"`
+}
+
+type Device struct {
+	SubnetID *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+type RegKey struct {
+	SubnetID *uuid.UUID `gorm:"type:uuid"`
+}
+
+func init() {
+	migrationId := "20240313-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&Subnet{}),
+		AddTableColumnsAction(&Device{}),
+		AddTableColumnsAction(&RegKey{}),
+	)
+}
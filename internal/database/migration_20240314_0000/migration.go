@@ -0,0 +1,26 @@
+package migration_20240314_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type OrganizationQuota struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Devices        *int64
+	Vpcs           *int64
+	RegKeys        *int64
+	SecurityGroups *int64
+	Invitations    *int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func init() {
+	migrationId := "20240314-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&OrganizationQuota{}),
+	)
+}
@@ -0,0 +1,19 @@
+package migration_20240315_0000
+
+import (
+	"time"
+
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type IpamPopulation struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func init() {
+	migrationId := "20240315-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&IpamPopulation{}),
+	)
+}
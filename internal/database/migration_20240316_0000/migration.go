@@ -0,0 +1,16 @@
+package migration_20240316_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type IpamPrefix struct {
+	NextAddress string
+}
+
+func init() {
+	migrationId := "20240316-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnsAction(&IpamPrefix{}),
+	)
+}
@@ -90,6 +90,121 @@ const docTemplate = `{
                 }
             }
         },
+        "/private/organizations/{id}/quota": {
+            "get": {
+                "description": "Gets the quotas overriding the default quotas for an organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Private"
+                ],
+                "summary": "Get Organization Quota",
+                "operationId": "GetOrganizationQuota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationQuota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the quotas overriding the default quotas for an organization. A quota of 0 is unlimited, and the default quota applies to the resources left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Private"
+                ],
+                "summary": "Update Organization Quota",
+                "operationId": "UpdateOrganizationQuota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization Quota Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateOrganizationQuota"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationQuota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/private/ready": {
             "post": {
                 "description": "Checks if the service is ready to accept requests",
@@ -119,6 +234,15 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "models.BaseError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "something bad"
+                }
+            }
+        },
         "models.InternalServerError": {
             "type": "object",
             "properties": {
@@ -131,6 +255,60 @@ const docTemplate = `{
                 }
             }
         },
+        "models.OrganizationQuota": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "invitations": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "reg_keys": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "security_groups": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "vpcs": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.UpdateOrganizationQuota": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "invitations": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "reg_keys": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "security_groups": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "vpcs": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
         "models.ValidationError": {
             "type": "object",
             "properties": {
@@ -222,6 +222,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededError"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -633,9 +639,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/events": {
-            "post": {
-                "description": "Watches events occurring in the control plane",
+        "/api/devices/{id}/proxy-rules": {
+            "get": {
+                "description": "Lists the proxy rules nexd should apply on a device",
                 "consumes": [
                     "application/json"
                 ],
@@ -643,29 +649,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Devices"
                 ],
-                "summary": "Watch events occurring in the control plane",
-                "operationId": "Watch",
+                "summary": "List Device Proxy Rules",
+                "operationId": "ListDeviceProxyRules",
                 "parameters": [
                     {
-                        "description": "List of events to watch",
-                        "name": "Watches",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Watch"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WatchEvent"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProxyRule"
+                            }
                         }
                     },
                     "400": {
@@ -674,14 +684,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -693,11 +697,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/fflags": {
-            "get": {
-                "description": "Lists all feature flags",
+            },
+            "post": {
+                "description": "Adds a proxy rule that nexd will apply on the device",
                 "consumes": [
                     "application/json"
                 ],
@@ -705,26 +707,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FFlag"
+                    "Devices"
+                ],
+                "summary": "Add Device Proxy Rule",
+                "operationId": "CreateDeviceProxyRule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Proxy Rule",
+                        "name": "ProxyRule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddProxyRule"
+                        }
+                    }
                 ],
-                "summary": "List Feature Flags",
-                "operationId": "ListFeatureFlags",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/models.ProxyRule"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -734,9 +769,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/fflags/{name}": {
+        "/api/devices/{id}/proxy-rules/{rule_id}": {
             "get": {
-                "description": "Gets a Feature Flag by name",
+                "description": "Gets a proxy rule of a device by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -744,15 +779,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FFlag"
+                    "Devices"
                 ],
-                "summary": "Get Feature Flag",
-                "operationId": "GetFeatureFlag",
+                "summary": "Get Device Proxy Rule",
+                "operationId": "GetDeviceProxyRule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "feature flag name",
-                        "name": "name",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proxy Rule ID",
+                        "name": "rule_id",
                         "in": "path",
                         "required": true
                     }
@@ -761,10 +803,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/models.ProxyRule"
                         }
                     },
                     "400": {
@@ -779,12 +818,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -792,11 +825,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/invitations": {
-            "get": {
-                "description": "Lists all invitations",
+            },
+            "delete": {
+                "description": "Deletes a proxy rule of a device by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -804,28 +835,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Devices"
+                ],
+                "summary": "Delete Device Proxy Rule",
+                "operationId": "DeleteDeviceProxyRule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proxy Rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List Invitations",
-                "operationId": "ListInvitations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Invitation"
-                            }
+                            "$ref": "#/definitions/models.ProxyRule"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -838,8 +882,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create an invitation to an organization",
+            "patch": {
+                "description": "Updates a proxy rule of a device by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -847,26 +891,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Devices"
                 ],
-                "summary": "Create an invitation",
-                "operationId": "CreateInvitation",
+                "summary": "Update Device Proxy Rule",
+                "operationId": "UpdateDeviceProxyRule",
                 "parameters": [
                     {
-                        "description": "Add Invitation",
-                        "name": "Invitation",
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proxy Rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Proxy Rule Update",
+                        "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddInvitation"
+                            "$ref": "#/definitions/models.UpdateProxyRule"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Invitation"
+                            "$ref": "#/definitions/models.ProxyRule"
                         }
                     },
                     "400": {
@@ -881,10 +939,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "500": {
@@ -896,9 +960,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/invitations/{id}": {
-            "get": {
-                "description": "Gets an Invitation by Invitation ID",
+        "/api/devices/{id}/routes/approve": {
+            "post": {
+                "description": "Approves advertised CIDRs of a device so that peers route them to the device",
                 "consumes": [
                     "application/json"
                 ],
@@ -906,24 +970,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Devices"
                 ],
-                "summary": "Get Invitation",
-                "operationId": "GetInvitation",
+                "summary": "Approve Device Routes",
+                "operationId": "ApproveDeviceRoutes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invitation ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Device Routes",
+                        "name": "routes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeviceRoutes"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Invitation"
+                            "$ref": "#/definitions/models.Device"
                         }
                     },
                     "400": {
@@ -938,12 +1011,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -957,9 +1042,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an existing invitation",
+            }
+        },
+        "/api/devices/{id}/routes/reject": {
+            "post": {
+                "description": "Rejects advertised CIDRs of a device so that peers do not route them to the device",
                 "consumes": [
                     "application/json"
                 ],
@@ -967,24 +1054,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Devices"
                 ],
-                "summary": "Delete Invitation",
-                "operationId": "DeleteInvitation",
+                "summary": "Reject Device Routes",
+                "operationId": "RejectDeviceRoutes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invitation ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Device Routes",
+                        "name": "routes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeviceRoutes"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Organization"
+                            "$ref": "#/definitions/models.Device"
                         }
                     },
                     "400": {
@@ -993,64 +1089,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/invitations/{id}/accept": {
-            "post": {
-                "description": "Accept an invitation to an organization",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Invitation"
-                ],
-                "summary": "Accept an invitation",
-                "operationId": "AcceptInvitation",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Invitation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "429": {
@@ -1068,9 +1128,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations": {
+        "/api/devices/{id}/shares": {
             "get": {
-                "description": "Lists all Organizations",
+                "description": "Lists the VPCs of other organizations a device is shared into",
                 "consumes": [
                     "application/json"
                 ],
@@ -1078,28 +1138,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Devices"
+                ],
+                "summary": "List Device Shares",
+                "operationId": "ListDeviceShares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List Organizations",
-                "operationId": "ListOrganizations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Organization"
+                                "$ref": "#/definitions/models.DeviceShare"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -1113,7 +1182,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a named organization with the given CIDR",
+                "description": "Shares a device into a VPC of another organization, where it is served read-only with its existing tunnel IPs",
                 "consumes": [
                     "application/json"
                 ],
@@ -1121,18 +1190,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Devices"
                 ],
-                "summary": "Create an Organization",
-                "operationId": "CreateOrganization",
+                "summary": "Share Device",
+                "operationId": "CreateDeviceShare",
                 "parameters": [
                     {
-                        "description": "Add Organization",
-                        "name": "Organization",
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Device Share",
+                        "name": "share",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddOrganization"
+                            "$ref": "#/definitions/models.AddDeviceShare"
                         }
                     }
                 ],
@@ -1140,7 +1216,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Organization"
+                            "$ref": "#/definitions/models.DeviceShare"
                         }
                     },
                     "400": {
@@ -1149,14 +1225,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -1167,10 +1243,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "500": {
@@ -1182,9 +1258,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations/{id}": {
-            "get": {
-                "description": "Gets a Organization by Organization ID",
+        "/api/devices/{id}/shares/{vpc_id}": {
+            "delete": {
+                "description": "Revokes the sharing of a device into a VPC, by the owner of the device or an owner of the organization of the VPC",
                 "consumes": [
                     "application/json"
                 ],
@@ -1192,24 +1268,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Devices"
                 ],
-                "summary": "Get Organizations",
-                "operationId": "GetOrganizations",
+                "summary": "Revoke Device Share",
+                "operationId": "DeleteDeviceShare",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "vpc_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Organization"
+                            "$ref": "#/definitions/models.DeviceShare"
                         }
                     },
                     "400": {
@@ -1218,8 +1301,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -1230,12 +1313,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1243,9 +1320,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an existing organization and associated IPAM prefix",
+            }
+        },
+        "/api/events": {
+            "post": {
+                "description": "Watches events occurring in the control plane",
                 "consumes": [
                     "application/json"
                 ],
@@ -1253,24 +1332,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Events"
                 ],
-                "summary": "Delete Organization",
-                "operationId": "DeleteOrganization",
+                "summary": "Watch events occurring in the control plane",
+                "operationId": "Watch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "List of events to watch",
+                        "name": "Watches",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Watch"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Organization"
+                            "$ref": "#/definitions/models.WatchEvent"
                         }
                     },
                     "400": {
@@ -1279,8 +1363,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -1300,9 +1384,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations/{id}/users": {
+        "/api/fflags": {
             "get": {
-                "description": "Lists all the users of an organization",
+                "description": "Lists all feature flags",
                 "consumes": [
                     "application/json"
                 ],
@@ -1310,35 +1394,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
-                ],
-                "summary": "List Organization Users",
-                "operationId": "ListOrganizationUsers",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "FFlag"
                 ],
+                "summary": "List Feature Flags",
+                "operationId": "ListFeatureFlags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.UserOrganization"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1354,9 +1423,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations/{id}/users/{uid}": {
+        "/api/fflags/{name}": {
             "get": {
-                "description": "Gets a Organization User by Organization ID and User ID",
+                "description": "Gets a Feature Flag by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1364,22 +1433,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "FFlag"
                 ],
-                "summary": "Get Organization User",
-                "operationId": "GetOrganizationUser",
+                "summary": "Get Feature Flag",
+                "operationId": "GetFeatureFlag",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "uid",
+                        "description": "feature flag name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -1388,7 +1450,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserOrganization"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
                     "400": {
@@ -1397,12 +1462,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1422,9 +1481,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an existing organization user",
+            }
+        },
+        "/api/invitations": {
+            "get": {
+                "description": "Lists all invitations",
                 "consumes": [
                     "application/json"
                 ],
@@ -1432,75 +1493,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Invitation"
                 ],
-                "summary": "Delete a Organization User",
-                "operationId": "DeleteOrganizationUser",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "uid",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "$ref": "#/definitions/models.UserOrganization"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/reg-keys": {
-            "get": {
-                "description": "Lists all reg keys",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "RegKey"
-                ],
-                "summary": "List reg keys",
-                "operationId": "ListRegKeys",
+                "summary": "List Invitations",
+                "operationId": "ListInvitations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.RegKey"
+                                "$ref": "#/definitions/models.Invitation"
                             }
                         }
                     },
@@ -1525,7 +1528,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a RegKey for a vpc",
+                "description": "Create an invitation to an organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -1533,18 +1536,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Invitation"
                 ],
-                "summary": "Create a RegKey",
-                "operationId": "CreateRegKey",
+                "summary": "Create an invitation",
+                "operationId": "CreateInvitation",
                 "parameters": [
                     {
-                        "description": "Add RegKey",
-                        "name": "RegKey",
+                        "description": "Add Invitation",
+                        "name": "Invitation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddRegKey"
+                            "$ref": "#/definitions/models.AddInvitation"
                         }
                     }
                 ],
@@ -1552,7 +1555,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Invitation"
                         }
                     },
                     "400": {
@@ -1561,6 +1564,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1582,9 +1591,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reg-keys/{id}": {
+        "/api/invitations/{id}": {
             "get": {
-                "description": "Gets a RegKey by RegKey ID",
+                "description": "Gets an Invitation by Invitation ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1592,14 +1601,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Invitation"
                 ],
-                "summary": "Get a RegKey",
-                "operationId": "GetRegKey",
+                "summary": "Get Invitation",
+                "operationId": "GetInvitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1609,7 +1618,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Invitation"
                         }
                     },
                     "400": {
@@ -1645,7 +1654,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an existing RegKey",
+                "description": "Deletes an existing invitation",
                 "consumes": [
                     "application/json"
                 ],
@@ -1653,14 +1662,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Invitation"
                 ],
-                "summary": "Delete RegKey",
-                "operationId": "DeleteRegKey",
+                "summary": "Delete Invitation",
+                "operationId": "DeleteInvitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1670,7 +1679,7 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
@@ -1698,41 +1707,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates a RegKey by ID",
+            }
+        },
+        "/api/invitations/{id}/accept": {
+            "post": {
+                "description": "Accept an invitation to an organization",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Invitation"
                 ],
-                "summary": "Update RegKey",
-                "operationId": "UpdateRegKey",
+                "summary": "Accept an invitation",
+                "operationId": "AcceptInvitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "RegKey Update",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateRegKey"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.RegKey"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1740,24 +1742,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1773,32 +1763,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/security-groups": {
+        "/api/organizations": {
             "get": {
-                "description": "Lists all Security Groups",
+                "description": "Lists all Organizations",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
-                ],
-                "summary": "List Security Groups",
-                "operationId": "ListSecurityGroups",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    }
+                    "Organizations"
                 ],
+                "summary": "List Organizations",
+                "operationId": "ListOrganizations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.SecurityGroup"
+                                "$ref": "#/definitions/models.Organization"
                             }
                         }
                     },
@@ -1823,23 +1808,26 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Adds a new Security Group",
+                "description": "Creates a named organization with the given CIDR",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Add SecurityGroup",
-                "operationId": "CreateSecurityGroup",
+                "summary": "Create an Organization",
+                "operationId": "CreateOrganization",
                 "parameters": [
                     {
-                        "description": "Add SecurityGroup",
-                        "name": "SecurityGroup",
+                        "description": "Add Organization",
+                        "name": "Organization",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddSecurityGroup"
+                            "$ref": "#/definitions/models.AddOrganization"
                         }
                     }
                 ],
@@ -1847,7 +1835,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
@@ -1862,16 +1850,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "405": {
+                        "description": "Method Not Allowed",
                         "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
                     "429": {
@@ -1889,21 +1877,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/security-groups/{id}": {
+        "/api/organizations/{id}": {
             "get": {
-                "description": "Gets a security group by ID",
+                "description": "Gets a Organization by Organization ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Get SecurityGroup",
-                "operationId": "GetSecurityGroup",
+                "summary": "Get Organizations",
+                "operationId": "GetOrganizations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Security Group ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1913,7 +1904,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
@@ -1949,19 +1940,22 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an existing SecurityGroup",
+                "description": "Deletes an existing organization and associated IPAM prefix",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Delete SecurityGroup",
-                "operationId": "DeleteSecurityGroup",
+                "summary": "Delete Organization",
+                "operationId": "DeleteOrganization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Security Group ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1971,7 +1965,7 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
@@ -1980,6 +1974,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1993,40 +1993,2038 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates a Security Group by ID",
+            }
+        },
+        "/api/organizations/{id}/usage": {
+            "get": {
+                "description": "Gets the number of devices, VPCs, registration keys, security groups and invitations of an organization, and their quotas",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Update Security Group",
-                "operationId": "UpdateSecurityGroup",
+                "summary": "Get Organization Usage",
+                "operationId": "GetOrganizationUsage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Security Group ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationUsage"
+                        }
                     },
-                    {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{id}/users": {
+            "get": {
+                "description": "Lists all the users of an organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List Organization Users",
+                "operationId": "ListOrganizationUsers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserOrganization"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{id}/users/{uid}": {
+            "get": {
+                "description": "Gets a Organization User by Organization ID and User ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get Organization User",
+                "operationId": "GetOrganizationUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing organization user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Delete a Organization User",
+                "operationId": "DeleteOrganizationUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reg-keys": {
+            "get": {
+                "description": "Lists all reg keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RegKey"
+                ],
+                "summary": "List reg keys",
+                "operationId": "ListRegKeys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RegKey"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a RegKey for a vpc",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RegKey"
+                ],
+                "summary": "Create a RegKey",
+                "operationId": "CreateRegKey",
+                "parameters": [
+                    {
+                        "description": "Add RegKey",
+                        "name": "RegKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddRegKey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reg-keys/{id}": {
+            "get": {
+                "description": "Gets a RegKey by RegKey ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RegKey"
+                ],
+                "summary": "Get a RegKey",
+                "operationId": "GetRegKey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RegKey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing RegKey",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RegKey"
+                ],
+                "summary": "Delete RegKey",
+                "operationId": "DeleteRegKey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RegKey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a RegKey by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RegKey"
+                ],
+                "summary": "Update RegKey",
+                "operationId": "UpdateRegKey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RegKey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "RegKey Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRegKey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/security-groups": {
+            "get": {
+                "description": "Lists all Security Groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SecurityGroup"
+                ],
+                "summary": "List Security Groups",
+                "operationId": "ListSecurityGroups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SecurityGroup"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new Security Group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SecurityGroup"
+                ],
+                "summary": "Add SecurityGroup",
+                "operationId": "CreateSecurityGroup",
+                "parameters": [
+                    {
+                        "description": "Add SecurityGroup",
+                        "name": "SecurityGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSecurityGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecurityGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/security-groups/{id}": {
+            "get": {
+                "description": "Gets a security group by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SecurityGroup"
+                ],
+                "summary": "Get SecurityGroup",
+                "operationId": "GetSecurityGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Security Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecurityGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing SecurityGroup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SecurityGroup"
+                ],
+                "summary": "Delete SecurityGroup",
+                "operationId": "DeleteSecurityGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Security Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecurityGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a Security Group by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SecurityGroup"
+                ],
+                "summary": "Update Security Group",
+                "operationId": "UpdateSecurityGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Security Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
                         "description": "Security Group Update",
                         "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateSecurityGroup"
+                            "$ref": "#/definitions/models.UpdateSecurityGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecurityGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-networks": {
+            "get": {
+                "description": "Lists all ServiceNetworks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "List ServiceNetworks",
+                "operationId": "ListServiceNetworks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceNetwork"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a named serviceNetwork with the given CIDR",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Create an ServiceNetwork",
+                "operationId": "CreateServiceNetwork",
+                "parameters": [
+                    {
+                        "description": "Add ServiceNetwork",
+                        "name": "ServiceNetwork",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddServiceNetwork"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-networks/{id}": {
+            "get": {
+                "description": "Gets a ServiceNetwork by ServiceNetwork ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Get ServiceNetworks",
+                "operationId": "GetServiceNetwork",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ServiceNetwork ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing serviceNetwork",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Delete ServiceNetwork",
+                "operationId": "DeleteServiceNetwork",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ServiceNetwork ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a serviceNetwork by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Update ServiceNetworks",
+                "operationId": "UpdateServiceNetwork",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ServiceNetwork ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ServiceNetwork Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateServiceNetwork"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites": {
+            "get": {
+                "description": "Lists all sites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "List Sites",
+                "operationId": "ListSites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Site"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new site",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Add Sites",
+                "operationId": "CreateSite",
+                "parameters": [
+                    {
+                        "description": "Add Site",
+                        "name": "Site",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites/{id}": {
+            "get": {
+                "description": "Gets a site by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Get Sites",
+                "operationId": "GetSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing site and associated IPAM lease",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Delete Site",
+                "operationId": "DeleteSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a site by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Update Sites",
+                "operationId": "UpdateSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Site Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "description": "Lists all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List Users",
+                "operationId": "ListUsers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "description": "Gets a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User",
+                "operationId": "GetUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User",
+                "operationId": "DeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotAllowedError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/organizations/{organization}": {
+            "delete": {
+                "description": "Deletes an existing organization associated to a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Remove a User from an Organization",
+                "operationId": "DeleteUserFromOrganization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpc-peerings": {
+            "get": {
+                "description": "Lists the peerings of the VPCs of the organizations the user is a member of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "List VPC Peerings",
+                "operationId": "ListVpcPeerings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VpcPeering"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Requests the peering of a VPC with another VPC, of the same or of another organization. The peering is pending until an owner of the other VPC accepts it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Request VPC Peering",
+                "operationId": "CreateVpcPeering",
+                "parameters": [
+                    {
+                        "description": "Add VPC Peering",
+                        "name": "peering",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddVpcPeering"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.VpcPeering"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpc-peerings/{id}": {
+            "get": {
+                "description": "Gets a VPC peering by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Get VPC Peering",
+                "operationId": "GetVpcPeering",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC Peering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VpcPeering"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a VPC peering, by an owner of either VPC, which removes the devices each VPC exported from the other VPC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Delete VPC Peering",
+                "operationId": "DeleteVpcPeering",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC Peering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VpcPeering"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpc-peerings/{id}/accept": {
+            "post": {
+                "description": "Accepts a pending VPC peering, by an owner of the accepter VPC, which exports the given devices and CIDRs of the accepter VPC to the requester VPC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Accept VPC Peering",
+                "operationId": "AcceptVpcPeering",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC Peering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Accept VPC Peering",
+                        "name": "peering",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptVpcPeering"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VpcPeering"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpc/{id}/events": {
+            "post": {
+                "description": "Watches events occurring in the vpc",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Watch events occurring in the vpc",
+                "operationId": "WatchEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "connect as the device with the given public key, device will be considered to be online for the duration of this request",
+                        "name": "public_key",
+                        "in": "query"
+                    },
+                    {
+                        "description": "List of events to watch",
+                        "name": "Watches",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Watch"
+                            }
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.WatchEvent"
                         }
                     },
                     "400": {
@@ -2041,18 +4039,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2068,9 +4054,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/service-networks": {
+        "/api/vpcs": {
             "get": {
-                "description": "Lists all ServiceNetworks",
+                "description": "Lists all VPCs",
                 "consumes": [
                     "application/json"
                 ],
@@ -2078,17 +4064,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "VPC"
                 ],
-                "summary": "List ServiceNetworks",
-                "operationId": "ListServiceNetworks",
+                "summary": "List VPCs",
+                "operationId": "ListVPCs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.ServiceNetwork"
+                                "$ref": "#/definitions/models.VPC"
                             }
                         }
                     },
@@ -2113,7 +4099,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a named serviceNetwork with the given CIDR",
+                "description": "Creates a named vpc with the given CIDR",
                 "consumes": [
                     "application/json"
                 ],
@@ -2121,18 +4107,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "VPC"
                 ],
-                "summary": "Create an ServiceNetwork",
-                "operationId": "CreateServiceNetwork",
+                "summary": "Create an VPC",
+                "operationId": "CreateVPC",
                 "parameters": [
                     {
-                        "description": "Add ServiceNetwork",
-                        "name": "ServiceNetwork",
+                        "description": "Add VPC",
+                        "name": "VPC",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddServiceNetwork"
+                            "$ref": "#/definitions/models.AddVPC"
                         }
                     }
                 ],
@@ -2140,7 +4126,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.VPC"
                         }
                     },
                     "400": {
@@ -2155,6 +4141,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededError"
+                        }
+                    },
                     "405": {
                         "description": "Method Not Allowed",
                         "schema": {
@@ -2182,9 +4174,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/service-networks/{id}": {
+        "/api/vpcs/{id}": {
             "get": {
-                "description": "Gets a ServiceNetwork by ServiceNetwork ID",
+                "description": "Gets a VPC by VPC ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2192,14 +4184,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "VPC"
                 ],
-                "summary": "Get ServiceNetworks",
-                "operationId": "GetServiceNetwork",
+                "summary": "Get VPCs",
+                "operationId": "GetVPC",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2209,7 +4201,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.VPC"
                         }
                     },
                     "400": {
@@ -2245,7 +4237,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an existing serviceNetwork",
+                "description": "Deletes an existing vpc and associated IPAM prefix",
                 "consumes": [
                     "application/json"
                 ],
@@ -2253,14 +4245,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "VPC"
                 ],
-                "summary": "Delete ServiceNetwork",
-                "operationId": "DeleteServiceNetwork",
+                "summary": "Delete VPC",
+                "operationId": "DeleteVPC",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2270,7 +4262,7 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.VPC"
                         }
                     },
                     "400": {
@@ -2300,7 +4292,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Updates a serviceNetwork by ID",
+                "description": "Updates a vpc by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2308,25 +4300,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "VPC"
                 ],
-                "summary": "Update ServiceNetworks",
-                "operationId": "UpdateServiceNetwork",
+                "summary": "Update VPCs",
+                "operationId": "UpdateVPC",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "ServiceNetwork Update",
+                        "description": "VPC Update",
                         "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateServiceNetwork"
+                            "$ref": "#/definitions/models.UpdateVPC"
                         }
                     }
                 ],
@@ -2334,7 +4326,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.VPC"
                         }
                     },
                     "400": {
@@ -2370,52 +4362,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sites": {
+        "/api/vpcs/{id}/devices": {
             "get": {
-                "description": "Lists all sites",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sites"
-                ],
-                "summary": "List Sites",
-                "operationId": "ListSites",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Site"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Adds a new site",
+                "description": "Lists all devices for this VPC",
                 "consumes": [
                     "application/json"
                 ],
@@ -2423,79 +4372,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "VPC"
                 ],
-                "summary": "Add Sites",
-                "operationId": "CreateSite",
+                "summary": "List Devices",
+                "operationId": "ListDevicesInVPC",
                 "parameters": [
                     {
-                        "description": "Add Site",
-                        "name": "Site",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddSite"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Site"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/sites/{id}": {
-            "get": {
-                "description": "Gets a site by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sites"
-                ],
-                "summary": "Get Sites",
-                "operationId": "GetSite",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2505,7 +4395,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Device"
+                            }
                         }
                     },
                     "400": {
@@ -2520,12 +4413,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2539,9 +4426,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an existing site and associated IPAM lease",
+            }
+        },
+        "/api/vpcs/{id}/excluded-ranges": {
+            "get": {
+                "description": "Lists the ranges of addresses of a VPC kept out of the pool the devices of the VPC are assigned addresses from",
                 "consumes": [
                     "application/json"
                 ],
@@ -2549,24 +4438,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "VPC"
                 ],
-                "summary": "Delete Site",
-                "operationId": "DeleteSite",
+                "summary": "List Excluded Ranges",
+                "operationId": "ListExcludedRanges",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExcludedRange"
+                            }
                         }
                     },
                     "400": {
@@ -2575,6 +4467,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2589,8 +4493,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Updates a site by ID",
+            "post": {
+                "description": "Keeps a range of addresses of a VPC with a private CIDR out of the pool the devices of the VPC are assigned addresses from",
                 "consumes": [
                     "application/json"
                 ],
@@ -2598,33 +4502,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "VPC"
                 ],
-                "summary": "Update Sites",
-                "operationId": "UpdateSite",
+                "summary": "Exclude Range",
+                "operationId": "CreateExcludedRange",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Site Update",
-                        "name": "update",
+                        "description": "Add Excluded Range",
+                        "name": "excluded_range",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateSite"
+                            "$ref": "#/definitions/models.AddExcludedRange"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "$ref": "#/definitions/models.ExcludedRange"
                         }
                     },
                     "400": {
@@ -2639,55 +4543,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/users": {
-            "get": {
-                "description": "Lists all users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "List Users",
-                "operationId": "ListUsers",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "429": {
@@ -2705,9 +4582,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}": {
-            "get": {
-                "description": "Gets a user",
+        "/api/vpcs/{id}/excluded-ranges/{range_id}": {
+            "delete": {
+                "description": "Deletes an excluded range of a VPC, its addresses return to the pool of the VPC",
                 "consumes": [
                     "application/json"
                 ],
@@ -2715,24 +4592,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "VPC"
                 ],
-                "summary": "Get User",
-                "operationId": "GetUser",
+                "summary": "Delete Excluded Range",
+                "operationId": "DeleteExcludedRange",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Excluded Range ID",
+                        "name": "range_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.ExcludedRange"
                         }
                     },
                     "400": {
@@ -2747,6 +4631,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2766,9 +4656,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a user",
+            }
+        },
+        "/api/vpcs/{id}/ip-reservations": {
+            "get": {
+                "description": "Lists the addresses of a VPC reserved for a device, a registration key or a hostname",
                 "consumes": [
                     "application/json"
                 ],
@@ -2776,14 +4668,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "VPC"
                 ],
-                "summary": "Delete User",
-                "operationId": "DeleteUser",
+                "summary": "List IP Reservations",
+                "operationId": "ListIPReservations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2793,13 +4685,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.IPReservation"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.NotAllowedError"
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "429": {
@@ -2815,11 +4722,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/users/{id}/organizations/{organization}": {
-            "delete": {
-                "description": "Deletes an existing organization associated to a user",
+            },
+            "post": {
+                "description": "Reserves an address of a VPC for a device, a registration key or a hostname. The address is kept out of the pool of the VPC and only a matching device is assigned it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2827,31 +4732,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "VPC"
                 ],
-                "summary": "Remove a User from an Organization",
-                "operationId": "DeleteUserFromOrganization",
+                "summary": "Reserve IP",
+                "operationId": "CreateIPReservation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
+                        "description": "Add IP Reservation",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddIPReservation"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.IPReservation"
                         }
                     },
                     "400": {
@@ -2860,6 +4767,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2869,9 +4812,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpc/{id}/events": {
-            "post": {
-                "description": "Watches events occurring in the vpc",
+        "/api/vpcs/{id}/ip-reservations/{reservation_id}": {
+            "delete": {
+                "description": "Deletes an IP reservation of a VPC. A device assigned the address keeps it, otherwise the address returns to the pool of the VPC.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2881,31 +4824,20 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Watch events occurring in the vpc",
-                "operationId": "WatchEvents",
+                "summary": "Delete IP Reservation",
+                "operationId": "DeleteIPReservation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "connect as the device with the given public key, device will be considered to be online for the duration of this request",
-                        "name": "public_key",
-                        "in": "query"
-                    },
-                    {
-                        "description": "List of events to watch",
-                        "name": "Watches",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Watch"
-                            }
-                        }
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "VPC ID",
-                        "name": "id",
+                        "description": "IP Reservation ID",
+                        "name": "reservation_id",
                         "in": "path",
                         "required": true
                     }
@@ -2914,7 +4846,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WatchEvent"
+                            "$ref": "#/definitions/models.IPReservation"
                         }
                     },
                     "400": {
@@ -2929,6 +4861,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2944,9 +4888,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs": {
+        "/api/vpcs/{id}/metadata": {
             "get": {
-                "description": "Lists all VPCs",
+                "description": "Lists metadata for a device",
                 "consumes": [
                     "application/json"
                 ],
@@ -2956,30 +4900,49 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "List VPCs",
-                "operationId": "ListVPCs",
+                "summary": "List Device Metadata",
+                "operationId": "ListMetadataInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "used to filter down to the specified key prefixes",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "used to filter down to the specified key",
+                        "name": "key",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.VPC"
+                                "$ref": "#/definitions/models.DeviceMetadata"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2987,42 +4950,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a named vpc with the given CIDR",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/vpcs/{id}/security-groups": {
+            "get": {
+                "description": "Lists all Security Groups in a VPC",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Create an VPC",
-                "operationId": "CreateVPC",
+                "summary": "List Security Groups in a VPC",
+                "operationId": "ListSecurityGroupsInVPC",
                 "parameters": [
                     {
-                        "description": "Add VPC",
-                        "name": "VPC",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddVPC"
-                        }
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.VPC"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SecurityGroup"
+                            }
                         }
                     },
                     "401": {
@@ -3031,18 +4994,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3058,9 +5009,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs/{id}": {
+        "/api/vpcs/{id}/subnets": {
             "get": {
-                "description": "Gets a VPC by VPC ID",
+                "description": "Lists the subnets of a VPC",
                 "consumes": [
                     "application/json"
                 ],
@@ -3070,8 +5021,8 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Get VPCs",
-                "operationId": "GetVPC",
+                "summary": "List Subnets",
+                "operationId": "ListSubnets",
                 "parameters": [
                     {
                         "type": "string",
@@ -3085,7 +5036,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Subnet"
+                            }
                         }
                     },
                     "400": {
@@ -3120,8 +5074,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing vpc and associated IPAM prefix",
+            "post": {
+                "description": "Adds a named slice of the CIDRs of a VPC with a private CIDR. The devices joining the subnet are assigned addresses from it, and the other devices of the VPC are assigned addresses outside of it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3131,8 +5085,8 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Delete VPC",
-                "operationId": "DeleteVPC",
+                "summary": "Add Subnet",
+                "operationId": "CreateSubnet",
                 "parameters": [
                     {
                         "type": "string",
@@ -3140,13 +5094,22 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Add Subnet",
+                        "name": "subnet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSubnet"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Subnet"
                         }
                     },
                     "400": {
@@ -3155,12 +5118,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3174,9 +5161,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates a vpc by ID",
+            }
+        },
+        "/api/vpcs/{id}/subnets/{subnet_id}": {
+            "get": {
+                "description": "Gets a subnet of a VPC by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3186,8 +5175,8 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Update VPCs",
-                "operationId": "UpdateVPC",
+                "summary": "Get Subnet",
+                "operationId": "GetSubnet",
                 "parameters": [
                     {
                         "type": "string",
@@ -3197,20 +5186,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "VPC Update",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateVPC"
-                        }
+                        "type": "string",
+                        "description": "Subnet ID",
+                        "name": "subnet_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Subnet"
                         }
                     },
                     "400": {
@@ -3244,11 +5231,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/vpcs/{id}/devices": {
-            "get": {
-                "description": "Lists all devices for this VPC",
+            },
+            "delete": {
+                "description": "Deletes a subnet of a VPC that no device or registration key uses",
                 "consumes": [
                     "application/json"
                 ],
@@ -3258,31 +5243,29 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "List Devices",
-                "operationId": "ListDevicesInVPC",
+                "summary": "Delete Subnet",
+                "operationId": "DeleteSubnet",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subnet ID",
+                        "name": "subnet_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Device"
-                            }
+                            "$ref": "#/definitions/models.Subnet"
                         }
                     },
                     "400": {
@@ -3297,6 +5280,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3310,11 +5305,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/vpcs/{id}/metadata": {
-            "get": {
-                "description": "Lists metadata for a device",
+            },
+            "patch": {
+                "description": "Updates the settings of a subnet of a VPC. The security group only applies to the devices joining the subnet afterwards.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3324,8 +5317,8 @@ const docTemplate = `{
                 "tags": [
                     "VPC"
                 ],
-                "summary": "List Device Metadata",
-                "operationId": "ListMetadataInVPC",
+                "summary": "Update Subnet",
+                "operationId": "UpdateSubnet",
                 "parameters": [
                     {
                         "type": "string",
@@ -3334,82 +5327,34 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "used to filter down to the specified key prefixes",
-                        "name": "prefix",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "used to filter down to the specified key",
-                        "name": "key",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.DeviceMetadata"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/vpcs/{id}/security-groups": {
-            "get": {
-                "description": "Lists all Security Groups in a VPC",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "VPC"
-                ],
-                "summary": "List Security Groups in a VPC",
-                "operationId": "ListSecurityGroupsInVPC",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "VPC ID",
-                        "name": "id",
+                        "description": "Subnet ID",
+                        "name": "subnet_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Subnet Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSubnet"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SecurityGroup"
-                            }
+                            "$ref": "#/definitions/models.Subnet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "401": {
@@ -3418,6 +5363,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3699,6 +5662,31 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "models.AcceptVpcPeering": {
+            "type": "object",
+            "properties": {
+                "cidrs": {
+                    "description": "the CIDRs of the accepter VPC to export",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "100.64.0.0/24"
+                    ]
+                },
+                "devices": {
+                    "description": "the devices of the accepter VPC to export",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                    ]
+                }
+            }
+        },
         "models.AddDevice": {
             "type": "object",
             "properties": {
@@ -3736,9 +5724,17 @@ const docTemplate = `{
                 "relay": {
                     "type": "boolean"
                 },
+                "router_priority": {
+                    "type": "integer",
+                    "example": 100
+                },
                 "security_group_id": {
                     "type": "string"
                 },
+                "subnet_id": {
+                    "description": "the subnet of the VPC to join, the subnet of the registration key takes precedence",
+                    "type": "string"
+                },
                 "symmetric_nat": {
                     "type": "boolean"
                 },
@@ -3748,6 +5744,51 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AddDeviceShare": {
+            "type": "object",
+            "properties": {
+                "vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
+                }
+            }
+        },
+        "models.AddExcludedRange": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "type": "string",
+                    "example": "10.1.1.0/28"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "statically addressed appliances"
+                }
+            }
+        },
+        "models.AddIPReservation": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "100.64.0.10"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "the web server of the team"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "hostname": {
+                    "type": "string",
+                    "example": "web-1"
+                },
+                "reg_key_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.AddInvitation": {
             "type": "object",
             "properties": {
@@ -3783,9 +5824,51 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AddProxyRule": {
+            "type": "object",
+            "properties": {
+                "accept_proxy_protocol": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "destination_host": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "destination_port": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "listen_port": {
+                    "type": "integer",
+                    "example": 8080
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "tcp"
+                },
+                "send_proxy_protocol": {
+                    "type": "string",
+                    "example": "v2"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ingress"
+                }
+            }
+        },
         "models.AddRegKey": {
             "type": "object",
             "properties": {
+                "auto_approve_cidrs": {
+                    "description": "AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "description": "Description of the registration key.",
                     "type": "string"
@@ -3811,6 +5894,10 @@ const docTemplate = `{
                     "description": "SingleUse only allows the registration key to be used once.",
                     "type": "boolean"
                 },
+                "subnet_id": {
+                    "description": "SubnetID is the ID of the subnet of the VPC the device will join.",
+                    "type": "string"
+                },
                 "vpc_id": {
                     "description": "VpcID is the ID of the VPC the device will join.",
                     "type": "string"
@@ -3871,6 +5958,51 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AddSubnet": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "the production servers"
+                },
+                "dns_search_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "prod.lan"
+                    ]
+                },
+                "dns_servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.1.1.53"
+                    ]
+                },
+                "ipv4_cidr": {
+                    "type": "string",
+                    "example": "10.1.1.0/24"
+                },
+                "ipv6_cidr": {
+                    "type": "string",
+                    "example": "fc00:0:0:1::/64"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "prod"
+                },
+                "relay_device_id": {
+                    "type": "string"
+                },
+                "security_group_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.AddVPC": {
             "type": "object",
             "properties": {
@@ -3891,6 +6023,43 @@ const docTemplate = `{
                 },
                 "private_cidr": {
                     "type": "boolean"
+                },
+                "topology": {
+                    "type": "string",
+                    "example": "full-mesh"
+                }
+            }
+        },
+        "models.AddVpcPeering": {
+            "type": "object",
+            "properties": {
+                "accepter_vpc_id": {
+                    "type": "string",
+                    "example": "5f3b5d49-5e15-4e2a-9f5b-8a6f6a1c1e3d"
+                },
+                "cidrs": {
+                    "description": "the CIDRs of the requester VPC to export",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "172.16.42.0/24"
+                    ]
+                },
+                "devices": {
+                    "description": "the devices of the requester VPC to export",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                    ]
+                },
+                "requester_vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
                 }
             }
         },
@@ -3971,6 +6140,13 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "approved_cidrs": {
+                    "description": "the advertised CIDRs an organization owner approved, only these are routed by peers",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "bearer_token": {
                     "description": "the token nexd should use to reconcile device state.",
                     "type": "string"
@@ -3984,6 +6160,10 @@ const docTemplate = `{
                 "hostname": {
                     "type": "string"
                 },
+                "hub": {
+                    "description": "hubs peer with every device when the VPC is not a full mesh",
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string",
                     "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
@@ -4012,18 +6192,40 @@ const docTemplate = `{
                 "owner_id": {
                     "type": "string"
                 },
+                "peering_groups": {
+                    "description": "devices sharing a peering group peer with each other when the VPC topology is peering-groups",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "public_key": {
                     "type": "string"
                 },
+                "rejected_cidrs": {
+                    "description": "the advertised CIDRs an organization owner rejected",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "relay": {
                     "type": "boolean"
                 },
                 "revision": {
                     "type": "integer"
                 },
+                "router_priority": {
+                    "description": "peers route a prefix advertised by several devices to the online device with the highest priority",
+                    "type": "integer"
+                },
                 "security_group_id": {
                     "type": "string"
                 },
+                "subnet_id": {
+                    "description": "the subnet of the VPC the device joined, if any",
+                    "type": "string"
+                },
                 "symmetric_nat": {
                     "type": "boolean"
                 },
@@ -4048,37 +6250,122 @@ const docTemplate = `{
                 "value": {}
             }
         },
+        "models.DeviceRoutes": {
+            "type": "object",
+            "properties": {
+                "cidrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "172.16.42.0/24"
+                    ]
+                }
+            }
+        },
+        "models.DeviceShare": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
+                }
+            }
+        },
         "models.DeviceStartResponse": {
             "type": "object",
             "properties": {
                 "client_id": {
                     "type": "string"
                 },
-                "device_authorization_endpoint": {
-                    "description": "TODO: Remove this once golang/oauth2 supports device flow\nand when coreos/go-oidc adds device_authorization_endpoint discovery",
-                    "type": "string"
+                "device_authorization_endpoint": {
+                    "description": "TODO: Remove this once golang/oauth2 supports device flow\nand when coreos/go-oidc adds device_authorization_endpoint discovery",
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "server_time": {
+                    "description": "the current time on the server, can be used by a client to get an idea of what the time skew is\nin relation to the server.",
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "models.Endpoint": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "IP address and port of the endpoint.",
+                    "type": "string",
+                    "example": "10.1.1.1:51820"
+                },
+                "source": {
+                    "description": "How the endpoint was discovered",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExcludedRange": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "type": "string",
+                    "example": "10.1.1.0/28"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "statically addressed appliances"
                 },
-                "issuer": {
-                    "type": "string"
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
                 },
-                "server_time": {
-                    "description": "the current time on the server, can be used by a client to get an idea of what the time skew is\nin relation to the server.",
+                "vpc_id": {
                     "type": "string",
-                    "format": "date-time"
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
                 }
             }
         },
-        "models.Endpoint": {
+        "models.IPReservation": {
             "type": "object",
             "properties": {
                 "address": {
-                    "description": "IP address and port of the endpoint.",
+                    "description": "an IPv4 or IPv6 address of the VPC",
                     "type": "string",
-                    "example": "10.1.1.1:51820"
+                    "example": "100.64.0.10"
                 },
-                "source": {
-                    "description": "How the endpoint was discovered",
+                "description": {
+                    "type": "string",
+                    "example": "the web server of the team"
+                },
+                "device_id": {
+                    "description": "DeviceID reserves the address for the device with this id",
+                    "type": "string"
+                },
+                "hostname": {
+                    "description": "Hostname reserves the address for the device with this hostname",
+                    "type": "string",
+                    "example": "web-1"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "reg_key_id": {
+                    "description": "RegKeyID reserves the address for the device registered with this registration key",
                     "type": "string"
+                },
+                "vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
                 }
             }
         },
@@ -4210,9 +6497,105 @@ const docTemplate = `{
                 }
             }
         },
+        "models.OrganizationUsage": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "$ref": "#/definitions/models.ResourceUsage"
+                },
+                "invitations": {
+                    "$ref": "#/definitions/models.ResourceUsage"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "reg_keys": {
+                    "$ref": "#/definitions/models.ResourceUsage"
+                },
+                "security_groups": {
+                    "$ref": "#/definitions/models.ResourceUsage"
+                },
+                "vpcs": {
+                    "$ref": "#/definitions/models.ResourceUsage"
+                }
+            }
+        },
+        "models.ProxyRule": {
+            "type": "object",
+            "properties": {
+                "accept_proxy_protocol": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "destination_host": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "destination_port": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "listen_port": {
+                    "type": "integer",
+                    "example": 8080
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "tcp"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "send_proxy_protocol": {
+                    "type": "string",
+                    "example": "v2"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ingress"
+                },
+                "vpc_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QuotaExceededError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "something bad"
+                },
+                "quota": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "resource": {
+                    "type": "string",
+                    "example": "devices"
+                }
+            }
+        },
         "models.RegKey": {
             "type": "object",
             "properties": {
+                "auto_approve_cidrs": {
+                    "description": "AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "bearer_token": {
                     "description": "BearerToken is the bearer token the client should use to authenticate the device registration request.",
                     "type": "string"
@@ -4250,12 +6633,30 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "subnet_id": {
+                    "description": "SubnetID is the ID of the subnet of the VPC the device joins.",
+                    "type": "string"
+                },
                 "vpc_id": {
                     "description": "VpcID is the ID of the VPC the device can join.",
                     "type": "string"
                 }
             }
         },
+        "models.ResourceUsage": {
+            "type": "object",
+            "properties": {
+                "quota": {
+                    "description": "0 when unlimited",
+                    "type": "integer",
+                    "example": 100
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "models.SecurityGroup": {
             "type": "object",
             "properties": {
@@ -4378,6 +6779,65 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Subnet": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "the production servers"
+                },
+                "dns_search_domains": {
+                    "description": "the DNS search domains nexd passes to the hooks of the devices of the subnet",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "prod.lan"
+                    ]
+                },
+                "dns_servers": {
+                    "description": "the DNS servers nexd passes to the hooks of the devices of the subnet",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.1.1.53"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "ipv4_cidr": {
+                    "description": "a slice of an IPv4 CIDR of the VPC",
+                    "type": "string",
+                    "example": "10.1.1.0/24"
+                },
+                "ipv6_cidr": {
+                    "description": "a slice of an IPv6 CIDR of the VPC",
+                    "type": "string",
+                    "example": "fc00:0:0:1::/64"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "prod"
+                },
+                "relay_device_id": {
+                    "description": "the relay the devices of the subnet prefer over the other relays of the VPC",
+                    "type": "string"
+                },
+                "security_group_id": {
+                    "description": "the security group of the devices joining the subnet, instead of the default one of the VPC",
+                    "type": "string"
+                },
+                "vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
+                }
+            }
+        },
         "models.TunnelIP": {
             "type": "object",
             "properties": {
@@ -4415,12 +6875,30 @@ const docTemplate = `{
                     "type": "string",
                     "example": "myhost"
                 },
+                "hub": {
+                    "description": "only organization owners can designate hubs",
+                    "type": "boolean"
+                },
+                "peering_groups": {
+                    "description": "only organization owners can set peering groups",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "branch-east"
+                    ]
+                },
                 "relay": {
                     "type": "boolean"
                 },
                 "revision": {
                     "type": "integer"
                 },
+                "router_priority": {
+                    "type": "integer",
+                    "example": 100
+                },
                 "security_group_id": {
                     "type": "string"
                 },
@@ -4433,9 +6911,43 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateProxyRule": {
+            "type": "object",
+            "properties": {
+                "accept_proxy_protocol": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "destination_host": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "destination_port": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "listen_port": {
+                    "type": "integer",
+                    "example": 8080
+                },
+                "send_proxy_protocol": {
+                    "type": "string",
+                    "example": "v2"
+                }
+            }
+        },
         "models.UpdateRegKey": {
             "type": "object",
             "properties": {
+                "auto_approve_cidrs": {
+                    "description": "AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "description": "Description of the registration key.",
                     "type": "string"
@@ -4502,12 +7014,59 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateSubnet": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "the production servers"
+                },
+                "dns_search_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "prod.lan"
+                    ]
+                },
+                "dns_servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.1.1.53"
+                    ]
+                },
+                "relay_device_id": {
+                    "type": "string"
+                },
+                "security_group_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateVPC": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string",
                     "example": "The Red Zone"
+                },
+                "secondary_cidrs": {
+                    "description": "replaces the secondary CIDRs, only the CIDRs no device, IP reservation, excluded range or subnet uses can be removed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "172.16.43.0/24"
+                    ]
+                },
+                "topology": {
+                    "type": "string",
+                    "example": "hub-and-spoke"
                 }
             }
         },
@@ -4602,6 +7161,18 @@ const docTemplate = `{
                 },
                 "revision": {
                     "type": "integer"
+                },
+                "secondary_cidrs": {
+                    "description": "IPv4 and IPv6 CIDRs added after the VPC was created, devices are assigned addresses from them once the primary CIDR of the family is exhausted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topology": {
+                    "description": "which devices of the VPC peer with each other: full-mesh, hub-and-spoke or peering-groups",
+                    "type": "string",
+                    "example": "full-mesh"
                 }
             }
         },
@@ -4620,6 +7191,56 @@ const docTemplate = `{
                 }
             }
         },
+        "models.VpcPeering": {
+            "type": "object",
+            "properties": {
+                "accepter_cidrs": {
+                    "description": "the devices and approved routes of the accepter VPC within these CIDRs are exported to the requester VPC",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "accepter_devices": {
+                    "description": "the devices of the accepter VPC exported to the requester VPC",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "accepter_vpc_id": {
+                    "type": "string",
+                    "example": "5f3b5d49-5e15-4e2a-9f5b-8a6f6a1c1e3d"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "requester_cidrs": {
+                    "description": "the devices and approved routes of the requester VPC within these CIDRs are exported to the accepter VPC",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requester_devices": {
+                    "description": "the devices of the requester VPC exported to the accepter VPC",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requester_vpc_id": {
+                    "type": "string",
+                    "example": "694aa002-5d19-495e-980b-3d8fd508ea10"
+                },
+                "status": {
+                    "description": "pending until an owner of the accepter VPC accepts the peering, then active",
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
         "models.Watch": {
             "type": "object",
             "properties": {
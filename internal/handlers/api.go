@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/nexodus-io/nexodus/internal/database"
 	"github.com/open-policy-agent/opa/storage"
@@ -25,6 +26,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/fflags"
 	"github.com/nexodus-io/nexodus/internal/ipam"
+	ipamcmd "github.com/nexodus-io/nexodus/internal/ipam/cmd"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -59,6 +61,9 @@ type API struct {
 	SmtpFrom       string
 	caKeyPair      CertificateKeyPair
 	FrontendURL    string
+	DefaultQuotas  models.Quotas // the quotas of the organizations without overrides
+	// IPAMRepairGracePeriod is how long an IPAM repair leaves the leaks alone before it releases them
+	IPAMRepairGracePeriod time.Duration
 }
 
 func NewAPI(
@@ -121,6 +126,8 @@ func NewAPI(
 		fetchManager:   fetchManager,
 		onlineTracker:  onlineTracker,
 		caKeyPair:      caKeyPair,
+
+		IPAMRepairGracePeriod: ipamcmd.DefaultLeakGracePeriod,
 	}
 
 	if err := api.populateStore(ctx); err != nil {
@@ -203,6 +210,15 @@ func (api *API) FlagCheck(c *gin.Context, name string) bool {
 	return enabled
 }
 
+// ipamTx returns the IPAM making its changes in the transaction tx when IPAM is embedded in the apiserver
+// database, so that they are rolled back along with the transaction.
+func (api *API) ipamTx(tx *gorm.DB) ipam.IPAM {
+	if embedded, ok := api.ipam.(ipam.TransactionalIPAM); ok {
+		return embedded.WithTx(tx)
+	}
+	return api.ipam
+}
+
 func (api *API) createDefaultIPamNamespace(ctx context.Context) error {
 	// Create namespaces and cidrs
 	if err := api.ipam.CreateNamespace(ctx, defaultIPAMNamespace); err != nil {
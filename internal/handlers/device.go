@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -216,6 +219,7 @@ func (api *API) UpdateDevice(c *gin.Context) {
 
 	var device models.Device
 	var tokenClaims *models.NexodusClaims
+	var previousVpcId *uuid.UUID
 	err = api.transaction(ctx, func(tx *gorm.DB) error {
 
 		db := api.DeviceIsOwnedByCurrentUser(c, tx)
@@ -250,9 +254,9 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			return result.Error
 		}
 
-		originalIpamNamespace := defaultIPAMNamespace
+		ipamNamespace := defaultIPAMNamespace
 		if vpc.PrivateCidr {
-			originalIpamNamespace = vpc.ID
+			ipamNamespace = vpc.ID
 		}
 
 		if request.Hostname != "" {
@@ -263,60 +267,115 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			device.Endpoints = request.Endpoints
 		}
 
-		// TODO: re-enable this when we are ready to support changing a device's VPC.
+		if request.SecurityGroupId != nil {
+			// validated before the addresses are moved when the device also moves to another VPC
+			vpcId := device.VpcID
+			if request.VpcID != nil {
+				vpcId = *request.VpcID
+			}
+			var sg models.SecurityGroup
+			if result := api.SecurityGroupIsReadableByCurrentUser(c, tx).
+				First(&sg, "id = ?", *request.SecurityGroupId); result.Error != nil {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("security_group_id"))
+			}
+			if sg.VpcId != vpcId {
+				return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("security_group_id", "the security group is not in the vpc of the device"))
+			}
+			device.SecurityGroupId = *request.SecurityGroupId
+		}
 
-		if request.VpcID != nil && *request.VpcID != device.OrganizationID {
+		if request.VpcID != nil && *request.VpcID != device.VpcID {
+			// moving a device re-addresses it, so it can not be done with reg or device tokens.
+			if isTokenClaims(tokenClaims) {
+				return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("a device can only be moved to another vpc by its owner")))
+			}
 
 			var newVpc models.VPC
 			if result := api.VPCIsReadableByCurrentUser(c, tx).
-				Preload("Organization").
-				First(&newVpc, "id = ?", request.VpcID); result.Error != nil {
+				First(&newVpc, "id = ?", *request.VpcID); result.Error != nil {
 				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_id"))
 			}
 
+			if newVpc.OrganizationID != device.OrganizationID {
+				if err := api.checkQuota(tx, newVpc.OrganizationID, models.QuotaDevices); err != nil {
+					return err
+				}
+			}
+
+			// reg keys only grant access to the devices of their own VPC
+			if device.RegKeyID != uuid.Nil {
+				var count int64
+				if res := tx.Model(&models.RegKey{}).Where("id = ? AND vpc_id = ?", device.RegKeyID, newVpc.ID).Count(&count); res.Error != nil {
+					return res.Error
+				}
+				if count == 0 {
+					device.RegKeyID = uuid.Nil
+				}
+			}
+
 			newIpamNamespace := defaultIPAMNamespace
 			if newVpc.PrivateCidr {
 				newIpamNamespace = newVpc.ID
 			}
 
 			// We can reuse the ip address if the ipam namespace is not changing.
-			if originalIpamNamespace != newIpamNamespace {
+			if ipamNamespace != newIpamNamespace {
 
 				for _, t := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
 					address := t.Address
 					cidr := t.CIDR
 					if address != "" && cidr != "" {
-						if err := api.ipam.ReleaseToPool(c.Request.Context(), originalIpamNamespace, address, cidr); err != nil {
+						// reserved addresses stay out of the pool
+						reserved, err := addressReserved(tx, vpc.ID, address)
+						if err != nil {
+							return err
+						}
+						if reserved {
+							continue
+						}
+						if err := api.ipamTx(tx).ReleaseToPool(ctx, ipamNamespace, address, cidr); err != nil {
 							return fmt.Errorf("failed to release the ip address to pool: %w", err)
 						}
 					}
 				}
 				for _, cidr := range device.AdvertiseCidrs {
-					if err := api.ipam.ReleaseCIDR(c.Request.Context(), originalIpamNamespace, cidr); err != nil {
+					if util.IsDefaultIPRoute(cidr) {
+						continue
+					}
+					shared, err := cidrAdvertisedByOtherDevices(tx, device.VpcID, device.ID, cidr)
+					if err != nil {
+						return err
+					}
+					if shared {
+						continue
+					}
+					if err := api.ipamTx(tx).ReleaseCIDR(ctx, ipamNamespace, cidr); err != nil {
 						return fmt.Errorf("failed to release cidr: %w", err)
 					}
 				}
 
-				device.IPv4TunnelIPs[0].CIDR = newVpc.Ipv4Cidr
-				device.IPv4TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ctx, newIpamNamespace, newVpc.Ipv4Cidr)
+				ipv4, ipv6, err := api.assignTunnelIPs(ctx, tx, newVpc, tunnelIPRequest{
+					deviceId: device.ID,
+					regKeyId: device.RegKeyID,
+					hostname: device.Hostname,
+				})
 				if err != nil {
-					return fmt.Errorf("failed to request ipam address: %w", err)
+					return err
 				}
+				device.IPv4TunnelIPs = []models.TunnelIP{tunnelIP(newVpc, ipv4)}
+				device.IPv6TunnelIPs = []models.TunnelIP{tunnelIP(newVpc, ipv6)}
 
-				device.IPv6TunnelIPs[0].CIDR = newVpc.Ipv6Cidr
-				device.IPv6TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ctx, newIpamNamespace, newVpc.Ipv6Cidr)
-				if err != nil {
-					return fmt.Errorf("failed to request ipam address: %w", err)
-				}
-
-				// allocate a CIDR if requested
-				for _, cidr := range request.AdvertiseCidrs {
-					if !util.IsValidPrefix(cidr) {
-						return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
+				// the advertised CIDRs move along with the device, unless a router of the new VPC already advertises them
+				for _, cidr := range device.AdvertiseCidrs {
+					if util.IsDefaultIPRoute(cidr) {
+						continue
 					}
-					// Skip the prefix assignment if it's an IPv4 or IPv6 default route
-					if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-						if err := api.ipam.AssignCIDR(ctx, newIpamNamespace, cidr); err != nil {
+					shared, err := cidrAdvertisedByOtherDevices(tx, newVpc.ID, device.ID, cidr)
+					if err != nil {
+						return err
+					}
+					if !shared {
+						if err := api.ipamTx(tx).AssignCIDR(ctx, newIpamNamespace, cidr); err != nil {
 							return fmt.Errorf("failed to assign cidr: %w", err)
 						}
 					}
@@ -328,53 +387,144 @@ func (api *API) UpdateDevice(c *gin.Context) {
 				return err
 			}
 
-			device.VpcID = *request.VpcID
+			// security groups and subnets are scoped to a VPC, the device falls back to the default security
+			// group of the new VPC.
+			if request.SecurityGroupId == nil {
+				device.SecurityGroupId = newVpc.ID
+			}
+			device.SubnetID = nil
+
+			if err := moveDeviceWatches(tx, &device, vpc.ID, newVpc.ID); err != nil {
+				return err
+			}
+			// the device is now a member of a VPC it was shared into, and it can only stay shared into
+			// other VPCs when it keeps using the shared CG-NAT address space
+			if res := tx.Where("device_id = ? AND vpc_id = ?", device.ID, newVpc.ID).
+				Delete(&models.DeviceShare{}); res.Error != nil {
+				return res.Error
+			}
+			if newVpc.PrivateCidr {
+				var shared int64
+				if res := tx.Model(&models.DeviceShare{}).Where("device_id = ?", device.ID).Count(&shared); res.Error != nil {
+					return res.Error
+				}
+				if shared > 0 {
+					return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("vpc_id", "the device is shared, revoke its shares before moving it to a vpc with a private cidr"))
+				}
+			}
+
+			oldVpcId := device.VpcID
+			previousVpcId = &oldVpcId
+			device.VpcID = newVpc.ID
+			device.OrganizationID = newVpc.OrganizationID
+			ipamNamespace = newIpamNamespace
+
+			// the routes and the peers of a device are decided by the owners of its VPC, so they are
+			// approved again for the new VPC.
+			device.Hub = false
+			device.PeeringGroups = nil
+			device.ApprovedCidrs = nil
+			device.RejectedCidrs = nil
+			autoApproved, err := api.autoApprovedCidrs(c, tx, &device, tokenClaims, device.AdvertiseCidrs)
+			if err != nil {
+				return err
+			}
+			reconcileDeviceRouteApprovals(&device, autoApproved)
+			vpc = newVpc
 		}
 		if request.SymmetricNat != nil {
 			device.SymmetricNat = *request.SymmetricNat
 		}
-		if request.Relay != nil {
-			device.Relay = *request.Relay
+		if request.RouterPriority != nil {
+			device.RouterPriority = *request.RouterPriority
 		}
 
-		if request.SecurityGroupId != nil {
-			var sg models.SecurityGroup
-			if result := api.SecurityGroupIsReadableByCurrentUser(c, tx).
-				First(&sg, "id = ?", *request.SecurityGroupId); result.Error != nil {
-				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("security_group_id"))
+		peersChanged := false
+		if request.Relay != nil && *request.Relay != device.Relay {
+			// relays peer with every device regardless of the topology of the VPC, so a device keeps the
+			// relay setting it registered with unless an organization owner changes it.
+			if err := api.requireVpcOwner(c, tx, tokenClaims, vpc.ID, "relays can only be changed by organization owners"); err != nil {
+				return err
+			}
+			device.Relay = *request.Relay
+			peersChanged = true
+		}
+		if request.Hub != nil || request.PeeringGroups != nil {
+			// the peers of a device can only be changed by organization owners, not with reg or device tokens.
+			if err := api.requireVpcOwner(c, tx, tokenClaims, vpc.ID, "hubs and peering groups can only be set by organization owners"); err != nil {
+				return err
+			}
+			if slices.Contains(request.PeeringGroups, "") {
+				return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("peering_groups", "must not be empty"))
+			}
+			if request.Hub != nil && *request.Hub != device.Hub {
+				device.Hub = *request.Hub
+				peersChanged = true
+			}
+			if request.PeeringGroups != nil && !slices.Equal(request.PeeringGroups, device.PeeringGroups) {
+				device.PeeringGroups = request.PeeringGroups
+				peersChanged = true
+			}
+		}
+		// the other devices of the VPC may gain or lose the device as a peer, and the device may gain
+		// or lose all of them, so they are all sent again.
+		if peersChanged && vpc.Topology != "" && vpc.Topology != models.VPCTopologyFullMesh {
+			if err := touchVpcDevices(tx, vpc.ID); err != nil {
+				return err
 			}
-			device.SecurityGroupId = *request.SecurityGroupId
 		}
 
 		// check if the updated device advertised CIDRs match the existing device advertised CIDRs
 		if request.AdvertiseCidrs != nil && !advertiseCidrEquals(device.AdvertiseCidrs, request.AdvertiseCidrs) {
-			cidrAllocated := make(map[string]struct{})
-			for _, cidr := range device.AdvertiseCidrs {
+			requested := make(map[string]struct{})
+			for _, cidr := range request.AdvertiseCidrs {
 				if !util.IsValidPrefix(cidr) {
-					return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
+					return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("advertise_cidrs", fmt.Sprintf("invalid cidr %s", cidr)))
 				}
+				requested[cidr] = struct{}{}
+			}
+			cidrAllocated := make(map[string]struct{})
+			for _, cidr := range device.AdvertiseCidrs {
 				cidrAllocated[cidr] = struct{}{}
 			}
-			for _, cidr := range request.AdvertiseCidrs {
-				isDefaultRoute := util.IsDefaultIPRoute(cidr)
-				// If the prefix is not a default route, process IPAM allocation/release
-				if isDefaultRoute {
+			// Devices advertising the same prefix in a VPC form a router HA group that shares
+			// a single IPAM allocation, so only the first router in assigns and the last one out releases.
+			for cidr := range cidrAllocated {
+				if _, ok := requested[cidr]; ok || util.IsDefaultIPRoute(cidr) {
 					continue
 				}
-				// lookup miss of prefix means we need to release it
-				if _, ok := cidrAllocated[cidr]; ok {
-					if err := api.ipam.ReleaseCIDR(ctx, originalIpamNamespace, cidr); err != nil {
+				shared, err := cidrAdvertisedByOtherDevices(tx, device.VpcID, device.ID, cidr)
+				if err != nil {
+					return err
+				}
+				if !shared {
+					if err := api.ipamTx(tx).ReleaseCIDR(ctx, ipamNamespace, cidr); err != nil {
 						return err
 					}
-				} else {
-					// otherwise we need to allocate it
-					if err := api.ipam.AssignCIDR(ctx, originalIpamNamespace, cidr); err != nil {
+				}
+			}
+			for cidr := range requested {
+				if _, ok := cidrAllocated[cidr]; ok || util.IsDefaultIPRoute(cidr) {
+					continue
+				}
+				shared, err := cidrAdvertisedByOtherDevices(tx, device.VpcID, device.ID, cidr)
+				if err != nil {
+					return err
+				}
+				if !shared {
+					if err := api.ipamTx(tx).AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
 						return err
 					}
 				}
 			}
 			device.AdvertiseCidrs = request.AdvertiseCidrs
 
+			autoApproved, err := api.autoApprovedCidrs(c, tx, &device, tokenClaims, device.AdvertiseCidrs)
+			if err != nil {
+				return err
+			}
+			reconcileDeviceRouteApprovals(&device, autoApproved)
+
 		}
 
 		if res := tx.
@@ -400,7 +550,14 @@ func (api *API) UpdateDevice(c *gin.Context) {
 
 	hideDeviceBearerToken(&device, tokenClaims, api.GetCurrentUserID(c))
 
-	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	api.notifyDeviceWatchers(ctx, &device)
+	if previousVpcId != nil {
+		previous := models.Device{VpcID: *previousVpcId}
+		previous.ID = device.ID
+		api.notifyDeviceWatchers(ctx, &previous)
+		api.signalBus.Notify(fmt.Sprintf("/metadata/vpc=%s", device.VpcID.String()))
+		api.signalBus.Notify(proxyRuleSignal(device.ID))
+	}
 	c.JSON(http.StatusOK, device)
 }
 
@@ -444,6 +601,7 @@ func getAllowedIPs(ip string, ip6 string, relay bool) ([]string, error) {
 // @Success      201  {object}  models.Device
 // @Failure      400  {object}  models.BaseError
 // @Failure		 401  {object}  models.BaseError
+// @Failure		 403  {object}  models.QuotaExceededError
 // @Failure      409  {object}  models.ConflictsError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
@@ -491,6 +649,9 @@ func (api *API) CreateDevice(c *gin.Context) {
 		if res.Error != nil && !errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return res.Error
 		}
+		if err := api.checkQuota(tx, vpc.OrganizationID, models.QuotaDevices); err != nil {
+			return err
+		}
 
 		var err2 *ApiResponseError
 		tokenClaims, err2 = NxodusClaims(c, tx)
@@ -526,6 +687,11 @@ func (api *API) CreateDevice(c *gin.Context) {
 				return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("vpc_id", "does not match the reg key vpc_id"))
 			}
 		}
+
+		subnet, err := api.deviceSubnet(tx, vpc, request.SubnetID, regKeyID)
+		if err != nil {
+			return err
+		}
 		if deviceId == uuid.Nil {
 			deviceId = uuid.New()
 		}
@@ -541,29 +707,22 @@ func (api *API) CreateDevice(c *gin.Context) {
 			relay = true
 		}
 
-		var ipamIP string
-		var ipamIPv6 string
-
-		// If this was a static address request
-		// TODO: handle a user requesting an IP not in the IPAM prefix
+		// Currently only support v4 requesting of specific addresses
 		if len(request.IPv4TunnelIPs) > 1 {
 			return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("tunnel_ips_v4", "can only specify a single IPv4 address request"))
-		} else if len(request.IPv4TunnelIPs) == 1 {
-			ipamIP, err = api.ipam.AssignSpecificTunnelIP(ctx, ipamNamespace, vpc.Ipv4Cidr, request.IPv4TunnelIPs[0].Address)
-			if err != nil {
-				return fmt.Errorf("failed to request specific ipam address: %w", err)
-			}
-		} else {
-			ipamIP, err = api.ipam.AssignFromPool(ctx, ipamNamespace, vpc.Ipv4Cidr)
-			if err != nil {
-				return fmt.Errorf("failed to request ipam address: %w", err)
-			}
 		}
-
-		// Currently only support v4 requesting of specific addresses
-		ipamIPv6, err = api.ipam.AssignFromPool(ctx, ipamNamespace, vpc.Ipv6Cidr)
+		addressing := tunnelIPRequest{
+			deviceId: deviceId,
+			regKeyId: regKeyID,
+			hostname: request.Hostname,
+			subnet:   subnet,
+		}
+		if len(request.IPv4TunnelIPs) == 1 {
+			addressing.requestIPv4 = request.IPv4TunnelIPs[0].Address
+		}
+		ipamIP, ipamIPv6, err := api.assignTunnelIPs(ctx, tx, vpc, addressing)
 		if err != nil {
-			return fmt.Errorf("failed to request ipam v6 address: %w", err)
+			return err
 		}
 
 		// allocate a CIDR if requested
@@ -572,8 +731,16 @@ func (api *API) CreateDevice(c *gin.Context) {
 				return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
 			}
 			// Skip the prefix assignment if it's an IPv4 or IPv6 default route
-			if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-				if err := api.ipam.AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
+			if util.IsDefaultIPv4Route(cidr) || util.IsDefaultIPv6Route(cidr) {
+				continue
+			}
+			// Skip the prefix assignment if another router in the VPC already advertises it
+			shared, err := cidrAdvertisedByOtherDevices(tx, vpc.ID, deviceId, cidr)
+			if err != nil {
+				return err
+			}
+			if !shared {
+				if err := api.ipamTx(tx).AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
 					return fmt.Errorf("failed to assign cidr: %w", err)
 				}
 			}
@@ -594,25 +761,16 @@ func (api *API) CreateDevice(c *gin.Context) {
 			Base: models.Base{
 				ID: deviceId,
 			},
-			OwnerID:        userId,
-			VpcID:          vpc.ID,
-			OrganizationID: vpc.OrganizationID,
-			PublicKey:      request.PublicKey,
-			Endpoints:      request.Endpoints,
-			AllowedIPs:     allowedIPs,
-			IPv4TunnelIPs: []models.TunnelIP{
-				{
-					Address: ipamIP,
-					CIDR:    vpc.Ipv4Cidr,
-				},
-			},
-			IPv6TunnelIPs: []models.TunnelIP{
-				{
-					Address: ipamIPv6,
-					CIDR:    vpc.Ipv6Cidr,
-				},
-			},
+			OwnerID:         userId,
+			VpcID:           vpc.ID,
+			OrganizationID:  vpc.OrganizationID,
+			PublicKey:       request.PublicKey,
+			Endpoints:       request.Endpoints,
+			AllowedIPs:      allowedIPs,
+			IPv4TunnelIPs:   []models.TunnelIP{tunnelIP(vpc, ipamIP)},
+			IPv6TunnelIPs:   []models.TunnelIP{tunnelIP(vpc, ipamIPv6)},
 			AdvertiseCidrs:  request.AdvertiseCidrs,
+			RouterPriority:  request.RouterPriority,
 			Relay:           request.Relay,
 			SymmetricNat:    request.SymmetricNat,
 			Hostname:        request.Hostname,
@@ -621,6 +779,18 @@ func (api *API) CreateDevice(c *gin.Context) {
 			RegKeyID:        regKeyID,
 			BearerToken:     "DT:" + deviceToken.String(),
 		}
+		if subnet != nil {
+			device.SubnetID = &subnet.ID
+			if subnet.SecurityGroupId != nil {
+				device.SecurityGroupId = *subnet.SecurityGroupId
+			}
+		}
+
+		autoApproved, err := api.autoApprovedCidrs(c, tx, &device, tokenClaims, device.AdvertiseCidrs)
+		if err != nil {
+			return err
+		}
+		reconcileDeviceRouteApprovals(&device, autoApproved)
 
 		if res := tx.
 			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
@@ -644,7 +814,7 @@ func (api *API) CreateDevice(c *gin.Context) {
 	}
 
 	hideDeviceBearerToken(&device, tokenClaims, userId)
-	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	api.notifyDeviceWatchers(ctx, &device)
 	c.JSON(http.StatusCreated, device)
 }
 
@@ -704,47 +874,204 @@ func (api *API) DeleteDevice(c *gin.Context) {
 	orgPrefix := device.IPv4TunnelIPs[0].CIDR
 	advertiseCidrs := device.AdvertiseCidrs
 
-	// Null out unique fields to that a new device can be created later with the same values
-	if res := api.db.WithContext(ctx).
-		Model(&device).
-		Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
-		Where("id = ?", device.Base.ID).
-		Updates(map[string]interface{}{
-			"bearer_token": nil,
-			"public_key":   nil,
-			"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
-		}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
+	var shares []models.DeviceShare
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// the VPCs the device is shared into are sent its deletion
+		var err error
+		shares, err = revokeDeviceShares(tx, device.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		// Null out unique fields to that a new device can be created later with the same values
+		if res := tx.
+			Model(&device).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Where("id = ?", device.Base.ID).
+			Updates(map[string]interface{}{
+				"bearer_token": nil,
+				"public_key":   nil,
+				"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
+			}); res.Error != nil {
+			return res.Error
+		}
+
+		if res := tx.
+			Where("device_id = ?", device.Base.ID).
+			Delete(&models.ProxyRule{}); res.Error != nil {
+			return res.Error
+		}
+
+		// the devices of the subnets it relayed for fall back to the other relays of the VPC
+		if res := tx.Model(&models.Subnet{}).
+			Where("relay_device_id = ?", device.Base.ID).
+			Update("relay_device_id", nil); res.Error != nil {
+			return res.Error
+		}
+
+		// reserved addresses stay out of the pool
+		if reserved, err := addressReserved(tx, device.VpcID, ipamAddress); err != nil {
+			return err
+		} else if reserved {
+			ipamAddress = ""
+		}
+		if ipamAddress != "" && orgPrefix != "" {
+			if err := api.ipamTx(tx).ReleaseToPool(ctx, ipamNamespace, ipamAddress, orgPrefix); err != nil {
+				return fmt.Errorf("failed to release the v4 address to pool: %w", err)
+			}
+		}
+
+		for _, cidr := range advertiseCidrs {
+			// leave the prefix allocated while other routers in the VPC still advertise it
+			shared, err := cidrAdvertisedByOtherDevices(tx, device.VpcID, device.ID, cidr)
+			if err != nil {
+				return err
+			}
+			if shared {
+				continue
+			}
+			if err := api.ipamTx(tx).ReleaseCIDR(ctx, ipamNamespace, cidr); err != nil {
+				return fmt.Errorf("failed to release cidr: %w", err)
+			}
+		}
+
+		ipamAddressV6 := device.IPv6TunnelIPs[0].Address
+		orgPrefixV6 := device.IPv6TunnelIPs[0].CIDR
+		if reserved, err := addressReserved(tx, device.VpcID, ipamAddressV6); err != nil {
+			return err
+		} else if reserved {
+			ipamAddressV6 = ""
+		}
+		if ipamAddressV6 != "" && orgPrefixV6 != "" {
+			if err := api.ipamTx(tx).ReleaseToPool(ctx, ipamNamespace, ipamAddressV6, orgPrefixV6); err != nil {
+				return fmt.Errorf("failed to release the v6 address to pool: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 
 	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	for _, share := range shares {
+		api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", share.VpcID.String()))
+	}
+	api.signalBus.Notify(proxyRuleSignal(device.Base.ID))
 
-	if ipamAddress != "" && orgPrefix != "" {
-		if err := api.ipam.ReleaseToPool(c.Request.Context(), ipamNamespace, ipamAddress, orgPrefix); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release the v4 address to pool: %w", err))
-			return
-		}
+	c.JSON(http.StatusOK, device)
+}
+
+// notifyDeviceWatchers wakes the watchers of the devices of the VPC of a device, of the VPCs the
+// device is shared into and of the VPCs peered with its VPC.
+func (api *API) notifyDeviceWatchers(ctx context.Context, device *models.Device) {
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	var shares []models.DeviceShare
+	if res := api.db.WithContext(ctx).Select("vpc_id").Where("device_id = ?", device.ID).Find(&shares); res.Error != nil {
+		api.logger.Warnf("failed to list the shares of device %s: %v", device.ID, res.Error)
+		return
+	}
+	for _, share := range shares {
+		api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", share.VpcID.String()))
 	}
+	peerings, err := activeVpcPeerings(api.db.WithContext(ctx), device.VpcID)
+	if err != nil {
+		api.logger.Warnf("failed to list the peerings of vpc %s: %v", device.VpcID, err)
+		return
+	}
+	for _, peering := range peerings {
+		api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", peering.PeerVpcID(device.VpcID).String()))
+	}
+}
 
-	for _, cidr := range advertiseCidrs {
-		if err := api.ipam.ReleaseCIDR(c.Request.Context(), ipamNamespace, cidr); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release cidr: %w", err))
-			return
+// moveDeviceWatches updates the records following a device moved from one VPC to another: tombstones
+// send its deletion to the watchers of the old VPC and of the VPCs peered with it, and its metadata and
+// proxy rules are sent again to the watchers of the new VPC.
+func moveDeviceWatches(tx *gorm.DB, device *models.Device, fromVpcId uuid.UUID, toVpcId uuid.UUID) error {
+	if res := tx.Save(&models.DeviceTombstone{DeviceID: device.ID, VpcID: fromVpcId}); res.Error != nil {
+		return res.Error
+	}
+	peerings, err := activeVpcPeerings(tx, fromVpcId)
+	if err != nil {
+		return err
+	}
+	for _, peering := range peerings {
+		if res := tx.Save(&models.DeviceTombstone{DeviceID: device.ID, VpcID: peering.PeerVpcID(fromVpcId)}); res.Error != nil {
+			return res.Error
 		}
 	}
+	if res := tx.Where("device_id = ? AND vpc_id = ?", device.ID, toVpcId).
+		Delete(&models.DeviceTombstone{}); res.Error != nil {
+		return res.Error
+	}
+	if res := tx.Model(&models.DeviceMetadata{}).
+		Where("device_id = ?", device.ID).
+		Update("device_id", gorm.Expr("device_id")); res.Error != nil {
+		return res.Error
+	}
+	var vpc models.VPC
+	if res := tx.Select("organization_id").First(&vpc, "id = ?", toVpcId); res.Error != nil {
+		return res.Error
+	}
+	if res := tx.Model(&models.ProxyRule{}).
+		Where("device_id = ?", device.ID).
+		Updates(map[string]any{"vpc_id": toVpcId, "organization_id": vpc.OrganizationID}); res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
 
-	ipamAddressV6 := device.IPv6TunnelIPs[0].Address
-	orgPrefixV6 := device.IPv6TunnelIPs[0].CIDR
+// deviceTombstones returns the devices moved out of a VPC after the revision, as deleted devices.
+func deviceTombstones(db *gorm.DB, vpcId uuid.UUID, gtRevision uint64) (deviceList, error) {
+	var tombstones []models.DeviceTombstone
+	db = db.Limit(100).Order("revision").Where("vpc_id = ?", vpcId)
+	if gtRevision != 0 {
+		db = db.Where("revision > ?", gtRevision)
+	}
+	if res := db.Find(&tombstones); res.Error != nil {
+		return nil, res.Error
+	}
+	items := make(deviceList, 0, len(tombstones))
+	for _, t := range tombstones {
+		d := &models.Device{VpcID: t.VpcID, Revision: t.Revision}
+		d.ID = t.DeviceID
+		d.DeletedAt = gorm.DeletedAt{Time: t.CreatedAt, Valid: true}
+		items = append(items, d)
+	}
+	return items, nil
+}
 
-	if ipamAddressV6 != "" && orgPrefixV6 != "" {
-		if err := api.ipam.ReleaseToPool(c.Request.Context(), ipamNamespace, ipamAddressV6, orgPrefixV6); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release the v6 address to pool: %w", err))
-			return
-		}
+// mergeDeviceTombstones merges the tombstones of a VPC into the devices fetched from it, keeping the
+// first limit items in revision order.
+func mergeDeviceTombstones(items deviceList, tombstones deviceList, limit int) deviceList {
+	if len(tombstones) == 0 {
+		return items
+	}
+	items = append(items, tombstones...)
+	slices.SortFunc(items, func(a, b *models.Device) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+	if len(items) > limit {
+		items = items[:limit]
 	}
+	return items
+}
 
-	c.JSON(http.StatusOK, device)
+// cidrAdvertisedByOtherDevices returns true if a device in the VPC other than deviceId advertises the cidr.
+func cidrAdvertisedByOtherDevices(tx *gorm.DB, vpcId uuid.UUID, deviceId uuid.UUID, cidr string) (bool, error) {
+	var devices []models.Device
+	if res := tx.Select("id", "advertise_cidrs").
+		Where("vpc_id = ? AND id <> ?", vpcId, deviceId).
+		Find(&devices); res.Error != nil {
+		return false, res.Error
+	}
+	for _, d := range devices {
+		if slices.Contains(d.AdvertiseCidrs, cidr) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func advertiseCidrEquals(existingPrefix, newPrefix []string) bool {
@@ -796,10 +1123,24 @@ func (api *API) ListDevicesInVPC(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
 		return
 	}
+	tokenClaims, err2 := NxodusClaims(c, api.db.WithContext(ctx))
+	if err2 != nil {
+		c.JSON(err2.Status, err2.Body)
+		return
+	}
+
 	var vpc models.VPC
 	db := api.db.WithContext(ctx)
 	result := api.VPCIsReadableByCurrentUser(c, db).
 		First(&vpc, "id = ?", vpcId.String())
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		// devices shared into the VPC list it with their device token
+		if shared, err := vpcIsSharedWithTokenDevice(db, tokenClaims, vpcId); err != nil {
+			result.Error = err
+		} else if shared {
+			result = db.First(&vpc, "id = ?", vpcId.String())
+		}
+	}
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -816,14 +1157,8 @@ func (api *API) ListDevicesInVPC(c *gin.Context) {
 		return
 	}
 
-	tokenClaims, err2 := NxodusClaims(c, api.db.WithContext(ctx))
-	if err2 != nil {
-		c.JSON(err2.Status, err2.Body)
-		return
-	}
-
 	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
-		db = db.Where("vpc_id = ?", vpcId.String())
+		db = devicesOfVpc(db, vpcId)
 		db = FilterAndPaginateWithQuery(db, &models.Device{}, c, query, "hostname")
 
 		var items deviceList
@@ -831,6 +1166,24 @@ func (api *API) ListDevicesInVPC(c *gin.Context) {
 		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, result.Error
 		}
+		sharedDeviceViews(items, vpcId)
+		// the devices of the peered VPCs are only listed with the complete list, not with its pages
+		if _, _, err := query.GetRange(); err != nil {
+			peered, err := peeredDevices(db.Session(&gorm.Session{NewDB: true}), vpcId)
+			if err != nil {
+				return nil, err
+			}
+			for _, device := range peered {
+				if !device.DeletedAt.Valid {
+					items = append(items, device)
+				}
+			}
+		}
+
+		items, err := filterDevicesByTopology(db, vpcId, topologyDeviceId(tokenClaims), items)
+		if err != nil {
+			return nil, err
+		}
 
 		currentUserID := api.GetCurrentUserID(c)
 		for i := range items {
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeviceRoutesAreApprovableByCurrentUser limits devices to the ones whose advertised CIDRs the current user may approve or reject.
+func (api *API) DeviceRoutesAreApprovableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles)
+}
+
+// ApproveDeviceRoutes approves advertised CIDRs of a device
+// @Summary      Approve Device Routes
+// @Description  Approves advertised CIDRs of a device so that peers route them to the device
+// @Id  		 ApproveDeviceRoutes
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id      path   string               true  "Device ID"
+// @Param        routes  body   models.DeviceRoutes  true  "Device Routes"
+// @Success      200  {object}  models.Device
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/routes/approve [post]
+func (api *API) ApproveDeviceRoutes(c *gin.Context) {
+	api.updateDeviceRoutes(c, "ApproveDeviceRoutes", true)
+}
+
+// RejectDeviceRoutes rejects advertised CIDRs of a device
+// @Summary      Reject Device Routes
+// @Description  Rejects advertised CIDRs of a device so that peers do not route them to the device
+// @Id  		 RejectDeviceRoutes
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id      path   string               true  "Device ID"
+// @Param        routes  body   models.DeviceRoutes  true  "Device Routes"
+// @Success      200  {object}  models.Device
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/routes/reject [post]
+func (api *API) RejectDeviceRoutes(c *gin.Context) {
+	api.updateDeviceRoutes(c, "RejectDeviceRoutes", false)
+}
+
+func (api *API) updateDeviceRoutes(c *gin.Context, operation string, approve bool) {
+	ctx, span := tracer.Start(c.Request.Context(), operation, trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.DeviceRoutes
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if len(request.Cidrs) == 0 {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("cidrs"))
+		return
+	}
+
+	var device models.Device
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// routes can only be approved by organization owners, not with reg or device tokens.
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("device routes can only be approved by organization owners")))
+		}
+
+		if res := api.DeviceRoutesAreApprovableByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+			}
+			return res.Error
+		}
+
+		for _, cidr := range request.Cidrs {
+			if !slices.Contains(device.AdvertiseCidrs, cidr) {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidrs", fmt.Sprintf("%s is not advertised by the device", cidr)))
+			}
+			if approve {
+				device.RejectedCidrs = removeCidr(device.RejectedCidrs, cidr)
+				if !slices.Contains(device.ApprovedCidrs, cidr) {
+					device.ApprovedCidrs = append(device.ApprovedCidrs, cidr)
+				}
+			} else {
+				device.ApprovedCidrs = removeCidr(device.ApprovedCidrs, cidr)
+				if !slices.Contains(device.RejectedCidrs, cidr) {
+					device.RejectedCidrs = append(device.RejectedCidrs, cidr)
+				}
+			}
+		}
+
+		if res := tx.Model(&device).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Select("approved_cidrs", "rejected_cidrs").
+			Updates(&device); res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.notifyDeviceWatchers(ctx, &device)
+	hideDeviceBearerToken(&device, nil, api.GetCurrentUserID(c))
+	c.JSON(http.StatusOK, device)
+}
+
+// autoApprovedCidrs returns the cidrs that are approved without an organization owner having to approve them.
+// Organization owners approve the routes of the devices they register with their own credentials, while
+// devices registered with a reg key are limited to the auto approve policy of the reg key.
+func (api *API) autoApprovedCidrs(c *gin.Context, tx *gorm.DB, device *models.Device, tokenClaims *models.NexodusClaims, cidrs []string) ([]string, error) {
+	if len(cidrs) == 0 {
+		return nil, nil
+	}
+	if !isTokenClaims(tokenClaims) {
+		var org models.Organization
+		res := api.OrganizationIsOwnedByCurrentUser(c, tx).
+			First(&org, "id = ?", device.OrganizationID)
+		if res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, res.Error
+		}
+		return cidrs, nil
+	}
+	if device.RegKeyID == uuid.Nil {
+		return nil, nil
+	}
+	var regKey models.RegKey
+	res := tx.Unscoped().First(&regKey, "id = ?", device.RegKeyID)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, res.Error
+	}
+	return cidrsWithinPrefixes(cidrs, regKey.AutoApproveCidrs), nil
+}
+
+// reconcileDeviceRouteApprovals drops the approvals and rejections of CIDRs the device no longer
+// advertises, and approves the auto approved CIDRs that have not been rejected.
+func reconcileDeviceRouteApprovals(device *models.Device, autoApproved []string) {
+	var approved, rejected []string
+	for _, cidr := range device.AdvertiseCidrs {
+		switch {
+		case slices.Contains(device.RejectedCidrs, cidr):
+			rejected = append(rejected, cidr)
+		case slices.Contains(device.ApprovedCidrs, cidr), slices.Contains(autoApproved, cidr):
+			approved = append(approved, cidr)
+		}
+	}
+	device.ApprovedCidrs = approved
+	device.RejectedCidrs = rejected
+}
+
+// cidrsWithinPrefixes returns the cidrs that fall within one of the prefixes.
+func cidrsWithinPrefixes(cidrs []string, prefixes []string) []string {
+	var result []string
+	for _, cidr := range cidrs {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		for _, prefix := range prefixes {
+			allowed, err := netip.ParsePrefix(prefix)
+			if err != nil {
+				continue
+			}
+			if allowed.Bits() <= p.Bits() && allowed.Contains(p.Masked().Addr()) {
+				result = append(result, cidr)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// validatePrefixes returns the first entry of cidrs that is not a valid prefix.
+func validatePrefixes(cidrs []string) (string, bool) {
+	for _, cidr := range cidrs {
+		if !util.IsValidPrefix(cidr) {
+			return cidr, false
+		}
+	}
+	return "", true
+}
+
+func removeCidr(cidrs []string, cidr string) []string {
+	return slices.DeleteFunc(slices.Clone(cidrs), func(s string) bool {
+		return s == cidr
+	})
+}
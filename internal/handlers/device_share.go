@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type deviceShareList []*models.DeviceShare
+
+func (d deviceShareList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.VpcID.String(), 0, gorm.DeletedAt{}
+}
+
+func (d deviceShareList) Len() int {
+	return len(d)
+}
+
+// ListDeviceShares lists the VPCs a device is shared into
+// @Summary      List Device Shares
+// @Id  		 ListDeviceShares
+// @Tags         Devices
+// @Description  Lists the VPCs of other organizations a device is shared into
+// @Param        id   path   string  true  "Device ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.DeviceShare
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/shares [get]
+func (api *API) ListDeviceShares(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListDeviceShares", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var device models.Device
+	db := api.db.WithContext(ctx)
+	result := api.DeviceIsOwnedByCurrentUser(c, db).
+		First(&device, "id = ?", deviceId)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+			return
+		}
+		api.SendInternalServerError(c, fmt.Errorf("error fetching device shares: %w", result.Error))
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		var items deviceShareList
+		result := db.Where("device_id = ?", deviceId).Order("created_at").Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// CreateDeviceShare shares a device into a VPC
+// @Summary      Share Device
+// @Id  		 CreateDeviceShare
+// @Tags         Devices
+// @Description  Shares a device into a VPC of another organization, where it is served read-only with its existing tunnel IPs
+// @Param        id     path   string                 true  "Device ID"
+// @Param        share  body   models.AddDeviceShare  true  "Add Device Share"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.DeviceShare
+// @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/shares [post]
+func (api *API) CreateDeviceShare(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateDeviceShare", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddDeviceShare
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	var share models.DeviceShare
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// sharing a device lets it reach the devices of another organization, so it can not be done with reg or device tokens.
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("devices can only be shared by their owner")))
+		}
+
+		var device models.Device
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		}
+		var vpc models.VPC
+		if res := api.VPCIsReadableByCurrentUser(c, tx).
+			First(&vpc, "id = ?", request.VpcID); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_id"))
+		}
+		if vpc.ID == device.VpcID {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("vpc_id", "the device is in the vpc"))
+		}
+		if err := checkDeviceShareable(tx, device, vpc); err != nil {
+			return err
+		}
+
+		var existing int64
+		if res := tx.Model(&models.DeviceShare{}).
+			Where("device_id = ? AND vpc_id = ?", device.ID, vpc.ID).
+			Count(&existing); res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(vpc.ID.String()))
+		}
+
+		share = models.DeviceShare{
+			DeviceID:       device.ID,
+			VpcID:          vpc.ID,
+			OrganizationID: vpc.OrganizationID,
+		}
+		if res := tx.Create(&share); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Where("device_id = ? AND vpc_id = ?", device.ID, vpc.ID).
+			Delete(&models.DeviceTombstone{}); res.Error != nil {
+			return res.Error
+		}
+		// the devices of the VPC are sent the device, and the device learns that it was shared
+		return touchDevice(tx, device.ID)
+	})
+	if err != nil {
+		api.sendDeviceShareError(c, err)
+		return
+	}
+
+	api.notifyDeviceShare(share)
+	c.JSON(http.StatusCreated, share)
+}
+
+// DeleteDeviceShare revokes the sharing of a device into a VPC
+// @Summary      Revoke Device Share
+// @Id  		 DeleteDeviceShare
+// @Tags         Devices
+// @Description  Revokes the sharing of a device into a VPC, by the owner of the device or an owner of the organization of the VPC
+// @Param        id      path   string  true  "Device ID"
+// @Param        vpc_id  path   string  true  "VPC ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.DeviceShare
+// @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/shares/{vpc_id} [delete]
+func (api *API) DeleteDeviceShare(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteDeviceShare", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("vpc_id", c.Param("vpc_id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	vpcId, err := uuid.Parse(c.Param("vpc_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("vpc_id"))
+		return
+	}
+
+	var share models.DeviceShare
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("device shares can only be revoked by users")))
+		}
+
+		if res := tx.First(&share, "device_id = ? AND vpc_id = ?", deviceId, vpcId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device_share"))
+			}
+			return res.Error
+		}
+
+		// the owner of the device and the owners of the organization of the VPC can revoke the share
+		var allowed int64
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx.Model(&models.Device{})).
+			Where("id = ?", deviceId).Count(&allowed); res.Error != nil {
+			return res.Error
+		}
+		if allowed == 0 {
+			if res := api.VPCIsOwnedByCurrentUser(c, tx.Model(&models.VPC{})).
+				Where("id = ?", vpcId).Count(&allowed); res.Error != nil {
+				return res.Error
+			}
+		}
+		if allowed == 0 {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device_share"))
+		}
+
+		_, err := revokeDeviceShares(tx, deviceId, &vpcId)
+		return err
+	})
+	if err != nil {
+		api.sendDeviceShareError(c, err)
+		return
+	}
+
+	api.notifyDeviceShare(share)
+	c.JSON(http.StatusOK, share)
+}
+
+// checkDeviceShareable checks that a device keeps its tunnel IPs when it is served to a VPC, which is only
+// the case when both its VPC and the VPC use the shared CG-NAT address space.
+func checkDeviceShareable(tx *gorm.DB, device models.Device, vpc models.VPC) error {
+	var deviceVpc models.VPC
+	if res := tx.First(&deviceVpc, "id = ?", device.VpcID); res.Error != nil {
+		return res.Error
+	}
+	if deviceVpc.PrivateCidr || vpc.PrivateCidr {
+		return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("vpc_id", "devices can only be shared between vpcs using the shared CG-NAT address space"))
+	}
+	return nil
+}
+
+// revokeDeviceShares revokes the sharing of a device into a VPC, or into all VPCs when vpcId is nil, and returns
+// the revoked shares. A tombstone sends the deletion of the device to the watchers of the VPCs.
+func revokeDeviceShares(tx *gorm.DB, deviceId uuid.UUID, vpcId *uuid.UUID) ([]models.DeviceShare, error) {
+	var shares []models.DeviceShare
+	db := tx.Where("device_id = ?", deviceId)
+	if vpcId != nil {
+		db = db.Where("vpc_id = ?", *vpcId)
+	}
+	if res := db.Find(&shares); res.Error != nil {
+		return nil, res.Error
+	}
+	for _, share := range shares {
+		if res := tx.Delete(&share); res.Error != nil {
+			return nil, res.Error
+		}
+		if res := tx.Save(&models.DeviceTombstone{DeviceID: share.DeviceID, VpcID: share.VpcID}); res.Error != nil {
+			return nil, res.Error
+		}
+	}
+	if len(shares) == 0 {
+		return nil, nil
+	}
+	// the device learns that its shares changed
+	return shares, touchDevice(tx, deviceId)
+}
+
+// touchDevice bumps the revision of a device so that it is sent again to the devices watching it.
+func touchDevice(tx *gorm.DB, deviceId uuid.UUID) error {
+	return tx.Model(&models.Device{}).
+		Where("id = ?", deviceId).
+		Update("vpc_id", gorm.Expr("vpc_id")).Error
+}
+
+// devicesOfVpc limits devices to the ones of a VPC and the ones shared into it.
+func devicesOfVpc(db *gorm.DB, vpcId uuid.UUID) *gorm.DB {
+	return db.Where("(vpc_id = ? OR id IN (SELECT device_id FROM device_shares WHERE vpc_id = ?))", vpcId, vpcId)
+}
+
+// sharedDeviceViews applies sharedDeviceView to the devices shared into a VPC.
+func sharedDeviceViews(items deviceList, vpcId uuid.UUID) {
+	for _, d := range items {
+		if d.VpcID != vpcId {
+			sharedDeviceView(d)
+		}
+	}
+}
+
+// sharedDeviceView limits a device shared into a VPC to its tunnel IPs: its routes, relaying and peering
+// settings only apply to its own VPC. The device keeps the id of its own VPC, which tells it apart from
+// the devices of the VPC it is shared into.
+func sharedDeviceView(device *models.Device) {
+	device.AdvertiseCidrs = nil
+	device.ApprovedCidrs = nil
+	device.RejectedCidrs = nil
+	device.Relay = false
+	device.Hub = false
+	device.PeeringGroups = nil
+	var ipv4, ipv6 string
+	if len(device.IPv4TunnelIPs) > 0 {
+		ipv4 = device.IPv4TunnelIPs[0].Address
+	}
+	if len(device.IPv6TunnelIPs) > 0 {
+		ipv6 = device.IPv6TunnelIPs[0].Address
+	}
+	if allowedIPs, err := getAllowedIPs(ipv4, ipv6, false); err == nil {
+		device.AllowedIPs = allowedIPs
+	}
+}
+
+func (api *API) notifyDeviceShare(share models.DeviceShare) {
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", share.VpcID.String()))
+	var device models.Device
+	if res := api.db.Select("vpc_id").First(&device, "id = ?", share.DeviceID); res.Error == nil {
+		api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	}
+}
+
+func (api *API) sendDeviceShareError(c *gin.Context, err error) {
+	var apiResponseError *ApiResponseError
+	if errors.As(err, &apiResponseError) {
+		c.JSON(apiResponseError.Status, apiResponseError.Body)
+	} else {
+		api.SendInternalServerError(c, err)
+	}
+}
+
+// vpcIsSharedWithTokenDevice returns whether the device of a device token is shared into a VPC, which
+// lets the device watch the devices of the VPC.
+func vpcIsSharedWithTokenDevice(db *gorm.DB, claims *models.NexodusClaims, vpcId uuid.UUID) (bool, error) {
+	deviceId := topologyDeviceId(claims)
+	if deviceId == nil {
+		return false, nil
+	}
+	var count int64
+	if res := db.Model(&models.DeviceShare{}).
+		Where("device_id = ? AND vpc_id = ?", *deviceId, vpcId).
+		Count(&count); res.Error != nil {
+		return false, res.Error
+	}
+	return count > 0, nil
+}
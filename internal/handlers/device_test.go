@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/netip"
 	"testing"
 
 	"github.com/nexodus-io/nexodus/internal/models"
@@ -100,3 +101,237 @@ func TestAdvertiseCidrEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestCidrsWithinPrefixes(t *testing.T) {
+	cidrs := []string{"10.1.0.0/24", "10.2.0.0/16", "192.168.1.0/24", "0.0.0.0/0", "2001:db8:1::/48"}
+	assert.Equal(t, []string{"10.1.0.0/24", "10.2.0.0/16"}, cidrsWithinPrefixes(cidrs, []string{"10.0.0.0/8"}))
+	assert.Equal(t, []string{"2001:db8:1::/48"}, cidrsWithinPrefixes(cidrs, []string{"2001:db8::/32"}))
+	assert.Equal(t, []string{"10.1.0.0/24", "10.2.0.0/16", "192.168.1.0/24", "0.0.0.0/0"}, cidrsWithinPrefixes(cidrs, []string{"0.0.0.0/0"}))
+	assert.Empty(t, cidrsWithinPrefixes(cidrs, []string{"10.1.0.0/25"}))
+	assert.Empty(t, cidrsWithinPrefixes(cidrs, nil))
+}
+
+func TestReconcileDeviceRouteApprovals(t *testing.T) {
+	device := models.Device{
+		AdvertiseCidrs: []string{"10.1.0.0/24", "10.2.0.0/24", "10.3.0.0/24"},
+		ApprovedCidrs:  []string{"10.1.0.0/24", "10.9.0.0/24"},
+		RejectedCidrs:  []string{"10.2.0.0/24"},
+	}
+	reconcileDeviceRouteApprovals(&device, []string{"10.2.0.0/24", "10.3.0.0/24"})
+
+	// approvals of CIDRs that are no longer advertised are dropped, and an auto approval does not override a rejection.
+	assert.Equal(t, []string{"10.1.0.0/24", "10.3.0.0/24"}, []string(device.ApprovedCidrs))
+	assert.Equal(t, []string{"10.2.0.0/24"}, []string(device.RejectedCidrs))
+}
+
+func (suite *HandlerTestSuite) TestOwnerDeviceRoutesAutoApproved() {
+	require := suite.Require()
+
+	// the routes of the devices an organization owner registers are approved by the owner
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:          suite.testUserID,
+		PublicKey:      "anownerroutespubkey",
+		AdvertiseCidrs: []string{"172.16.20.0/24"},
+	}, http.StatusCreated, &device)
+	require.Equal([]string{"172.16.20.0/24"}, []string(device.ApprovedCidrs))
+
+	advertiseCidrs := []string{"172.16.20.0/24", "172.16.21.0/24"}
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		AdvertiseCidrs: advertiseCidrs,
+	}, http.StatusOK, &device)
+	require.Equal(advertiseCidrs, []string(device.ApprovedCidrs))
+}
+
+func (suite *HandlerTestSuite) TestMoveDevice() {
+	require := suite.Require()
+
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:          suite.testUserID,
+		PublicKey:      "amovedpubkey",
+		AdvertiseCidrs: []string{"172.16.10.0/24"},
+	}, http.StatusCreated, &device)
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "move-target",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.5.0/24",
+		Ipv6Cidr:       "fc00:5000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+
+	// the security group must belong to the VPC of the device
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		VpcID:           &vpc.ID,
+		SecurityGroupId: &suite.testUserID,
+	}, http.StatusBadRequest, nil)
+
+	var moved models.Device
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		VpcID: &vpc.ID,
+	}, http.StatusOK, &moved)
+
+	require.Equal(vpc.ID, moved.VpcID)
+	require.Equal(vpc.ID, moved.SecurityGroupId)
+	require.True(netip.MustParsePrefix(vpc.Ipv4Cidr).Contains(netip.MustParseAddr(moved.IPv4TunnelIPs[0].Address)))
+	require.Equal([]string{moved.IPv4TunnelIPs[0].Address + "/32", moved.IPv6TunnelIPs[0].Address + "/128"}, []string(moved.AllowedIPs))
+	require.Equal([]string{"172.16.10.0/24"}, []string(moved.AdvertiseCidrs))
+
+	// the watchers of the old VPC are sent the deletion of the device
+	tombstones, err := deviceTombstones(suite.api.db, suite.testUserID, 0)
+	require.NoError(err)
+	require.Len(tombstones, 1)
+	require.Equal(device.ID, tombstones[0].ID)
+	require.True(tombstones[0].DeletedAt.Valid)
+}
+
+func (suite *HandlerTestSuite) TestMoveDeviceToAnotherOrganization() {
+	require := suite.Require()
+
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:          suite.testUserID,
+		PublicKey:      "anorgmovedpubkey",
+		AdvertiseCidrs: []string{"172.16.30.0/24"},
+	}, http.StatusCreated, &device)
+	hub := true
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		Hub:           &hub,
+		PeeringGroups: []string{"branch-east"},
+	}, http.StatusOK, &device)
+	require.Equal([]string{"172.16.30.0/24"}, []string(device.ApprovedCidrs))
+
+	// the vpc of the other organization must be readable by the user
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		VpcID: &suite.testUser2ID,
+	}, http.StatusNotFound, nil)
+
+	require.NoError(suite.api.db.Create(&models.UserOrganization{
+		UserID:         suite.testUserID,
+		OrganizationID: suite.testUser2ID,
+		Roles:          []string{"member"},
+	}).Error)
+
+	// the device counts against the quota of the other organization
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:     suite.testUser2ID,
+		PublicKey: "anorgquotapubkey",
+	}, http.StatusCreated, nil)
+	var usage models.OrganizationUsage
+	suite.serve(http.MethodGet, "/:id/usage", fmt.Sprintf("/%s/usage", suite.testUser2ID), suite.api.GetOrganizationUsage, nil, http.StatusOK, &usage)
+	suite.api.DefaultQuotas = models.Quotas{Devices: usage.Devices.Used}
+	var quotaErr models.QuotaExceededError
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		VpcID: &suite.testUser2ID,
+	}, http.StatusForbidden, &quotaErr)
+	require.Equal(models.QuotaDevices, quotaErr.Resource)
+	suite.api.DefaultQuotas = models.Quotas{Devices: usage.Devices.Used + 1}
+	defer func() {
+		suite.api.DefaultQuotas = models.Quotas{}
+	}()
+
+	// the user is not an owner of the other organization, so the routes and the peers of the device
+	// are no longer approved there
+	var moved models.Device
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		VpcID: &suite.testUser2ID,
+	}, http.StatusOK, &moved)
+	require.Equal(suite.testUser2ID, moved.VpcID)
+	var stored models.Device
+	require.NoError(suite.api.db.First(&stored, "id = ?", device.ID).Error)
+	require.Equal(suite.testUser2ID, stored.OrganizationID)
+	require.Equal([]string{"172.16.30.0/24"}, []string(moved.AdvertiseCidrs))
+	require.Empty(moved.ApprovedCidrs)
+	require.Empty(moved.RejectedCidrs)
+	require.False(moved.Hub)
+	require.Empty(moved.PeeringGroups)
+}
+
+func (suite *HandlerTestSuite) TestShareDevice() {
+	require := suite.Require()
+
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:          suite.testUserID,
+		PublicKey:      "asharedpubkey",
+		AdvertiseCidrs: []string{"172.16.20.0/24"},
+	}, http.StatusCreated, &device)
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "share-target",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+
+	sharesPath := fmt.Sprintf("/%s/shares", device.ID)
+	suite.serve(http.MethodPost, "/:id/shares", sharesPath, suite.api.CreateDeviceShare, models.AddDeviceShare{
+		VpcID: suite.testUserID,
+	}, http.StatusUnprocessableEntity, nil)
+
+	var share models.DeviceShare
+	suite.serve(http.MethodPost, "/:id/shares", sharesPath, suite.api.CreateDeviceShare, models.AddDeviceShare{
+		VpcID: vpc.ID,
+	}, http.StatusCreated, &share)
+	require.Equal(device.ID, share.DeviceID)
+	require.Equal(vpc.ID, share.VpcID)
+
+	suite.serve(http.MethodPost, "/:id/shares", sharesPath, suite.api.CreateDeviceShare, models.AddDeviceShare{
+		VpcID: vpc.ID,
+	}, http.StatusConflict, nil)
+
+	var shares []models.DeviceShare
+	suite.serve(http.MethodGet, "/:id/shares", sharesPath, suite.api.ListDeviceShares, nil, http.StatusOK, &shares)
+	require.Len(shares, 1)
+
+	// the device is listed in the VPC with its own VPC id and without its routes
+	var devices []models.Device
+	suite.serve(http.MethodGet, "/:id/devices", fmt.Sprintf("/%s/devices", vpc.ID), suite.api.ListDevicesInVPC, nil, http.StatusOK, &devices)
+	require.Len(devices, 1)
+	require.Equal(device.ID, devices[0].ID)
+	require.Equal(suite.testUserID, devices[0].VpcID)
+	require.Equal(device.IPv4TunnelIPs, devices[0].IPv4TunnelIPs)
+	require.Empty(devices[0].AdvertiseCidrs)
+	require.Equal([]string{device.IPv4TunnelIPs[0].Address + "/32", device.IPv6TunnelIPs[0].Address + "/128"}, []string(devices[0].AllowedIPs))
+
+	suite.serve(http.MethodDelete, "/:id/shares/:vpc_id", fmt.Sprintf("/%s/shares/%s", device.ID, vpc.ID), suite.api.DeleteDeviceShare, nil, http.StatusOK, nil)
+
+	// the watchers of the VPC are sent the deletion of the device
+	suite.serve(http.MethodGet, "/:id/devices", fmt.Sprintf("/%s/devices", vpc.ID), suite.api.ListDevicesInVPC, nil, http.StatusOK, &devices)
+	require.Empty(devices)
+	tombstones, err := deviceTombstones(suite.api.db, vpc.ID, 0)
+	require.NoError(err)
+	require.Len(tombstones, 1)
+	require.Equal(device.ID, tombstones[0].ID)
+
+	// deleting the VPC revokes the share and notifies the watchers of the home VPC of the device
+	suite.serve(http.MethodPost, "/:id/shares", sharesPath, suite.api.CreateDeviceShare, models.AddDeviceShare{
+		VpcID: vpc.ID,
+	}, http.StatusCreated, nil)
+	sub := suite.api.signalBus.Subscribe(fmt.Sprintf("/devices/vpc=%s", device.VpcID))
+	defer sub.Close()
+	suite.serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", vpc.ID), suite.api.DeleteVPC, nil, http.StatusOK, nil)
+	require.True(sub.IsSignaled())
+	suite.serve(http.MethodGet, "/:id/shares", sharesPath, suite.api.ListDeviceShares, nil, http.StatusOK, &shares)
+	require.Empty(shares)
+}
+
+func TestMergeDeviceTombstones(t *testing.T) {
+	device := func(revision uint64) *models.Device {
+		return &models.Device{Revision: revision}
+	}
+	revisions := func(items deviceList) []uint64 {
+		var r []uint64
+		for _, d := range items {
+			r = append(r, d.Revision)
+		}
+		return r
+	}
+
+	items := mergeDeviceTombstones(deviceList{device(1), device(4)}, nil, 3)
+	assert.Equal(t, []uint64{1, 4}, revisions(items))
+
+	items = mergeDeviceTombstones(deviceList{device(1), device(4), device(6)}, deviceList{device(2), device(5)}, 3)
+	assert.Equal(t, []uint64{1, 2, 4}, revisions(items))
+}
@@ -111,8 +111,14 @@ func (api *API) WatchEvents(c *gin.Context) {
 
 		var vpc models.VPC
 		db := api.db.WithContext(ctx)
-		db = api.VPCIsReadableByCurrentUser(c, db)
-		result := db.First(&vpc, "id = ?", vpcId.String())
+		result := api.VPCIsReadableByCurrentUser(c, db).First(&vpc, "id = ?", vpcId.String())
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			// devices shared into the VPC watch it with their device token
+			claims, _ := NxodusClaims(c, db)
+			if shared, err := vpcIsSharedWithTokenDevice(db, claims, vpcId); err == nil && shared {
+				result = db.First(&vpc, "id = ?", vpcId.String())
+			}
+		}
 
 		if result.Error != nil {
 			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -157,15 +163,27 @@ func (api *API) WatchEvents(c *gin.Context) {
 
 			fetcher := api.fetchManager.Open("org-devices:"+vpcId.String(), deviceCacheSize, func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
 				var items deviceList
+				tombstones, err := deviceTombstones(db, vpcId, gtRevision)
+				if err != nil {
+					return nil, err
+				}
 				db = db.Unscoped().Limit(100).Order("revision")
 				if gtRevision != 0 {
 					db = db.Where("revision > ?", gtRevision)
 				}
-				db = db.Where("vpc_id = ?", vpcId.String())
+				peeredDb := db.Session(&gorm.Session{})
+				db = devicesOfVpc(peeredDb, vpcId)
 				result := db.Find(&items)
 				if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 					return nil, result.Error
 				}
+				sharedDeviceViews(items, vpcId)
+				items = mergeDeviceTombstones(items, tombstones, 100)
+				peered, err := peeredDevices(peeredDb, vpcId)
+				if err != nil {
+					return nil, err
+				}
+				items = mergeDeviceTombstones(items, peered, 100)
 
 				for i := range items {
 					hideDeviceBearerToken(items[i], tokenClaims, currentUserID)
@@ -180,7 +198,7 @@ func (api *API) WatchEvents(c *gin.Context) {
 				gtRevision: r.GtRevision,
 				atTail:     r.AtTail,
 				signal:     fmt.Sprintf("/devices/vpc=%s", vpcId.String()),
-				fetch:      fetcher.Fetch,
+				fetch:      watchDevicesByTopology(fetcher.Fetch, vpcId, topologyDeviceId(tokenClaims)),
 			})
 
 		case "security-group":
@@ -319,6 +337,55 @@ func (api *API) WatchEvents(c *gin.Context) {
 				},
 			})
 
+		case "proxy-rule":
+
+			if !api.FlagCheck(c, "devices") {
+				return
+			}
+
+			if r.Options == nil || r.Options["device-id"] == nil {
+				c.JSON(http.StatusBadRequest, models.NewFieldValidationError(fmt.Sprintf("request[%d].options.device-id", i), "required"))
+				return
+			}
+			deviceId, err := uuid.Parse(fmt.Sprint(r.Options["device-id"]))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.NewFieldValidationError(fmt.Sprintf("request[%d].options.device-id", i), err.Error()))
+				return
+			}
+
+			// verify we can read the device...
+			var device models.Device
+			result := api.DeviceIsOwnedByCurrentUser(c, api.db.WithContext(ctx)).
+				First(&device, "id = ?", deviceId)
+			if result.Error != nil {
+				if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+					c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+				} else {
+					api.SendInternalServerError(c, result.Error)
+				}
+				return
+			}
+
+			watches = append(watches, Watch{
+				kind:       r.Kind,
+				gtRevision: r.GtRevision,
+				atTail:     r.AtTail,
+				signal:     proxyRuleSignal(deviceId),
+				fetch: func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
+					var items proxyRuleList
+					db = db.Unscoped().Limit(100).Order("revision")
+					if gtRevision != 0 {
+						db = db.Where("revision > ?", gtRevision)
+					}
+					db = db.Where("device_id = ?", deviceId.String())
+					result := db.Find(&items)
+					if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+						return nil, result.Error
+					}
+					return items, nil
+				},
+			})
+
 		case "vpc":
 			vpcId, organizationID, apiErr := getVpcAndOrg(r, i)
 			if apiErr != nil {
@@ -526,15 +593,27 @@ func (api *API) WatchEventsInVPC(c *gin.Context) {
 
 			fetcher := api.fetchManager.Open("org-devices:"+vpcId.String(), deviceCacheSize, func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
 				var items deviceList
+				tombstones, err := deviceTombstones(db, vpcId, gtRevision)
+				if err != nil {
+					return nil, err
+				}
 				db = db.Unscoped().Limit(100).Order("revision")
 				if gtRevision != 0 {
 					db = db.Where("revision > ?", gtRevision)
 				}
-				db = db.Where("vpc_id = ?", vpcId.String())
+				peeredDb := db.Session(&gorm.Session{})
+				db = devicesOfVpc(peeredDb, vpcId)
 				result := db.Find(&items)
 				if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 					return nil, result.Error
 				}
+				sharedDeviceViews(items, vpcId)
+				items = mergeDeviceTombstones(items, tombstones, 100)
+				peered, err := peeredDevices(peeredDb, vpcId)
+				if err != nil {
+					return nil, err
+				}
+				items = mergeDeviceTombstones(items, peered, 100)
 
 				for i := range items {
 					hideDeviceBearerToken(items[i], tokenClaims, currentUserID)
@@ -544,12 +623,16 @@ func (api *API) WatchEventsInVPC(c *gin.Context) {
 			})
 			defer fetcher.Close()
 
+			peerOf := topologyDeviceId(tokenClaims)
+			if peerOf == nil {
+				peerOf = deviceId
+			}
 			watches = append(watches, Watch{
 				kind:       r.Kind,
 				gtRevision: r.GtRevision,
 				atTail:     r.AtTail,
 				signal:     fmt.Sprintf("/devices/vpc=%s", vpcId.String()),
-				fetch:      fetcher.Fetch,
+				fetch:      watchDevicesByTopology(fetcher.Fetch, vpcId, peerOf),
 			})
 
 		case "security-group":
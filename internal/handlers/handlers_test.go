@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/database"
 	"github.com/nexodus-io/nexodus/internal/fflags"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr/memfm"
 	"github.com/nexodus-io/nexodus/internal/ipam"
 	"github.com/open-policy-agent/opa/storage/inmem"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +43,10 @@ type HandlerTestSuite struct {
 	api         *API
 	testUserID  uuid.UUID
 	testUser2ID uuid.UUID
+	// embeddedIPAM runs the suite with the IPAM embedded in the apiserver database instead of the go-ipam service
+	embeddedIPAM bool
+	// claims are the token claims of the requests, they are made by the test user without a token when nil
+	claims map[string]interface{}
 }
 
 func (suite *HandlerTestSuite) SetupSuite() {
@@ -49,21 +55,28 @@ func (suite *HandlerTestSuite) SetupSuite() {
 		suite.T().Fatal(err)
 	}
 	suite.logger = zaptest.NewLogger(suite.T()).Sugar()
-	suite.ipam = ipam.NewTestIPAMServer()
 	suite.wg = &sync.WaitGroup{}
-	suite.wg.Add(1)
 
-	listener, err := net.Listen("tcp", "[::1]:49090")
-	suite.Require().NoError(err)
-
-	go func() {
-		defer suite.wg.Done()
-		if err := suite.ipam.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
-			suite.T().Logf("unexpected error starting ipam server: %s", err)
-		}
-	}()
-
-	ipamClient := ipam.NewIPAM(suite.logger, ipamClientAddr)
+	var ipamClient ipam.IPAM
+	if suite.embeddedIPAM {
+		ipamClient, err = ipam.NewEmbeddedIPAM(suite.logger, db)
+		suite.Require().NoError(err)
+	} else {
+		suite.ipam = ipam.NewTestIPAMServer()
+		suite.wg.Add(1)
+
+		listener, err := net.Listen("tcp", "[::1]:49090")
+		suite.Require().NoError(err)
+
+		go func() {
+			defer suite.wg.Done()
+			if err := suite.ipam.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				suite.T().Logf("unexpected error starting ipam server: %s", err)
+			}
+		}()
+
+		ipamClient = ipam.NewIPAM(suite.logger, ipamClientAddr)
+	}
 
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:             "localhost:6379",
@@ -78,15 +91,20 @@ func (suite *HandlerTestSuite) SetupSuite() {
 	if err != nil {
 		suite.T().Fatal(err)
 	}
+	suite.api.IPAMRepairGracePeriod = 0
+	// the watches fetch the changes from the database rather than through a cache in redis
+	suite.api.fetchManager = memfm.New()
 }
 
 func (suite *HandlerTestSuite) BeforeTest(_, _ string) {
+	suite.api.db.Exec("DELETE FROM proxy_rules")
 	suite.api.db.Exec("DELETE FROM devices")
 	suite.api.db.Exec("DELETE FROM vpcs")
 	suite.api.db.Exec("DELETE FROM user_organizations")
 	suite.api.db.Exec("DELETE FROM organizations")
 	suite.api.db.Exec("DELETE FROM user_identities")
 	suite.api.db.Exec("DELETE FROM users")
+	suite.claims = nil
 	var err error
 	suite.testUserID, err = suite.api.CreateUserIfNotExists(context.Background(), TestUserIdpID, "testuser", nil)
 	suite.Require().NoError(err)
@@ -95,15 +113,23 @@ func (suite *HandlerTestSuite) BeforeTest(_, _ string) {
 }
 
 func (suite *HandlerTestSuite) ServeRequest(method, path string, uri string, handler func(*gin.Context), body io.Reader) (*http.Request, *httptest.ResponseRecorder, error) {
+	return suite.ServeRequestContext(context.Background(), method, path, uri, handler, body)
+}
+
+// ServeRequestContext serves a request made with ctx, which lets watches be closed by canceling it.
+func (suite *HandlerTestSuite) ServeRequestContext(ctx context.Context, method, path string, uri string, handler func(*gin.Context), body io.Reader) (*http.Request, *httptest.ResponseRecorder, error) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	r.Use(func(c *gin.Context) {
 		c.Set(gin.AuthUserKey, suite.testUserID)
+		if suite.claims != nil {
+			c.Set("_nexodus.Claims", suite.claims)
+		}
 		c.Next()
 	})
 
 	r.Any(path, handler)
-	req, err := http.NewRequest(method, uri, body)
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
 	if err != nil {
 		return req, httptest.NewRecorder(), err
 	}
@@ -117,6 +143,10 @@ func TestHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
 
+func TestHandlerTestSuiteEmbeddedIPAM(t *testing.T) {
+	suite.Run(t, &HandlerTestSuite{embeddedIPAM: true})
+}
+
 func TestQuerySort(t *testing.T) {
 	q := Query{Sort: `["name","DESC"]`}
 	expected := "name DESC"
@@ -143,6 +173,22 @@ func TestQueryFilter(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+// serve serves request as the JSON body of a request to handler, requires the response to have status, and
+// decodes the body of the response into response unless it is nil.
+func (suite *HandlerTestSuite) serve(method, path, uri string, handler func(*gin.Context), request any, status int, response any) {
+	require := suite.Require()
+	reqBody, err := json.Marshal(request)
+	require.NoError(err)
+	_, res, err := suite.ServeRequest(method, path, uri, handler, bytes.NewBuffer(reqBody))
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(status, res.Code, "HTTP error: %s", string(body))
+	if response != nil {
+		require.NoError(json.Unmarshal(body, response))
+	}
+}
+
 func (suite *HandlerTestSuite) jsonMarshal(v any) []byte {
 	bytes, err := json.Marshal(v)
 	suite.Require().NoError(err)
@@ -31,6 +31,7 @@ var allowedRoles = map[string]struct{}{
 // @Param        Invitation  body     models.AddInvitation  true  "Add Invitation"
 // @Success      201  {object}  models.Invitation
 // @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.QuotaExceededError
 // @Failure      404  {object}  models.BaseError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
@@ -155,8 +156,19 @@ func (api *API) CreateInvitation(c *gin.Context) {
 	}
 	invite.FromID = from.ID
 
-	if res := db.Create(&invite); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		if err := api.checkQuota(tx, org.ID, models.QuotaInvitations); err != nil {
+			return err
+		}
+		return tx.Create(&invite).Error
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
 		return
 	}
 
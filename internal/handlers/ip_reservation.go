@@ -0,0 +1,853 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/ipam"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// maxExcludedRangeSize is the number of addresses an excluded range may have, each of them is held in IPAM.
+const maxExcludedRangeSize = 1024
+
+type ipReservationList []*models.IPReservation
+
+func (d ipReservationList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.ID.String(), 0, item.DeletedAt
+}
+
+func (d ipReservationList) Len() int {
+	return len(d)
+}
+
+type excludedRangeList []*models.ExcludedRange
+
+func (d excludedRangeList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.ID.String(), 0, item.DeletedAt
+}
+
+func (d excludedRangeList) Len() int {
+	return len(d)
+}
+
+// ListIPReservations lists the IP reservations of a VPC
+// @Summary      List IP Reservations
+// @Id  		 ListIPReservations
+// @Tags         VPC
+// @Description  Lists the addresses of a VPC reserved for a device, a registration key or a hostname
+// @Param        id   path   string  true  "VPC ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.IPReservation
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/ip-reservations [get]
+func (api *API) ListIPReservations(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListIPReservations", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	if res := api.VPCIsReadableByCurrentUser(c, db).First(&vpc, "id = ?", vpcId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		var items ipReservationList
+		db = db.Where("vpc_id = ?", vpcId)
+		db = FilterAndPaginate(db, &models.IPReservation{}, c, "address")
+		result := db.Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// CreateIPReservation reserves an address of a VPC
+// @Summary      Reserve IP
+// @Id  		 CreateIPReservation
+// @Tags         VPC
+// @Description  Reserves an address of a VPC for a device, a registration key or a hostname. The address is kept out of the pool of the VPC and only a matching device is assigned it.
+// @Param        id           path   string                   true  "VPC ID"
+// @Param        reservation  body   models.AddIPReservation  true  "Add IP Reservation"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.IPReservation
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/ip-reservations [post]
+func (api *API) CreateIPReservation(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateIPReservation", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddIPReservation
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	targets := 0
+	if request.DeviceID != nil {
+		targets++
+	}
+	if request.RegKeyID != nil {
+		targets++
+	}
+	if request.Hostname != "" {
+		targets++
+	}
+	if targets != 1 {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("device_id", "exactly one of device_id, reg_key_id and hostname must be set"))
+		return
+	}
+	address, err := netip.ParseAddr(request.Address)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("address", fmt.Sprintf("%s is not a valid address", request.Address)))
+		return
+	}
+
+	var reservation models.IPReservation
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("ip reservations can only be managed by users")))
+		}
+
+		var vpc models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		cidr, ok := vpcCidrOf(vpc, address)
+		if !ok {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("address", "the address is not in the cidrs of the vpc"))
+		}
+		if excluded, err := excludedRangeOf(tx, vpc.ID, address); err != nil {
+			return err
+		} else if excluded != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("address", fmt.Sprintf("the address is in the excluded range %s", excluded.Cidr)))
+		}
+		if existing, err := ipReservationOf(tx, vpc.ID, address); err != nil {
+			return err
+		} else if existing != nil {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(existing.ID.String()))
+		}
+		if request.DeviceID != nil {
+			var count int64
+			if res := tx.Model(&models.Device{}).Where("id = ? AND vpc_id = ?", *request.DeviceID, vpc.ID).Count(&count); res.Error != nil {
+				return res.Error
+			}
+			if count == 0 {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("device_id", "the device is not in the vpc"))
+			}
+		}
+		if request.RegKeyID != nil {
+			var count int64
+			if res := tx.Model(&models.RegKey{}).Where("id = ? AND vpc_id = ?", *request.RegKeyID, vpc.ID).Count(&count); res.Error != nil {
+				return res.Error
+			}
+			if count == 0 {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("reg_key_id", "the registration key is not for the vpc"))
+			}
+		}
+
+		reservation = models.IPReservation{
+			VpcID:          vpc.ID,
+			OrganizationID: vpc.OrganizationID,
+			Address:        address.String(),
+			DeviceID:       request.DeviceID,
+			RegKeyID:       request.RegKeyID,
+			Hostname:       request.Hostname,
+			Description:    request.Description,
+		}
+		if res := tx.Create(&reservation); res.Error != nil {
+			return res.Error
+		}
+
+		// the address is kept out of the pool, unless it is already assigned to the device it is reserved for
+		holder, err := deviceWithAddress(tx, vpc, address.String())
+		if err != nil {
+			return err
+		}
+		if holder != nil {
+			if request.DeviceID == nil || *request.DeviceID != holder.ID {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(holder.ID.String()))
+			}
+			return nil
+		}
+		if err := api.ipamTx(tx).AcquireIP(ctx, vpcIPAMNamespace(vpc), cidr, address.String()); err != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("address", fmt.Sprintf("the address is not available: %v", err)))
+		}
+		return nil
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// DeleteIPReservation deletes an IP reservation of a VPC
+// @Summary      Delete IP Reservation
+// @Id  		 DeleteIPReservation
+// @Tags         VPC
+// @Description  Deletes an IP reservation of a VPC. A device assigned the address keeps it, otherwise the address returns to the pool of the VPC.
+// @Param        id              path   string  true  "VPC ID"
+// @Param        reservation_id  path   string  true  "IP Reservation ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.IPReservation
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/ip-reservations/{reservation_id} [delete]
+func (api *API) DeleteIPReservation(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteIPReservation", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("reservation_id", c.Param("reservation_id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	reservationId, err := uuid.Parse(c.Param("reservation_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("reservation_id"))
+		return
+	}
+
+	var reservation models.IPReservation
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("ip reservations can only be managed by users")))
+		}
+
+		var vpc models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		if res := tx.First(&reservation, "id = ? AND vpc_id = ?", reservationId, vpc.ID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("ip_reservation"))
+			}
+			return res.Error
+		}
+		return api.deleteIPReservation(ctx, tx, vpc, reservation)
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// deleteIPReservation deletes an IP reservation, its address returns to the pool of the VPC unless a device
+// is assigned it.
+func (api *API) deleteIPReservation(ctx context.Context, tx *gorm.DB, vpc models.VPC, reservation models.IPReservation) error {
+	if res := tx.Delete(&reservation); res.Error != nil {
+		return res.Error
+	}
+	holder, err := deviceWithAddress(tx, vpc, reservation.Address)
+	if err != nil || holder != nil {
+		return err
+	}
+	address, err := netip.ParseAddr(reservation.Address)
+	if err != nil {
+		return err
+	}
+	cidr, ok := vpcCidrOf(vpc, address)
+	if !ok {
+		return nil
+	}
+	return api.ipamTx(tx).ReleaseToPool(ctx, vpcIPAMNamespace(vpc), reservation.Address, cidr)
+}
+
+// ListExcludedRanges lists the excluded ranges of a VPC
+// @Summary      List Excluded Ranges
+// @Id  		 ListExcludedRanges
+// @Tags         VPC
+// @Description  Lists the ranges of addresses of a VPC kept out of the pool the devices of the VPC are assigned addresses from
+// @Param        id   path   string  true  "VPC ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.ExcludedRange
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/excluded-ranges [get]
+func (api *API) ListExcludedRanges(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListExcludedRanges", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	if res := api.VPCIsReadableByCurrentUser(c, db).First(&vpc, "id = ?", vpcId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		var items excludedRangeList
+		db = db.Where("vpc_id = ?", vpcId)
+		db = FilterAndPaginate(db, &models.ExcludedRange{}, c, "cidr")
+		result := db.Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// CreateExcludedRange excludes a range of addresses of a VPC
+// @Summary      Exclude Range
+// @Id  		 CreateExcludedRange
+// @Tags         VPC
+// @Description  Keeps a range of addresses of a VPC with a private CIDR out of the pool the devices of the VPC are assigned addresses from
+// @Param        id     path   string                   true  "VPC ID"
+// @Param        excluded_range  body   models.AddExcludedRange  true  "Add Excluded Range"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.ExcludedRange
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/excluded-ranges [post]
+func (api *API) CreateExcludedRange(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateExcludedRange", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddExcludedRange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	prefix, err := netip.ParsePrefix(request.Cidr)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", fmt.Sprintf("%s is not a valid cidr", request.Cidr)))
+		return
+	}
+	prefix = prefix.Masked()
+	if hostBits := prefix.Addr().BitLen() - prefix.Bits(); hostBits > 10 || 1<<hostBits > maxExcludedRangeSize {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", fmt.Sprintf("an excluded range can have at most %d addresses", maxExcludedRangeSize)))
+		return
+	}
+
+	var excluded models.ExcludedRange
+	var acquired []string
+	var vpc models.VPC
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("excluded ranges can only be managed by users")))
+		}
+
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		// the shared CG-NAT address space is shared by the VPCs, so only VPCs with a private CIDR can exclude addresses from it
+		if !vpc.PrivateCidr {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", "addresses can only be excluded from vpcs with a private cidr"))
+		}
+		vpcCidr, ok := vpcCidrOf(vpc, prefix.Addr())
+		if !ok || !prefixesContain([]netip.Prefix{netip.MustParsePrefix(vpcCidr).Masked()}, prefix) {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", "the range is not in the cidrs of the vpc"))
+		}
+
+		var ranges []models.ExcludedRange
+		if res := tx.Where("vpc_id = ?", vpc.ID).Find(&ranges); res.Error != nil {
+			return res.Error
+		}
+		for _, r := range ranges {
+			if p, err := netip.ParsePrefix(r.Cidr); err == nil && p.Overlaps(prefix) {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(r.ID.String()))
+			}
+		}
+		var reservations []models.IPReservation
+		if res := tx.Where("vpc_id = ?", vpc.ID).Find(&reservations); res.Error != nil {
+			return res.Error
+		}
+		for _, r := range reservations {
+			if a, err := netip.ParseAddr(r.Address); err == nil && prefix.Contains(a) {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", fmt.Sprintf("the range contains the reserved address %s", r.Address)))
+			}
+		}
+		for _, address := range ipam.RangeAddresses(vpcCidr, prefix) {
+			holder, err := deviceWithAddress(tx, vpc, address)
+			if err != nil {
+				return err
+			}
+			if holder != nil {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(holder.ID.String()))
+			}
+		}
+
+		excluded = models.ExcludedRange{
+			VpcID:          vpc.ID,
+			OrganizationID: vpc.OrganizationID,
+			Cidr:           prefix.String(),
+			Description:    request.Description,
+		}
+		if res := tx.Create(&excluded); res.Error != nil {
+			return res.Error
+		}
+		for _, address := range ipam.RangeAddresses(vpcCidr, prefix) {
+			if err := api.ipamTx(tx).AcquireIP(ctx, vpc.ID, vpcCidr, address); err != nil {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidr", fmt.Sprintf("the address %s is not available: %v", address, err)))
+			}
+			acquired = append(acquired, address)
+		}
+		return nil
+	})
+	if err != nil {
+		// the addresses acquired before the failure return to the pool, the ones rolled back along
+		// with the transaction already are not found, which is only logged
+		for _, address := range acquired {
+			vpcCidr, _ := vpcCidrOf(vpc, prefix.Addr())
+			if err := api.ipam.ReleaseToPool(ctx, vpc.ID, address, vpcCidr); err != nil {
+				api.logger.Warnf("failed to release the excluded address %s: %v", address, err)
+			}
+		}
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, excluded)
+}
+
+// DeleteExcludedRange deletes an excluded range of a VPC
+// @Summary      Delete Excluded Range
+// @Id  		 DeleteExcludedRange
+// @Tags         VPC
+// @Description  Deletes an excluded range of a VPC, its addresses return to the pool of the VPC
+// @Param        id        path   string  true  "VPC ID"
+// @Param        range_id  path   string  true  "Excluded Range ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.ExcludedRange
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/excluded-ranges/{range_id} [delete]
+func (api *API) DeleteExcludedRange(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteExcludedRange", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("range_id", c.Param("range_id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	rangeId, err := uuid.Parse(c.Param("range_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("range_id"))
+		return
+	}
+
+	var excluded models.ExcludedRange
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("excluded ranges can only be managed by users")))
+		}
+
+		var vpc models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		if res := tx.First(&excluded, "id = ? AND vpc_id = ?", rangeId, vpc.ID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("excluded_range"))
+			}
+			return res.Error
+		}
+		return api.deleteExcludedRange(ctx, tx, vpc, excluded)
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, excluded)
+}
+
+// deleteExcludedRange deletes an excluded range, its addresses return to the pool of the VPC.
+func (api *API) deleteExcludedRange(ctx context.Context, tx *gorm.DB, vpc models.VPC, excluded models.ExcludedRange) error {
+	if res := tx.Delete(&excluded); res.Error != nil {
+		return res.Error
+	}
+	prefix, err := netip.ParsePrefix(excluded.Cidr)
+	if err != nil {
+		return err
+	}
+	vpcCidr, ok := vpcCidrOf(vpc, prefix.Addr())
+	if !ok {
+		return nil
+	}
+	for _, address := range ipam.RangeAddresses(vpcCidr, prefix) {
+		if err := api.ipamTx(tx).ReleaseToPool(ctx, vpc.ID, address, vpcCidr); err != nil {
+			return fmt.Errorf("failed to release the excluded address %s: %w", address, err)
+		}
+	}
+	return nil
+}
+
+// deleteVpcAddressing deletes the IP reservations, excluded ranges and subnets of a VPC being deleted, which
+// returns their addresses to IPAM.
+func (api *API) deleteVpcAddressing(ctx context.Context, tx *gorm.DB, vpc models.VPC) error {
+	if res := tx.Where("vpc_id = ?", vpc.ID).Delete(&models.Subnet{}); res.Error != nil {
+		return res.Error
+	}
+	var reservations []models.IPReservation
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&reservations); res.Error != nil {
+		return res.Error
+	}
+	for _, reservation := range reservations {
+		if err := api.deleteIPReservation(ctx, tx, vpc, reservation); err != nil {
+			return err
+		}
+	}
+	var ranges []models.ExcludedRange
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&ranges); res.Error != nil {
+		return res.Error
+	}
+	for _, excluded := range ranges {
+		if err := api.deleteExcludedRange(ctx, tx, vpc, excluded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vpcIPAMNamespace returns the IPAM namespace the addresses of a VPC are assigned from.
+func vpcIPAMNamespace(vpc models.VPC) uuid.UUID {
+	if vpc.PrivateCidr {
+		return vpc.ID
+	}
+	return defaultIPAMNamespace
+}
+
+// vpcCidrOf returns the CIDR of a VPC, primary or secondary, an address is in.
+func vpcCidrOf(vpc models.VPC, address netip.Addr) (string, bool) {
+	for _, cidr := range vpc.Cidrs() {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(address) {
+			return cidr, true
+		}
+	}
+	return "", false
+}
+
+// ipReservationOf returns the reservation of an address of a VPC, or nil.
+func ipReservationOf(tx *gorm.DB, vpcId uuid.UUID, address netip.Addr) (*models.IPReservation, error) {
+	var reservation models.IPReservation
+	if res := tx.First(&reservation, "vpc_id = ? AND address = ?", vpcId, address.String()); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, res.Error
+	}
+	return &reservation, nil
+}
+
+// excludedRangeOf returns the excluded range of a VPC an address is in, or nil.
+func excludedRangeOf(tx *gorm.DB, vpcId uuid.UUID, address netip.Addr) (*models.ExcludedRange, error) {
+	var ranges []models.ExcludedRange
+	if res := tx.Where("vpc_id = ?", vpcId).Find(&ranges); res.Error != nil {
+		return nil, res.Error
+	}
+	for _, r := range ranges {
+		if prefix, err := netip.ParsePrefix(r.Cidr); err == nil && prefix.Contains(address) {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+// deviceWithAddress returns the device assigned an address of the IPAM namespace of a VPC, or nil.
+func deviceWithAddress(tx *gorm.DB, vpc models.VPC, address string) (*models.Device, error) {
+	db := tx.Select("id", "vpc_id", "ipv4_tunnel_ips", "ipv6_tunnel_ips")
+	if vpc.PrivateCidr {
+		db = db.Where("vpc_id = ?", vpc.ID)
+	} else {
+		db = db.Where("vpc_id IN (SELECT id FROM vpcs WHERE private_cidr = ?)", false)
+	}
+	// narrow the devices down with the serialized tunnel IPs, then check their addresses
+	pattern := "%\"" + address + "\"%"
+	var devices []models.Device
+	if res := db.Where("(CAST(ipv4_tunnel_ips AS TEXT) LIKE ? OR CAST(ipv6_tunnel_ips AS TEXT) LIKE ?)", pattern, pattern).
+		Find(&devices); res.Error != nil {
+		return nil, res.Error
+	}
+	for _, device := range devices {
+		for _, ip := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+			if ip.Address == address {
+				return &device, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// addressReserved returns whether an address of a VPC is reserved, in which case it stays out of the pool
+// when the device assigned it leaves the VPC.
+func addressReserved(tx *gorm.DB, vpcId uuid.UUID, address string) (bool, error) {
+	var count int64
+	if res := tx.Model(&models.IPReservation{}).
+		Where("vpc_id = ? AND address = ?", vpcId, address).
+		Count(&count); res.Error != nil {
+		return false, res.Error
+	}
+	return count > 0, nil
+}
+
+// tunnelIPRequest identifies a device joining a VPC, to match the IP reservations of the VPC.
+type tunnelIPRequest struct {
+	deviceId    uuid.UUID
+	regKeyId    uuid.UUID
+	hostname    string
+	requestIPv4 string
+	subnet      *models.Subnet // the subnet of the VPC the device joins, if any
+}
+
+// priority returns how closely an IP reservation matches the device: reservations for the device take
+// precedence over the ones for its registration key, which take precedence over the ones for its hostname.
+// It returns 0 when the reservation is not for the device.
+func (r tunnelIPRequest) priority(reservation models.IPReservation) int {
+	switch {
+	case reservation.DeviceID != nil:
+		if *reservation.DeviceID == r.deviceId {
+			return 3
+		}
+	case reservation.RegKeyID != nil:
+		if r.regKeyId != uuid.Nil && *reservation.RegKeyID == r.regKeyId {
+			return 2
+		}
+	case reservation.Hostname != "":
+		if reservation.Hostname == r.hostname {
+			return 1
+		}
+	}
+	return 0
+}
+
+// assignTunnelIPs assigns the tunnel IPs of a device joining a VPC: the addresses of the VPC reserved for
+// the device, the requested IPv4 address, or addresses from the pool of the VPC. Requesting an address
+// reserved for another device or in an excluded range fails instead of falling back to the pool. When the
+// VPC has subnets, the pool of the device is its subnet, or the addresses of the VPC outside of the subnets.
+func (api *API) assignTunnelIPs(ctx context.Context, tx *gorm.DB, vpc models.VPC, request tunnelIPRequest) (ipv4 string, ipv6 string, err error) {
+	ipamNamespace := vpcIPAMNamespace(vpc)
+
+	var subnets []models.Subnet
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&subnets); res.Error != nil {
+		return "", "", res.Error
+	}
+
+	var reservations []models.IPReservation
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&reservations); res.Error != nil {
+		return "", "", res.Error
+	}
+	sort.SliceStable(reservations, func(i, j int) bool {
+		return request.priority(reservations[i]) > request.priority(reservations[j])
+	})
+	for _, reservation := range reservations {
+		if request.priority(reservation) == 0 {
+			continue
+		}
+		address, err := netip.ParseAddr(reservation.Address)
+		if err != nil {
+			continue
+		}
+		if address.Is4() && ipv4 != "" || !address.Is4() && ipv6 != "" {
+			continue
+		}
+		holder, err := deviceWithAddress(tx, vpc, reservation.Address)
+		if err != nil {
+			return "", "", err
+		}
+		if holder != nil && holder.ID != request.deviceId {
+			return "", "", NewApiResponseError(http.StatusConflict, models.NewConflictsError(holder.ID.String()))
+		}
+		if address.Is4() {
+			ipv4 = reservation.Address
+		} else {
+			ipv6 = reservation.Address
+		}
+	}
+
+	if request.requestIPv4 != "" {
+		requested, err := netip.ParseAddr(request.requestIPv4)
+		if err != nil {
+			return "", "", NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("tunnel_ips_v4", fmt.Sprintf("%s is not a valid address", request.requestIPv4)))
+		}
+		if ipv4 != "" && ipv4 != requested.String() {
+			return "", "", NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("tunnel_ips_v4", fmt.Sprintf("the address %s is reserved for the device", ipv4)))
+		}
+		if ipv4 == "" {
+			if reservation, err := ipReservationOf(tx, vpc.ID, requested); err != nil {
+				return "", "", err
+			} else if reservation != nil {
+				return "", "", NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("tunnel_ips_v4", fmt.Sprintf("the address %s is reserved for another device", requested)))
+			}
+			if excluded, err := excludedRangeOf(tx, vpc.ID, requested); err != nil {
+				return "", "", err
+			} else if excluded != nil {
+				return "", "", NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("tunnel_ips_v4", fmt.Sprintf("the address %s is in the excluded range %s", requested, excluded.Cidr)))
+			}
+			// a requested address that is not available is replaced by an address from the pool
+			if cidr, ok := vpcCidrOf(vpc, requested); ok && requested.Is4() {
+				if len(subnets) > 0 && !inSubnetAddressPool(vpc, request.subnet, subnets, requested) {
+					api.logger.Infof("the requested address %s is not in the pool of the device, assigning an address from the pool", requested)
+				} else if err := api.ipamTx(tx).AcquireIP(ctx, ipamNamespace, cidr, requested.String()); err != nil {
+					api.logger.Infof("failed to assign the requested address %s, assigning an address from the pool: %v", requested, err)
+				} else {
+					ipv4 = requested.String()
+				}
+			}
+		}
+	}
+	if ipv4 == "" {
+		if len(subnets) > 0 {
+			ipv4, err = api.assignFromSubnets(ctx, tx, vpc, request.subnet, subnets, true)
+		} else {
+			ipv4, err = api.assignFromPools(ctx, tx, ipamNamespace, vpc.Ipv4Cidrs())
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to request ipam address: %w", err)
+		}
+	}
+	if ipv6 == "" {
+		if len(subnets) > 0 {
+			ipv6, err = api.assignFromSubnets(ctx, tx, vpc, request.subnet, subnets, false)
+		} else {
+			ipv6, err = api.assignFromPools(ctx, tx, ipamNamespace, vpc.Ipv6Cidrs())
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to request ipam v6 address: %w", err)
+		}
+	}
+	return ipv4, ipv6, nil
+}
+
+// assignFromPools assigns an address from the first CIDR of a VPC that is not exhausted, the primary CIDR
+// comes first.
+func (api *API) assignFromPools(ctx context.Context, tx *gorm.DB, ipamNamespace uuid.UUID, cidrs []string) (string, error) {
+	var err error
+	for _, cidr := range cidrs {
+		var address string
+		address, err = api.ipamTx(tx).AssignFromPool(ctx, ipamNamespace, cidr)
+		if err == nil {
+			return address, nil
+		}
+		if !ipam.IsExhausted(err) {
+			return "", err
+		}
+	}
+	return "", err
+}
+
+// tunnelIP returns the tunnel IP of an address assigned from the CIDRs of a VPC.
+func tunnelIP(vpc models.VPC, address string) models.TunnelIP {
+	tunnelIP := models.TunnelIP{Address: address}
+	if a, err := netip.ParseAddr(address); err == nil {
+		tunnelIP.CIDR, _ = vpcCidrOf(vpc, a)
+	}
+	return tunnelIP
+}
+
+func (api *API) sendIPReservationError(c *gin.Context, err error) {
+	var apiResponseError *ApiResponseError
+	if errors.As(err, &apiResponseError) {
+		c.JSON(apiResponseError.Status, apiResponseError.Body)
+	} else {
+		api.SendInternalServerError(c, err)
+	}
+}
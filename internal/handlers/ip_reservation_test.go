@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestIPReservations() {
+	require := suite.Require()
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "reservations",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.6.0/24",
+		Ipv6Cidr:       "fc00:6000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+	vpcPath := fmt.Sprintf("/%s", vpc.ID)
+
+	var reservation models.IPReservation
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address:  "10.1.6.10",
+		Hostname: "reserved-host",
+	}, http.StatusCreated, &reservation)
+	require.Equal(vpc.ID, reservation.VpcID)
+
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address:  "10.1.6.10",
+		Hostname: "another-host",
+	}, http.StatusConflict, nil)
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address:  "10.1.7.10",
+		Hostname: "another-host",
+	}, http.StatusUnprocessableEntity, nil)
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address: "10.1.6.11",
+	}, http.StatusUnprocessableEntity, nil)
+
+	var excluded models.ExcludedRange
+	suite.serve(http.MethodPost, "/:id/excluded-ranges", vpcPath+"/excluded-ranges", suite.api.CreateExcludedRange, models.AddExcludedRange{
+		Cidr: "10.1.6.16/28",
+	}, http.StatusCreated, &excluded)
+	suite.serve(http.MethodPost, "/:id/excluded-ranges", vpcPath+"/excluded-ranges", suite.api.CreateExcludedRange, models.AddExcludedRange{
+		Cidr: "10.1.6.20/30",
+	}, http.StatusConflict, nil)
+	suite.serve(http.MethodPost, "/:id/excluded-ranges", vpcPath+"/excluded-ranges", suite.api.CreateExcludedRange, models.AddExcludedRange{
+		Cidr: "10.1.6.8/30",
+	}, http.StatusUnprocessableEntity, nil)
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address:  "10.1.6.17",
+		Hostname: "another-host",
+	}, http.StatusUnprocessableEntity, nil)
+
+	// requesting a reserved or excluded address fails instead of falling back to the pool
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:         vpc.ID,
+		PublicKey:     "areservationthiefpubkey",
+		Hostname:      "another-host",
+		IPv4TunnelIPs: []models.TunnelIP{{Address: "10.1.6.10"}},
+	}, http.StatusUnprocessableEntity, nil)
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:         vpc.ID,
+		PublicKey:     "areservationthiefpubkey",
+		Hostname:      "another-host",
+		IPv4TunnelIPs: []models.TunnelIP{{Address: "10.1.6.17"}},
+	}, http.StatusUnprocessableEntity, nil)
+
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:     vpc.ID,
+		PublicKey: "areservedpubkey",
+		Hostname:  "reserved-host",
+	}, http.StatusCreated, &device)
+	require.Equal("10.1.6.10", device.IPv4TunnelIPs[0].Address)
+
+	var reservations []models.IPReservation
+	suite.serve(http.MethodGet, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.ListIPReservations, nil, http.StatusOK, &reservations)
+	require.Len(reservations, 1)
+
+	// the address stays with the device when the reservation is deleted
+	suite.serve(http.MethodDelete, "/:id/ip-reservations/:reservation_id", fmt.Sprintf("%s/ip-reservations/%s", vpcPath, reservation.ID),
+		suite.api.DeleteIPReservation, nil, http.StatusOK, nil)
+	suite.serve(http.MethodPost, "/:id/ip-reservations", vpcPath+"/ip-reservations", suite.api.CreateIPReservation, models.AddIPReservation{
+		Address:  "10.1.6.10",
+		Hostname: "another-host",
+	}, http.StatusConflict, nil)
+
+	suite.serve(http.MethodDelete, "/:id/excluded-ranges/:range_id", fmt.Sprintf("%s/excluded-ranges/%s", vpcPath, excluded.ID),
+		suite.api.DeleteExcludedRange, nil, http.StatusOK, nil)
+	var ranges []models.ExcludedRange
+	suite.serve(http.MethodGet, "/:id/excluded-ranges", vpcPath+"/excluded-ranges", suite.api.ListExcludedRanges, nil, http.StatusOK, &ranges)
+	require.Empty(ranges)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	ipamcmd "github.com/nexodus-io/nexodus/internal/ipam/cmd"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+// VerifyIPAM reports the differences between IPAM and the addressing of the devices and VPCs
+// @Summary      Verifies IPAM
+// @Description  Reports the namespaces, prefixes and addresses IPAM is missing or leaks, and the addresses assigned to more than one device
+// @Id           VerifyIPAM
+// @Tags         Private
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.IPAMReport
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /private/ipam/verify [get]
+func (api *API) VerifyIPAM(c *gin.Context) {
+	api.verifyIPAM(c, false)
+}
+
+// RepairIPAM repairs the differences between IPAM and the addressing of the devices and VPCs
+// @Summary      Repairs IPAM
+// @Description  Acquires what IPAM is missing, releases what it leaks, and assigns new addresses to all but the oldest of the devices sharing an address. The leaks are released once they are still leaked after a grace period, so the request takes at least that long when there are leaks
+// @Id           RepairIPAM
+// @Tags         Private
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.IPAMReport
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /private/ipam/repair [post]
+func (api *API) RepairIPAM(c *gin.Context) {
+	api.verifyIPAM(c, true)
+}
+
+func (api *API) verifyIPAM(c *gin.Context, repair bool) {
+	ctx, span := tracer.Start(c.Request.Context(), "VerifyIPAM")
+	defer span.End()
+
+	report, err := ipamcmd.Verify(ctx, api.logger.Desugar(), api.db, api.ipam, repair, api.IPAMRepairGracePeriod)
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+
+	// the watchers of the readdressed devices are sent their new addresses
+	for _, duplicate := range report.DuplicateAddresses {
+		for _, id := range duplicate.Readdressed {
+			var device models.Device
+			if res := api.db.WithContext(ctx).First(&device, "id = ?", id); res.Error != nil {
+				api.logger.Warnf("failed to load the readdressed device %s: %v", id, res.Error)
+				continue
+			}
+			api.notifyDeviceWatchers(ctx, &device)
+		}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/ipam"
+	ipamcmd "github.com/nexodus-io/nexodus/internal/ipam/cmd"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestVerifyIPAM() {
+	require := suite.Require()
+	ctx := context.Background()
+
+	report := func(method string, repair bool, ns uuid.UUID) models.IPAMReport {
+		handler := suite.api.VerifyIPAM
+		if repair {
+			handler = suite.api.RepairIPAM
+		}
+		var all models.IPAMReport
+		suite.serve(method, "/", "/", handler, nil, http.StatusOK, &all)
+
+		// only the namespace of the VPC of this test is checked, the other tests share the database
+		filtered := models.IPAMReport{Repaired: all.Repaired, Errors: all.Errors}
+		for _, p := range all.MissingPrefixes {
+			if p.Namespace == ns {
+				filtered.MissingPrefixes = append(filtered.MissingPrefixes, p)
+			}
+		}
+		for _, a := range all.MissingAddresses {
+			if a.Namespace == ns {
+				filtered.MissingAddresses = append(filtered.MissingAddresses, a)
+			}
+		}
+		for _, a := range all.LeakedAddresses {
+			if a.Namespace == ns {
+				filtered.LeakedAddresses = append(filtered.LeakedAddresses, a)
+			}
+		}
+		for _, d := range all.DuplicateAddresses {
+			if d.Namespace == ns {
+				filtered.DuplicateAddresses = append(filtered.DuplicateAddresses, d)
+			}
+		}
+		return filtered
+	}
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "ipam-verify",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.7.0/24",
+		Ipv6Cidr:       "fc00:7000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+
+	var device, other models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:     vpc.ID,
+		PublicKey: "averifiedpubkey",
+	}, http.StatusCreated, &device)
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:     vpc.ID,
+		PublicKey: "anotherverifiedpubkey",
+	}, http.StatusCreated, &other)
+
+	r := report(http.MethodGet, false, vpc.ID)
+	require.Empty(r.Errors)
+	require.True(r.Consistent(), "%+v", r)
+
+	// a leaked address, a missing address and a duplicate address
+	require.NoError(suite.api.ipam.AcquireIP(ctx, vpc.ID, vpc.Ipv4Cidr, "10.1.7.200"))
+	require.NoError(suite.api.ipam.ReleaseToPool(ctx, vpc.ID, device.IPv6TunnelIPs[0].Address, vpc.Ipv6Cidr))
+	otherAddress := other.IPv4TunnelIPs[0].Address
+	other.IPv4TunnelIPs[0].Address = device.IPv4TunnelIPs[0].Address
+	other.AllowedIPs[0] = device.IPv4TunnelIPs[0].Address + "/32"
+	require.NoError(suite.api.db.Model(&other).Select("ipv4_tunnel_ips", "allowed_ips").Updates(&other).Error)
+
+	r = report(http.MethodGet, false, vpc.ID)
+	require.False(r.Repaired)
+	require.Len(r.MissingAddresses, 1)
+	require.Equal(device.IPv6TunnelIPs[0].Address, r.MissingAddresses[0].Address)
+	require.Len(r.DuplicateAddresses, 1)
+	require.Equal([]uuid.UUID{device.ID, other.ID}, r.DuplicateAddresses[0].DeviceIDs)
+	var leaked []string
+	for _, a := range r.LeakedAddresses {
+		leaked = append(leaked, a.Address)
+	}
+	require.ElementsMatch([]string{"10.1.7.200", otherAddress}, leaked)
+
+	// verifying does not change IPAM
+	r = report(http.MethodGet, false, vpc.ID)
+	require.Len(r.MissingAddresses, 1)
+	require.Len(r.LeakedAddresses, 2)
+
+	r = report(http.MethodPost, true, vpc.ID)
+	require.True(r.Repaired)
+	require.Empty(r.Errors)
+	require.Equal([]uuid.UUID{other.ID}, r.DuplicateAddresses[0].Readdressed)
+
+	var readdressed models.Device
+	require.NoError(suite.api.db.First(&readdressed, "id = ?", other.ID).Error)
+	require.NotEqual(device.IPv4TunnelIPs[0].Address, readdressed.IPv4TunnelIPs[0].Address)
+	require.Contains([]string(readdressed.AllowedIPs), readdressed.IPv4TunnelIPs[0].Address+"/32")
+
+	r = report(http.MethodGet, false, vpc.ID)
+	require.True(r.Consistent(), "%+v", r)
+
+	// a leak in use once the grace period is over, like the address of a request in flight, is kept
+	require.NoError(suite.api.ipam.AcquireIP(ctx, vpc.ID, vpc.Ipv4Cidr, "10.1.7.201"))
+	repaired := make(chan *models.IPAMReport, 1)
+	go func() {
+		r, err := ipamcmd.Verify(ctx, suite.logger.Desugar(), suite.api.db, suite.api.ipam, true, time.Second)
+		if err != nil {
+			suite.T().Error(err)
+		}
+		repaired <- r
+	}()
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(suite.api.db.Create(&models.IPReservation{
+		VpcID:          vpc.ID,
+		OrganizationID: vpc.OrganizationID,
+		Address:        "10.1.7.201",
+		Hostname:       "in-flight",
+	}).Error)
+	all := <-repaired
+	require.NotNil(all)
+	var leakedAddresses []string
+	for _, a := range all.LeakedAddresses {
+		leakedAddresses = append(leakedAddresses, a.Address)
+	}
+	require.Contains(leakedAddresses, "10.1.7.201")
+	require.True(ipam.IsAlreadyAllocated(suite.api.ipam.AcquireIP(ctx, vpc.ID, vpc.Ipv4Cidr, "10.1.7.201")))
+
+	// the leaks of prefixes too large to probe are listed when IPAM can list them, and counted otherwise
+	var large models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "ipam-verify-large",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.9.0.0/16",
+		Ipv6Cidr:       "fc00:9000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &large)
+	require.NoError(suite.api.ipam.AcquireIP(ctx, large.ID, large.Ipv4Cidr, "10.9.200.1"))
+	all, err := ipamcmd.Verify(ctx, suite.logger.Desugar(), suite.api.db, suite.api.ipam, false, 0)
+	require.NoError(err)
+	var unaccounted []models.IPAMPrefix
+	for _, p := range all.UnaccountedAddresses {
+		if p.Namespace == large.ID {
+			unaccounted = append(unaccounted, p)
+		}
+	}
+	leakedAddresses = nil
+	for _, a := range all.LeakedAddresses {
+		if a.Namespace == large.ID {
+			leakedAddresses = append(leakedAddresses, a.Address)
+		}
+	}
+	if _, ok := suite.api.ipam.(ipam.AddressLister); ok {
+		require.Equal([]string{"10.9.200.1"}, leakedAddresses)
+		require.Empty(unaccounted)
+	} else {
+		require.Empty(leakedAddresses)
+		require.Equal([]models.IPAMPrefix{{Namespace: large.ID, Cidr: "10.9.0.0/16", Count: 1}}, unaccounted)
+	}
+}
@@ -80,6 +80,8 @@ func (at *AgentTracker) Connected(api *API, c *gin.Context, agentIdPtr *uuid.UUI
 			logger.Warn("failed to update db state for device", zap.Error(err))
 			fn()
 		}
+		// let peers know, so they can fail back routes advertised by this device
+		api.notifyDeviceWatchers(c.Request.Context(), device)
 	}
 	if site.ID != uuid.Nil && !site.Online {
 		site.Online = true
@@ -111,9 +113,12 @@ func (at *AgentTracker) Connected(api *API, c *gin.Context, agentIdPtr *uuid.UUI
 			if device.ID != uuid.Nil {
 				device.Online = false
 				device.OnlineAt = &now
-				err = api.db.Select("online", "online_at").Where("online = true").Updates(device).Error
-				if err != nil {
-					logger.Warn("failed to update db state for device", zap.Error(err))
+				res := api.db.Select("online", "online_at").Where("online = true").Updates(device)
+				if res.Error != nil {
+					logger.Warn("failed to update db state for device", zap.Error(res.Error))
+				} else if res.RowsAffected > 0 {
+					// let peers know, so they can fail over routes advertised by this device
+					api.notifyDeviceWatchers(context.Background(), device)
 				}
 			}
 			if site.ID != uuid.Nil {
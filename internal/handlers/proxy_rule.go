@@ -0,0 +1,431 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type proxyRuleList []*models.ProxyRule
+
+func (d proxyRuleList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.ID.String(), item.Revision, item.DeletedAt
+}
+
+func (d proxyRuleList) Len() int {
+	return len(d)
+}
+
+func proxyRuleSignal(deviceId uuid.UUID) string {
+	return fmt.Sprintf("/proxy-rules/device=%s", deviceId.String())
+}
+
+// ListDeviceProxyRules lists the proxy rules of a device
+// @Summary      List Device Proxy Rules
+// @Id  		 ListDeviceProxyRules
+// @Tags         Devices
+// @Description  Lists the proxy rules nexd should apply on a device
+// @Param        id          path   string  true  "Device ID"
+// @Param		 gt_revision query  uint64  false "greater than revision"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.ProxyRule
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/proxy-rules [get]
+func (api *API) ListDeviceProxyRules(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListDeviceProxyRules", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var query Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewApiError(err))
+		return
+	}
+
+	var device models.Device
+	db := api.db.WithContext(ctx)
+	result := api.DeviceIsOwnedByCurrentUser(c, db).
+		First(&device, "id = ?", deviceId)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+			return
+		}
+		api.SendInternalServerError(c, fmt.Errorf("error fetching proxy rules: %w", result.Error))
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		db = db.Where("device_id = ?", deviceId.String())
+		db = FilterAndPaginateWithQuery(db, &models.ProxyRule{}, c, query, "listen_port")
+
+		var items proxyRuleList
+		result := db.Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// GetDeviceProxyRule gets a proxy rule of a device
+// @Summary      Get Device Proxy Rule
+// @Id  		 GetDeviceProxyRule
+// @Tags         Devices
+// @Description  Gets a proxy rule of a device by ID
+// @Param        id       path   string  true  "Device ID"
+// @Param        rule_id  path   string  true  "Proxy Rule ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.ProxyRule
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/proxy-rules/{rule_id} [get]
+func (api *API) GetDeviceProxyRule(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetDeviceProxyRule", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("rule_id", c.Param("rule_id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rule_id"))
+		return
+	}
+
+	var rule models.ProxyRule
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var device models.Device
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		}
+		if res := tx.First(&rule, "id = ? AND device_id = ?", ruleId, deviceId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("proxy_rule"))
+			}
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		api.sendProxyRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateDeviceProxyRule adds a proxy rule to a device
+// @Summary      Add Device Proxy Rule
+// @Id  		 CreateDeviceProxyRule
+// @Tags         Devices
+// @Description  Adds a proxy rule that nexd will apply on the device
+// @Param        id          path   string               true  "Device ID"
+// @Param        ProxyRule   body   models.AddProxyRule  true  "Add Proxy Rule"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.ProxyRule
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/proxy-rules [post]
+func (api *API) CreateDeviceProxyRule(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateDeviceProxyRule", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddProxyRule
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	rule := models.ProxyRule{
+		Type:                strings.ToLower(request.Type),
+		Protocol:            strings.ToLower(request.Protocol),
+		ListenPort:          request.ListenPort,
+		DestinationHost:     request.DestinationHost,
+		DestinationPort:     request.DestinationPort,
+		SendProxyProtocol:   strings.ToLower(request.SendProxyProtocol),
+		AcceptProxyProtocol: request.AcceptProxyProtocol,
+		Description:         request.Description,
+	}
+	if field, reason := ValidateProxyRule(rule); field != "" {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, reason))
+		return
+	}
+
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var device models.Device
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		}
+		rule.DeviceID = device.ID
+		rule.VpcID = device.VpcID
+		rule.OrganizationID = device.OrganizationID
+
+		if err := checkProxyRuleConflicts(tx, rule); err != nil {
+			return err
+		}
+
+		if res := tx.
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Create(&rule); res.Error != nil {
+			return res.Error
+		}
+		span.SetAttributes(attribute.String("rule_id", rule.ID.String()))
+		return nil
+	})
+	if err != nil {
+		api.sendProxyRuleError(c, err)
+		return
+	}
+
+	api.signalBus.Notify(proxyRuleSignal(rule.DeviceID))
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateDeviceProxyRule updates a proxy rule of a device
+// @Summary      Update Device Proxy Rule
+// @Id  		 UpdateDeviceProxyRule
+// @Tags         Devices
+// @Description  Updates a proxy rule of a device by ID
+// @Param        id       path   string                  true  "Device ID"
+// @Param        rule_id  path   string                  true  "Proxy Rule ID"
+// @Param        update   body   models.UpdateProxyRule  true  "Proxy Rule Update"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.ProxyRule
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/proxy-rules/{rule_id} [patch]
+func (api *API) UpdateDeviceProxyRule(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateDeviceProxyRule", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("rule_id", c.Param("rule_id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rule_id"))
+		return
+	}
+
+	var request models.UpdateProxyRule
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	var rule models.ProxyRule
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var device models.Device
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		}
+		if res := tx.First(&rule, "id = ? AND device_id = ?", ruleId, deviceId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("proxy_rule"))
+			}
+			return res.Error
+		}
+
+		if request.ListenPort != nil {
+			rule.ListenPort = *request.ListenPort
+		}
+		if request.DestinationHost != nil {
+			rule.DestinationHost = *request.DestinationHost
+		}
+		if request.DestinationPort != nil {
+			rule.DestinationPort = *request.DestinationPort
+		}
+		if request.SendProxyProtocol != nil {
+			rule.SendProxyProtocol = strings.ToLower(*request.SendProxyProtocol)
+		}
+		if request.AcceptProxyProtocol != nil {
+			rule.AcceptProxyProtocol = *request.AcceptProxyProtocol
+		}
+		if request.Description != nil {
+			rule.Description = *request.Description
+		}
+		if field, reason := ValidateProxyRule(rule); field != "" {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, reason))
+		}
+		if err := checkProxyRuleConflicts(tx, rule); err != nil {
+			return err
+		}
+
+		if res := tx.
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Save(&rule); res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		api.sendProxyRuleError(c, err)
+		return
+	}
+
+	api.signalBus.Notify(proxyRuleSignal(rule.DeviceID))
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteDeviceProxyRule removes a proxy rule from a device
+// @Summary      Delete Device Proxy Rule
+// @Id  		 DeleteDeviceProxyRule
+// @Tags         Devices
+// @Description  Deletes a proxy rule of a device by ID
+// @Param        id       path   string  true  "Device ID"
+// @Param        rule_id  path   string  true  "Proxy Rule ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.ProxyRule
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/proxy-rules/{rule_id} [delete]
+func (api *API) DeleteDeviceProxyRule(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteDeviceProxyRule", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("rule_id", c.Param("rule_id")),
+	))
+	defer span.End()
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rule_id"))
+		return
+	}
+
+	var rule models.ProxyRule
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var device models.Device
+		if res := api.DeviceIsOwnedByCurrentUser(c, tx).
+			First(&device, "id = ?", deviceId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		}
+		if res := tx.First(&rule, "id = ? AND device_id = ?", ruleId, deviceId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("proxy_rule"))
+			}
+			return res.Error
+		}
+		return tx.Delete(&rule).Error
+	})
+	if err != nil {
+		api.sendProxyRuleError(c, err)
+		return
+	}
+
+	api.signalBus.Notify(proxyRuleSignal(rule.DeviceID))
+	c.JSON(http.StatusOK, rule)
+}
+
+func (api *API) sendProxyRuleError(c *gin.Context, err error) {
+	var apiResponseError *ApiResponseError
+	if errors.As(err, &apiResponseError) {
+		c.JSON(apiResponseError.Status, apiResponseError.Body)
+	} else {
+		api.SendInternalServerError(c, err)
+	}
+}
+
+// checkProxyRuleConflicts rejects a rule that duplicates an existing rule on the same device
+// or that disagrees with the PROXY protocol settings of rules sharing its listener.
+func checkProxyRuleConflicts(tx *gorm.DB, rule models.ProxyRule) error {
+	var existing []models.ProxyRule
+	res := tx.Where("device_id = ? AND type = ? AND protocol = ? AND listen_port = ? AND id <> ?",
+		rule.DeviceID, rule.Type, rule.Protocol, rule.ListenPort, rule.ID).
+		Find(&existing)
+	if res.Error != nil {
+		return res.Error
+	}
+	for _, other := range existing {
+		if other.DestinationHost == rule.DestinationHost && other.DestinationPort == rule.DestinationPort {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(other.ID.String()))
+		}
+		if other.AcceptProxyProtocol != rule.AcceptProxyProtocol {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("accept_proxy_protocol",
+				fmt.Sprintf("must match the other rules listening on %s port %d", rule.Protocol, rule.ListenPort)))
+		}
+	}
+	return nil
+}
+
+// ValidateProxyRule validates a proxy rule, returning the name of the invalid field and the reason.
+func ValidateProxyRule(rule models.ProxyRule) (string, string) {
+	if rule.Type != models.ProxyRuleTypeIngress && rule.Type != models.ProxyRuleTypeEgress {
+		return "type", "must be ingress or egress"
+	}
+	if rule.Protocol != protoTCP && rule.Protocol != protoUDP {
+		return "protocol", "must be tcp or udp"
+	}
+	if rule.ListenPort < 1 || rule.ListenPort > 65535 {
+		return "listen_port", "must be between 1 and 65535"
+	}
+	if rule.DestinationHost == "" || strings.ContainsAny(rule.DestinationHost, " /[]") {
+		return "destination_host", "must be a valid hostname or IP address"
+	}
+	if rule.DestinationPort < 1 || rule.DestinationPort > 65535 {
+		return "destination_port", "must be between 1 and 65535"
+	}
+	switch rule.SendProxyProtocol {
+	case "", "v1", "v2":
+	default:
+		return "send_proxy_protocol", "must be v1 or v2"
+	}
+	if rule.Protocol != protoTCP && (rule.SendProxyProtocol != "" || rule.AcceptProxyProtocol) {
+		return "protocol", "the PROXY protocol is only supported on tcp rules"
+	}
+	return "", ""
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *HandlerTestSuite) TestCreateListDeleteDeviceProxyRule() {
+	require := suite.Require()
+	assert := suite.Assert()
+
+	resBody, err := json.Marshal(models.AddDevice{
+		VpcID:     suite.testUserID,
+		PublicKey: "aproxyrulepubkey",
+	})
+	require.NoError(err)
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateDevice, bytes.NewBuffer(resBody),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var device models.Device
+	err = json.Unmarshal(body, &device)
+	require.NoError(err)
+
+	newRule := models.AddProxyRule{
+		Type:              models.ProxyRuleTypeIngress,
+		Protocol:          "tcp",
+		ListenPort:        8080,
+		DestinationHost:   "127.0.0.1",
+		DestinationPort:   80,
+		SendProxyProtocol: "v2",
+	}
+	resBody, err = json.Marshal(newRule)
+	require.NoError(err)
+	_, res, err = suite.ServeRequest(
+		http.MethodPost,
+		"/:id/proxy-rules", fmt.Sprintf("/%s/proxy-rules", device.ID),
+		suite.api.CreateDeviceProxyRule, bytes.NewBuffer(resBody),
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var rule models.ProxyRule
+	err = json.Unmarshal(body, &rule)
+	require.NoError(err)
+	assert.Equal(device.ID, rule.DeviceID)
+	assert.Equal(device.VpcID, rule.VpcID)
+	assert.Equal(8080, rule.ListenPort)
+	assert.Equal("v2", rule.SendProxyProtocol)
+
+	// the same rule a second time conflicts with the first one
+	_, res, err = suite.ServeRequest(
+		http.MethodPost,
+		"/:id/proxy-rules", fmt.Sprintf("/%s/proxy-rules", device.ID),
+		suite.api.CreateDeviceProxyRule, bytes.NewBuffer(resBody),
+	)
+	require.NoError(err)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet,
+		"/:id/proxy-rules", fmt.Sprintf("/%s/proxy-rules", device.ID),
+		suite.api.ListDeviceProxyRules, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var rules []models.ProxyRule
+	err = json.Unmarshal(body, &rules)
+	require.NoError(err)
+	require.Len(rules, 1)
+	assert.Equal(rule.ID, rules[0].ID)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete,
+		"/:id/proxy-rules/:rule_id", fmt.Sprintf("/%s/proxy-rules/%s", device.ID, rule.ID),
+		suite.api.DeleteDeviceProxyRule, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet,
+		"/:id/proxy-rules/:rule_id", fmt.Sprintf("/%s/proxy-rules/%s", device.ID, rule.ID),
+		suite.api.GetDeviceProxyRule, nil,
+	)
+	require.NoError(err)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestValidateProxyRule(t *testing.T) {
+	valid := models.ProxyRule{
+		Type:            models.ProxyRuleTypeEgress,
+		Protocol:        "tcp",
+		ListenPort:      443,
+		DestinationHost: "10.0.0.1",
+		DestinationPort: 8443,
+	}
+	tests := []struct {
+		name          string
+		modify        func(r *models.ProxyRule)
+		expectedField string
+	}{
+		{
+			name:   "valid rule",
+			modify: func(r *models.ProxyRule) {},
+		},
+		{
+			name:          "bad type",
+			modify:        func(r *models.ProxyRule) { r.Type = "sideways" },
+			expectedField: "type",
+		},
+		{
+			name:          "bad protocol",
+			modify:        func(r *models.ProxyRule) { r.Protocol = "icmp" },
+			expectedField: "protocol",
+		},
+		{
+			name:          "listen port out of range",
+			modify:        func(r *models.ProxyRule) { r.ListenPort = 70000 },
+			expectedField: "listen_port",
+		},
+		{
+			name:          "missing destination host",
+			modify:        func(r *models.ProxyRule) { r.DestinationHost = "" },
+			expectedField: "destination_host",
+		},
+		{
+			name:          "unknown proxy protocol version",
+			modify:        func(r *models.ProxyRule) { r.SendProxyProtocol = "v3" },
+			expectedField: "send_proxy_protocol",
+		},
+		{
+			name: "proxy protocol on udp",
+			modify: func(r *models.ProxyRule) {
+				r.Protocol = "udp"
+				r.AcceptProxyProtocol = true
+			},
+			expectedField: "protocol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid
+			tt.modify(&rule)
+			field, _ := ValidateProxyRule(rule)
+			assert.Equal(t, tt.expectedField, field)
+		})
+	}
+}
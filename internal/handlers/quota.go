@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// organizationQuotas returns the default quotas with the overrides of the organization applied.
+func (api *API) organizationQuotas(tx *gorm.DB, orgId uuid.UUID) (models.Quotas, error) {
+	var overrides models.OrganizationQuota
+	if res := tx.Find(&overrides, "organization_id = ?", orgId); res.Error != nil {
+		return models.Quotas{}, res.Error
+	}
+	return overrides.Apply(api.DefaultQuotas), nil
+}
+
+// countQuotaUsage counts the resources of an organization limited by a quota. The default security
+// groups of the VPCs are not counted, and neither are expired invitations.
+func countQuotaUsage(tx *gorm.DB, orgId uuid.UUID, resource string) (int64, error) {
+	var db *gorm.DB
+	switch resource {
+	case models.QuotaDevices:
+		db = tx.Model(&models.Device{}).Where("organization_id = ?", orgId)
+	case models.QuotaVpcs:
+		db = tx.Model(&models.VPC{}).Where("organization_id = ?", orgId)
+	case models.QuotaRegKeys:
+		db = tx.Model(&models.RegKey{}).Where("organization_id = ? OR sn_organization_id = ?", orgId, orgId)
+	case models.QuotaSecurityGroups:
+		db = tx.Model(&models.SecurityGroup{}).Where("organization_id = ? AND id <> vpc_id", orgId)
+	case models.QuotaInvitations:
+		db = tx.Model(&models.Invitation{}).Where("organization_id = ? AND expires_at > ?", orgId, time.Now())
+	default:
+		return 0, fmt.Errorf("unknown quota resource: %s", resource)
+	}
+	var count int64
+	if res := db.Count(&count); res.Error != nil {
+		return 0, res.Error
+	}
+	return count, nil
+}
+
+// checkQuota fails with an HTTP 403 when creating one more resource would exceed the quota of the
+// organization. The organization is locked until the transaction ends, so that concurrent creates
+// cannot exceed the quota together.
+func (api *API) checkQuota(tx *gorm.DB, orgId uuid.UUID, resource string) error {
+	quotas, err := api.organizationQuotas(tx, orgId)
+	if err != nil {
+		return err
+	}
+	quota := quotas.Quota(resource)
+	if quota == 0 {
+		return nil
+	}
+
+	var org models.Organization
+	if res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&org, "id = ?", orgId); res.Error != nil {
+		return fmt.Errorf("failed to lock the organization: %w", res.Error)
+	}
+	used, err := countQuotaUsage(tx, orgId, resource)
+	if err != nil {
+		return err
+	}
+	if used >= quota {
+		return NewApiResponseError(http.StatusForbidden, models.NewQuotaExceededError(resource, quota))
+	}
+	return nil
+}
+
+// GetOrganizationUsage gets the usage of the quotas of an organization
+// @Summary      Get Organization Usage
+// @Description  Gets the number of devices, VPCs, registration keys, security groups and invitations of an organization, and their quotas
+// @Id 			 GetOrganizationUsage
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Success      200  {object}  models.OrganizationUsage
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/usage [get]
+func (api *API) GetOrganizationUsage(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetOrganizationUsage",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+	k, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var org models.Organization
+	db := api.db.WithContext(ctx)
+	if res := api.OrganizationIsReadableByCurrentUser(c, db).
+		First(&org, "id = ?", k.String()); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("organization"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	quotas, err := api.organizationQuotas(db, org.ID)
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+	usage := models.OrganizationUsage{
+		OrganizationID: org.ID,
+	}
+	for resource, ru := range map[string]*models.ResourceUsage{
+		models.QuotaDevices:        &usage.Devices,
+		models.QuotaVpcs:           &usage.Vpcs,
+		models.QuotaRegKeys:        &usage.RegKeys,
+		models.QuotaSecurityGroups: &usage.SecurityGroups,
+		models.QuotaInvitations:    &usage.Invitations,
+	} {
+		ru.Used, err = countQuotaUsage(db, org.ID, resource)
+		if err != nil {
+			api.SendInternalServerError(c, err)
+			return
+		}
+		ru.Quota = quotas.Quota(resource)
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetOrganizationQuota gets the quota overrides of an organization
+// @Summary      Get Organization Quota
+// @Description  Gets the quotas overriding the default quotas for an organization
+// @Id           GetOrganizationQuota
+// @Tags         Private
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Success      200  {object}  models.OrganizationQuota
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /private/organizations/{id}/quota [get]
+func (api *API) GetOrganizationQuota(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetOrganizationQuota",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+	k, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	db := api.db.WithContext(ctx)
+	var org models.Organization
+	if res := db.First(&org, "id = ?", k); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("organization"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	quota := models.OrganizationQuota{
+		OrganizationID: org.ID,
+	}
+	if res := db.Find(&quota, "organization_id = ?", org.ID); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, quota)
+}
+
+// UpdateOrganizationQuota replaces the quota overrides of an organization
+// @Summary      Update Organization Quota
+// @Description  Replaces the quotas overriding the default quotas for an organization. A quota of 0 is unlimited, and the default quota applies to the resources left out.
+// @Id           UpdateOrganizationQuota
+// @Tags         Private
+// @Accept       json
+// @Produce      json
+// @Param		 id      path   string                          true "Organization ID"
+// @Param		 update  body   models.UpdateOrganizationQuota  true "Organization Quota Update"
+// @Success      200  {object}  models.OrganizationQuota
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /private/organizations/{id}/quota [put]
+func (api *API) UpdateOrganizationQuota(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateOrganizationQuota",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+	k, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.UpdateOrganizationQuota
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	for field, value := range map[string]*int64{
+		models.QuotaDevices:        request.Devices,
+		models.QuotaVpcs:           request.Vpcs,
+		models.QuotaRegKeys:        request.RegKeys,
+		models.QuotaSecurityGroups: request.SecurityGroups,
+		models.QuotaInvitations:    request.Invitations,
+	} {
+		if value != nil && *value < 0 {
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, "must not be negative"))
+			return
+		}
+	}
+
+	quota := models.OrganizationQuota{
+		OrganizationID: k,
+	}
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var org models.Organization
+		if res := tx.First(&org, "id = ?", k); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
+			}
+			return res.Error
+		}
+		if res := tx.Find(&quota, "organization_id = ?", k); res.Error != nil {
+			return res.Error
+		}
+		quota.Devices = request.Devices
+		quota.Vpcs = request.Vpcs
+		quota.RegKeys = request.RegKeys
+		quota.SecurityGroups = request.SecurityGroups
+		quota.Invitations = request.Invitations
+		if res := tx.Save(&quota); res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, quota)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestQuotas() {
+	require := suite.Require()
+
+	orgPath := fmt.Sprintf("/%s", suite.testUserID)
+	var usage models.OrganizationUsage
+	suite.serve(http.MethodGet, "/:id/usage", orgPath+"/usage", suite.api.GetOrganizationUsage, nil, http.StatusOK, &usage)
+	require.Equal(suite.testUserID, usage.OrganizationID)
+	require.Equal(int64(0), usage.Vpcs.Quota)
+
+	// the default quotas apply to the organizations without overrides
+	suite.api.DefaultQuotas = models.Quotas{Vpcs: usage.Vpcs.Used + 1}
+	defer func() {
+		suite.api.DefaultQuotas = models.Quotas{}
+	}()
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "quotas",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.20.0/24",
+		Ipv6Cidr:       "fc00:b000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+
+	var quotaErr models.QuotaExceededError
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "quotas",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.21.0/24",
+		Ipv6Cidr:       "fc00:c000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusForbidden, &quotaErr)
+	require.Equal(models.NewQuotaExceededError(models.QuotaVpcs, usage.Vpcs.Used+1), quotaErr)
+
+	// the overrides of an organization replace the default quotas
+	suite.serve(http.MethodGet, "/:id/usage", orgPath+"/usage", suite.api.GetOrganizationUsage, nil, http.StatusOK, &usage)
+	securityGroups := usage.SecurityGroups.Used + 1
+	unlimited := int64(0)
+	var quota models.OrganizationQuota
+	suite.serve(http.MethodPut, "/:id/quota", orgPath+"/quota", suite.api.UpdateOrganizationQuota, models.UpdateOrganizationQuota{
+		Vpcs:           &unlimited,
+		SecurityGroups: &securityGroups,
+	}, http.StatusOK, &quota)
+	defer suite.serve(http.MethodPut, "/:id/quota", orgPath+"/quota", suite.api.UpdateOrganizationQuota, models.UpdateOrganizationQuota{}, http.StatusOK, nil)
+	require.Equal(suite.testUserID, quota.OrganizationID)
+	require.Equal(securityGroups, *quota.SecurityGroups)
+
+	negative := int64(-1)
+	suite.serve(http.MethodPut, "/:id/quota", orgPath+"/quota", suite.api.UpdateOrganizationQuota, models.UpdateOrganizationQuota{
+		Devices: &negative,
+	}, http.StatusUnprocessableEntity, nil)
+
+	// the default security group of the vpc is not counted
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateSecurityGroup, models.AddSecurityGroup{
+		Description: "quotas",
+		VpcId:       vpc.ID,
+	}, http.StatusCreated, nil)
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateSecurityGroup, models.AddSecurityGroup{
+		Description: "quotas",
+		VpcId:       vpc.ID,
+	}, http.StatusForbidden, &quotaErr)
+	require.Equal(models.QuotaSecurityGroups, quotaErr.Resource)
+
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "quotas",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.21.0/24",
+		Ipv6Cidr:       "fc00:c000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, nil)
+
+	suite.serve(http.MethodGet, "/:id/usage", orgPath+"/usage", suite.api.GetOrganizationUsage, nil, http.StatusOK, &usage)
+	require.Equal(models.ResourceUsage{Used: securityGroups, Quota: securityGroups}, usage.SecurityGroups)
+	require.Equal(int64(0), usage.Vpcs.Quota)
+}
@@ -29,6 +29,7 @@ import (
 // @Param        RegKey  body     models.AddRegKey  true  "Add RegKey"
 // @Success      201  {object}  models.RegKey
 // @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.QuotaExceededError
 // @Failure      404  {object}  models.BaseError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
@@ -49,6 +50,11 @@ func (api *API) CreateRegKey(c *gin.Context) {
 		return
 	}
 
+	if cidr, ok := validatePrefixes(request.AutoApproveCidrs); !ok {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("auto_approve_cidrs", fmt.Sprintf("invalid cidr %s", cidr)))
+		return
+	}
+
 	// use a wg private key as the token, since it should be hard to guess.
 	token, err := wgtypes.GeneratePrivateKey()
 	if err != nil {
@@ -71,6 +77,7 @@ func (api *API) CreateRegKey(c *gin.Context) {
 			Description:      request.Description,
 			ExpiresAt:        request.ExpiresAt,
 			Settings:         request.Settings,
+			AutoApproveCidrs: request.AutoApproveCidrs,
 		}
 
 		// User needs to be a member of the VPC's org
@@ -81,6 +88,25 @@ func (api *API) CreateRegKey(c *gin.Context) {
 				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
 			}
 			record.OrganizationID = &vpc.OrganizationID
+
+			// only organization owners can approve advertised CIDRs
+			if len(request.AutoApproveCidrs) > 0 {
+				if res := api.VPCIsOwnedByCurrentUser(c, tx).
+					First(&vpc, "id = ?", vpc.ID); res.Error != nil {
+					return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("only organization owners can set auto_approve_cidrs")))
+				}
+			}
+
+			if request.SubnetID != nil {
+				var count int64
+				if res := tx.Model(&models.Subnet{}).Where("id = ? AND vpc_id = ?", *request.SubnetID, vpc.ID).Count(&count); res.Error != nil {
+					return res.Error
+				}
+				if count == 0 {
+					return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("subnet_id", "the subnet is not in the vpc"))
+				}
+				record.SubnetID = request.SubnetID
+			}
 		}
 
 		// User needs to be a member of the ServiceNetwork's org
@@ -107,6 +133,16 @@ func (api *API) CreateRegKey(c *gin.Context) {
 			record.DeviceId = &deviceID
 		}
 
+		orgId := record.OrganizationID
+		if orgId == nil {
+			orgId = record.SNOrganizationID
+		}
+		if orgId != nil {
+			if err := api.checkQuota(tx, *orgId, models.QuotaRegKeys); err != nil {
+				return err
+			}
+		}
+
 		if res := tx.Create(&record); res.Error != nil {
 			return res.Error
 		}
@@ -193,6 +229,23 @@ func (api *API) UpdateRegKey(c *gin.Context) {
 		if request.Settings != nil {
 			regKey.Settings = request.Settings
 		}
+		if request.AutoApproveCidrs != nil {
+			if cidr, ok := validatePrefixes(request.AutoApproveCidrs); !ok {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("auto_approve_cidrs", fmt.Sprintf("invalid cidr %s", cidr)))
+			}
+			// only organization owners can approve advertised CIDRs
+			if len(request.AutoApproveCidrs) > 0 {
+				var vpc models.VPC
+				if regKey.VpcID == nil {
+					return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("auto_approve_cidrs", "only supported for vpc reg keys"))
+				}
+				if res := api.VPCIsOwnedByCurrentUser(c, tx).
+					First(&vpc, "id = ?", *regKey.VpcID); res.Error != nil {
+					return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("only organization owners can set auto_approve_cidrs")))
+				}
+			}
+			regKey.AutoApproveCidrs = request.AutoApproveCidrs
+		}
 
 		if res := tx.
 			Save(&regKey); res.Error != nil {
@@ -225,6 +278,11 @@ func NxodusClaims(c *gin.Context, tx *gorm.DB) (*models.NexodusClaims, *ApiRespo
 	return &claims, nil
 }
 
+// isTokenClaims returns whether the request was authenticated with a reg or device token rather than by a user.
+func isTokenClaims(claims *models.NexodusClaims) bool {
+	return claims != nil && (claims.Scope == "reg-token" || claims.Scope == "device-token")
+}
+
 // ListRegKeys lists reg keys
 // @Summary      List reg keys
 // @Description  Lists all reg keys
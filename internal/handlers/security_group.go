@@ -213,6 +213,7 @@ func (api *API) GetSecurityGroup(c *gin.Context) {
 // @Success      201  {object}  models.SecurityGroup
 // @Failure      400  {object}  models.BaseError
 // @Failure      401  {object}  models.BaseError
+// @Failure      403  {object}  models.QuotaExceededError
 // @Failure      409  {object}  models.ConflictsError
 // @Failure      422  {object}  models.ValidationError
 // @Failure      429  {object}  models.BaseError
@@ -260,6 +261,9 @@ func (api *API) CreateSecurityGroup(c *gin.Context) {
 			First(&vpc, "id = ?", request.VpcId); res.Error != nil {
 			return res.Error
 		}
+		if err := api.checkQuota(tx, vpc.OrganizationID, models.QuotaSecurityGroups); err != nil {
+			return err
+		}
 
 		sg = models.SecurityGroup{
 			VpcId:          vpc.ID,
@@ -280,8 +284,11 @@ func (api *API) CreateSecurityGroup(c *gin.Context) {
 	})
 
 	if err != nil {
+		var apiResponseError *ApiResponseError
 		if errors.Is(err, errUserNotFound) {
 			c.JSON(http.StatusNotFound, models.NewApiError(err))
+		} else if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
 		} else {
 			api.SendInternalServerError(c, err)
 		}
@@ -370,6 +377,12 @@ func (api *API) DeleteSecurityGroup(c *gin.Context) {
 			Update("security_group_id", nil); res.Error != nil {
 			return res.Error
 		}
+		// the devices joining the subnets using it fall back to the default security group of the VPC
+		if res := tx.Model(&models.Subnet{}).
+			Where("vpc_id = ? AND security_group_id = ?", sg.VpcId, sg.ID).
+			Update("security_group_id", nil); res.Error != nil {
+			return res.Error
+		}
 
 		return nil
 	})
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"gorm.io/gorm"
+)
+
+func validVpcTopology(topology string) bool {
+	return slices.Contains(models.VPCTopologies, topology)
+}
+
+// touchVpcDevices bumps the revision of all the devices of a VPC so that they are sent again to the
+// devices watching the VPC, with the peers they may have under its current topology.
+func touchVpcDevices(tx *gorm.DB, vpcId uuid.UUID) error {
+	return tx.Model(&models.Device{}).
+		Where("vpc_id = ?", vpcId).
+		Update("vpc_id", gorm.Expr("vpc_id")).Error
+}
+
+// requireVpcOwner returns a 403 with the given message unless the request is made by an owner of the organization
+// of the VPC rather than with a reg or device token.
+func (api *API) requireVpcOwner(c *gin.Context, tx *gorm.DB, tokenClaims *models.NexodusClaims, vpcId uuid.UUID, message string) error {
+	if isTokenClaims(tokenClaims) {
+		return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New(message)))
+	}
+	var owned int64
+	if res := api.VPCIsOwnedByCurrentUser(c, tx.Model(&models.VPC{})).
+		Where("id = ?", vpcId).Count(&owned); res.Error != nil {
+		return res.Error
+	}
+	if owned == 0 {
+		return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New(message)))
+	}
+	return nil
+}
+
+// topologyDeviceId returns the id of the device a device token was issued to, so that the devices
+// it is served are limited to its peers. Users are served every device of a VPC.
+func topologyDeviceId(claims *models.NexodusClaims) *uuid.UUID {
+	if claims == nil || claims.Scope != "device-token" {
+		return nil
+	}
+	id, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// topologyPeerFilter returns whether a device of a VPC may be served to the device with the given id:
+// the device itself and the devices it may peer with given the topology of the VPC. It returns nil
+// when every device may be served.
+func topologyPeerFilter(db *gorm.DB, vpcId uuid.UUID, deviceId *uuid.UUID) (func(device *models.Device) bool, error) {
+	if deviceId == nil {
+		return nil, nil
+	}
+	// the callers may pass the query of the devices they list
+	db = db.Session(&gorm.Session{NewDB: true})
+	var vpc models.VPC
+	if res := db.Select("topology").First(&vpc, "id = ?", vpcId); res.Error != nil {
+		return nil, res.Error
+	}
+	if vpc.Topology == "" || vpc.Topology == models.VPCTopologyFullMesh {
+		return nil, nil
+	}
+	var self models.Device
+	if res := db.First(&self, "id = ? AND (vpc_id = ? OR id IN (SELECT device_id FROM device_shares WHERE vpc_id = ?))", *deviceId, vpcId, vpcId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, res.Error
+	}
+	if self.VpcID != vpcId {
+		sharedDeviceView(&self)
+	}
+	return func(device *models.Device) bool {
+		return device.ID == self.ID || models.PeeringAllowed(vpc.Topology, &self, device)
+	}, nil
+}
+
+// filterDevicesByTopology drops the devices the device with the given id may not peer with.
+func filterDevicesByTopology(db *gorm.DB, vpcId uuid.UUID, deviceId *uuid.UUID, items deviceList) (deviceList, error) {
+	allowed, err := topologyPeerFilter(db, vpcId, deviceId)
+	if err != nil || allowed == nil {
+		return items, err
+	}
+	return slices.DeleteFunc(items, func(device *models.Device) bool {
+		return !allowed(device)
+	}), nil
+}
+
+// watchDevicesByTopology wraps the fetch of a device watch so that the devices the device with the given id
+// may not peer with are sent as deleted, which also removes them from its peers when the topology changes.
+func watchDevicesByTopology(fetch fetchmgr.FetchFn, vpcId uuid.UUID, deviceId *uuid.UUID) fetchmgr.FetchFn {
+	if deviceId == nil {
+		return fetch
+	}
+	return func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
+		list, err := fetch(db, gtRevision)
+		if err != nil || list.Len() == 0 {
+			return list, err
+		}
+		allowed, err := topologyPeerFilter(db, vpcId, deviceId)
+		if err != nil || allowed == nil {
+			return list, err
+		}
+		items := make(fetchmgr.ResourceItemList, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			item, id, revision, deletedAt := list.Item(i)
+			if !deletedAt.Valid {
+				device, err := watchedDevice(item)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed(device) {
+					deletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+				}
+			}
+			items = append(items, fetchmgr.ResourceItem{Item: item, Id: id, Revision: revision, DeletedAt: deletedAt})
+		}
+		return items, nil
+	}
+}
+
+// watchedDevice returns the device of a watch list item, which is decoded from JSON when it was cached in redis.
+func watchedDevice(item any) (*models.Device, error) {
+	if device, ok := item.(*models.Device); ok {
+		return device, nil
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var device models.Device
+	if err := json.Unmarshal(data, &device); err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestTopology() {
+	require := suite.Require()
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "topology",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.20.0/24",
+		Ipv6Cidr:       "fc00:b000::/20",
+		OrganizationID: suite.testUserID,
+		Topology:       models.VPCTopologyHubAndSpoke,
+	}, http.StatusCreated, &vpc)
+	vpcPath := fmt.Sprintf("/%s", vpc.ID)
+
+	devices := map[string]models.Device{}
+	for _, name := range []string{"hub", "spoke1", "spoke2"} {
+		var device models.Device
+		suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+			VpcID:     vpc.ID,
+			PublicKey: "topology" + name,
+		}, http.StatusCreated, &device)
+		devices[name] = device
+	}
+	hub, spoke1, spoke2 := devices["hub"], devices["spoke1"], devices["spoke2"]
+	yes, no, fullMesh := true, false, models.VPCTopologyFullMesh
+	suite.serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", hub.ID), suite.api.UpdateDevice, models.UpdateDevice{
+		Hub: &yes,
+	}, http.StatusOK, nil)
+
+	// a device can't make itself a hub or a relay to get around the topology
+	suite.claims = map[string]interface{}{"sub": suite.testUserID.String(), "scope": "device-token", "jti": spoke1.ID.String()}
+	spoke1Path := fmt.Sprintf("/%s", spoke1.ID)
+	suite.serve(http.MethodPatch, "/:id", spoke1Path, suite.api.UpdateDevice, models.UpdateDevice{
+		Hub: &yes,
+	}, http.StatusForbidden, nil)
+	suite.serve(http.MethodPatch, "/:id", spoke1Path, suite.api.UpdateDevice, models.UpdateDevice{
+		Relay: &yes,
+	}, http.StatusForbidden, nil)
+	// it can keep the relay setting it registered with
+	suite.serve(http.MethodPatch, "/:id", spoke1Path, suite.api.UpdateDevice, models.UpdateDevice{
+		Relay: &no,
+	}, http.StatusOK, nil)
+
+	// the revisions of the devices are set by a postgres trigger, the watches need them to reach their tail
+	for i, device := range []models.Device{hub, spoke1, spoke2} {
+		require.NoError(suite.api.db.Exec("UPDATE devices SET revision = ? WHERE id = ?", i+1, device.ID).Error)
+	}
+
+	// a spoke is only served the hubs
+	var listed []models.Device
+	suite.serve(http.MethodGet, "/:id/devices", vpcPath+"/devices", suite.api.ListDevicesInVPC, nil, http.StatusOK, &listed)
+	require.ElementsMatch([]uuid.UUID{spoke1.ID, hub.ID}, deviceIds(listed))
+	require.ElementsMatch([]uuid.UUID{spoke1.ID, hub.ID}, suite.watchDevicesInVPC(vpc.ID))
+
+	// users are served every device
+	suite.claims = nil
+	suite.serve(http.MethodGet, "/:id/devices", vpcPath+"/devices", suite.api.ListDevicesInVPC, nil, http.StatusOK, &listed)
+	require.ElementsMatch([]uuid.UUID{spoke1.ID, spoke2.ID, hub.ID}, deviceIds(listed))
+
+	// spokes are served each other once the VPC is a full mesh
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, models.UpdateVPC{
+		Topology: &fullMesh,
+	}, http.StatusOK, nil)
+	suite.claims = map[string]interface{}{"sub": suite.testUserID.String(), "scope": "device-token", "jti": spoke1.ID.String()}
+	suite.serve(http.MethodGet, "/:id/devices", vpcPath+"/devices", suite.api.ListDevicesInVPC, nil, http.StatusOK, &listed)
+	require.ElementsMatch([]uuid.UUID{spoke1.ID, spoke2.ID, hub.ID}, deviceIds(listed))
+	require.ElementsMatch([]uuid.UUID{spoke1.ID, spoke2.ID, hub.ID}, suite.watchDevicesInVPC(vpc.ID))
+}
+
+func deviceIds(devices []models.Device) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, device := range devices {
+		ids = append(ids, device.ID)
+	}
+	return ids
+}
+
+// watchDevicesInVPC watches the devices of a VPC until the watch reaches its tail, and returns the ids of
+// the devices it was sent.
+func (suite *HandlerTestSuite) watchDevicesInVPC(vpcId uuid.UUID) []uuid.UUID {
+	require := suite.Require()
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	body, err := json.Marshal([]models.Watch{{Kind: "device"}})
+	require.NoError(err)
+	_, res, err := suite.ServeRequestContext(ctx, http.MethodPost, "/:id/events", fmt.Sprintf("/%s/events", vpcId), suite.api.WatchEventsInVPC, bytes.NewBuffer(body))
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+
+	var ids []uuid.UUID
+	decoder := json.NewDecoder(res.Body)
+	for {
+		var event struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		}
+		err := decoder.Decode(&event)
+		if err == io.EOF || event.Type == "tail" {
+			return ids
+		}
+		require.NoError(err)
+		require.Equal("change", event.Type, string(event.Value))
+		var device models.Device
+		require.NoError(json.Unmarshal(event.Value, &device))
+		ids = append(ids, device.ID)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/nexodus-io/nexodus/internal/util"
 	"gorm.io/gorm/clause"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -34,6 +36,7 @@ const (
 // @Success      201  {object}  models.VPC
 // @Failure      400  {object}  models.BaseError
 // @Failure		 401  {object}  models.BaseError
+// @Failure		 403  {object}  models.QuotaExceededError
 // @Failure		 405  {object}  models.BaseError
 // @Failure      409  {object}  models.ConflictsError
 // @Failure		 429  {object}  models.BaseError
@@ -88,6 +91,13 @@ func (api *API) CreateVPC(c *gin.Context) {
 		return
 	}
 
+	if request.Topology == "" {
+		request.Topology = models.VPCTopologyFullMesh
+	} else if !validVpcTopology(request.Topology) {
+		c.JSON(http.StatusBadRequest, models.NewFieldValidationError("topology", fmt.Sprintf("must be one of: %s", strings.Join(models.VPCTopologies, ", "))))
+		return
+	}
+
 	var vpc models.VPC
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
 
@@ -96,6 +106,9 @@ func (api *API) CreateVPC(c *gin.Context) {
 			First(&org, "id = ?", request.OrganizationID.String()); res.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
 		}
+		if err := api.checkQuota(tx, org.ID, models.QuotaVpcs); err != nil {
+			return err
+		}
 
 		vpc = models.VPC{
 			OrganizationID: request.OrganizationID,
@@ -103,6 +116,7 @@ func (api *API) CreateVPC(c *gin.Context) {
 			PrivateCidr:    request.PrivateCidr,
 			Ipv4Cidr:       request.Ipv4Cidr,
 			Ipv6Cidr:       request.Ipv6Cidr,
+			Topology:       request.Topology,
 		}
 
 		if res := tx.
@@ -118,15 +132,15 @@ func (api *API) CreateVPC(c *gin.Context) {
 		if vpc.PrivateCidr {
 			ipamNamespace = vpc.ID
 		}
-		if err := api.ipam.CreateNamespace(ctx, ipamNamespace); err != nil {
+		if err := api.ipamTx(tx).CreateNamespace(ctx, ipamNamespace); err != nil {
 			return fmt.Errorf("failed to create namespace: %w", err)
 		}
 
-		if err := api.ipam.AssignCIDR(ctx, ipamNamespace, request.Ipv4Cidr); err != nil {
+		if err := api.ipamTx(tx).AssignCIDR(ctx, ipamNamespace, request.Ipv4Cidr); err != nil {
 			return fmt.Errorf("failed to assign IPv4 prefix: %w", err)
 		}
 
-		if err := api.ipam.AssignCIDR(ctx, ipamNamespace, request.Ipv6Cidr); err != nil {
+		if err := api.ipamTx(tx).AssignCIDR(ctx, ipamNamespace, request.Ipv6Cidr); err != nil {
 			return fmt.Errorf("failed to assign IPv6 prefix: %w", err)
 		}
 
@@ -296,38 +310,69 @@ func (api *API) DeleteVPC(c *gin.Context) {
 		return
 	}
 
-	// Cascade delete related records
-	if res := db.Where("vpc_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
-	if res := db.Where("vpc_id = ?", id).Delete(&models.SecurityGroup{}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
+	// the devices shared into the VPC learn that their share was revoked, and the peered VPCs that
+	// the devices of the VPC are gone, so the tombstones are written along with the deletions.
+	var peerings []models.VpcPeering
+	var revoked []models.DeviceShare
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// Cascade delete related records
+		if res := tx.Where("vpc_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Where("vpc_id = ?", id).Delete(&models.SecurityGroup{}); res.Error != nil {
+			return res.Error
+		}
 
-	result = db.Delete(&vpc)
-	if result.Error != nil {
-		api.SendInternalServerError(c, result.Error)
-		return
-	}
+		var shares []models.DeviceShare
+		if res := tx.Where("vpc_id = ?", id).Find(&shares); res.Error != nil {
+			return res.Error
+		}
+		revoked = nil
+		for _, share := range shares {
+			deviceShares, err := revokeDeviceShares(tx, share.DeviceID, &id)
+			if err != nil {
+				return err
+			}
+			revoked = append(revoked, deviceShares...)
+		}
 
-	ipamNamespace := defaultIPAMNamespace
-	if vpc.PrivateCidr {
-		ipamNamespace = vpc.ID
-	}
-	vpcCIDR := vpc.Ipv4Cidr
-	vpcCIDRV6 := vpc.Ipv6Cidr
-	if vpc.PrivateCidr {
-		if err := api.ipam.ReleaseCIDR(c.Request.Context(), ipamNamespace, vpcCIDR); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release ipam vpc prefix: %w", err))
-			return
+		if res := tx.Where("requester_vpc_id = ? OR accepter_vpc_id = ?", id, id).Find(&peerings); res.Error != nil {
+			return res.Error
+		}
+		for _, peering := range peerings {
+			if err := deleteVpcPeering(tx, peering); err != nil {
+				return err
+			}
 		}
 
-		if err := api.ipam.ReleaseCIDR(c.Request.Context(), ipamNamespace, vpcCIDRV6); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release ipam vpc prefix: %w", err))
-			return
+		// the reserved and excluded addresses are released before the prefixes of the VPC
+		if err := api.deleteVpcAddressing(ctx, tx, vpc); err != nil {
+			return err
 		}
+
+		if res := tx.Delete(&vpc); res.Error != nil {
+			return res.Error
+		}
+
+		if vpc.PrivateCidr {
+			ipamNamespace := vpcIPAMNamespace(vpc)
+			for _, cidr := range vpc.Cidrs() {
+				if err := api.ipamTx(tx).ReleaseCIDR(ctx, ipamNamespace, cidr); err != nil {
+					return fmt.Errorf("failed to release ipam vpc prefix: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+	for _, share := range revoked {
+		api.notifyDeviceShare(share)
+	}
+	for _, peering := range peerings {
+		api.notifyVpcPeering(peering)
 	}
 
 	c.JSON(http.StatusOK, vpc)
@@ -366,8 +411,13 @@ func (api *API) UpdateVPC(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
 		return
 	}
+	if request.Topology != nil && !validVpcTopology(*request.Topology) {
+		c.JSON(http.StatusBadRequest, models.NewFieldValidationError("topology", fmt.Sprintf("must be one of: %s", strings.Join(models.VPCTopologies, ", "))))
+		return
+	}
 
 	var vpc models.VPC
+	devicesTouched := false
 	err = api.transaction(ctx, func(tx *gorm.DB) error {
 
 		result := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", id)
@@ -379,6 +429,28 @@ func (api *API) UpdateVPC(c *gin.Context) {
 			vpc.Description = *request.Description
 		}
 
+		if request.Topology != nil && *request.Topology != vpc.Topology {
+			vpc.Topology = *request.Topology
+			devicesTouched = true
+			if err := touchVpcDevices(tx, vpc.ID); err != nil {
+				return err
+			}
+		}
+
+		if request.SecondaryCidrs != nil {
+			previous := slices.Clone(vpc.SecondaryCidrs)
+			if err := api.updateSecondaryCidrs(ctx, tx, &vpc, *request.SecondaryCidrs); err != nil {
+				return err
+			}
+			// devices relay traffic for all the CIDRs of the VPC, so they need to see the change
+			if !devicesTouched && !slices.Equal(previous, vpc.SecondaryCidrs) {
+				devicesTouched = true
+				if err := touchVpcDevices(tx, vpc.ID); err != nil {
+					return err
+				}
+			}
+		}
+
 		if res := tx.
 			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
 			Save(&vpc); res.Error != nil {
@@ -398,6 +470,9 @@ func (api *API) UpdateVPC(c *gin.Context) {
 	}
 
 	api.signalBus.Notify(fmt.Sprintf("/vpc=%s", vpc.ID.String()))
+	if devicesTouched {
+		api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", vpc.ID.String()))
+	}
 	c.JSON(http.StatusOK, vpc)
 }
 
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"gorm.io/gorm"
+)
+
+// updateSecondaryCidrs replaces the secondary CIDRs of a VPC. The added CIDRs are assigned as IPAM
+// prefixes after checking that they do not overlap the CIDRs of the VPC, the CIDRs its devices advertise
+// and the VPCs it is peered with. The removed CIDRs are released once no device, IP reservation, excluded
+// range or subnet uses them.
+func (api *API) updateSecondaryCidrs(ctx context.Context, tx *gorm.DB, vpc *models.VPC, requested []string) error {
+	var cidrs []string
+	for _, cidr := range requested {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Addr().Is4() {
+			err = util.ValidateIPv4Cidr(cidr)
+		} else if err == nil {
+			err = util.ValidateIPv6Cidr(cidr)
+		}
+		if err != nil {
+			return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("secondary_cidrs", err.Error()))
+		}
+		cidr = prefix.Masked().String()
+		if slices.Contains(cidrs, cidr) {
+			return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("secondary_cidrs", fmt.Sprintf("%s is listed more than once", cidr)))
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	var added, removed []string
+	for _, cidr := range cidrs {
+		if !slices.Contains(vpc.SecondaryCidrs, cidr) {
+			added = append(added, cidr)
+		}
+	}
+	for _, cidr := range vpc.SecondaryCidrs {
+		if !slices.Contains(cidrs, cidr) {
+			removed = append(removed, cidr)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if len(added) > 0 && !vpc.PrivateCidr {
+		return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("secondary_cidrs", "can only be added when private_cidr is enabled"))
+	}
+
+	for _, cidr := range removed {
+		if err := secondaryCidrUnused(tx, *vpc, cidr); err != nil {
+			return err
+		}
+	}
+
+	// the added CIDRs must not overlap the remaining CIDRs of the VPC, nor each other
+	existing := []string{vpc.Ipv4Cidr, vpc.Ipv6Cidr}
+	for _, cidr := range vpc.SecondaryCidrs {
+		if !slices.Contains(removed, cidr) {
+			existing = append(existing, cidr)
+		}
+	}
+	var devices []models.Device
+	if res := tx.Select("id", "advertise_cidrs").Where("vpc_id = ?", vpc.ID).Find(&devices); res.Error != nil {
+		return res.Error
+	}
+	for _, device := range devices {
+		for _, cidr := range device.AdvertiseCidrs {
+			if !util.IsDefaultIPRoute(cidr) {
+				existing = append(existing, cidr)
+			}
+		}
+	}
+	for _, cidr := range added {
+		prefix := netip.MustParsePrefix(cidr)
+		for _, other := range existing {
+			if p, err := netip.ParsePrefix(other); err == nil && p.Overlaps(prefix) {
+				return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("secondary_cidrs", fmt.Sprintf("%s overlaps %s", cidr, other)))
+			}
+		}
+		existing = append(existing, cidr)
+	}
+
+	updated := *vpc
+	updated.SecondaryCidrs = cidrs
+	var peerings []models.VpcPeering
+	if res := tx.Where("requester_vpc_id = ? OR accepter_vpc_id = ?", vpc.ID, vpc.ID).Find(&peerings); res.Error != nil {
+		return res.Error
+	}
+	for _, peering := range peerings {
+		var peer models.VPC
+		if res := tx.First(&peer, "id = ?", peering.PeerVpcID(vpc.ID)); res.Error != nil {
+			return res.Error
+		}
+		requester, accepter := updated, peer
+		if peering.AccepterVpcID == vpc.ID {
+			requester, accepter = peer, updated
+		}
+		if err := checkVpcPeeringOverlap(requester, accepter, peering.RequesterCidrs, peering.AccepterCidrs); err != nil {
+			return err
+		}
+	}
+
+	ipamNamespace := vpcIPAMNamespace(*vpc)
+	for _, cidr := range added {
+		if err := api.ipamTx(tx).AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
+			return fmt.Errorf("failed to assign the secondary prefix %s: %w", cidr, err)
+		}
+	}
+	for _, cidr := range removed {
+		if err := api.ipamTx(tx).ReleaseCIDR(ctx, ipamNamespace, cidr); err != nil {
+			return fmt.Errorf("failed to release the secondary prefix %s: %w", cidr, err)
+		}
+	}
+	vpc.SecondaryCidrs = cidrs
+	return nil
+}
+
+// secondaryCidrUnused checks that no device, IP reservation, excluded range or subnet of a VPC uses a CIDR.
+func secondaryCidrUnused(tx *gorm.DB, vpc models.VPC, cidr string) error {
+	prefix := netip.MustParsePrefix(cidr)
+	inUse := func(what string) error {
+		return NewApiResponseError(http.StatusBadRequest, models.NewNotAllowedError(fmt.Sprintf("secondary cidr %s cannot be removed while %s use it", cidr, what)))
+	}
+
+	var devices []models.Device
+	if res := tx.Select("id", "ipv4_tunnel_ips", "ipv6_tunnel_ips").Where("vpc_id = ?", vpc.ID).Find(&devices); res.Error != nil {
+		return res.Error
+	}
+	for _, device := range devices {
+		for _, ip := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+			if address, err := netip.ParseAddr(ip.Address); err == nil && prefix.Contains(address) {
+				return inUse("devices")
+			}
+		}
+	}
+
+	var reservations []models.IPReservation
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&reservations); res.Error != nil {
+		return res.Error
+	}
+	for _, reservation := range reservations {
+		if address, err := netip.ParseAddr(reservation.Address); err == nil && prefix.Contains(address) {
+			return inUse("ip reservations")
+		}
+	}
+
+	var ranges []models.ExcludedRange
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&ranges); res.Error != nil {
+		return res.Error
+	}
+	for _, excluded := range ranges {
+		if r, err := netip.ParsePrefix(excluded.Cidr); err == nil && prefix.Overlaps(r) {
+			return inUse("excluded ranges")
+		}
+	}
+
+	var subnets []models.Subnet
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&subnets); res.Error != nil {
+		return res.Error
+	}
+	for _, subnet := range subnets {
+		for _, cidr := range []string{subnet.Ipv4Cidr, subnet.Ipv6Cidr} {
+			if r, err := netip.ParsePrefix(cidr); err == nil && prefix.Overlaps(r) {
+				return inUse("subnets")
+			}
+		}
+	}
+	return nil
+}
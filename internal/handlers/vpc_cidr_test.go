@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestSecondaryCidrs() {
+	require := suite.Require()
+
+	secondaryCidrs := func(cidrs ...string) models.UpdateVPC {
+		cidrs = append([]string{}, cidrs...)
+		return models.UpdateVPC{SecondaryCidrs: &cidrs}
+	}
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "secondary cidrs",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.8.0/30",
+		Ipv6Cidr:       "fc00:8000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+	vpcPath := fmt.Sprintf("/%s", vpc.ID)
+
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("10.1.8.0/24"), http.StatusBadRequest, nil)
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("10.1.9.0/24", "10.1.9.0/24"), http.StatusBadRequest, nil)
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("10.1.9.0/24", "10.1.9.128/25"), http.StatusBadRequest, nil)
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("notacidr"), http.StatusBadRequest, nil)
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("10.1.9.7/24", "fc00:9000::/20"), http.StatusOK, &vpc)
+	require.Equal([]string{"10.1.9.0/24", "fc00:9000::/20"}, []string(vpc.SecondaryCidrs))
+
+	// devices are assigned addresses from the secondary CIDR once the primary one is exhausted
+	var addresses []string
+	for i := 0; i < 3; i++ {
+		var device models.Device
+		suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+			VpcID:     vpc.ID,
+			PublicKey: fmt.Sprintf("asecondarycidrpubkey%d", i),
+		}, http.StatusCreated, &device)
+		addresses = append(addresses, device.IPv4TunnelIPs[0].Address)
+		if i == 2 {
+			require.Equal("10.1.9.0/24", device.IPv4TunnelIPs[0].CIDR)
+		}
+	}
+	require.Equal([]string{"10.1.8.1", "10.1.8.2", "10.1.9.1"}, addresses)
+
+	// a secondary CIDR is only removed when nothing uses it
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs(), http.StatusBadRequest, nil)
+	suite.serve(http.MethodPatch, "/:id", vpcPath, suite.api.UpdateVPC, secondaryCidrs("10.1.9.0/24"), http.StatusOK, &vpc)
+	require.Equal([]string{"10.1.9.0/24"}, []string(vpc.SecondaryCidrs))
+}
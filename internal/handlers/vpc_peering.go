@@ -0,0 +1,578 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// VpcPeeringIsReadableByCurrentUser limits VPC peerings to the ones with a VPC of an organization the current user is a member of.
+func (api *API) VpcPeeringIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	newDb := db.Session(&gorm.Session{NewDB: true})
+	return db.Where("(requester_vpc_id IN (?) OR accepter_vpc_id IN (?))",
+		api.VPCIsReadableByCurrentUser(c, newDb.Model(&models.VPC{}).Select("id")),
+		api.VPCIsReadableByCurrentUser(c, newDb.Model(&models.VPC{}).Select("id")),
+	)
+}
+
+// ListVpcPeerings lists the VPC peerings
+// @Summary      List VPC Peerings
+// @Id  		 ListVpcPeerings
+// @Tags         VPC
+// @Description  Lists the peerings of the VPCs of the organizations the user is a member of
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.VpcPeering
+// @Failure		 401  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpc-peerings [get]
+func (api *API) ListVpcPeerings(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListVpcPeerings")
+	defer span.End()
+	var peerings []models.VpcPeering
+
+	db := api.db.WithContext(ctx)
+	db = api.VpcPeeringIsReadableByCurrentUser(c, db)
+	db = FilterAndPaginate(db, &models.VpcPeering{}, c, "created_at")
+	result := db.Find(&peerings)
+	if result.Error != nil {
+		api.SendInternalServerError(c, result.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, peerings)
+}
+
+// GetVpcPeering gets a VPC peering
+// @Summary      Get VPC Peering
+// @Id  		 GetVpcPeering
+// @Tags         VPC
+// @Description  Gets a VPC peering by ID
+// @Param        id   path   string  true  "VPC Peering ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.VpcPeering
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpc-peerings/{id} [get]
+func (api *API) GetVpcPeering(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetVpcPeering", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var peering models.VpcPeering
+	db := api.db.WithContext(ctx)
+	result := api.VpcPeeringIsReadableByCurrentUser(c, db).
+		First(&peering, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc_peering"))
+		} else {
+			api.SendInternalServerError(c, result.Error)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, peering)
+}
+
+// CreateVpcPeering requests the peering of a VPC with another VPC
+// @Summary      Request VPC Peering
+// @Id  		 CreateVpcPeering
+// @Tags         VPC
+// @Description  Requests the peering of a VPC with another VPC, of the same or of another organization. The peering is pending until an owner of the other VPC accepts it.
+// @Param        peering  body   models.AddVpcPeering  true  "Add VPC Peering"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.VpcPeering
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpc-peerings [post]
+func (api *API) CreateVpcPeering(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateVpcPeering")
+	defer span.End()
+
+	var request models.AddVpcPeering
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.RequesterVpcID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("requester_vpc_id"))
+		return
+	}
+	if request.AccepterVpcID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("accepter_vpc_id"))
+		return
+	}
+
+	var peering models.VpcPeering
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		// peering lets the devices of the VPCs reach each other, so it can not be done with reg or device tokens.
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("vpc peerings can only be requested by users")))
+		}
+
+		var requester models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).
+			First(&requester, "id = ?", request.RequesterVpcID); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("requester_vpc_id"))
+		}
+		// the accepter VPC may be of an organization the user is not a member of, an owner of it accepts the peering.
+		var accepter models.VPC
+		if res := tx.First(&accepter, "id = ?", request.AccepterVpcID); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("accepter_vpc_id"))
+		}
+		if requester.ID == accepter.ID {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("accepter_vpc_id", "a vpc can not be peered with itself"))
+		}
+		if err := checkVpcPeeringExport(tx, requester, request.Devices, request.Cidrs); err != nil {
+			return err
+		}
+		if err := checkVpcPeeringOverlap(requester, accepter, request.Cidrs, nil); err != nil {
+			return err
+		}
+
+		var existing int64
+		if res := tx.Model(&models.VpcPeering{}).
+			Where("(requester_vpc_id = ? AND accepter_vpc_id = ?) OR (requester_vpc_id = ? AND accepter_vpc_id = ?)",
+				requester.ID, accepter.ID, accepter.ID, requester.ID).
+			Count(&existing); res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(accepter.ID.String()))
+		}
+
+		peering = models.VpcPeering{
+			RequesterVpcID:          requester.ID,
+			RequesterOrganizationID: requester.OrganizationID,
+			RequesterDevices:        request.Devices,
+			RequesterCidrs:          request.Cidrs,
+			AccepterVpcID:           accepter.ID,
+			AccepterOrganizationID:  accepter.OrganizationID,
+			Status:                  models.VpcPeeringPending,
+		}
+		return tx.Create(&peering).Error
+	})
+	if err != nil {
+		api.sendVpcPeeringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, peering)
+}
+
+// AcceptVpcPeering accepts the peering of a VPC with another VPC
+// @Summary      Accept VPC Peering
+// @Id  		 AcceptVpcPeering
+// @Tags         VPC
+// @Description  Accepts a pending VPC peering, by an owner of the accepter VPC, which exports the given devices and CIDRs of the accepter VPC to the requester VPC
+// @Param        id       path   string                   true  "VPC Peering ID"
+// @Param        peering  body   models.AcceptVpcPeering  true  "Accept VPC Peering"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.VpcPeering
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpc-peerings/{id}/accept [post]
+func (api *API) AcceptVpcPeering(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "AcceptVpcPeering", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AcceptVpcPeering
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	var peering models.VpcPeering
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("vpc peerings can only be accepted by users")))
+		}
+
+		if res := tx.First(&peering, "id = ?", id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_peering"))
+			}
+			return res.Error
+		}
+		var accepter models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).
+			First(&accepter, "id = ?", peering.AccepterVpcID); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_peering"))
+		}
+		if peering.Status != models.VpcPeeringPending {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("status", "the vpc peering was already accepted"))
+		}
+		var requester models.VPC
+		if res := tx.First(&requester, "id = ?", peering.RequesterVpcID); res.Error != nil {
+			return res.Error
+		}
+		if err := checkVpcPeeringExport(tx, accepter, request.Devices, request.Cidrs); err != nil {
+			return err
+		}
+		if err := checkVpcPeeringOverlap(requester, accepter, peering.RequesterCidrs, request.Cidrs); err != nil {
+			return err
+		}
+
+		peering.AccepterDevices = request.Devices
+		peering.AccepterCidrs = request.Cidrs
+		peering.Status = models.VpcPeeringActive
+		if res := tx.Save(&peering); res.Error != nil {
+			return res.Error
+		}
+
+		// the exported devices are sent to the watchers of the other VPC
+		for _, vpcId := range []uuid.UUID{peering.RequesterVpcID, peering.AccepterVpcID} {
+			if res := tx.Where("vpc_id = ? AND device_id IN (SELECT id FROM devices WHERE vpc_id = ?)", vpcId, peering.PeerVpcID(vpcId)).
+				Delete(&models.DeviceTombstone{}); res.Error != nil {
+				return res.Error
+			}
+			if err := touchVpcDevices(tx, vpcId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		api.sendVpcPeeringError(c, err)
+		return
+	}
+
+	api.notifyVpcPeering(peering)
+	c.JSON(http.StatusOK, peering)
+}
+
+// DeleteVpcPeering deletes a VPC peering
+// @Summary      Delete VPC Peering
+// @Id  		 DeleteVpcPeering
+// @Tags         VPC
+// @Description  Deletes a VPC peering, by an owner of either VPC, which removes the devices each VPC exported from the other VPC
+// @Param        id   path   string  true  "VPC Peering ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.VpcPeering
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpc-peerings/{id} [delete]
+func (api *API) DeleteVpcPeering(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteVpcPeering", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var peering models.VpcPeering
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("vpc peerings can only be deleted by users")))
+		}
+
+		if res := tx.First(&peering, "id = ?", id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_peering"))
+			}
+			return res.Error
+		}
+		var allowed int64
+		if res := api.VPCIsOwnedByCurrentUser(c, tx.Model(&models.VPC{})).
+			Where("id IN (?, ?)", peering.RequesterVpcID, peering.AccepterVpcID).
+			Count(&allowed); res.Error != nil {
+			return res.Error
+		}
+		if allowed == 0 {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_peering"))
+		}
+
+		return deleteVpcPeering(tx, peering)
+	})
+	if err != nil {
+		api.sendVpcPeeringError(c, err)
+		return
+	}
+
+	api.notifyVpcPeering(peering)
+	c.JSON(http.StatusOK, peering)
+}
+
+// deleteVpcPeering deletes a VPC peering. Tombstones send the deletion of the devices each VPC exported
+// to the watchers of the other VPC.
+func deleteVpcPeering(tx *gorm.DB, peering models.VpcPeering) error {
+	if res := tx.Delete(&peering); res.Error != nil {
+		return res.Error
+	}
+	if peering.Status != models.VpcPeeringActive {
+		return nil
+	}
+	for _, vpcId := range []uuid.UUID{peering.RequesterVpcID, peering.AccepterVpcID} {
+		var devices []*models.Device
+		if res := tx.Where("vpc_id = ?", vpcId).Find(&devices); res.Error != nil {
+			return res.Error
+		}
+		export := newVpcPeeringExport(peering.Exports(vpcId))
+		for _, device := range devices {
+			if exported, _ := export.exports(device); !exported {
+				continue
+			}
+			if res := tx.Save(&models.DeviceTombstone{DeviceID: device.ID, VpcID: peering.PeerVpcID(vpcId)}); res.Error != nil {
+				return res.Error
+			}
+		}
+	}
+	return nil
+}
+
+// checkVpcPeeringExport checks that the devices a VPC exports are devices of the VPC and that the CIDRs it
+// exports are valid.
+func checkVpcPeeringExport(tx *gorm.DB, vpc models.VPC, devices []string, cidrs []string) error {
+	for _, id := range devices {
+		deviceId, err := uuid.Parse(id)
+		if err != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("devices", fmt.Sprintf("%s is not a valid device id", id)))
+		}
+		var count int64
+		if res := tx.Model(&models.Device{}).Where("id = ? AND vpc_id = ?", deviceId, vpc.ID).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count == 0 {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("devices", fmt.Sprintf("device %s is not in vpc %s", id, vpc.ID)))
+		}
+	}
+	for _, cidr := range cidrs {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidrs", fmt.Sprintf("%s is not a valid cidr", cidr)))
+		}
+	}
+	return nil
+}
+
+// checkVpcPeeringOverlap checks that the addresses the peered VPCs see of each other are not ambiguous. VPCs
+// using the shared CG-NAT address space never overlap since their devices get unique tunnel IPs, VPCs with a
+// private CIDR must not overlap the other VPC. The exported CIDRs outside the CIDRs of their VPC are routes,
+// which must not overlap the other VPC or the routes it exports.
+func checkVpcPeeringOverlap(requester, accepter models.VPC, requesterCidrs, accepterCidrs []string) error {
+	requesterVpcCidrs := vpcPrefixes(requester)
+	accepterVpcCidrs := vpcPrefixes(accepter)
+	if requester.PrivateCidr || accepter.PrivateCidr {
+		if overlappingPrefix(requesterVpcCidrs, accepterVpcCidrs) != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("accepter_vpc_id", "the cidrs of the vpcs overlap"))
+		}
+	}
+	requesterRoutes := exportedRoutes(requesterVpcCidrs, requesterCidrs)
+	accepterRoutes := exportedRoutes(accepterVpcCidrs, accepterCidrs)
+	if p := overlappingPrefix(requesterRoutes, accepterVpcCidrs); p != nil {
+		return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidrs", fmt.Sprintf("%s overlaps the cidrs of vpc %s", p, accepter.ID)))
+	}
+	if p := overlappingPrefix(accepterRoutes, requesterVpcCidrs); p != nil {
+		return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidrs", fmt.Sprintf("%s overlaps the cidrs of vpc %s", p, requester.ID)))
+	}
+	if p := overlappingPrefix(accepterRoutes, requesterRoutes); p != nil {
+		return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("cidrs", fmt.Sprintf("%s overlaps the cidrs exported by vpc %s", p, requester.ID)))
+	}
+	return nil
+}
+
+func vpcPrefixes(vpc models.VPC) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, cidr := range vpc.Cidrs() {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		}
+	}
+	return prefixes
+}
+
+// exportedRoutes returns the exported CIDRs that are not within the CIDRs of their VPC.
+func exportedRoutes(vpcCidrs []netip.Prefix, cidrs []string) []netip.Prefix {
+	var routes []netip.Prefix
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		prefix = prefix.Masked()
+		if !prefixesContain(vpcCidrs, prefix) {
+			routes = append(routes, prefix)
+		}
+	}
+	return routes
+}
+
+// overlappingPrefix returns the first prefix of a that overlaps a prefix of b, or nil.
+func overlappingPrefix(a, b []netip.Prefix) *netip.Prefix {
+	for _, pa := range a {
+		for _, pb := range b {
+			if pa.Overlaps(pb) {
+				return &pa
+			}
+		}
+	}
+	return nil
+}
+
+// prefixesContain returns whether one of the prefixes contains the prefix.
+func prefixesContain(prefixes []netip.Prefix, prefix netip.Prefix) bool {
+	for _, p := range prefixes {
+		if p.Addr().Is4() == prefix.Addr().Is4() && p.Bits() <= prefix.Bits() && p.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcPeeringExport is what a VPC exports to the other VPC of a peering.
+type vpcPeeringExport struct {
+	devices []string
+	cidrs   []netip.Prefix
+}
+
+func newVpcPeeringExport(devices []string, cidrs []string) vpcPeeringExport {
+	export := vpcPeeringExport{devices: devices}
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			export.cidrs = append(export.cidrs, prefix.Masked())
+		}
+	}
+	return export
+}
+
+// exports returns whether a device is exported, and its approved routes within the exported CIDRs. A device
+// is exported when it is listed, when one of its tunnel IPs is within the exported CIDRs, or when it routes
+// an exported CIDR.
+func (e vpcPeeringExport) exports(device *models.Device) (bool, []string) {
+	exported := slices.Contains(e.devices, device.ID.String())
+	for _, ip := range slices.Concat(device.IPv4TunnelIPs, device.IPv6TunnelIPs) {
+		if addr, err := netip.ParseAddr(ip.Address); err == nil && prefixesContain(e.cidrs, netip.PrefixFrom(addr, addr.BitLen())) {
+			exported = true
+		}
+	}
+	var routes []string
+	for _, cidr := range device.ApprovedCidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefixesContain(e.cidrs, prefix.Masked()) {
+			routes = append(routes, cidr)
+		}
+	}
+	return exported || len(routes) > 0, routes
+}
+
+// activeVpcPeerings returns the accepted peerings of a VPC.
+func activeVpcPeerings(db *gorm.DB, vpcId uuid.UUID) ([]models.VpcPeering, error) {
+	var peerings []models.VpcPeering
+	if res := db.Where("(requester_vpc_id = ? OR accepter_vpc_id = ?) AND status = ?", vpcId, vpcId, models.VpcPeeringActive).
+		Find(&peerings); res.Error != nil {
+		return nil, res.Error
+	}
+	return peerings, nil
+}
+
+// peeredDevices returns the devices of the VPCs peered with a VPC, the query of the devices being narrowed by db,
+// which must be a session that can be reused.
+// The exported devices are limited to sharedDeviceView with their exported routes, the others are returned as
+// deleted devices so that the watchers of the VPC drop the devices that are no longer exported. The devices
+// shared into the VPC are left to devicesOfVpc.
+func peeredDevices(db *gorm.DB, vpcId uuid.UUID) (deviceList, error) {
+	peerings, err := activeVpcPeerings(db.Session(&gorm.Session{NewDB: true}), vpcId)
+	if err != nil || len(peerings) == 0 {
+		return nil, err
+	}
+	var items deviceList
+	for _, peering := range peerings {
+		peerVpcId := peering.PeerVpcID(vpcId)
+		export := newVpcPeeringExport(peering.Exports(peerVpcId))
+		var devices deviceList
+		result := db.Where("vpc_id = ? AND id NOT IN (SELECT device_id FROM device_shares WHERE vpc_id = ?)", peerVpcId, vpcId).
+			Find(&devices)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		for _, device := range devices {
+			exported, routes := export.exports(device)
+			if device.DeletedAt.Valid || !exported {
+				d := &models.Device{VpcID: device.VpcID, Revision: device.Revision}
+				d.ID = device.ID
+				d.DeletedAt = gorm.DeletedAt{Time: device.UpdatedAt, Valid: true}
+				items = append(items, d)
+				continue
+			}
+			sharedDeviceView(device)
+			device.AdvertiseCidrs = routes
+			device.ApprovedCidrs = routes
+			items = append(items, device)
+		}
+	}
+	return items, nil
+}
+
+// notifyVpcPeering wakes the watchers of the devices of both VPCs of a peering.
+func (api *API) notifyVpcPeering(peering models.VpcPeering) {
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", peering.RequesterVpcID.String()))
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", peering.AccepterVpcID.String()))
+}
+
+func (api *API) sendVpcPeeringError(c *gin.Context, err error) {
+	var apiResponseError *ApiResponseError
+	if errors.As(err, &apiResponseError) {
+		c.JSON(apiResponseError.Status, apiResponseError.Body)
+	} else {
+		api.SendInternalServerError(c, err)
+	}
+}
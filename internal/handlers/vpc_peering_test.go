@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *HandlerTestSuite) TestVpcPeering() {
+	require := suite.Require()
+
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:          suite.testUserID,
+		PublicKey:      "apeeredpubkey",
+		AdvertiseCidrs: []string{"172.16.30.0/24"},
+	}, http.StatusCreated, &device)
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "peering-target",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVpcPeering, models.AddVpcPeering{
+		RequesterVpcID: vpc.ID,
+		AccepterVpcID:  vpc.ID,
+	}, http.StatusUnprocessableEntity, nil)
+
+	var peering models.VpcPeering
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVpcPeering, models.AddVpcPeering{
+		RequesterVpcID: suite.testUserID,
+		AccepterVpcID:  vpc.ID,
+		Devices:        []string{device.ID.String()},
+		Cidrs:          []string{"172.16.30.0/24"},
+	}, http.StatusCreated, &peering)
+	require.Equal(models.VpcPeeringPending, peering.Status)
+
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVpcPeering, models.AddVpcPeering{
+		RequesterVpcID: vpc.ID,
+		AccepterVpcID:  suite.testUserID,
+	}, http.StatusConflict, nil)
+
+	// the devices are only exported once the peering is accepted
+	devicesPath := fmt.Sprintf("/%s/devices", vpc.ID)
+	var devices []models.Device
+	suite.serve(http.MethodGet, "/:id/devices", devicesPath, suite.api.ListDevicesInVPC, nil, http.StatusOK, &devices)
+	require.Empty(devices)
+
+	peeringPath := fmt.Sprintf("/%s", peering.ID)
+	suite.serve(http.MethodPost, "/:id/accept", peeringPath+"/accept", suite.api.AcceptVpcPeering, models.AcceptVpcPeering{}, http.StatusOK, &peering)
+	require.Equal(models.VpcPeeringActive, peering.Status)
+	suite.serve(http.MethodPost, "/:id/accept", peeringPath+"/accept", suite.api.AcceptVpcPeering, models.AcceptVpcPeering{}, http.StatusUnprocessableEntity, nil)
+
+	var peerings []models.VpcPeering
+	suite.serve(http.MethodGet, "/", "/", suite.api.ListVpcPeerings, nil, http.StatusOK, &peerings)
+	require.Len(peerings, 1)
+
+	// the device is listed in the peered VPC with its own VPC id and its exported routes
+	suite.serve(http.MethodGet, "/:id/devices", devicesPath, suite.api.ListDevicesInVPC, nil, http.StatusOK, &devices)
+	require.Len(devices, 1)
+	require.Equal(device.ID, devices[0].ID)
+	require.Equal(suite.testUserID, devices[0].VpcID)
+	require.Equal([]string(device.ApprovedCidrs), []string(devices[0].ApprovedCidrs))
+
+	suite.serve(http.MethodDelete, "/:id", peeringPath, suite.api.DeleteVpcPeering, nil, http.StatusOK, nil)
+
+	// the watchers of the peered VPC are sent the deletion of the device
+	suite.serve(http.MethodGet, "/:id/devices", devicesPath, suite.api.ListDevicesInVPC, nil, http.StatusOK, &devices)
+	require.Empty(devices)
+	tombstones, err := deviceTombstones(suite.api.db, vpc.ID, 0)
+	require.NoError(err)
+	require.Len(tombstones, 1)
+	require.Equal(device.ID, tombstones[0].ID)
+}
+
+func TestCheckVpcPeeringOverlap(t *testing.T) {
+	shared := models.VPC{Ipv4Cidr: "100.64.0.0/10", Ipv6Cidr: "200::/64"}
+	private := func(cidr string) models.VPC {
+		return models.VPC{PrivateCidr: true, Ipv4Cidr: cidr}
+	}
+
+	assert.NoError(t, checkVpcPeeringOverlap(shared, shared, nil, nil))
+	assert.Error(t, checkVpcPeeringOverlap(private("10.1.0.0/16"), private("10.1.2.0/24"), nil, nil))
+	assert.NoError(t, checkVpcPeeringOverlap(private("10.1.0.0/16"), private("10.2.0.0/16"), []string{"10.1.2.0/24"}, nil))
+	assert.Error(t, checkVpcPeeringOverlap(private("10.1.0.0/16"), private("10.2.0.0/16"), []string{"10.2.2.0/24"}, nil))
+	assert.Error(t, checkVpcPeeringOverlap(shared, shared, []string{"172.16.0.0/16"}, []string{"172.16.1.0/24"}))
+	assert.Error(t, checkVpcPeeringOverlap(shared, shared, []string{"100.127.0.0/16", "172.16.0.0/16"}, []string{"172.16.1.0/24"}))
+	assert.NoError(t, checkVpcPeeringOverlap(shared, shared, []string{"172.16.0.0/16"}, []string{"172.17.0.0/16"}))
+}
+
+func TestVpcPeeringExport(t *testing.T) {
+	device := &models.Device{
+		IPv4TunnelIPs: []models.TunnelIP{{Address: "100.64.0.5"}},
+		ApprovedCidrs: []string{"172.16.1.0/24", "172.17.0.0/16"},
+	}
+
+	exported, routes := newVpcPeeringExport(nil, []string{"100.64.0.0/24"}).exports(device)
+	assert.True(t, exported)
+	assert.Empty(t, routes)
+
+	exported, routes = newVpcPeeringExport(nil, []string{"172.16.0.0/16"}).exports(device)
+	assert.True(t, exported)
+	assert.Equal(t, []string{"172.16.1.0/24"}, routes)
+
+	exported, _ = newVpcPeeringExport(nil, []string{"100.64.1.0/24", "172.17.1.0/24"}).exports(device)
+	assert.False(t, exported)
+
+	exported, _ = newVpcPeeringExport([]string{device.ID.String()}, nil).exports(device)
+	assert.True(t, exported)
+}
@@ -0,0 +1,662 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/ipam"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// maxSubnetAcquireAttempts is the number of addresses tried in IPAM when assigning an address to a device
+// of a VPC with subnets, before the range is considered exhausted.
+const maxSubnetAcquireAttempts = 4096
+
+type subnetList []*models.Subnet
+
+func (d subnetList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.ID.String(), 0, item.DeletedAt
+}
+
+func (d subnetList) Len() int {
+	return len(d)
+}
+
+// ListSubnets lists the subnets of a VPC
+// @Summary      List Subnets
+// @Id  		 ListSubnets
+// @Tags         VPC
+// @Description  Lists the subnets of a VPC
+// @Param        id   path   string  true  "VPC ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  []models.Subnet
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/subnets [get]
+func (api *API) ListSubnets(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListSubnets", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	if res := api.VPCIsReadableByCurrentUser(c, db).First(&vpc, "id = ?", vpcId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		var items subnetList
+		db = db.Where("vpc_id = ?", vpcId)
+		db = FilterAndPaginate(db, &models.Subnet{}, c, "name")
+		result := db.Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// GetSubnet gets a subnet of a VPC
+// @Summary      Get Subnet
+// @Id  		 GetSubnet
+// @Tags         VPC
+// @Description  Gets a subnet of a VPC by ID
+// @Param        id         path   string  true  "VPC ID"
+// @Param        subnet_id  path   string  true  "Subnet ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.Subnet
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/subnets/{subnet_id} [get]
+func (api *API) GetSubnet(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetSubnet", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("subnet_id", c.Param("subnet_id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	subnetId, err := uuid.Parse(c.Param("subnet_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("subnet_id"))
+		return
+	}
+
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	if res := api.VPCIsReadableByCurrentUser(c, db).First(&vpc, "id = ?", vpcId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	var subnet models.Subnet
+	if res := db.First(&subnet, "id = ? AND vpc_id = ?", subnetId, vpc.ID); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("subnet"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, subnet)
+}
+
+// CreateSubnet adds a subnet to a VPC
+// @Summary      Add Subnet
+// @Id  		 CreateSubnet
+// @Tags         VPC
+// @Description  Adds a named slice of the CIDRs of a VPC with a private CIDR. The devices joining the subnet are assigned addresses from it, and the other devices of the VPC are assigned addresses outside of it.
+// @Param        id      path   string            true  "VPC ID"
+// @Param        subnet  body   models.AddSubnet  true  "Add Subnet"
+// @Accept	     json
+// @Produce      json
+// @Success      201  {object}  models.Subnet
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/subnets [post]
+func (api *API) CreateSubnet(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateSubnet", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddSubnet
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.Name == "" {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("name"))
+		return
+	}
+	if request.Ipv4Cidr == "" && request.Ipv6Cidr == "" {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("ipv4_cidr", "at least one of ipv4_cidr and ipv6_cidr must be set"))
+		return
+	}
+	var prefixes []netip.Prefix
+	for _, field := range []struct {
+		name  string
+		cidr  *string
+		ipv4  bool
+		label string
+	}{
+		{"ipv4_cidr", &request.Ipv4Cidr, true, "IPv4"},
+		{"ipv6_cidr", &request.Ipv6Cidr, false, "IPv6"},
+	} {
+		if *field.cidr == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(*field.cidr)
+		if err != nil || prefix.Addr().Is4() != field.ipv4 {
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError(field.name, fmt.Sprintf("%s is not a valid %s cidr", *field.cidr, field.label)))
+			return
+		}
+		prefix = prefix.Masked()
+		*field.cidr = prefix.String()
+		prefixes = append(prefixes, prefix)
+	}
+
+	var subnet models.Subnet
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("subnets can only be managed by users")))
+		}
+
+		var vpc models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		// the shared CG-NAT address space is shared by the VPCs, so only VPCs with a private CIDR can be sliced
+		if !vpc.PrivateCidr {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("ipv4_cidr", "only vpcs with a private cidr can have subnets"))
+		}
+
+		var existing []models.Subnet
+		if res := tx.Where("vpc_id = ?", vpc.ID).Find(&existing); res.Error != nil {
+			return res.Error
+		}
+		for _, other := range existing {
+			if other.Name == request.Name {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(other.ID.String()))
+			}
+		}
+		for _, prefix := range prefixes {
+			field := "ipv6_cidr"
+			if prefix.Addr().Is4() {
+				field = "ipv4_cidr"
+			}
+			vpcCidr, ok := vpcCidrOf(vpc, prefix.Addr())
+			if !ok || !prefixesContain([]netip.Prefix{netip.MustParsePrefix(vpcCidr).Masked()}, prefix) {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, "the subnet is not in the cidrs of the vpc"))
+			}
+			for _, other := range existing {
+				for _, cidr := range []string{other.Ipv4Cidr, other.Ipv6Cidr} {
+					if p, err := netip.ParsePrefix(cidr); err == nil && p.Overlaps(prefix) {
+						return NewApiResponseError(http.StatusConflict, models.NewConflictsError(other.ID.String()))
+					}
+				}
+			}
+		}
+
+		// the devices already in the VPC are not in the subnet, so they must not hold addresses of it
+		var devices []models.Device
+		if res := tx.Select("id", "ipv4_tunnel_ips", "ipv6_tunnel_ips").Where("vpc_id = ?", vpc.ID).Find(&devices); res.Error != nil {
+			return res.Error
+		}
+		for _, device := range devices {
+			for _, ip := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+				if address, err := netip.ParseAddr(ip.Address); err == nil && prefixesContainAddr(prefixes, address) {
+					return NewApiResponseError(http.StatusConflict, models.NewConflictsError(device.ID.String()))
+				}
+			}
+		}
+
+		if err := validateSubnetSettings(tx, vpc, request.SecurityGroupId, request.RelayDeviceId, request.DnsServers); err != nil {
+			return err
+		}
+
+		subnet = models.Subnet{
+			VpcID:            vpc.ID,
+			OrganizationID:   vpc.OrganizationID,
+			Name:             request.Name,
+			Description:      request.Description,
+			Ipv4Cidr:         request.Ipv4Cidr,
+			Ipv6Cidr:         request.Ipv6Cidr,
+			SecurityGroupId:  request.SecurityGroupId,
+			RelayDeviceId:    request.RelayDeviceId,
+			DnsServers:       request.DnsServers,
+			DnsSearchDomains: request.DnsSearchDomains,
+		}
+		if res := tx.Create(&subnet); res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subnet)
+}
+
+// UpdateSubnet updates a subnet of a VPC
+// @Summary      Update Subnet
+// @Id  		 UpdateSubnet
+// @Tags         VPC
+// @Description  Updates the settings of a subnet of a VPC. The security group only applies to the devices joining the subnet afterwards.
+// @Param        id         path   string               true  "VPC ID"
+// @Param        subnet_id  path   string               true  "Subnet ID"
+// @Param        update     body   models.UpdateSubnet  true  "Subnet Update"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.Subnet
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/subnets/{subnet_id} [patch]
+func (api *API) UpdateSubnet(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateSubnet", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("subnet_id", c.Param("subnet_id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	subnetId, err := uuid.Parse(c.Param("subnet_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("subnet_id"))
+		return
+	}
+
+	var request models.UpdateSubnet
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	var subnet models.Subnet
+	var vpc models.VPC
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("subnets can only be managed by users")))
+		}
+
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		if res := tx.First(&subnet, "id = ? AND vpc_id = ?", subnetId, vpc.ID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("subnet"))
+			}
+			return res.Error
+		}
+		if err := validateSubnetSettings(tx, vpc, request.SecurityGroupId, request.RelayDeviceId, request.DnsServers); err != nil {
+			return err
+		}
+
+		if request.Description != nil {
+			subnet.Description = *request.Description
+		}
+		if request.SecurityGroupId != nil {
+			subnet.SecurityGroupId = request.SecurityGroupId
+		}
+		if request.RelayDeviceId != nil {
+			subnet.RelayDeviceId = request.RelayDeviceId
+		}
+		if request.DnsServers != nil {
+			subnet.DnsServers = request.DnsServers
+		}
+		if request.DnsSearchDomains != nil {
+			subnet.DnsSearchDomains = request.DnsSearchDomains
+		}
+		if res := tx.Save(&subnet); res.Error != nil {
+			return res.Error
+		}
+
+		// the devices of the subnet get the relay and DNS settings again when they see their device change
+		return tx.Model(&models.Device{}).
+			Where("subnet_id = ?", subnet.ID).
+			Update("subnet_id", gorm.Expr("subnet_id")).Error
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", vpc.ID.String()))
+	c.JSON(http.StatusOK, subnet)
+}
+
+// DeleteSubnet deletes a subnet of a VPC
+// @Summary      Delete Subnet
+// @Id  		 DeleteSubnet
+// @Tags         VPC
+// @Description  Deletes a subnet of a VPC that no device or registration key uses
+// @Param        id         path   string  true  "VPC ID"
+// @Param        subnet_id  path   string  true  "Subnet ID"
+// @Accept	     json
+// @Produce      json
+// @Success      200  {object}  models.Subnet
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/subnets/{subnet_id} [delete]
+func (api *API) DeleteSubnet(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteSubnet", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+		attribute.String("subnet_id", c.Param("subnet_id")),
+	))
+	defer span.End()
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	subnetId, err := uuid.Parse(c.Param("subnet_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("subnet_id"))
+		return
+	}
+
+	var subnet models.Subnet
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		tokenClaims, err2 := NxodusClaims(c, tx)
+		if err2 != nil {
+			return err2
+		}
+		if isTokenClaims(tokenClaims) {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("subnets can only be managed by users")))
+		}
+
+		var vpc models.VPC
+		if res := api.VPCIsOwnedByCurrentUser(c, tx).First(&vpc, "id = ?", vpcId); res.Error != nil {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		}
+		if res := tx.First(&subnet, "id = ? AND vpc_id = ?", subnetId, vpc.ID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("subnet"))
+			}
+			return res.Error
+		}
+
+		var count int64
+		if res := tx.Model(&models.Device{}).Where("subnet_id = ?", subnet.ID).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count > 0 {
+			return NewApiResponseError(http.StatusBadRequest, models.NewNotAllowedError("subnet cannot be deleted while devices are still using it"))
+		}
+		if res := tx.Model(&models.RegKey{}).Where("subnet_id = ?", subnet.ID).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count > 0 {
+			return NewApiResponseError(http.StatusBadRequest, models.NewNotAllowedError("subnet cannot be deleted while registration keys are still using it"))
+		}
+		return tx.Delete(&subnet).Error
+	})
+	if err != nil {
+		api.sendIPReservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subnet)
+}
+
+// deviceSubnet returns the subnet of a VPC a device joins: the subnet of the registration key it registers
+// with, or else the requested subnet. It returns nil when the device joins no subnet.
+func (api *API) deviceSubnet(tx *gorm.DB, vpc models.VPC, subnetId *uuid.UUID, regKeyId uuid.UUID) (*models.Subnet, error) {
+	if regKeyId != uuid.Nil {
+		var regKey models.RegKey
+		if res := tx.Select("id", "subnet_id").First(&regKey, "id = ?", regKeyId); res.Error != nil && !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, res.Error
+		}
+		if regKey.SubnetID != nil {
+			if subnetId != nil && *subnetId != *regKey.SubnetID {
+				return nil, NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("subnet_id", "does not match the reg key subnet_id"))
+			}
+			subnetId = regKey.SubnetID
+		}
+	}
+	if subnetId == nil {
+		return nil, nil
+	}
+	var subnet models.Subnet
+	if res := tx.First(&subnet, "id = ? AND vpc_id = ?", *subnetId, vpc.ID); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("subnet_id", "the subnet is not in the vpc"))
+		}
+		return nil, res.Error
+	}
+	return &subnet, nil
+}
+
+// validateSubnetSettings checks that the security group and the relay of a subnet belong to its VPC, and
+// that its DNS servers are addresses.
+func validateSubnetSettings(tx *gorm.DB, vpc models.VPC, securityGroupId *uuid.UUID, relayDeviceId *uuid.UUID, dnsServers []string) error {
+	if securityGroupId != nil {
+		var count int64
+		if res := tx.Model(&models.SecurityGroup{}).Where("id = ? AND vpc_id = ?", *securityGroupId, vpc.ID).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count == 0 {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("security_group_id", "the security group is not in the vpc"))
+		}
+	}
+	if relayDeviceId != nil {
+		var count int64
+		if res := tx.Model(&models.Device{}).Where("id = ? AND vpc_id = ? AND relay = ?", *relayDeviceId, vpc.ID, true).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count == 0 {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("relay_device_id", "the device is not a relay of the vpc"))
+		}
+	}
+	for _, server := range dnsServers {
+		if _, err := netip.ParseAddr(server); err != nil {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("dns_servers", fmt.Sprintf("%s is not a valid address", server)))
+		}
+	}
+	return nil
+}
+
+// subnetCidr returns the CIDR of a subnet of the given family, or an empty string.
+func subnetCidr(subnet models.Subnet, ipv4 bool) string {
+	if ipv4 {
+		return subnet.Ipv4Cidr
+	}
+	return subnet.Ipv6Cidr
+}
+
+// subnetAddressPool returns the ranges the addresses of a family are assigned from to a device of a VPC with
+// subnets: the CIDR of the subnet of the device, or else the CIDRs of the VPC outside of its subnets, which
+// are returned as the ranges to skip.
+func subnetAddressPool(vpc models.VPC, subnet *models.Subnet, subnets []models.Subnet, ipv4 bool) (ranges []netip.Prefix, skip []netip.Prefix) {
+	if subnet != nil {
+		if cidr := subnetCidr(*subnet, ipv4); cidr != "" {
+			return []netip.Prefix{netip.MustParsePrefix(cidr)}, nil
+		}
+	}
+	cidrs := vpc.Ipv6Cidrs()
+	if ipv4 {
+		cidrs = vpc.Ipv4Cidrs()
+	}
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			ranges = append(ranges, prefix.Masked())
+		}
+	}
+	for _, other := range subnets {
+		if cidr := subnetCidr(other, ipv4); cidr != "" {
+			skip = append(skip, netip.MustParsePrefix(cidr))
+		}
+	}
+	return ranges, skip
+}
+
+// inSubnetAddressPool returns whether a device of a VPC with subnets may be assigned an address.
+func inSubnetAddressPool(vpc models.VPC, subnet *models.Subnet, subnets []models.Subnet, address netip.Addr) bool {
+	ranges, skip := subnetAddressPool(vpc, subnet, subnets, address.Is4())
+	return prefixesContainAddr(ranges, address) && !prefixesContainAddr(skip, address)
+}
+
+// assignFromSubnets assigns the first free address of the pool of a device of a VPC with subnets. The subnets
+// are slices of the IPAM prefixes of the VPC, so the addresses are acquired one by one, skipping the ones
+// the devices, IP reservations and excluded ranges of the VPC are known to hold.
+func (api *API) assignFromSubnets(ctx context.Context, tx *gorm.DB, vpc models.VPC, subnet *models.Subnet, subnets []models.Subnet, ipv4 bool) (string, error) {
+	ranges, skip := subnetAddressPool(vpc, subnet, subnets, ipv4)
+
+	taken := map[netip.Addr]bool{}
+	var devices []models.Device
+	if res := tx.Select("id", "ipv4_tunnel_ips", "ipv6_tunnel_ips").Where("vpc_id = ?", vpc.ID).Find(&devices); res.Error != nil {
+		return "", res.Error
+	}
+	for _, device := range devices {
+		for _, ip := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+			if address, err := netip.ParseAddr(ip.Address); err == nil {
+				taken[address] = true
+			}
+		}
+	}
+	var reservations []models.IPReservation
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&reservations); res.Error != nil {
+		return "", res.Error
+	}
+	for _, reservation := range reservations {
+		if address, err := netip.ParseAddr(reservation.Address); err == nil {
+			taken[address] = true
+		}
+	}
+	var excluded []models.ExcludedRange
+	if res := tx.Where("vpc_id = ?", vpc.ID).Find(&excluded); res.Error != nil {
+		return "", res.Error
+	}
+	for _, r := range excluded {
+		if prefix, err := netip.ParsePrefix(r.Cidr); err == nil {
+			skip = append(skip, prefix.Masked())
+		}
+	}
+
+	ipamNamespace := vpcIPAMNamespace(vpc)
+	attempts := 0
+	for _, r := range ranges {
+		vpcCidr, ok := vpcCidrOf(vpc, r.Addr())
+		if !ok {
+			continue
+		}
+		for address := r.Addr(); r.Contains(address); address = address.Next() {
+			if p, ok := prefixOfAddr(skip, address); ok {
+				address = lastAddr(p)
+				continue
+			}
+			// the network and broadcast addresses of a subnet are left out, like the ones of the IPAM prefixes
+			if address == r.Addr() || address.Is4() && r.Bits() < 31 && address == lastAddr(r) {
+				continue
+			}
+			if taken[address] {
+				continue
+			}
+			if attempts++; attempts > maxSubnetAcquireAttempts {
+				return "", fmt.Errorf("no address is available after %d attempts", maxSubnetAcquireAttempts)
+			}
+			err := api.ipamTx(tx).AcquireIP(ctx, ipamNamespace, vpcCidr, address.String())
+			if err == nil {
+				return address.String(), nil
+			}
+			if !ipam.IsAlreadyAllocated(err) {
+				return "", err
+			}
+		}
+	}
+	return "", fmt.Errorf("no address is available in %v", ranges)
+}
+
+// prefixesContainAddr returns whether one of the prefixes contains an address.
+func prefixesContainAddr(prefixes []netip.Prefix, address netip.Addr) bool {
+	_, ok := prefixOfAddr(prefixes, address)
+	return ok
+}
+
+// prefixOfAddr returns the first of the prefixes that contains an address.
+func prefixOfAddr(prefixes []netip.Prefix, address netip.Addr) (netip.Prefix, bool) {
+	for _, prefix := range prefixes {
+		if prefix.Contains(address) {
+			return prefix, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	address, _ := netip.AddrFromSlice(b)
+	return address
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestSubnets() {
+	require := suite.Require()
+
+	var vpc models.VPC
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateVPC, models.AddVPC{
+		Description:    "subnets",
+		PrivateCidr:    true,
+		Ipv4Cidr:       "10.1.10.0/24",
+		Ipv6Cidr:       "fc00:a000::/20",
+		OrganizationID: suite.testUserID,
+	}, http.StatusCreated, &vpc)
+	vpcPath := fmt.Sprintf("/%s", vpc.ID)
+
+	var sg models.SecurityGroup
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateSecurityGroup, models.AddSecurityGroup{
+		Description: "prod",
+		VpcId:       vpc.ID,
+	}, http.StatusCreated, &sg)
+
+	var prod models.Subnet
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:            "prod",
+		Ipv4Cidr:        "10.1.10.7/26",
+		SecurityGroupId: &sg.ID,
+		DnsServers:      []string{"10.1.10.53"},
+	}, http.StatusCreated, &prod)
+	require.Equal("10.1.10.0/26", prod.Ipv4Cidr)
+
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:     "prod",
+		Ipv4Cidr: "10.1.10.128/26",
+	}, http.StatusConflict, nil)
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:     "ci",
+		Ipv4Cidr: "10.1.10.32/27",
+	}, http.StatusConflict, nil)
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:     "ci",
+		Ipv4Cidr: "10.1.11.0/26",
+	}, http.StatusUnprocessableEntity, nil)
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:       "ci",
+		Ipv4Cidr:   "10.1.10.128/26",
+		DnsServers: []string{"notanaddress"},
+	}, http.StatusUnprocessableEntity, nil)
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name: "ci",
+	}, http.StatusUnprocessableEntity, nil)
+
+	// the devices of a subnet are assigned addresses from it, and get its security group
+	var device models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:         vpc.ID,
+		SubnetID:      &prod.ID,
+		PublicKey:     "asubnetpubkey",
+		IPv4TunnelIPs: []models.TunnelIP{{Address: "10.1.10.200"}},
+	}, http.StatusCreated, &device)
+	require.Equal("10.1.10.1", device.IPv4TunnelIPs[0].Address)
+	require.Equal(prod.ID, *device.SubnetID)
+	require.Equal(sg.ID, device.SecurityGroupId)
+
+	// the other devices are assigned addresses outside of the subnets
+	var other models.Device
+	suite.serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{
+		VpcID:     vpc.ID,
+		PublicKey: "anothersubnetpubkey",
+	}, http.StatusCreated, &other)
+	require.Equal("10.1.10.64", other.IPv4TunnelIPs[0].Address)
+	require.Nil(other.SubnetID)
+	require.Equal(vpc.ID, other.SecurityGroupId)
+
+	// a subnet cannot take the addresses of the devices already in the vpc
+	suite.serve(http.MethodPost, "/:id/subnets", vpcPath+"/subnets", suite.api.CreateSubnet, models.AddSubnet{
+		Name:     "ci",
+		Ipv4Cidr: "10.1.10.64/26",
+	}, http.StatusConflict, nil)
+
+	dnsServers := []string{"10.1.10.54"}
+	suite.serve(http.MethodPatch, "/:id/subnets/:subnet_id", fmt.Sprintf("%s/subnets/%s", vpcPath, prod.ID), suite.api.UpdateSubnet, models.UpdateSubnet{
+		DnsServers: dnsServers,
+	}, http.StatusOK, &prod)
+	require.Equal(dnsServers, []string(prod.DnsServers))
+
+	var subnets []models.Subnet
+	suite.serve(http.MethodGet, "/:id/subnets", vpcPath+"/subnets", suite.api.ListSubnets, nil, http.StatusOK, &subnets)
+	require.Len(subnets, 1)
+
+	suite.serve(http.MethodDelete, "/:id/subnets/:subnet_id", fmt.Sprintf("%s/subnets/%s", vpcPath, prod.ID), suite.api.DeleteSubnet, nil, http.StatusBadRequest, nil)
+	suite.serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", device.ID), suite.api.DeleteDevice, nil, http.StatusOK, nil)
+	suite.serve(http.MethodDelete, "/:id/subnets/:subnet_id", fmt.Sprintf("%s/subnets/%s", vpcPath, prod.ID), suite.api.DeleteSubnet, nil, http.StatusOK, nil)
+	suite.serve(http.MethodGet, "/:id/subnets/:subnet_id", fmt.Sprintf("%s/subnets/%s", vpcPath, prod.ID), suite.api.GetSubnet, nil, http.StatusNotFound, nil)
+}
@@ -3,91 +3,72 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/lib/pq"
+	"github.com/nexodus-io/nexodus/internal/database"
 	"github.com/nexodus-io/nexodus/internal/ipam"
-	"github.com/nexodus-io/nexodus/internal/models"
-	"github.com/nexodus-io/nexodus/internal/util"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var defaultIPAMNamespace = uuid.UUID{}
 
-func Rebuild(ctx context.Context, log *zap.Logger, db *gorm.DB, ipam ipam.IPAM) error {
-
-	type result struct {
-		ID             uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
-		TunnelIP       string         `json:"tunnel_ip"`
-		TunnelIpV6     string         `json:"tunnel_ip_v6"`
-		AdvertiseCidrs pq.StringArray `json:"advertise_cidrs" gorm:"type:text[]" swaggertype:"array,string"`
-		OrganizationID uuid.UUID      `json:"organization_id"`
-		PrivateCidr    bool           `json:"private_cidr"`
-		IpCidr         string         `json:"ip_cidr"`
-		IpCidrV6       string         `json:"ip_cidr_v6"`
-	}
-	rows, err := db.Model(&models.Device{}).
-		Select("devices.id, organization_id, tunnel_ip, tunnel_ip_v6, advertise_cidrs, private_cidr, ip_cidr, ip_cidr_v6").
-		Joins("inner join organizations on organizations.id::text = devices.organization_id").
-		Rows()
+// Rebuild recreates the namespaces, prefixes and addresses of the devices and VPCs in IPAM, and releases
+// what IPAM holds that they no longer use once it is still leaked after gracePeriod.
+func Rebuild(ctx context.Context, log *zap.Logger, db *gorm.DB, ipam ipam.IPAM, gracePeriod time.Duration) error {
+	report, err := Verify(ctx, log, db, ipam, true, gracePeriod)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var device result
-		err = db.ScanRows(rows, &device)
-		if err != nil {
-			return err
-		}
-
-		log.Info("processing", zap.String("device", device.ID.String()))
-
-		ipamNamespace := defaultIPAMNamespace
-		if device.PrivateCidr {
-			ipamNamespace = device.OrganizationID
-		}
-
-		if err := ipam.CreateNamespace(ctx, ipamNamespace); err != nil {
-			return fmt.Errorf("failed to create ipam namespace: %w", err)
-		}
+	log.Info("rebuilt ipam",
+		zap.Int("namespaces", len(report.MissingNamespaces)),
+		zap.Int("prefixes", len(report.MissingPrefixes)),
+		zap.Int("addresses", len(report.MissingAddresses)),
+		zap.Int("leaked_addresses", len(report.LeakedAddresses)),
+		zap.Int("duplicate_addresses", len(report.DuplicateAddresses)),
+	)
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("failed to rebuild ipam: %d errors, first: %s", len(report.Errors), report.Errors[0])
+	}
+	return nil
+}
 
-		if err := ipam.AssignCIDR(ctx, ipamNamespace, device.IpCidr); err != nil {
-			return fmt.Errorf("can't assign default ipam v4 prefix: %w", err)
-		}
-		if err := ipam.AssignCIDR(ctx, ipamNamespace, device.IpCidrV6); err != nil {
-			return fmt.Errorf("can't assign default ipam v6 prefix: %w", err)
-		}
+// embeddedPopulation is the id of the marker recording that the embedded IPAM was populated.
+const embeddedPopulation = "embedded"
 
-		if device.TunnelIP != "" {
-			err = ipam.AcquireIP(ctx, ipamNamespace, device.IpCidr, device.TunnelIP)
-			if err != nil {
-				log.Sugar().Warnf("Failed to allocate ip %s for device %s", device.TunnelIP, device.ID)
-			}
-		}
+// ipamPopulation marks an IPAM populated from the devices and VPCs.
+type ipamPopulation struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
 
-		if device.TunnelIpV6 != "" {
-			err = ipam.AcquireIP(ctx, ipamNamespace, device.IpCidrV6, device.TunnelIpV6)
-			if err != nil {
-				log.Sugar().Warnf("Failed to allocate ip %s for device %s", device.TunnelIpV6, device.ID)
-			}
+// Populate rebuilds the embedded IPAM from the devices and VPCs the first time the apiserver starts with it.
+// This moves the addressing to the embedded IPAM from the go-ipam grpc service. The IPAM is rebuilt in the
+// transaction that marks it populated, so a population that fails partway is rolled back and done again on
+// the next start, and the apiserver replicas starting along with it wait for it instead of populating it too.
+// The mark is not cleared when the apiserver switches back to the go-ipam grpc service, so the tables of an
+// embedded IPAM used before are stale and have to be emptied before switching to the embedded IPAM again.
+func Populate(ctx context.Context, log *zap.Logger, db *gorm.DB, backend ipam.IPAM) error {
+	embedded, ok := backend.(ipam.TransactionalIPAM)
+	if !ok {
+		return fmt.Errorf("only the embedded ipam can be populated")
+	}
+	transaction, _, err := database.GetTransactionFunc(db)
+	if err != nil {
+		return err
+	}
+	return transaction(ctx, func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ipamPopulation{ID: embeddedPopulation})
+		if res.Error != nil {
+			return res.Error
 		}
-
-		// allocate a cidr if requested
-		for _, cidr := range device.AdvertiseCidrs {
-			if !util.IsValidPrefix(cidr) {
-				return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
-			}
-			// Skip the prefix assignment if it's an IPv4 or IPv6 default route
-			if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-				if err := ipam.AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
-					return fmt.Errorf("failed to assign cidr: %w", err)
-				}
-			}
+		if res.RowsAffected == 0 {
+			return nil
 		}
-
-	}
-	return nil
+		log.Info("populating the embedded ipam from the database")
+		// the embedded ipam is changed in this transaction only, so its leaks are released right away
+		return Rebuild(ctx, log, tx, embedded.WithTx(tx), 0)
+	})
 }
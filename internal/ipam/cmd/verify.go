@@ -0,0 +1,783 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/ipam"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxProbedPrefixSize is the size of the largest prefix whose addresses are probed one by one to find
+// the leaked addresses no deleted device, IP reservation or excluded range accounts for, when IPAM cannot
+// list the addresses acquired from a prefix.
+const maxProbedPrefixSize = 4096
+
+// DefaultLeakGracePeriod is how long a repair waits before it checks the leaks it found again and releases
+// the ones still leaked. The apiserver changes IPAM before it commits the devices, IP reservations and VPCs
+// using the addresses and prefixes, so what looks leaked once can be in use by a request in flight.
+const DefaultLeakGracePeriod = 30 * time.Second
+
+// namespaceState is the addressing the devices and VPCs of a namespace expect IPAM to hold.
+type namespaceState struct {
+	// vpcCidrs are the CIDRs of the VPCs of the namespace, the devices are assigned addresses from them
+	vpcCidrs map[string]bool
+	// prefixes are the CIDRs of the VPCs and the CIDRs advertised by the devices of the namespace
+	prefixes map[string]bool
+	// addresses are the addresses used by the devices, IP reservations and excluded ranges of the namespace
+	addresses map[string]*addressUse
+	// present is set when IPAM has the namespace
+	present bool
+	// actualPrefixes are the prefixes IPAM has in the namespace
+	actualPrefixes map[string]bool
+}
+
+// addressUse lists the devices, IP reservations and excluded ranges using an address.
+type addressUse struct {
+	cidr    string
+	devices []models.Device
+	owners  []uuid.UUID
+	held    bool
+}
+
+type verifier struct {
+	log    *zap.SugaredLogger
+	db     *gorm.DB
+	ipam   ipam.IPAM
+	repair bool
+	// gracePeriod is how long the leaks are left alone before they are released
+	gracePeriod time.Duration
+	// leakedAddresses are the leaked addresses released once the grace period is over
+	leakedAddresses []models.IPAMAddress
+	report          *models.IPAMReport
+	namespaces      map[uuid.UUID]*namespaceState
+	vpcs            map[uuid.UUID]uuid.UUID
+	leaked          map[uuid.UUID]map[string]bool
+}
+
+// Verify diffs the IPAM namespaces against the addressing of the devices and VPCs, and when repair is set,
+// changes IPAM, and the devices assigned duplicate addresses, to match. Every change is re-checked against
+// the database before it is made. The leaked addresses, prefixes and namespaces are released last, once
+// they are still leaked after gracePeriod, so that a repair does not release what the requests in flight
+// acquired before committing the devices, IP reservations and VPCs using it.
+func Verify(ctx context.Context, log *zap.Logger, db *gorm.DB, ipam ipam.IPAM, repair bool, gracePeriod time.Duration) (*models.IPAMReport, error) {
+	v := &verifier{
+		log:         log.Sugar(),
+		db:          db.WithContext(ctx),
+		ipam:        ipam,
+		repair:      repair,
+		gracePeriod: gracePeriod,
+		report:      &models.IPAMReport{Repaired: repair},
+		namespaces:  map[uuid.UUID]*namespaceState{},
+		vpcs:        map[uuid.UUID]uuid.UUID{},
+		leaked:      map[uuid.UUID]map[string]bool{},
+	}
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+	leakedNamespaces, err := v.verifyNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leakedPrefixes, err := v.verifyPrefixes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.verifyDuplicates(ctx)
+	v.verifyAddresses(ctx)
+	if err := v.verifyLeakedAddresses(ctx); err != nil {
+		return nil, err
+	}
+	v.verifyUnaccountedAddresses(ctx)
+
+	if repair {
+		v.releaseLeaks(ctx, leakedPrefixes, leakedNamespaces)
+	}
+	return v.report, nil
+}
+
+// releaseLeaks waits for the grace period, and then releases the leaked addresses, and once they are
+// released, the leaked prefixes and namespaces, that the database still does not use.
+func (v *verifier) releaseLeaks(ctx context.Context, leakedPrefixes []models.IPAMPrefix, leakedNamespaces []uuid.UUID) {
+	if len(v.leakedAddresses) == 0 && len(leakedPrefixes) == 0 && len(leakedNamespaces) == 0 {
+		return
+	}
+	if v.gracePeriod > 0 {
+		v.log.Infof("releasing the leaks still found in %s", v.gracePeriod)
+		select {
+		case <-ctx.Done():
+			v.errorf("the leaks were not released: %v", ctx.Err())
+			return
+		case <-time.After(v.gracePeriod):
+		}
+	}
+
+	for _, a := range v.leakedAddresses {
+		if v.addressInUse(a.Namespace, a.Address) {
+			continue
+		}
+		// the request that acquired the address may have released it since
+		if err := v.ipam.ReleaseToPool(ctx, a.Namespace, a.Address, a.Cidr); err != nil && !ipam.IsNotFound(err) {
+			v.errorf("failed to release the leaked address %s of namespace %s: %v", a.Address, a.Namespace, err)
+		}
+	}
+	for _, prefix := range leakedPrefixes {
+		if v.prefixInUse(prefix.Namespace, prefix.Cidr) {
+			continue
+		}
+		if err := v.ipam.ReleaseCIDR(ctx, prefix.Namespace, prefix.Cidr); err != nil {
+			v.errorf("failed to release the leaked prefix %s of namespace %s: %v", prefix.Cidr, prefix.Namespace, err)
+		}
+	}
+	for _, ns := range leakedNamespaces {
+		var count int64
+		if res := v.db.Model(&models.VPC{}).Where("id = ?", ns).Count(&count); res.Error != nil || count > 0 {
+			continue
+		}
+		if err := v.ipam.DeleteNamespace(ctx, ns); err != nil {
+			v.errorf("failed to delete the leaked namespace %s: %v", ns, err)
+		}
+	}
+}
+
+func (v *verifier) errorf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	v.log.Warn(msg)
+	v.report.Errors = append(v.report.Errors, msg)
+}
+
+func namespaceOf(vpc models.VPC) uuid.UUID {
+	if vpc.PrivateCidr {
+		return vpc.ID
+	}
+	return defaultIPAMNamespace
+}
+
+// canonicalCidr returns the masked form of a CIDR, which IPAM stores its prefixes with.
+func canonicalCidr(cidr string) (string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", err
+	}
+	return prefix.Masked().String(), nil
+}
+
+func (v *verifier) namespace(ns uuid.UUID) *namespaceState {
+	st, ok := v.namespaces[ns]
+	if !ok {
+		st = &namespaceState{
+			vpcCidrs:       map[string]bool{},
+			prefixes:       map[string]bool{},
+			addresses:      map[string]*addressUse{},
+			actualPrefixes: map[string]bool{},
+		}
+		v.namespaces[ns] = st
+	}
+	return st
+}
+
+// cidrOf returns the VPC CIDR of a namespace an address is in.
+func (st *namespaceState) cidrOf(address netip.Addr) string {
+	for cidr := range st.vpcCidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(address) {
+			return cidr
+		}
+	}
+	return ""
+}
+
+func (st *namespaceState) use(address string) *addressUse {
+	a, err := netip.ParseAddr(address)
+	if err != nil {
+		return nil
+	}
+	cidr := st.cidrOf(a)
+	if cidr == "" {
+		return nil
+	}
+	use, ok := st.addresses[a.String()]
+	if !ok {
+		use = &addressUse{cidr: cidr}
+		st.addresses[a.String()] = use
+	}
+	return use
+}
+
+// load reads the addressing of the devices and VPCs.
+func (v *verifier) load() error {
+	v.namespace(defaultIPAMNamespace)
+
+	var vpcs []models.VPC
+	if res := v.db.Find(&vpcs); res.Error != nil {
+		return res.Error
+	}
+	for _, vpc := range vpcs {
+		ns := namespaceOf(vpc)
+		v.vpcs[vpc.ID] = ns
+		st := v.namespace(ns)
+		for _, cidr := range vpc.Cidrs() {
+			cidr, err := canonicalCidr(cidr)
+			if err != nil {
+				v.errorf("vpc %s has an invalid cidr: %v", vpc.ID, err)
+				continue
+			}
+			st.vpcCidrs[cidr] = true
+			st.prefixes[cidr] = true
+		}
+	}
+
+	var devices []models.Device
+	if res := v.db.Order("created_at").Find(&devices); res.Error != nil {
+		return res.Error
+	}
+	for _, device := range devices {
+		ns, ok := v.vpcs[device.VpcID]
+		if !ok {
+			continue
+		}
+		st := v.namespace(ns)
+		for _, t := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+			if t.Address == "" {
+				continue
+			}
+			use := st.use(t.Address)
+			if use == nil {
+				v.errorf("device %s has the address %s outside the cidrs of its vpc", device.ID, t.Address)
+				continue
+			}
+			use.devices = append(use.devices, device)
+			use.owners = append(use.owners, device.ID)
+		}
+		for _, cidr := range device.AdvertiseCidrs {
+			if util.IsDefaultIPRoute(cidr) {
+				continue
+			}
+			cidr, err := canonicalCidr(cidr)
+			if err != nil {
+				v.errorf("device %s advertises an invalid cidr: %v", device.ID, err)
+				continue
+			}
+			st.prefixes[cidr] = true
+		}
+	}
+
+	var reservations []models.IPReservation
+	if res := v.db.Find(&reservations); res.Error != nil {
+		return res.Error
+	}
+	for _, reservation := range reservations {
+		ns, ok := v.vpcs[reservation.VpcID]
+		if !ok {
+			continue
+		}
+		if use := v.namespace(ns).use(reservation.Address); use != nil {
+			use.owners = append(use.owners, reservation.ID)
+		}
+	}
+
+	var ranges []models.ExcludedRange
+	if res := v.db.Find(&ranges); res.Error != nil {
+		return res.Error
+	}
+	for _, excluded := range ranges {
+		ns, ok := v.vpcs[excluded.VpcID]
+		if !ok {
+			continue
+		}
+		for _, address := range v.rangeAddresses(v.namespace(ns), excluded.Cidr) {
+			if use := v.namespace(ns).use(address); use != nil {
+				use.owners = append(use.owners, excluded.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func (v *verifier) rangeAddresses(st *namespaceState, cidr string) []string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil
+	}
+	return ipam.RangeAddresses(st.cidrOf(prefix.Addr()), prefix)
+}
+
+func sortedNamespaces[T any](m map[uuid.UUID]T) []uuid.UUID {
+	var keys []uuid.UUID
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// verifyNamespaces reports the missing and leaked namespaces, and creates the missing ones when repairing.
+func (v *verifier) verifyNamespaces(ctx context.Context) ([]uuid.UUID, error) {
+	actual, err := v.ipam.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var leaked []uuid.UUID
+	for _, ns := range actual {
+		if st, ok := v.namespaces[ns]; ok {
+			st.present = true
+			continue
+		}
+		leaked = append(leaked, ns)
+	}
+	sort.Slice(leaked, func(i, j int) bool { return leaked[i].String() < leaked[j].String() })
+	v.report.LeakedNamespaces = leaked
+
+	for _, ns := range sortedNamespaces(v.namespaces) {
+		st := v.namespaces[ns]
+		if st.present {
+			continue
+		}
+		v.report.MissingNamespaces = append(v.report.MissingNamespaces, ns)
+		if v.repair {
+			if err := v.ipam.CreateNamespace(ctx, ns); err != nil {
+				v.errorf("failed to create the namespace %s: %v", ns, err)
+				continue
+			}
+			st.present = true
+		}
+	}
+	return leaked, nil
+}
+
+// verifyPrefixes reports the missing prefixes, and assigns them when repairing, and the CIDRs advertised by
+// deleted devices that IPAM still has. IPAM does not list the prefixes of a namespace, so the leaked
+// prefixes nothing in the database accounts for are not found.
+func (v *verifier) verifyPrefixes(ctx context.Context) ([]models.IPAMPrefix, error) {
+	for _, ns := range sortedNamespaces(v.namespaces) {
+		st := v.namespaces[ns]
+		for _, cidr := range sortedKeys(st.prefixes) {
+			if st.present {
+				found, err := v.ipam.HasPrefix(ctx, ns, cidr)
+				if err != nil {
+					v.errorf("failed to check the prefix %s of namespace %s: %v", cidr, ns, err)
+					continue
+				}
+				if found {
+					st.actualPrefixes[cidr] = true
+					continue
+				}
+			}
+			v.report.MissingPrefixes = append(v.report.MissingPrefixes, models.IPAMPrefix{Namespace: ns, Cidr: cidr})
+			if v.repair && st.present {
+				if err := v.ipam.AssignCIDR(ctx, ns, cidr); err != nil {
+					v.errorf("failed to assign the prefix %s of namespace %s: %v", cidr, ns, err)
+					continue
+				}
+				st.actualPrefixes[cidr] = true
+			}
+		}
+	}
+
+	var devices []models.Device
+	if res := v.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&devices); res.Error != nil {
+		return nil, res.Error
+	}
+	var leaked []models.IPAMPrefix
+	for _, device := range devices {
+		ns, ok := v.vpcs[device.VpcID]
+		if !ok || !v.namespaces[ns].present {
+			continue
+		}
+		st := v.namespaces[ns]
+		for _, cidr := range device.AdvertiseCidrs {
+			cidr, err := canonicalCidr(cidr)
+			if err != nil || st.prefixes[cidr] || st.actualPrefixes[cidr] {
+				continue
+			}
+			found, err := v.ipam.HasPrefix(ctx, ns, cidr)
+			if err != nil {
+				v.errorf("failed to check the prefix %s of namespace %s: %v", cidr, ns, err)
+				continue
+			}
+			if !found {
+				continue
+			}
+			st.actualPrefixes[cidr] = true
+			leaked = append(leaked, models.IPAMPrefix{Namespace: ns, Cidr: cidr})
+		}
+	}
+	v.report.LeakedPrefixes = leaked
+	return leaked, nil
+}
+
+// verifyDuplicates reports the addresses assigned to more than one device, and assigns new addresses to
+// all of them but the oldest one when repairing.
+func (v *verifier) verifyDuplicates(ctx context.Context) {
+	for _, ns := range sortedNamespaces(v.namespaces) {
+		st := v.namespaces[ns]
+		for _, address := range sortedKeys(st.addresses) {
+			use := st.addresses[address]
+			if len(use.devices) < 2 {
+				continue
+			}
+			duplicate := models.IPAMDuplicateAddress{Namespace: ns, Address: address}
+			for _, device := range use.devices {
+				duplicate.DeviceIDs = append(duplicate.DeviceIDs, device.ID)
+			}
+			if v.repair && st.actualPrefixes[use.cidr] {
+				for _, device := range use.devices[1:] {
+					if err := v.readdress(ctx, ns, use.cidr, device, address); err != nil {
+						v.errorf("failed to assign device %s another address than %s: %v", device.ID, address, err)
+						continue
+					}
+					duplicate.Readdressed = append(duplicate.Readdressed, device.ID)
+				}
+			}
+			v.report.DuplicateAddresses = append(v.report.DuplicateAddresses, duplicate)
+		}
+	}
+}
+
+// readdress assigns a device a new address from the pool in place of an address it shares with another device.
+func (v *verifier) readdress(ctx context.Context, ns uuid.UUID, cidr string, device models.Device, address string) error {
+	replacement, err := v.ipam.AssignFromPool(ctx, ns, cidr)
+	if err != nil {
+		return err
+	}
+	err = v.db.Transaction(func(tx *gorm.DB) error {
+		if res := tx.First(&device, "id = ?", device.ID); res.Error != nil {
+			return res.Error
+		}
+		for i := range device.IPv4TunnelIPs {
+			if device.IPv4TunnelIPs[i].Address == address {
+				device.IPv4TunnelIPs[i].Address = replacement
+			}
+		}
+		for i := range device.IPv6TunnelIPs {
+			if device.IPv6TunnelIPs[i].Address == address {
+				device.IPv6TunnelIPs[i].Address = replacement
+			}
+		}
+		for i, allowed := range device.AllowedIPs {
+			if allowed == address || strings.HasPrefix(allowed, address+"/") {
+				device.AllowedIPs[i] = replacement + strings.TrimPrefix(allowed, address)
+			}
+		}
+		return tx.Model(&device).
+			Select("ipv4_tunnel_ips", "ipv6_tunnel_ips", "allowed_ips").
+			Updates(&device).Error
+	})
+	if err != nil {
+		if releaseErr := v.ipam.ReleaseToPool(ctx, ns, replacement, cidr); releaseErr != nil {
+			v.errorf("failed to release the address %s of namespace %s: %v", replacement, ns, releaseErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// probe acquires an address and reports whether IPAM already held it. The address stays acquired when
+// IPAM did not hold it.
+func (v *verifier) probe(ctx context.Context, ns uuid.UUID, cidr, address string) (bool, error) {
+	err := v.ipam.AcquireIP(ctx, ns, cidr, address)
+	if ipam.IsAlreadyAllocated(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// verifyAddresses reports the addresses of the devices, IP reservations and excluded ranges IPAM does not
+// hold, and acquires them when repairing.
+func (v *verifier) verifyAddresses(ctx context.Context) {
+	for _, ns := range sortedNamespaces(v.namespaces) {
+		st := v.namespaces[ns]
+		for _, address := range sortedKeys(st.addresses) {
+			use := st.addresses[address]
+			if !st.actualPrefixes[use.cidr] {
+				continue
+			}
+			held, err := v.probe(ctx, ns, use.cidr, address)
+			if err != nil {
+				v.errorf("failed to check the address %s of namespace %s: %v", address, ns, err)
+				continue
+			}
+			use.held = true
+			if held {
+				continue
+			}
+			v.report.MissingAddresses = append(v.report.MissingAddresses, models.IPAMAddress{
+				Namespace: ns, Cidr: use.cidr, Address: address, Owner: use.owners[0],
+			})
+			// the probe acquired the address: it is kept when repairing and it is still in use
+			if v.repair && v.addressInUse(ns, address) {
+				continue
+			}
+			use.held = false
+			if err := v.ipam.ReleaseToPool(ctx, ns, address, use.cidr); err != nil {
+				v.errorf("failed to release the address %s of namespace %s: %v", address, ns, err)
+			}
+		}
+	}
+}
+
+// verifyLeakedAddresses reports the addresses of deleted devices, IP reservations and excluded ranges IPAM
+// still holds, and releases them when repairing.
+func (v *verifier) verifyLeakedAddresses(ctx context.Context) error {
+	type candidate struct {
+		ns      uuid.UUID
+		address string
+		owner   uuid.UUID
+	}
+	var candidates []candidate
+
+	var devices []models.Device
+	if res := v.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&devices); res.Error != nil {
+		return res.Error
+	}
+	for _, device := range devices {
+		if ns, ok := v.vpcs[device.VpcID]; ok {
+			for _, t := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+				candidates = append(candidates, candidate{ns, t.Address, device.ID})
+			}
+		}
+	}
+	var reservations []models.IPReservation
+	if res := v.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&reservations); res.Error != nil {
+		return res.Error
+	}
+	for _, reservation := range reservations {
+		if ns, ok := v.vpcs[reservation.VpcID]; ok {
+			candidates = append(candidates, candidate{ns, reservation.Address, reservation.ID})
+		}
+	}
+	var ranges []models.ExcludedRange
+	if res := v.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&ranges); res.Error != nil {
+		return res.Error
+	}
+	for _, excluded := range ranges {
+		if ns, ok := v.vpcs[excluded.VpcID]; ok {
+			for _, address := range v.rangeAddresses(v.namespace(ns), excluded.Cidr) {
+				candidates = append(candidates, candidate{ns, address, excluded.ID})
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		st := v.namespaces[c.ns]
+		address, err := netip.ParseAddr(c.address)
+		if err != nil {
+			continue
+		}
+		cidr := st.cidrOf(address)
+		if cidr == "" || !st.actualPrefixes[cidr] || st.addresses[address.String()] != nil || v.leaked[c.ns][address.String()] {
+			continue
+		}
+		v.checkLeak(ctx, c.ns, cidr, address.String(), c.owner)
+	}
+	return nil
+}
+
+// checkLeak probes an address no device, IP reservation or excluded range uses, and reports it when IPAM
+// holds it.
+func (v *verifier) checkLeak(ctx context.Context, ns uuid.UUID, cidr, address string, owner uuid.UUID) {
+	held, err := v.probe(ctx, ns, cidr, address)
+	if err != nil {
+		v.errorf("failed to check the address %s of namespace %s: %v", address, ns, err)
+		return
+	}
+	if !held {
+		// the probe acquired the address
+		if err := v.ipam.ReleaseToPool(ctx, ns, address, cidr); err != nil {
+			v.errorf("failed to release the address %s of namespace %s: %v", address, ns, err)
+		}
+		return
+	}
+	v.reportLeak(ns, cidr, address, owner)
+}
+
+// reportLeak reports an address IPAM holds that no device, IP reservation or excluded range uses, and
+// releases it once the grace period is over when repairing.
+func (v *verifier) reportLeak(ns uuid.UUID, cidr, address string, owner uuid.UUID) {
+	if v.leaked[ns] == nil {
+		v.leaked[ns] = map[string]bool{}
+	}
+	v.leaked[ns][address] = true
+	leaked := models.IPAMAddress{
+		Namespace: ns, Cidr: cidr, Address: address, Owner: owner,
+	}
+	v.report.LeakedAddresses = append(v.report.LeakedAddresses, leaked)
+	if v.repair {
+		v.leakedAddresses = append(v.leakedAddresses, leaked)
+	}
+}
+
+// verifyUnaccountedAddresses compares the number of addresses IPAM holds in the VPC prefixes with the
+// addresses known to be held, and looks for the leaked addresses that are not accounted for.
+func (v *verifier) verifyUnaccountedAddresses(ctx context.Context) {
+	for _, ns := range sortedNamespaces(v.namespaces) {
+		st := v.namespaces[ns]
+		for _, cidr := range sortedKeys(st.vpcCidrs) {
+			if !st.actualPrefixes[cidr] {
+				continue
+			}
+			prefix := netip.MustParsePrefix(cidr)
+			acquired, err := v.ipam.AcquiredIPs(ctx, ns, cidr)
+			if err != nil {
+				v.errorf("failed to get the usage of the prefix %s of namespace %s: %v", cidr, ns, err)
+				continue
+			}
+			// IPAM holds the network address, and the broadcast address of IPv4 prefixes
+			known := uint64(1)
+			if prefix.Addr().Is4() {
+				known = 2
+			}
+			for _, use := range st.addresses {
+				if use.cidr == cidr && use.held {
+					known++
+				}
+			}
+			for address := range v.leaked[ns] {
+				if prefix.Contains(netip.MustParseAddr(address)) {
+					known++
+				}
+			}
+			if acquired <= known {
+				continue
+			}
+			if lister, ok := v.ipam.(ipam.AddressLister); ok {
+				v.listLeaks(ctx, lister, ns, cidr)
+				continue
+			}
+			hostBits := prefix.Addr().BitLen() - prefix.Bits()
+			if hostBits > 12 || 1<<hostBits > maxProbedPrefixSize {
+				v.report.UnaccountedAddresses = append(v.report.UnaccountedAddresses, models.IPAMPrefix{
+					Namespace: ns, Cidr: cidr, Count: acquired - known,
+				})
+				continue
+			}
+			for _, address := range ipam.RangeAddresses(cidr, prefix) {
+				if st.addresses[address] != nil || v.leaked[ns][address] {
+					continue
+				}
+				v.checkLeak(ctx, ns, cidr, address, uuid.Nil)
+			}
+		}
+	}
+}
+
+// listLeaks reports the addresses IPAM lists as acquired from a VPC prefix that no device, IP reservation or
+// excluded range uses.
+func (v *verifier) listLeaks(ctx context.Context, lister ipam.AddressLister, ns uuid.UUID, cidr string) {
+	st := v.namespaces[ns]
+	prefix := netip.MustParsePrefix(cidr)
+	addresses, err := lister.AcquiredAddresses(ctx, ns, cidr)
+	if err != nil {
+		v.errorf("failed to list the addresses of the prefix %s of namespace %s: %v", cidr, ns, err)
+		return
+	}
+	for _, a := range addresses {
+		address, err := netip.ParseAddr(a)
+		if err != nil {
+			continue
+		}
+		// IPAM holds the network address, and the broadcast address of IPv4 prefixes
+		if address == prefix.Addr() || address.Is4() && !prefix.Contains(address.Next()) {
+			continue
+		}
+		if st.addresses[address.String()] != nil || v.leaked[ns][address.String()] {
+			continue
+		}
+		v.reportLeak(ns, cidr, address.String(), uuid.Nil)
+	}
+}
+
+// vpcsOfNamespace returns the ids of the VPCs of a namespace.
+func (v *verifier) vpcsOfNamespace(ns uuid.UUID) *gorm.DB {
+	db := v.db.Session(&gorm.Session{NewDB: true}).Model(&models.VPC{}).Select("id")
+	if ns == defaultIPAMNamespace {
+		return db.Where("private_cidr = ?", false)
+	}
+	return db.Where("id = ?", ns)
+}
+
+// addressInUse checks the database again for a device, IP reservation or excluded range using an address.
+func (v *verifier) addressInUse(ns uuid.UUID, address string) bool {
+	pattern := "%\"" + address + "\"%"
+	var devices []models.Device
+	if res := v.db.Select("id", "ipv4_tunnel_ips", "ipv6_tunnel_ips").
+		Where("vpc_id IN (?)", v.vpcsOfNamespace(ns)).
+		Where("(CAST(ipv4_tunnel_ips AS TEXT) LIKE ? OR CAST(ipv6_tunnel_ips AS TEXT) LIKE ?)", pattern, pattern).
+		Find(&devices); res.Error != nil {
+		// leave the address alone when in doubt
+		return true
+	}
+	for _, device := range devices {
+		for _, t := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+			if t.Address == address {
+				return true
+			}
+		}
+	}
+	var count int64
+	if res := v.db.Model(&models.IPReservation{}).
+		Where("vpc_id IN (?) AND address = ?", v.vpcsOfNamespace(ns), address).
+		Count(&count); res.Error != nil || count > 0 {
+		return true
+	}
+	var ranges []models.ExcludedRange
+	if res := v.db.Where("vpc_id IN (?)", v.vpcsOfNamespace(ns)).Find(&ranges); res.Error != nil {
+		return true
+	}
+	a := netip.MustParseAddr(address)
+	for _, excluded := range ranges {
+		if prefix, err := netip.ParsePrefix(excluded.Cidr); err == nil && prefix.Contains(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixInUse checks the database again for a VPC or a device using a prefix.
+func (v *verifier) prefixInUse(ns uuid.UUID, cidr string) bool {
+	canonical, err := canonicalCidr(cidr)
+	if err != nil {
+		return true
+	}
+	var vpcs []models.VPC
+	if res := v.db.Where("id IN (?)", v.vpcsOfNamespace(ns)).Find(&vpcs); res.Error != nil {
+		return true
+	}
+	for _, vpc := range vpcs {
+		for _, c := range vpc.Cidrs() {
+			if c, err := canonicalCidr(c); err == nil && c == canonical {
+				return true
+			}
+		}
+	}
+	var devices []models.Device
+	if res := v.db.Select("id", "advertise_cidrs").
+		Where("vpc_id IN (?)", v.vpcsOfNamespace(ns)).
+		Find(&devices); res.Error != nil {
+		return true
+	}
+	for _, device := range devices {
+		for _, c := range device.AdvertiseCidrs {
+			if c, err := canonicalCidr(c); err == nil && c == canonical {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,448 @@
+package ipam
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/google/uuid"
+	goipam "github.com/metal-stack/go-ipam"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxPoolAttempts is the number of times an address is picked from the pool when concurrent
+// requests pick the same address.
+const maxPoolAttempts = 10
+
+// poolProbeSize is the number of addresses looked up at once when picking an address from the pool.
+const poolProbeSize = 64
+
+// ipamNamespace is a namespace of the embedded IPAM.
+type ipamNamespace struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+// ipamPrefix is a prefix of a namespace of the embedded IPAM.
+type ipamPrefix struct {
+	NamespaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Cidr        string    `gorm:"primaryKey"`
+	// NextAddress is where picking an address from the pool starts: the addresses of the prefix
+	// before it are all acquired. Empty means the start of the prefix.
+	NextAddress string
+}
+
+// ipamAddress is an address acquired from a prefix of the embedded IPAM.
+type ipamAddress struct {
+	NamespaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Address     string    `gorm:"primaryKey"`
+	Cidr        string    `gorm:"index"`
+}
+
+// embeddedIPAM is the IPAM that keeps its namespaces, prefixes and addresses in the apiserver database.
+type embeddedIPAM struct {
+	logger      *zap.SugaredLogger
+	db          *gorm.DB
+	transaction database.TransactionFunc
+}
+
+// NewEmbeddedIPAM returns the IPAM that keeps its namespaces, prefixes and addresses in the tables of
+// the apiserver database. It runs its own transactions, use WithTx to make its changes in the transaction
+// of the caller instead.
+func NewEmbeddedIPAM(logger *zap.SugaredLogger, db *gorm.DB) (TransactionalIPAM, error) {
+	transaction, _, err := database.GetTransactionFunc(db)
+	if err != nil {
+		return nil, err
+	}
+	return &embeddedIPAM{
+		logger:      logger,
+		db:          db,
+		transaction: transaction,
+	}, nil
+}
+
+// WithTx returns the embedded IPAM making its changes in tx. Each change is made in a savepoint of tx, so
+// that a failed change, like acquiring an address a concurrent request acquired first, does not abort tx.
+func (i *embeddedIPAM) WithTx(tx *gorm.DB) IPAM {
+	return &embeddedIPAM{
+		logger: i.logger,
+		db:     tx,
+		transaction: func(ctx context.Context, fn func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+			return tx.WithContext(ctx).Transaction(fn, opts...)
+		},
+	}
+}
+
+// reservedAddresses returns the addresses a prefix holds for itself: its network address, and the
+// broadcast address of IPv4 prefixes. Like go-ipam, the single address of a /32 is held once and both
+// addresses of a /31 are held.
+func reservedAddresses(prefix netip.Prefix) []netip.Addr {
+	reserved := []netip.Addr{prefix.Addr()}
+	if prefix.Addr().Is4() {
+		network := prefix.Addr().As4()
+		hostBits := 32 - prefix.Bits()
+		var broadcast [4]byte
+		binary.BigEndian.PutUint32(broadcast[:], uint32(uint64(binary.BigEndian.Uint32(network[:]))|(1<<hostBits-1)))
+		if last := netip.AddrFrom4(broadcast); last != prefix.Addr() {
+			reserved = append(reserved, last)
+		}
+	}
+	return reserved
+}
+
+func parseMaskedPrefix(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+func namespaceExists(tx *gorm.DB, namespace uuid.UUID) error {
+	var count int64
+	if res := tx.Model(&ipamNamespace{}).Where("id = ?", namespace).Count(&count); res.Error != nil {
+		return res.Error
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", goipam.ErrNamespaceDoesNotExist, namespace)
+	}
+	return nil
+}
+
+func readPrefix(tx *gorm.DB, namespace uuid.UUID, cidr string) (netip.Prefix, error) {
+	prefix, err := parseMaskedPrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if err := namespaceExists(tx, namespace); err != nil {
+		return netip.Prefix{}, err
+	}
+	var count int64
+	if res := tx.Model(&ipamPrefix{}).Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Count(&count); res.Error != nil {
+		return netip.Prefix{}, res.Error
+	}
+	if count == 0 {
+		return netip.Prefix{}, fmt.Errorf("%w: unable to find prefix for cidr:%s", goipam.ErrNotFound, cidr)
+	}
+	return prefix, nil
+}
+
+func (i *embeddedIPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) error {
+	ctx, span := tracer.Start(parent, "CreateNamespace")
+	defer span.End()
+	return i.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ipamNamespace{ID: namespace}).Error
+}
+
+func (i *embeddedIPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) error {
+	ctx, span := tracer.Start(parent, "DeleteNamespace")
+	defer span.End()
+	return i.transaction(ctx, func(tx *gorm.DB) error {
+		if err := namespaceExists(tx, namespace); err != nil {
+			return err
+		}
+		if res := tx.Where("namespace_id = ?", namespace).Delete(&ipamAddress{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Where("namespace_id = ?", namespace).Delete(&ipamPrefix{}); res.Error != nil {
+			return res.Error
+		}
+		return tx.Where("id = ?", namespace).Delete(&ipamNamespace{}).Error
+	})
+}
+
+func (i *embeddedIPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error {
+	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
+	defer span.End()
+	if err := validateIP(TunnelIP); err != nil {
+		return fmt.Errorf("Address %s is not valid", TunnelIP)
+	}
+	_, err := i.acquire(ctx, namespace, ipamPrefix, TunnelIP)
+	return err
+}
+
+func (i *embeddedIPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error) {
+	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
+	defer span.End()
+	if err := validateIP(TunnelIP); err != nil {
+		return "", fmt.Errorf("Address %s is not valid", TunnelIP)
+	}
+	ip, err := i.acquire(ctx, namespace, ipamPrefix, TunnelIP)
+	if err != nil {
+		i.logger.Errorf("failed to assign the requested address %s, assigning an address from the pool: %v\n", TunnelIP, err)
+		return i.AssignFromPool(ctx, namespace, ipamPrefix)
+	}
+	return ip, nil
+}
+
+func (i *embeddedIPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (string, error) {
+	ctx, span := tracer.Start(parent, "AssignFromPool")
+	defer span.End()
+	var err error
+	for attempt := 0; attempt < maxPoolAttempts; attempt++ {
+		var ip string
+		ip, err = i.acquire(ctx, namespace, ipamPrefix, "")
+		if err == nil {
+			return ip, nil
+		}
+		// a concurrent request acquired the same address first
+		if !database.IsDuplicateError(err) {
+			break
+		}
+	}
+	return "", fmt.Errorf("failed to acquire an IPAM assigned address %w\n", err)
+}
+
+// acquire acquires a specific address of a prefix, or the first available address of the prefix
+// when address is empty.
+func (i *embeddedIPAM) acquire(ctx context.Context, namespace uuid.UUID, cidr string, address string) (string, error) {
+	var acquired string
+	err := i.transaction(ctx, func(tx *gorm.DB) error {
+		prefix, err := readPrefix(tx, namespace, cidr)
+		if err != nil {
+			return err
+		}
+
+		if address != "" {
+			ip, err := netip.ParseAddr(address)
+			if err != nil {
+				return fmt.Errorf("given ip:%s in not valid", address)
+			}
+			if !prefix.Contains(ip) {
+				return fmt.Errorf("given ip:%s is not in %s", address, cidr)
+			}
+			var count int64
+			if res := tx.Model(&ipamAddress{}).Where("namespace_id = ? AND address = ?", namespace, ip.String()).Count(&count); res.Error != nil {
+				return res.Error
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: given ip:%s is already allocated", goipam.ErrAlreadyAllocated, ip)
+			}
+			acquired = ip.String()
+		} else {
+			ip, err := nextFreeAddress(tx, namespace, prefix)
+			if err != nil {
+				return err
+			}
+			acquired = ip.String()
+		}
+
+		return tx.Create(&ipamAddress{NamespaceID: namespace, Address: acquired, Cidr: prefix.String()}).Error
+	})
+	if err != nil && address != "" && database.IsDuplicateError(err) {
+		err = fmt.Errorf("%w: given ip:%s is already allocated", goipam.ErrAlreadyAllocated, address)
+	}
+	return acquired, err
+}
+
+// readNextAddress returns where picking an address from the pool of a prefix starts, and the stored value it was read from.
+func readNextAddress(tx *gorm.DB, namespace uuid.UUID, prefix netip.Prefix) (netip.Addr, string, error) {
+	var row ipamPrefix
+	if res := tx.Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).First(&row); res.Error != nil {
+		return netip.Addr{}, "", res.Error
+	}
+	next, err := netip.ParseAddr(row.NextAddress)
+	if err != nil || !prefix.Contains(next) {
+		return prefix.Addr(), row.NextAddress, nil
+	}
+	return next, row.NextAddress, nil
+}
+
+// nextFreeAddress returns the first address of a prefix that is not acquired. The addresses are looked up
+// by primary key, in batches, from the next address of the prefix, which then moves past the returned address.
+func nextFreeAddress(tx *gorm.DB, namespace uuid.UUID, prefix netip.Prefix) (netip.Addr, error) {
+	start, stored, err := readNextAddress(tx, namespace, prefix)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	for ip := start; prefix.Contains(ip); {
+		var candidates []string
+		for ; prefix.Contains(ip) && len(candidates) < poolProbeSize; ip = ip.Next() {
+			candidates = append(candidates, ip.String())
+		}
+		var held []string
+		if res := tx.Model(&ipamAddress{}).Where("namespace_id = ? AND address IN ?", namespace, candidates).Pluck("address", &held); res.Error != nil {
+			return netip.Addr{}, res.Error
+		}
+		used := make(map[string]bool, len(held))
+		for _, a := range held {
+			used[a] = true
+		}
+		for _, c := range candidates {
+			if used[c] {
+				continue
+			}
+			free := netip.MustParseAddr(c)
+			// a concurrent release moved the next address back already when it changed meanwhile
+			res := tx.Model(&ipamPrefix{}).
+				Where("namespace_id = ? AND cidr = ? AND next_address = ?", namespace, prefix.String(), stored).
+				Update("next_address", free.Next().String())
+			if res.Error != nil {
+				return netip.Addr{}, res.Error
+			}
+			return free, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("%w: no more ips in prefix: %s left", goipam.ErrNoIPAvailable, prefix)
+}
+
+func (i *embeddedIPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) error {
+	ctx, span := tracer.Start(parent, "AssignPrefix")
+	defer span.End()
+	cidr, err := cleanCidr(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid prefix requested: %w", err)
+	}
+	prefix, err := parseMaskedPrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid prefix requested: %w", err)
+	}
+	return i.transaction(ctx, func(tx *gorm.DB) error {
+		if err := namespaceExists(tx, namespace); err != nil {
+			return err
+		}
+		var existing []string
+		if res := tx.Model(&ipamPrefix{}).Where("namespace_id = ?", namespace).Pluck("cidr", &existing); res.Error != nil {
+			return res.Error
+		}
+		for _, e := range existing {
+			// the prefix was already created
+			if e == prefix.String() {
+				return nil
+			}
+		}
+		if err := goipam.PrefixesOverlapping(existing, []string{prefix.String()}); err != nil {
+			return err
+		}
+		if res := tx.Create(&ipamPrefix{NamespaceID: namespace, Cidr: prefix.String()}); res.Error != nil {
+			return res.Error
+		}
+		for _, reserved := range reservedAddresses(prefix) {
+			if res := tx.Create(&ipamAddress{NamespaceID: namespace, Address: reserved.String(), Cidr: prefix.String()}); res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+}
+
+// ReleaseToPool release the ipam address back to the specified prefix
+func (i *embeddedIPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error {
+	err := i.transaction(ctx, func(tx *gorm.DB) error {
+		prefix, err := readPrefix(tx, namespace, cidr)
+		if err != nil {
+			return err
+		}
+		res := tx.Where("namespace_id = ? AND cidr = ? AND address = ?", namespace, prefix.String(), address).Delete(&ipamAddress{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: unable to release ip:%s because it is not allocated in prefix:%s", goipam.ErrNotFound, address, cidr)
+		}
+
+		// the pool is picked from the released address again when it comes before the next address
+		released, err := netip.ParseAddr(address)
+		if err != nil {
+			return err
+		}
+		next, _, err := readNextAddress(tx, namespace, prefix)
+		if err != nil {
+			return err
+		}
+		if released.Less(next) {
+			return tx.Model(&ipamPrefix{}).
+				Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).
+				Update("next_address", released.String()).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release IPAM address %w", err)
+	}
+	return nil
+}
+
+// ReleaseCIDR release the ipam address back to the specified prefix
+func (i *embeddedIPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error {
+	err := i.transaction(ctx, func(tx *gorm.DB) error {
+		prefix, err := readPrefix(tx, namespace, cidr)
+		if err != nil {
+			return err
+		}
+		var count int64
+		if res := tx.Model(&ipamAddress{}).Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Count(&count); res.Error != nil {
+			return res.Error
+		}
+		if count > int64(len(reservedAddresses(prefix))) {
+			return fmt.Errorf("prefix %s has ips, delete prefix not possible", prefix)
+		}
+		if res := tx.Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Delete(&ipamAddress{}); res.Error != nil {
+			return res.Error
+		}
+		return tx.Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Delete(&ipamPrefix{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release IPAM prefix %w", err)
+	}
+	return nil
+}
+
+func (i *embeddedIPAM) ListNamespaces(parent context.Context) ([]uuid.UUID, error) {
+	ctx, span := tracer.Start(parent, "ListNamespaces")
+	defer span.End()
+	var namespaces []uuid.UUID
+	if res := i.db.WithContext(ctx).Model(&ipamNamespace{}).Pluck("id", &namespaces); res.Error != nil {
+		return nil, fmt.Errorf("failed to list IPAM namespaces %w", res.Error)
+	}
+	return namespaces, nil
+}
+
+func (i *embeddedIPAM) HasPrefix(parent context.Context, namespace uuid.UUID, cidr string) (bool, error) {
+	ctx, span := tracer.Start(parent, "HasPrefix")
+	defer span.End()
+	_, err := readPrefix(i.db.WithContext(ctx), namespace, cidr)
+	if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNamespaceDoesNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the IPAM prefix %w", err)
+	}
+	return true, nil
+}
+
+func (i *embeddedIPAM) AcquiredIPs(parent context.Context, namespace uuid.UUID, cidr string) (uint64, error) {
+	ctx, span := tracer.Start(parent, "AcquiredIPs")
+	defer span.End()
+	db := i.db.WithContext(ctx)
+	prefix, err := readPrefix(db, namespace, cidr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the IPAM prefix usage %w", err)
+	}
+	var count int64
+	if res := db.Model(&ipamAddress{}).Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Count(&count); res.Error != nil {
+		return 0, fmt.Errorf("failed to get the IPAM prefix usage %w", res.Error)
+	}
+	return uint64(count), nil
+}
+
+func (i *embeddedIPAM) AcquiredAddresses(parent context.Context, namespace uuid.UUID, cidr string) ([]string, error) {
+	ctx, span := tracer.Start(parent, "AcquiredAddresses")
+	defer span.End()
+	db := i.db.WithContext(ctx)
+	prefix, err := readPrefix(db, namespace, cidr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the IPAM prefix addresses %w", err)
+	}
+	var addresses []string
+	if res := db.Model(&ipamAddress{}).Where("namespace_id = ? AND cidr = ?", namespace, prefix.String()).Pluck("address", &addresses); res.Error != nil {
+		return nil, fmt.Errorf("failed to list the IPAM prefix addresses %w", res.Error)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,213 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+	"gorm.io/gorm"
+)
+
+type EmbeddedIpamTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ipam TransactionalIPAM
+}
+
+func (suite *EmbeddedIpamTestSuite) SetupSuite() {
+	db, err := database.NewTestDatabase()
+	suite.Require().NoError(err)
+	suite.db = db
+	suite.ipam, err = NewEmbeddedIPAM(zaptest.NewLogger(suite.T()).Sugar(), db)
+	suite.Require().NoError(err)
+}
+
+func (suite *EmbeddedIpamTestSuite) TestAllocateTunnelIP() {
+	require := suite.Require()
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "10.20.30.0/24"
+
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, "10.20.30.7/24"))
+	require.Error(suite.ipam.AssignCIDR(ctx, namespace, "10.20.0.0/16"))
+
+	_, err := suite.ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "notanipaddress")
+	require.Error(err)
+
+	ip, err := suite.ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.30.1")
+	require.NoError(err)
+	require.Equal("10.20.30.1", ip)
+
+	// conflicting and mismatched addresses are assigned from the pool
+	ip, err = suite.ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.30.1")
+	require.NoError(err)
+	require.Equal("10.20.30.2", ip)
+	ip, err = suite.ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.40.1")
+	require.NoError(err)
+	require.Equal("10.20.30.3", ip)
+
+	err = suite.ipam.AcquireIP(ctx, namespace, prefix, "10.20.30.2")
+	require.True(IsAlreadyAllocated(err), "%v", err)
+	require.True(IsAlreadyAllocated(suite.ipam.AcquireIP(ctx, namespace, prefix, "10.20.30.255")))
+
+	// the network and broadcast addresses are acquired along with the prefix
+	acquired, err := suite.ipam.AcquiredIPs(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal(uint64(5), acquired)
+
+	require.NoError(suite.ipam.ReleaseToPool(ctx, namespace, "10.20.30.2", prefix))
+	require.Error(suite.ipam.ReleaseToPool(ctx, namespace, "10.20.30.2", prefix))
+	ip, err = suite.ipam.AssignFromPool(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal("10.20.30.2", ip)
+}
+
+func (suite *EmbeddedIpamTestSuite) TestAssignFromPoolOrder() {
+	require := suite.Require()
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "10.20.60.0/24"
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+
+	// the pool is picked in order, across more than one batch of looked up addresses
+	require.NoError(suite.ipam.AcquireIP(ctx, namespace, prefix, "10.20.60.100"))
+	for n := 1; n <= 2*poolProbeSize; n++ {
+		ip, err := suite.ipam.AssignFromPool(ctx, namespace, prefix)
+		require.NoError(err)
+		if n < 100 {
+			require.Equal(fmt.Sprintf("10.20.60.%d", n), ip)
+		} else {
+			require.Equal(fmt.Sprintf("10.20.60.%d", n+1), ip)
+		}
+	}
+
+	// the lowest released address is picked first
+	require.NoError(suite.ipam.ReleaseToPool(ctx, namespace, "10.20.60.40", prefix))
+	require.NoError(suite.ipam.ReleaseToPool(ctx, namespace, "10.20.60.7", prefix))
+	for _, expected := range []string{"10.20.60.7", "10.20.60.40", fmt.Sprintf("10.20.60.%d", 2*poolProbeSize+2)} {
+		ip, err := suite.ipam.AssignFromPool(ctx, namespace, prefix)
+		require.NoError(err)
+		require.Equal(expected, ip)
+	}
+}
+
+func (suite *EmbeddedIpamTestSuite) TestPointToPointPrefixes() {
+	require := suite.Require()
+	ctx := context.Background()
+	namespace := uuid.New()
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+
+	// the single address of a /32 is held once
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, "172.16.9.5/32"))
+	acquired, err := suite.ipam.AcquiredIPs(ctx, namespace, "172.16.9.5/32")
+	require.NoError(err)
+	require.Equal(uint64(1), acquired)
+	_, err = suite.ipam.AssignFromPool(ctx, namespace, "172.16.9.5/32")
+	require.True(IsExhausted(err), "%v", err)
+	require.NoError(suite.ipam.ReleaseCIDR(ctx, namespace, "172.16.9.5/32"))
+
+	// both addresses of a /31 are held
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, "172.16.9.6/31"))
+	acquired, err = suite.ipam.AcquiredIPs(ctx, namespace, "172.16.9.6/31")
+	require.NoError(err)
+	require.Equal(uint64(2), acquired)
+	_, err = suite.ipam.AssignFromPool(ctx, namespace, "172.16.9.6/31")
+	require.True(IsExhausted(err), "%v", err)
+	require.NoError(suite.ipam.ReleaseCIDR(ctx, namespace, "172.16.9.6/31"))
+
+	found, err := suite.ipam.HasPrefix(ctx, namespace, "172.16.9.6/31")
+	require.NoError(err)
+	require.False(found)
+}
+
+func (suite *EmbeddedIpamTestSuite) TestWithTx() {
+	require := suite.Require()
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "10.20.50.0/24"
+
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+	require.NoError(suite.ipam.AcquireIP(ctx, namespace, prefix, "10.20.50.1"))
+
+	// a failed change does not abort the transaction, and the changes are rolled back along with it
+	errRollback := errors.New("rollback")
+	err := suite.db.Transaction(func(tx *gorm.DB) error {
+		txIpam := suite.ipam.WithTx(tx)
+		require.True(IsAlreadyAllocated(txIpam.AcquireIP(ctx, namespace, prefix, "10.20.50.1")))
+		ip, err := txIpam.AssignFromPool(ctx, namespace, prefix)
+		require.NoError(err)
+		require.Equal("10.20.50.2", ip)
+		return errRollback
+	})
+	require.ErrorIs(err, errRollback)
+	acquired, err := suite.ipam.AcquiredIPs(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal(uint64(3), acquired)
+
+	err = suite.db.Transaction(func(tx *gorm.DB) error {
+		_, err := suite.ipam.WithTx(tx).AssignFromPool(ctx, namespace, prefix)
+		return err
+	})
+	require.NoError(err)
+	acquired, err = suite.ipam.AcquiredIPs(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal(uint64(4), acquired)
+}
+
+func (suite *EmbeddedIpamTestSuite) TestPrefixesAndNamespaces() {
+	require := suite.Require()
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "fc00:20::/64"
+
+	require.Error(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+	require.NoError(suite.ipam.CreateNamespace(ctx, namespace))
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+
+	found, err := suite.ipam.HasPrefix(ctx, namespace, prefix)
+	require.NoError(err)
+	require.True(found)
+	found, err = suite.ipam.HasPrefix(ctx, namespace, "fc00:21::/64")
+	require.NoError(err)
+	require.False(found)
+	found, err = suite.ipam.HasPrefix(ctx, uuid.New(), prefix)
+	require.NoError(err)
+	require.False(found)
+
+	ip, err := suite.ipam.AssignFromPool(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal("fc00:20::1", ip)
+
+	// a prefix is not released while addresses are acquired from it
+	require.Error(suite.ipam.ReleaseCIDR(ctx, namespace, prefix))
+	require.NoError(suite.ipam.ReleaseToPool(ctx, namespace, ip, prefix))
+	require.NoError(suite.ipam.ReleaseCIDR(ctx, namespace, prefix))
+	found, err = suite.ipam.HasPrefix(ctx, namespace, prefix)
+	require.NoError(err)
+	require.False(found)
+
+	require.NoError(suite.ipam.AssignCIDR(ctx, namespace, prefix))
+	namespaces, err := suite.ipam.ListNamespaces(ctx)
+	require.NoError(err)
+	require.Contains(namespaces, namespace)
+
+	require.NoError(suite.ipam.DeleteNamespace(ctx, namespace))
+	require.Error(suite.ipam.DeleteNamespace(ctx, namespace))
+	namespaces, err = suite.ipam.ListNamespaces(ctx)
+	require.NoError(err)
+	require.NotContains(namespaces, namespace)
+}
+
+func TestEmbeddedIpamTestSuite(t *testing.T) {
+	suite.Run(t, new(EmbeddedIpamTestSuite))
+}
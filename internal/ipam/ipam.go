@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/google/uuid"
+	goipam "github.com/metal-stack/go-ipam"
 	apiv1 "github.com/metal-stack/go-ipam/api/v1"
 	"github.com/metal-stack/go-ipam/api/v1/apiv1connect"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 var tracer trace.Tracer
@@ -26,13 +29,59 @@ func uuidToNamespace(id uuid.UUID) string {
 	return strings.ReplaceAll(id.String(), "-", "_")
 }
 
-type IPAM struct {
+// IPAM assigns the prefixes and addresses of the VPCs from namespaces.
+type IPAM interface {
+	// CreateNamespace creates a namespace if it does not exist.
+	CreateNamespace(ctx context.Context, namespace uuid.UUID) error
+	// DeleteNamespace deletes a namespace along with its prefixes and addresses.
+	DeleteNamespace(ctx context.Context, namespace uuid.UUID) error
+	// AcquireIP acquires a specific address of a prefix.
+	AcquireIP(ctx context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error
+	// AssignSpecificTunnelIP acquires a specific address of a prefix, or an address from the pool
+	// when the address is not available.
+	AssignSpecificTunnelIP(ctx context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error)
+	// AssignFromPool acquires the next available address of a prefix.
+	AssignFromPool(ctx context.Context, namespace uuid.UUID, ipamPrefix string) (string, error)
+	// AssignCIDR creates a prefix if it does not exist.
+	AssignCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error
+	// ReleaseToPool releases an address of a prefix.
+	ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error
+	// ReleaseCIDR deletes a prefix, it fails while addresses of the prefix are acquired.
+	ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error
+	// ListNamespaces returns the namespaces that were created for a uuid.
+	ListNamespaces(ctx context.Context) ([]uuid.UUID, error)
+	// HasPrefix returns whether a namespace has a prefix.
+	HasPrefix(ctx context.Context, namespace uuid.UUID, cidr string) (bool, error)
+	// AcquiredIPs returns the number of addresses acquired from a prefix, including the network address
+	// of the prefix and the broadcast address of IPv4 prefixes.
+	AcquiredIPs(ctx context.Context, namespace uuid.UUID, cidr string) (uint64, error)
+}
+
+// TransactionalIPAM is an IPAM that keeps its state in the apiserver database, so that its changes can be
+// made in the transactions of the apiserver and are rolled back along with them.
+type TransactionalIPAM interface {
+	IPAM
+	// WithTx returns the IPAM making its changes in the transaction tx.
+	WithTx(tx *gorm.DB) IPAM
+}
+
+// AddressLister is an IPAM that can list the addresses acquired from a prefix, so that the leaked addresses
+// of prefixes of any size can be found without probing them one by one.
+type AddressLister interface {
+	// AcquiredAddresses returns the addresses acquired from a prefix, including the network address of the
+	// prefix and the broadcast address of IPv4 prefixes.
+	AcquiredAddresses(ctx context.Context, namespace uuid.UUID, cidr string) ([]string, error)
+}
+
+// clientIPAM is the IPAM of the go-ipam grpc service.
+type clientIPAM struct {
 	logger *zap.SugaredLogger
 	client apiv1connect.IpamServiceClient
 }
 
+// NewIPAM returns the IPAM of the go-ipam grpc service at ipamAddress.
 func NewIPAM(logger *zap.SugaredLogger, ipamAddress string) IPAM {
-	return IPAM{
+	return &clientIPAM{
 		logger: logger,
 		client: apiv1connect.NewIpamServiceClient(
 			http.DefaultClient,
@@ -41,7 +90,7 @@ func NewIPAM(logger *zap.SugaredLogger, ipamAddress string) IPAM {
 		)}
 }
 
-func (i *IPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) error {
+func (i *clientIPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) error {
 	ctx, span := tracer.Start(parent, "CreateNamespace")
 	defer span.End()
 	_, err := i.client.CreateNamespace(ctx, connect.NewRequest(&apiv1.CreateNamespaceRequest{
@@ -50,7 +99,7 @@ func (i *IPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) erro
 	return err
 }
 
-func (i *IPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) error {
+func (i *clientIPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) error {
 	ctx, span := tracer.Start(parent, "DeleteNamespace")
 	defer span.End()
 	_, err := i.client.DeleteNamespace(ctx, connect.NewRequest(&apiv1.DeleteNamespaceRequest{
@@ -59,7 +108,7 @@ func (i *IPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) erro
 	return err
 }
 
-func (i *IPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error {
+func (i *clientIPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error {
 	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
 	defer span.End()
 	if err := validateIP(TunnelIP); err != nil {
@@ -74,7 +123,7 @@ func (i *IPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix
 	return err
 }
 
-func (i *IPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error) {
+func (i *clientIPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error) {
 	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
 	defer span.End()
 	if err := validateIP(TunnelIP); err != nil {
@@ -93,7 +142,7 @@ func (i *IPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUI
 	return res.Msg.Ip.Ip, nil
 }
 
-func (i *IPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (string, error) {
+func (i *clientIPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (string, error) {
 	ctx, span := tracer.Start(parent, "AssignFromPool")
 	defer span.End()
 	ns := uuidToNamespace(namespace)
@@ -107,7 +156,7 @@ func (i *IPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamP
 	return res.Msg.Ip.Ip, nil
 }
 
-func (i *IPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) error {
+func (i *clientIPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) error {
 	ctx, span := tracer.Start(parent, "AssignPrefix")
 	defer span.End()
 	cidr, err := cleanCidr(cidr)
@@ -130,7 +179,7 @@ func (i *IPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr stri
 }
 
 // ReleaseToPool release the ipam address back to the specified prefix
-func (i *IPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error {
+func (i *clientIPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error {
 	ns := uuidToNamespace(namespace)
 	_, err := i.client.ReleaseIP(ctx, connect.NewRequest(&apiv1.ReleaseIPRequest{
 		Ip:         address,
@@ -145,7 +194,7 @@ func (i *IPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address,
 }
 
 // ReleaseCIDR release the ipam address back to the specified prefix
-func (i *IPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error {
+func (i *clientIPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error {
 	ns := uuidToNamespace(namespace)
 	_, err := i.client.DeletePrefix(ctx, connect.NewRequest(&apiv1.DeletePrefixRequest{
 		Cidr:      cidr,
@@ -158,6 +207,91 @@ func (i *IPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string
 	return nil
 }
 
+// ListNamespaces returns the namespaces of IPAM that were created for a uuid.
+func (i *clientIPAM) ListNamespaces(parent context.Context) ([]uuid.UUID, error) {
+	ctx, span := tracer.Start(parent, "ListNamespaces")
+	defer span.End()
+	res, err := i.client.ListNamespaces(ctx, connect.NewRequest(&apiv1.ListNamespacesRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IPAM namespaces %w", err)
+	}
+	var namespaces []uuid.UUID
+	for _, ns := range res.Msg.Namespace {
+		// skip the namespaces IPAM creates for itself
+		id, err := uuid.Parse(strings.ReplaceAll(ns, "_", "-"))
+		if err != nil {
+			continue
+		}
+		namespaces = append(namespaces, id)
+	}
+	return namespaces, nil
+}
+
+// HasPrefix returns whether a namespace has a prefix.
+func (i *clientIPAM) HasPrefix(parent context.Context, namespace uuid.UUID, cidr string) (bool, error) {
+	ctx, span := tracer.Start(parent, "HasPrefix")
+	defer span.End()
+	ns := uuidToNamespace(namespace)
+	_, err := i.client.PrefixUsage(ctx, connect.NewRequest(&apiv1.PrefixUsageRequest{Cidr: cidr, Namespace: &ns}))
+	if connect.CodeOf(err) == connect.CodeInvalidArgument {
+		// IPAM does not find the prefix
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the IPAM prefix %w", err)
+	}
+	return true, nil
+}
+
+// AcquiredIPs returns the number of addresses acquired from a prefix, including the network address of the
+// prefix and the broadcast address of IPv4 prefixes.
+func (i *clientIPAM) AcquiredIPs(parent context.Context, namespace uuid.UUID, cidr string) (uint64, error) {
+	ctx, span := tracer.Start(parent, "AcquiredIPs")
+	defer span.End()
+	ns := uuidToNamespace(namespace)
+	res, err := i.client.PrefixUsage(ctx, connect.NewRequest(&apiv1.PrefixUsageRequest{Cidr: cidr, Namespace: &ns}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the IPAM prefix usage %w", err)
+	}
+	return res.Msg.AcquiredIps, nil
+}
+
+// IsAlreadyAllocated returns whether AcquireIP failed because the address is already acquired.
+func IsAlreadyAllocated(err error) bool {
+	return err != nil && strings.Contains(err.Error(), goipam.ErrAlreadyAllocated.Error())
+}
+
+// IsNotFound returns whether releasing failed because the address or prefix is not acquired.
+func IsNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), goipam.ErrNotFound.Error())
+}
+
+// IsExhausted returns whether acquiring an address failed because every address of the prefix is acquired.
+func IsExhausted(err error) bool {
+	return err != nil && strings.Contains(err.Error(), goipam.ErrNoIPAvailable.Error())
+}
+
+// RangeAddresses returns the addresses of a range IPAM can assign from a prefix: IPAM holds the network
+// address of the prefix, and the broadcast address of IPv4 prefixes, for itself.
+func RangeAddresses(prefixCidr string, r netip.Prefix) []string {
+	prefix, err := netip.ParsePrefix(prefixCidr)
+	if err != nil {
+		return nil
+	}
+	prefix = prefix.Masked()
+	var addresses []string
+	for a := r.Masked().Addr(); r.Contains(a); a = a.Next() {
+		if a == prefix.Addr() {
+			continue
+		}
+		if a.Is4() && !prefix.Contains(a.Next()) {
+			continue
+		}
+		addresses = append(addresses, a.String())
+	}
+	return addresses
+}
+
 // cleanCidr ensures a valid IP4/IP6 address is provided and return a proper
 // network prefix if the network address if the network address was not precise.
 // example: if a user provides 192.168.1.1/24 we will infer 192.168.1.0/24.
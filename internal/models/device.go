@@ -13,13 +13,19 @@ type Device struct {
 	Base
 	OwnerID         uuid.UUID      `json:"owner_id"`
 	VpcID           uuid.UUID      `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
-	OrganizationID  uuid.UUID      `json:"-"` // Denormalized from the VPC record for performance
+	SubnetID        *uuid.UUID     `json:"subnet_id,omitempty"` // the subnet of the VPC the device joined, if any
+	OrganizationID  uuid.UUID      `json:"-"`                   // Denormalized from the VPC record for performance
 	PublicKey       string         `json:"public_key"`
 	AllowedIPs      pq.StringArray `json:"allowed_ips" gorm:"type:text[]" swaggertype:"array,string"`
 	IPv4TunnelIPs   []TunnelIP     `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
 	IPv6TunnelIPs   []TunnelIP     `json:"ipv6_tunnel_ips" gorm:"type:JSONB; serializer:json"`
 	AdvertiseCidrs  pq.StringArray `json:"advertise_cidrs" gorm:"type:text[]" swaggertype:"array,string"`
+	ApprovedCidrs   pq.StringArray `json:"approved_cidrs" gorm:"type:text[]" swaggertype:"array,string"` // the advertised CIDRs an organization owner approved, only these are routed by peers
+	RejectedCidrs   pq.StringArray `json:"rejected_cidrs" gorm:"type:text[]" swaggertype:"array,string"` // the advertised CIDRs an organization owner rejected
+	RouterPriority  int            `json:"router_priority"`                                              // peers route a prefix advertised by several devices to the online device with the highest priority
 	Relay           bool           `json:"relay"`
+	Hub             bool           `json:"hub"`                                                          // hubs peer with every device when the VPC is not a full mesh
+	PeeringGroups   pq.StringArray `json:"peering_groups" gorm:"type:text[]" swaggertype:"array,string"` // devices sharing a peering group peer with each other when the VPC topology is peering-groups
 	SymmetricNat    bool           `json:"symmetric_nat"`
 	Hostname        string         `json:"hostname"`
 	Os              string         `json:"os"`
@@ -35,8 +41,10 @@ type Device struct {
 // AddDevice is the information needed to add a new Device.
 type AddDevice struct {
 	VpcID           uuid.UUID  `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	SubnetID        *uuid.UUID `json:"subnet_id,omitempty"` // the subnet of the VPC to join, the subnet of the registration key takes precedence
 	PublicKey       string     `json:"public_key"`
 	AdvertiseCidrs  []string   `json:"advertise_cidrs" example:"172.16.42.0/24"`
+	RouterPriority  int        `json:"router_priority" example:"100"`
 	IPv4TunnelIPs   []TunnelIP `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
 	Relay           bool       `json:"relay"`
 	SymmetricNat    bool       `json:"symmetric_nat"`
@@ -50,10 +58,27 @@ type AddDevice struct {
 type UpdateDevice struct {
 	VpcID           *uuid.UUID `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
 	AdvertiseCidrs  []string   `json:"advertise_cidrs" example:"172.16.42.0/24"`
+	RouterPriority  *int       `json:"router_priority" example:"100"`
 	SymmetricNat    *bool      `json:"symmetric_nat"`
 	Hostname        string     `json:"hostname" example:"myhost"`
 	Endpoints       []Endpoint `json:"endpoints" gorm:"type:JSONB; serializer:json"`
 	Revision        *uint64    `json:"revision"`
 	Relay           *bool      `json:"relay"`
 	SecurityGroupId *uuid.UUID `json:"security_group_id"`
+	Hub             *bool      `json:"hub"`                                  // only organization owners can designate hubs
+	PeeringGroups   []string   `json:"peering_groups" example:"branch-east"` // only organization owners can set peering groups
+}
+
+// DeviceRoutes is a list of advertised CIDRs of a device to approve or reject.
+type DeviceRoutes struct {
+	Cidrs []string `json:"cidrs" example:"172.16.42.0/24"`
+}
+
+// DeviceTombstone records that a device was moved out of a VPC, so that the devices
+// watching the VPC are sent its deletion.
+type DeviceTombstone struct {
+	DeviceID  uuid.UUID `gorm:"type:uuid;primary_key"`
+	VpcID     uuid.UUID `gorm:"type:uuid;primary_key"`
+	Revision  uint64    `gorm:"type:bigserial;index:"` // from the revision sequence of the devices
+	CreatedAt time.Time
 }
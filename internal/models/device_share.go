@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceShare grants a VPC of another organization access to a device. The device is served
+// read-only to the devices of the VPC, with its existing tunnel IPs.
+type DeviceShare struct {
+	DeviceID       uuid.UUID `json:"device_id" gorm:"type:uuid;primary_key"`
+	VpcID          uuid.UUID `json:"vpc_id"    gorm:"type:uuid;primary_key;index" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	OrganizationID uuid.UUID `json:"-"         gorm:"type:uuid;index"` // Denormalized from the VPC record for performance
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// AddDeviceShare is the information needed to share a device into a VPC.
+type AddDeviceShare struct {
+	VpcID uuid.UUID `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+}
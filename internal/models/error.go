@@ -138,3 +138,21 @@ func NewNotAllowedError(reason string) NotAllowedError {
 		},
 	}
 }
+
+// QuotaExceededError is returned in the body of an HTTP 403 when creating a resource would exceed
+// a quota of its organization
+type QuotaExceededError struct {
+	BaseError
+	Resource string `json:"resource" example:"devices"`
+	Quota    int64  `json:"quota" example:"100"`
+}
+
+func NewQuotaExceededError(resource string, quota int64) QuotaExceededError {
+	return QuotaExceededError{
+		Resource: resource,
+		Quota:    quota,
+		BaseError: BaseError{
+			Error: "quota exceeded",
+		},
+	}
+}
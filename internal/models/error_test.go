@@ -18,3 +18,15 @@ func TestConflictsError(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, e, e2)
 }
+
+func TestQuotaExceededError(t *testing.T) {
+	e := NewQuotaExceededError(QuotaDevices, 100)
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	require.Equal(t, `{"error":"quota exceeded","resource":"devices","quota":100}`, string(b))
+
+	var e2 QuotaExceededError
+	err = json.Unmarshal(b, &e2)
+	require.NoError(t, err)
+	require.Equal(t, e, e2)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// IPReservation binds an address of a VPC to a device, a registration key or a hostname. The address is
+// kept out of the pool of the VPC, and only a device matching the reservation is assigned it.
+type IPReservation struct {
+	Base
+	VpcID          uuid.UUID  `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	OrganizationID uuid.UUID  `json:"-"`                                  // Denormalized from the VPC record for performance
+	Address        string     `json:"address" example:"100.64.0.10"`      // an IPv4 or IPv6 address of the VPC
+	DeviceID       *uuid.UUID `json:"device_id,omitempty"`                // DeviceID reserves the address for the device with this id
+	RegKeyID       *uuid.UUID `json:"reg_key_id,omitempty"`               // RegKeyID reserves the address for the device registered with this registration key
+	Hostname       string     `json:"hostname,omitempty" example:"web-1"` // Hostname reserves the address for the device with this hostname
+	Description    string     `json:"description,omitempty" example:"the web server of the team"`
+}
+
+// AddIPReservation is the information needed to reserve an address of a VPC. Exactly one of DeviceID,
+// RegKeyID and Hostname must be set.
+type AddIPReservation struct {
+	Address     string     `json:"address" example:"100.64.0.10"`
+	DeviceID    *uuid.UUID `json:"device_id,omitempty"`
+	RegKeyID    *uuid.UUID `json:"reg_key_id,omitempty"`
+	Hostname    string     `json:"hostname,omitempty" example:"web-1"`
+	Description string     `json:"description,omitempty" example:"the web server of the team"`
+}
+
+// ExcludedRange keeps a range of addresses of a VPC out of the pool the devices of the VPC are assigned
+// addresses from.
+type ExcludedRange struct {
+	Base
+	VpcID          uuid.UUID `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	OrganizationID uuid.UUID `json:"-"` // Denormalized from the VPC record for performance
+	Cidr           string    `json:"cidr" example:"10.1.1.0/28"`
+	Description    string    `json:"description,omitempty" example:"statically addressed appliances"`
+}
+
+// AddExcludedRange is the information needed to exclude a range of addresses of a VPC.
+type AddExcludedRange struct {
+	Cidr        string `json:"cidr" example:"10.1.1.0/28"`
+	Description string `json:"description,omitempty" example:"statically addressed appliances"`
+}
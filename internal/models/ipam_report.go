@@ -0,0 +1,65 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// IPAMReport lists the differences between the IPAM namespaces and the addressing of the devices and VPCs.
+type IPAMReport struct {
+	// MissingNamespaces are the namespaces of VPCs that IPAM does not have
+	MissingNamespaces []uuid.UUID `json:"missing_namespaces,omitempty"`
+	// LeakedNamespaces are the namespaces IPAM still has for VPCs that no longer exist
+	LeakedNamespaces []uuid.UUID `json:"leaked_namespaces,omitempty"`
+	// MissingPrefixes are the CIDRs of VPCs and the CIDRs advertised by devices that IPAM does not have
+	MissingPrefixes []IPAMPrefix `json:"missing_prefixes,omitempty"`
+	// LeakedPrefixes are the prefixes IPAM still has that no VPC or device uses
+	LeakedPrefixes []IPAMPrefix `json:"leaked_prefixes,omitempty"`
+	// MissingAddresses are the addresses of devices, IP reservations and excluded ranges IPAM does not hold
+	MissingAddresses []IPAMAddress `json:"missing_addresses,omitempty"`
+	// LeakedAddresses are the addresses IPAM holds that no device, IP reservation or excluded range uses
+	LeakedAddresses []IPAMAddress `json:"leaked_addresses,omitempty"`
+	// UnaccountedAddresses are the numbers of leaked addresses of the prefixes too large to find them in, when
+	// IPAM cannot list the addresses of a prefix. They are not released by a repair.
+	UnaccountedAddresses []IPAMPrefix `json:"unaccounted_addresses,omitempty"`
+	// DuplicateAddresses are the addresses assigned to more than one device
+	DuplicateAddresses []IPAMDuplicateAddress `json:"duplicate_addresses,omitempty"`
+	// Errors are the problems found while verifying or repairing IPAM
+	Errors []string `json:"errors,omitempty"`
+	// Repaired is set when the differences were repaired
+	Repaired bool `json:"repaired"`
+}
+
+// Consistent returns whether IPAM matches the addressing of the devices and VPCs.
+func (r *IPAMReport) Consistent() bool {
+	return len(r.MissingNamespaces) == 0 && len(r.LeakedNamespaces) == 0 &&
+		len(r.MissingPrefixes) == 0 && len(r.LeakedPrefixes) == 0 &&
+		len(r.MissingAddresses) == 0 && len(r.LeakedAddresses) == 0 &&
+		len(r.UnaccountedAddresses) == 0 && len(r.DuplicateAddresses) == 0 &&
+		len(r.Errors) == 0
+}
+
+// IPAMPrefix is a prefix of an IPAM namespace.
+type IPAMPrefix struct {
+	Namespace uuid.UUID `json:"namespace"`
+	Cidr      string    `json:"cidr" example:"100.64.0.0/10"`
+	// Count is the number of unaccounted addresses of the prefix
+	Count uint64 `json:"count,omitempty"`
+}
+
+// IPAMAddress is an address of an IPAM namespace.
+type IPAMAddress struct {
+	Namespace uuid.UUID `json:"namespace"`
+	Cidr      string    `json:"cidr" example:"100.64.0.0/10"`
+	Address   string    `json:"address" example:"100.64.0.1"`
+	// Owner is the id of the device, IP reservation or excluded range the address is or was used by
+	Owner uuid.UUID `json:"owner,omitempty"`
+}
+
+// IPAMDuplicateAddress is an address assigned to more than one device.
+type IPAMDuplicateAddress struct {
+	Namespace uuid.UUID   `json:"namespace"`
+	Address   string      `json:"address" example:"100.64.0.1"`
+	DeviceIDs []uuid.UUID `json:"device_ids"`
+	// Readdressed are the devices that were assigned another address by the repair, the oldest device keeps the address
+	Readdressed []uuid.UUID `json:"readdressed,omitempty"`
+}
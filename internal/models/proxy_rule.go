@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	ProxyRuleTypeIngress = "ingress"
+	ProxyRuleTypeEgress  = "egress"
+)
+
+// ProxyRule is a userspace proxy rule that nexd applies on the device it is scoped to.
+type ProxyRule struct {
+	Base
+	DeviceID            uuid.UUID `json:"device_id"`
+	VpcID               uuid.UUID `json:"vpc_id"`
+	OrganizationID      uuid.UUID `json:"-"` // Denormalized from the device record for performance
+	Type                string    `json:"type" example:"ingress"`
+	Protocol            string    `json:"protocol" example:"tcp"`
+	ListenPort          int       `json:"listen_port" example:"8080"`
+	DestinationHost     string    `json:"destination_host" example:"127.0.0.1"`
+	DestinationPort     int       `json:"destination_port" example:"80"`
+	SendProxyProtocol   string    `json:"send_proxy_protocol,omitempty" example:"v2"`
+	AcceptProxyProtocol bool      `json:"accept_proxy_protocol,omitempty"`
+	Description         string    `json:"description"`
+	Revision            uint64    `json:"revision" gorm:"type:bigserial;index:"`
+}
+
+// AddProxyRule is the information needed to add a new proxy rule to a device.
+type AddProxyRule struct {
+	Type                string `json:"type" example:"ingress"`
+	Protocol            string `json:"protocol" example:"tcp"`
+	ListenPort          int    `json:"listen_port" example:"8080"`
+	DestinationHost     string `json:"destination_host" example:"127.0.0.1"`
+	DestinationPort     int    `json:"destination_port" example:"80"`
+	SendProxyProtocol   string `json:"send_proxy_protocol,omitempty" example:"v2"`
+	AcceptProxyProtocol bool   `json:"accept_proxy_protocol,omitempty"`
+	Description         string `json:"description"`
+}
+
+// UpdateProxyRule is the information needed to update an existing proxy rule.
+type UpdateProxyRule struct {
+	ListenPort          *int    `json:"listen_port,omitempty" example:"8080"`
+	DestinationHost     *string `json:"destination_host,omitempty" example:"127.0.0.1"`
+	DestinationPort     *int    `json:"destination_port,omitempty" example:"80"`
+	SendProxyProtocol   *string `json:"send_proxy_protocol,omitempty" example:"v2"`
+	AcceptProxyProtocol *bool   `json:"accept_proxy_protocol,omitempty"`
+	Description         *string `json:"description,omitempty"`
+}
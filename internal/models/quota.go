@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The resources of an organization limited by quotas
+const (
+	QuotaDevices        = "devices"
+	QuotaVpcs           = "vpcs"
+	QuotaRegKeys        = "reg_keys"
+	QuotaSecurityGroups = "security_groups"
+	QuotaInvitations    = "invitations"
+)
+
+// Quotas are the maximum number of each resource an organization can have. A quota of 0 is unlimited.
+type Quotas struct {
+	Devices        int64 `json:"devices" example:"100"`
+	Vpcs           int64 `json:"vpcs" example:"10"`
+	RegKeys        int64 `json:"reg_keys" example:"100"`
+	SecurityGroups int64 `json:"security_groups" example:"50"`
+	Invitations    int64 `json:"invitations" example:"20"`
+}
+
+// Quota returns the quota of a resource.
+func (q Quotas) Quota(resource string) int64 {
+	switch resource {
+	case QuotaDevices:
+		return q.Devices
+	case QuotaVpcs:
+		return q.Vpcs
+	case QuotaRegKeys:
+		return q.RegKeys
+	case QuotaSecurityGroups:
+		return q.SecurityGroups
+	case QuotaInvitations:
+		return q.Invitations
+	}
+	return 0
+}
+
+// OrganizationQuota overrides the service wide default quotas for an organization. The default quota
+// of a resource applies when its override is not set.
+type OrganizationQuota struct {
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;primary_key" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
+	Devices        *int64    `json:"devices,omitempty" example:"500"`
+	Vpcs           *int64    `json:"vpcs,omitempty" example:"10"`
+	RegKeys        *int64    `json:"reg_keys,omitempty" example:"100"`
+	SecurityGroups *int64    `json:"security_groups,omitempty" example:"50"`
+	Invitations    *int64    `json:"invitations,omitempty" example:"20"`
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"-"`
+}
+
+// Apply returns the quotas with the overrides of the organization applied.
+func (o OrganizationQuota) Apply(quotas Quotas) Quotas {
+	override := func(quota *int64, value *int64) {
+		if value != nil {
+			*quota = *value
+		}
+	}
+	override(&quotas.Devices, o.Devices)
+	override(&quotas.Vpcs, o.Vpcs)
+	override(&quotas.RegKeys, o.RegKeys)
+	override(&quotas.SecurityGroups, o.SecurityGroups)
+	override(&quotas.Invitations, o.Invitations)
+	return quotas
+}
+
+// UpdateOrganizationQuota replaces the quota overrides of an organization. Leave out a resource to use its default quota.
+type UpdateOrganizationQuota struct {
+	Devices        *int64 `json:"devices,omitempty" example:"500"`
+	Vpcs           *int64 `json:"vpcs,omitempty" example:"10"`
+	RegKeys        *int64 `json:"reg_keys,omitempty" example:"100"`
+	SecurityGroups *int64 `json:"security_groups,omitempty" example:"50"`
+	Invitations    *int64 `json:"invitations,omitempty" example:"20"`
+}
+
+// ResourceUsage is the number of a resource an organization has and its quota.
+type ResourceUsage struct {
+	Used  int64 `json:"used" example:"42"`
+	Quota int64 `json:"quota" example:"100"` // 0 when unlimited
+}
+
+// OrganizationUsage is the consumption of the quotas of an organization.
+type OrganizationUsage struct {
+	OrganizationID uuid.UUID     `json:"organization_id" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
+	Devices        ResourceUsage `json:"devices"`
+	Vpcs           ResourceUsage `json:"vpcs"`
+	RegKeys        ResourceUsage `json:"reg_keys"`
+	SecurityGroups ResourceUsage `json:"security_groups"`
+	Invitations    ResourceUsage `json:"invitations"`
+}
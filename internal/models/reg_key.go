@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // RegKey is used to register devices without an interactive login.
@@ -12,6 +13,7 @@ type RegKey struct {
 	Base
 	OwnerID          uuid.UUID              `json:"owner_id,omitempty"`                            // OwnerID is the ID of the user that created the registration key.
 	VpcID            *uuid.UUID             `json:"vpc_id,omitempty"`                              // VpcID is the ID of the VPC the device can join.
+	SubnetID         *uuid.UUID             `json:"subnet_id,omitempty"`                           // SubnetID is the ID of the subnet of the VPC the device joins.
 	OrganizationID   *uuid.UUID             `json:"-" gorm:"type:uuid"`                            // OrganizationID is denormalized from the VPC record for performance
 	ServiceNetworkID *uuid.UUID             `json:"service_network_id,omitempty"`                  // ServiceNetworkID is the ID of the Service Network the device can join.
 	SNOrganizationID *uuid.UUID             `json:"-" gorm:"type:uuid; column:sn_organization_id"` // OrganizationID is denormalized from the ServiceNetwork record for performance
@@ -21,6 +23,9 @@ type RegKey struct {
 	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`                          // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
 	SecurityGroupId  *uuid.UUID             `json:"security_group_id"`                             // SecurityGroupId is the ID of the security group to assign to the device.
 	Settings         map[string]interface{} `json:"settings" gorm:"type:JSONB; serializer:json"`   // Settings contains general settings for the device.
+
+	// AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
+	AutoApproveCidrs pq.StringArray `json:"auto_approve_cidrs,omitempty" gorm:"type:text[]" swaggertype:"array,string"`
 }
 type NexodusClaims struct {
 	jwt.RegisteredClaims
@@ -32,12 +37,14 @@ type NexodusClaims struct {
 
 type AddRegKey struct {
 	VpcID            *uuid.UUID             `json:"vpc_id,omitempty"`             // VpcID is the ID of the VPC the device will join.
+	SubnetID         *uuid.UUID             `json:"subnet_id,omitempty"`          // SubnetID is the ID of the subnet of the VPC the device will join.
 	ServiceNetworkID *uuid.UUID             `json:"service_network_id,omitempty"` // ServiceNetworkID is the ID of the Service Network the device can join.
 	Description      string                 `json:"description,omitempty"`        // Description of the registration key.
 	SingleUse        bool                   `json:"single_use,omitempty"`         // SingleUse only allows the registration key to be used once.
 	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`         // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
 	SecurityGroupId  *uuid.UUID             `json:"security_group_id"`            // SecurityGroupId is the ID of the security group to assign to the device.
 	Settings         map[string]interface{} `json:"settings"`                     // Settings contains general settings for the device.
+	AutoApproveCidrs []string               `json:"auto_approve_cidrs,omitempty"` // AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
 }
 
 type UpdateRegKey struct {
@@ -45,4 +52,7 @@ type UpdateRegKey struct {
 	ExpiresAt       *time.Time             `json:"expires_at,omitempty"`  // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
 	SecurityGroupId *uuid.UUID             `json:"security_group_id"`     // SecurityGroupId is the ID of the security group to assign to the device.
 	Settings        map[string]interface{} `json:"settings"`              // Settings contains general settings for the device.
+
+	// AutoApproveCidrs approves CIDRs advertised by devices registered with the key that fall within these prefixes.
+	AutoApproveCidrs []string `json:"auto_approve_cidrs,omitempty"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Subnet is a named slice of the CIDRs of a VPC. The devices joining the subnet are assigned addresses
+// from its CIDRs and get its default security group, relay and DNS settings.
+type Subnet struct {
+	Base
+	VpcID            uuid.UUID      `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	OrganizationID   uuid.UUID      `json:"-"` // Denormalized from the VPC record for performance
+	Name             string         `json:"name" example:"prod"`
+	Description      string         `json:"description,omitempty" example:"the production servers"`
+	Ipv4Cidr         string         `json:"ipv4_cidr,omitempty" example:"10.1.1.0/24"`                                                     // a slice of an IPv4 CIDR of the VPC
+	Ipv6Cidr         string         `json:"ipv6_cidr,omitempty" example:"fc00:0:0:1::/64"`                                                 // a slice of an IPv6 CIDR of the VPC
+	SecurityGroupId  *uuid.UUID     `json:"security_group_id,omitempty"`                                                                   // the security group of the devices joining the subnet, instead of the default one of the VPC
+	RelayDeviceId    *uuid.UUID     `json:"relay_device_id,omitempty"`                                                                     // the relay the devices of the subnet prefer over the other relays of the VPC
+	DnsServers       pq.StringArray `json:"dns_servers,omitempty" gorm:"type:text[]" swaggertype:"array,string" example:"10.1.1.53"`       // the DNS servers nexd passes to the hooks of the devices of the subnet
+	DnsSearchDomains pq.StringArray `json:"dns_search_domains,omitempty" gorm:"type:text[]" swaggertype:"array,string" example:"prod.lan"` // the DNS search domains nexd passes to the hooks of the devices of the subnet
+}
+
+// AddSubnet is the information needed to add a subnet to a VPC. At least one of Ipv4Cidr and Ipv6Cidr
+// must be set.
+type AddSubnet struct {
+	Name             string     `json:"name" example:"prod"`
+	Description      string     `json:"description,omitempty" example:"the production servers"`
+	Ipv4Cidr         string     `json:"ipv4_cidr,omitempty" example:"10.1.1.0/24"`
+	Ipv6Cidr         string     `json:"ipv6_cidr,omitempty" example:"fc00:0:0:1::/64"`
+	SecurityGroupId  *uuid.UUID `json:"security_group_id,omitempty"`
+	RelayDeviceId    *uuid.UUID `json:"relay_device_id,omitempty"`
+	DnsServers       []string   `json:"dns_servers,omitempty" example:"10.1.1.53"`
+	DnsSearchDomains []string   `json:"dns_search_domains,omitempty" example:"prod.lan"`
+}
+
+// UpdateSubnet is the information needed to update a subnet. The CIDRs of a subnet cannot be changed.
+type UpdateSubnet struct {
+	Description      *string    `json:"description,omitempty" example:"the production servers"`
+	SecurityGroupId  *uuid.UUID `json:"security_group_id,omitempty"`
+	RelayDeviceId    *uuid.UUID `json:"relay_device_id,omitempty"`
+	DnsServers       []string   `json:"dns_servers,omitempty" example:"10.1.1.53"`
+	DnsSearchDomains []string   `json:"dns_search_domains,omitempty" example:"prod.lan"`
+}
@@ -1,19 +1,81 @@
 package models
 
 import (
+	"net/netip"
+	"slices"
+
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// The topologies of a VPC decide which of its devices peer with each other.
+const (
+	// VPCTopologyFullMesh peers every device with every other device.
+	VPCTopologyFullMesh = "full-mesh"
+	// VPCTopologyHubAndSpoke only peers devices with the hubs of the VPC.
+	VPCTopologyHubAndSpoke = "hub-and-spoke"
+	// VPCTopologyPeeringGroups only peers devices with the hubs of the VPC and with the devices that share one of their peering groups.
+	VPCTopologyPeeringGroups = "peering-groups"
 )
 
+var VPCTopologies = []string{VPCTopologyFullMesh, VPCTopologyHubAndSpoke, VPCTopologyPeeringGroups}
+
 // VPC contains Devices
 type VPC struct {
 	Base
-	OrganizationID uuid.UUID     `json:"organization_id"`
-	Description    string        `json:"description"`
-	PrivateCidr    bool          `json:"private_cidr"`
-	Ipv4Cidr       string        `json:"ipv4_cidr"`
-	Ipv6Cidr       string        `json:"ipv6_cidr"`
-	Organization   *Organization `json:"-"`
-	Revision       uint64        `json:"revision" gorm:"type:bigserial;index:"`
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	Description    string         `json:"description"`
+	PrivateCidr    bool           `json:"private_cidr"`
+	Ipv4Cidr       string         `json:"ipv4_cidr"`
+	Ipv6Cidr       string         `json:"ipv6_cidr"`
+	SecondaryCidrs pq.StringArray `json:"secondary_cidrs" gorm:"type:text[]" swaggertype:"array,string"` // IPv4 and IPv6 CIDRs added after the VPC was created, devices are assigned addresses from them once the primary CIDR of the family is exhausted
+	Topology       string         `json:"topology" example:"full-mesh"`                                  // which devices of the VPC peer with each other: full-mesh, hub-and-spoke or peering-groups
+	Organization   *Organization  `json:"-"`
+	Revision       uint64         `json:"revision" gorm:"type:bigserial;index:"`
+}
+
+// Ipv4Cidrs returns the IPv4 CIDR of the VPC followed by its secondary IPv4 CIDRs.
+func (vpc *VPC) Ipv4Cidrs() []string {
+	return vpc.familyCidrs(vpc.Ipv4Cidr, true)
+}
+
+// Ipv6Cidrs returns the IPv6 CIDR of the VPC followed by its secondary IPv6 CIDRs.
+func (vpc *VPC) Ipv6Cidrs() []string {
+	return vpc.familyCidrs(vpc.Ipv6Cidr, false)
+}
+
+// Cidrs returns all the CIDRs of the VPC, the primary ones first.
+func (vpc *VPC) Cidrs() []string {
+	return append([]string{vpc.Ipv4Cidr, vpc.Ipv6Cidr}, vpc.SecondaryCidrs...)
+}
+
+func (vpc *VPC) familyCidrs(primary string, ipv4 bool) []string {
+	cidrs := []string{primary}
+	for _, cidr := range vpc.SecondaryCidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Addr().Is4() == ipv4 {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// PeeringAllowed returns whether two devices of a VPC with the given topology peer with each other.
+// Relays and hubs peer with every device.
+func PeeringAllowed(topology string, a, b *Device) bool {
+	if topology == "" || topology == VPCTopologyFullMesh {
+		return true
+	}
+	if a.Relay || b.Relay || a.Hub || b.Hub {
+		return true
+	}
+	if topology == VPCTopologyPeeringGroups {
+		for _, group := range a.PeeringGroups {
+			if slices.Contains(b.PeeringGroups, group) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 type AddVPC struct {
@@ -22,8 +84,11 @@ type AddVPC struct {
 	PrivateCidr    bool      `json:"private_cidr"`
 	Ipv4Cidr       string    `json:"ipv4_cidr" example:"172.16.42.0/24"`
 	Ipv6Cidr       string    `json:"ipv6_cidr" example:"0200::/8"`
+	Topology       string    `json:"topology" example:"full-mesh"`
 }
 
 type UpdateVPC struct {
-	Description *string `json:"description" example:"The Red Zone"`
+	Description    *string   `json:"description" example:"The Red Zone"`
+	Topology       *string   `json:"topology" example:"hub-and-spoke"`
+	SecondaryCidrs *[]string `json:"secondary_cidrs" example:"172.16.43.0/24"` // replaces the secondary CIDRs, only the CIDRs no device, IP reservation, excluded range or subnet uses can be removed
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// The states of a VPC peering.
+const (
+	// VpcPeeringPending is a peering that an owner of the accepter VPC has not accepted yet.
+	VpcPeeringPending = "pending"
+	// VpcPeeringActive is an accepted peering, the VPCs see the devices the other VPC exports.
+	VpcPeeringActive = "active"
+)
+
+// VpcPeering connects two VPCs. The devices each VPC exports are visible, with the routes
+// they export, in the device list and watch stream of the other VPC.
+type VpcPeering struct {
+	Base
+	RequesterVpcID          uuid.UUID      `json:"requester_vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	RequesterOrganizationID uuid.UUID      `json:"-"`                                                               // Denormalized from the VPC record for performance
+	RequesterDevices        pq.StringArray `json:"requester_devices" gorm:"type:text[]" swaggertype:"array,string"` // the devices of the requester VPC exported to the accepter VPC
+	RequesterCidrs          pq.StringArray `json:"requester_cidrs" gorm:"type:text[]" swaggertype:"array,string"`   // the devices and approved routes of the requester VPC within these CIDRs are exported to the accepter VPC
+	AccepterVpcID           uuid.UUID      `json:"accepter_vpc_id" example:"5f3b5d49-5e15-4e2a-9f5b-8a6f6a1c1e3d"`
+	AccepterOrganizationID  uuid.UUID      `json:"-"`                                                              // Denormalized from the VPC record for performance
+	AccepterDevices         pq.StringArray `json:"accepter_devices" gorm:"type:text[]" swaggertype:"array,string"` // the devices of the accepter VPC exported to the requester VPC
+	AccepterCidrs           pq.StringArray `json:"accepter_cidrs" gorm:"type:text[]" swaggertype:"array,string"`   // the devices and approved routes of the accepter VPC within these CIDRs are exported to the requester VPC
+	Status                  string         `json:"status" example:"pending"`                                       // pending until an owner of the accepter VPC accepts the peering, then active
+}
+
+// PeerVpcID returns the other VPC of the peering.
+func (p *VpcPeering) PeerVpcID(vpcId uuid.UUID) uuid.UUID {
+	if p.RequesterVpcID == vpcId {
+		return p.AccepterVpcID
+	}
+	return p.RequesterVpcID
+}
+
+// Exports returns the devices and CIDRs the VPC with the given id exports to the other VPC of the peering.
+func (p *VpcPeering) Exports(vpcId uuid.UUID) (devices []string, cidrs []string) {
+	if p.RequesterVpcID == vpcId {
+		return p.RequesterDevices, p.RequesterCidrs
+	}
+	return p.AccepterDevices, p.AccepterCidrs
+}
+
+// AddVpcPeering is the information needed to request the peering of a VPC with another VPC.
+type AddVpcPeering struct {
+	RequesterVpcID uuid.UUID `json:"requester_vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	AccepterVpcID  uuid.UUID `json:"accepter_vpc_id" example:"5f3b5d49-5e15-4e2a-9f5b-8a6f6a1c1e3d"`
+	Devices        []string  `json:"devices" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"` // the devices of the requester VPC to export
+	Cidrs          []string  `json:"cidrs" example:"172.16.42.0/24"`                         // the CIDRs of the requester VPC to export
+}
+
+// AcceptVpcPeering is the information needed to accept the peering of a VPC with another VPC.
+type AcceptVpcPeering struct {
+	Devices []string `json:"devices" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"` // the devices of the accepter VPC to export
+	Cidrs   []string `json:"cidrs" example:"100.64.0.0/24"`                          // the CIDRs of the accepter VPC to export
+}
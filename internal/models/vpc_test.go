@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeeringAllowed(t *testing.T) {
+	require := require.New(t)
+	hub := &Device{Hub: true}
+	relay := &Device{Relay: true}
+	east1 := &Device{PeeringGroups: pq.StringArray{"east"}}
+	east2 := &Device{PeeringGroups: pq.StringArray{"east", "lab"}}
+	west := &Device{PeeringGroups: pq.StringArray{"west"}}
+
+	require.True(PeeringAllowed("", east1, west))
+	require.True(PeeringAllowed(VPCTopologyFullMesh, east1, west))
+
+	require.True(PeeringAllowed(VPCTopologyHubAndSpoke, east1, hub))
+	require.True(PeeringAllowed(VPCTopologyHubAndSpoke, hub, west))
+	require.True(PeeringAllowed(VPCTopologyHubAndSpoke, relay, west))
+	require.False(PeeringAllowed(VPCTopologyHubAndSpoke, east1, east2))
+
+	require.True(PeeringAllowed(VPCTopologyPeeringGroups, east1, east2))
+	require.True(PeeringAllowed(VPCTopologyPeeringGroups, west, hub))
+	require.False(PeeringAllowed(VPCTopologyPeeringGroups, east2, west))
+	require.False(PeeringAllowed(VPCTopologyPeeringGroups, &Device{}, &Device{}))
+}
@@ -0,0 +1,224 @@
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.zx2c4.com/wireguard/tun"
+)
+
+// the number of packets buffered for a capture before further packets are dropped
+const captureBufferSize = 1024
+
+// captureRequest selects the packets of a capture.
+type captureRequest struct {
+	// Peer only captures the packets from or to the allowed IPs of a peer, see peerMatches
+	Peer string
+	// Filter is a capture filter, see parseCaptureFilter
+	Filter string
+	// SnapLen truncates the captured packets, pcapngDefaultSnapLen when zero
+	SnapLen int
+	// Count stops the capture after this many packets when not zero
+	Count int
+}
+
+type capturedPacket struct {
+	time     time.Time
+	data     []byte
+	outbound bool
+}
+
+// captureSession receives the packets of a capture that match its filter.
+type captureSession struct {
+	filter  captureFilter
+	packets chan capturedPacket
+	dropped atomic.Uint64
+}
+
+// offer queues a copy of the packet when it matches the filter of the session.
+func (s *captureSession) offer(packet []byte, outbound bool) {
+	ip, ok := decodeCapturedIP(packet)
+	if !ok || !s.filter(ip) {
+		return
+	}
+	select {
+	case s.packets <- capturedPacket{time: time.Now(), data: append([]byte(nil), packet...), outbound: outbound}:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// captureSessions fans out the packets tapped from the userspace tunnel device to the captures.
+type captureSessions struct {
+	active   atomic.Bool
+	mu       sync.RWMutex
+	sessions map[*captureSession]struct{}
+}
+
+func (c *captureSessions) add(s *captureSession) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sessions == nil {
+		c.sessions = map[*captureSession]struct{}{}
+	}
+	c.sessions[s] = struct{}{}
+	c.active.Store(true)
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.sessions, s)
+		c.active.Store(len(c.sessions) > 0)
+	}
+}
+
+func (c *captureSessions) offer(packet []byte, outbound bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for s := range c.sessions {
+		s.offer(packet, outbound)
+	}
+}
+
+// captureTun taps the decrypted packets read from and written to a tunnel device. The packets read from
+// the device are sent to the peers, the packets written to it were received from the peers.
+type captureTun struct {
+	tun.Device
+	captures *captureSessions
+}
+
+func newCaptureTun(device tun.Device, captures *captureSessions) tun.Device {
+	return &captureTun{Device: device, captures: captures}
+}
+
+func (t *captureTun) Read(bufs [][]byte, sizes []int, offset int) (int, error) {
+	n, err := t.Device.Read(bufs, sizes, offset)
+	if t.captures.active.Load() {
+		for i := 0; i < n; i++ {
+			t.captures.offer(bufs[i][offset:offset+sizes[i]], true)
+		}
+	}
+	return n, err
+}
+
+func (t *captureTun) Write(bufs [][]byte, offset int) (int, error) {
+	if t.captures.active.Load() {
+		for _, buf := range bufs {
+			t.captures.offer(buf[offset:], false)
+		}
+	}
+	return t.Device.Write(bufs, offset)
+}
+
+// capture writes the decrypted packets of the tunnel matching the request to w in the pcapng format
+// until ctx is done or the requested number of packets was captured. flush is called after every packet.
+func (nx *Nexodus) capture(ctx context.Context, w io.Writer, flush func(), req captureRequest) error {
+	filter, err := parseCaptureFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+	if req.Peer != "" {
+		peerFilter, err := nx.peerCaptureFilter(req.Peer)
+		if err != nil {
+			return err
+		}
+		f := filter
+		filter = func(ip capturedIP) bool { return peerFilter(ip) && f(ip) }
+	}
+	snapLen := req.SnapLen
+	if snapLen <= 0 {
+		snapLen = pcapngDefaultSnapLen
+	}
+
+	session := &captureSession{
+		filter:  filter,
+		packets: make(chan capturedPacket, captureBufferSize),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// the errors of the capture of the kernel interface, never sent in userspace mode
+	var errCh chan error
+	if nx.userspaceMode {
+		remove := nx.captures.add(session)
+		defer remove()
+	} else {
+		errCh = make(chan error, 1)
+		go func() {
+			errCh <- captureInterface(ctx, nx.tunnelIface, session)
+		}()
+		// report the errors opening the interface before starting the stream
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return err
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	comment := fmt.Sprintf("captured by nexd %s", nx.version)
+	pw, err := newPcapngWriter(w, nx.tunnelIface, snapLen, comment)
+	if err != nil {
+		return err
+	}
+	flush()
+	nx.logger.Infof("Started a packet capture on %s", nx.tunnelIface)
+	defer func() {
+		nx.logger.Infof("Stopped a packet capture on %s, %d packets were dropped", nx.tunnelIface, session.dropped.Load())
+	}()
+
+	count := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			return err
+		case packet := <-session.packets:
+			if err := pw.writePacket(packet.time, packet.data, packet.outbound); err != nil {
+				// the client went away
+				return nil
+			}
+			flush()
+			count++
+			if req.Count > 0 && count >= req.Count {
+				return nil
+			}
+		}
+	}
+}
+
+// peerCaptureFilter returns a filter matching the packets from or to the allowed IPs of a peer.
+func (nx *Nexodus) peerCaptureFilter(query string) (captureFilter, error) {
+	nx.deviceCacheLock.RLock()
+	defer nx.deviceCacheLock.RUnlock()
+	for _, d := range nx.deviceCache {
+		if d.device.GetPublicKey() == nx.wireguardPubKey || !peerMatches(d.device.GetPublicKey(), d.device.GetId(), d.device.GetHostname(), deviceTunnelIPs(d), query) {
+			continue
+		}
+		var prefixes []netip.Prefix
+		for _, allowedIP := range d.device.AllowedIps {
+			if prefix, err := netip.ParsePrefix(allowedIP); err == nil {
+				prefixes = append(prefixes, prefix.Masked())
+			}
+		}
+		for _, tunnelIP := range deviceTunnelIPs(d) {
+			if addr, err := netip.ParseAddr(tunnelIP); err == nil {
+				prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			}
+		}
+		return func(ip capturedIP) bool {
+			for _, prefix := range prefixes {
+				if prefix.Contains(ip.src) || prefix.Contains(ip.dst) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	return nil, fmt.Errorf("no peer found matching %q", query)
+}
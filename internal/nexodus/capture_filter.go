@@ -0,0 +1,271 @@
+package nexodus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	ipProtoICMP   = 1
+	ipProtoTCP    = 6
+	ipProtoUDP    = 17
+	ipProtoICMPv6 = 58
+)
+
+// capturedIP is the decoded header of a captured IP packet.
+type capturedIP struct {
+	version  int
+	proto    int
+	src, dst netip.Addr
+	// the ports are only set for the first fragment of TCP and UDP packets
+	hasPorts         bool
+	srcPort, dstPort uint16
+}
+
+// decodeCapturedIP decodes the header of a raw IPv4 or IPv6 packet.
+func decodeCapturedIP(packet []byte) (capturedIP, bool) {
+	var ip capturedIP
+	var payload []byte
+	if len(packet) < 1 {
+		return ip, false
+	}
+	switch packet[0] >> 4 {
+	case 4:
+		if len(packet) < 20 {
+			return ip, false
+		}
+		ihl := int(packet[0]&0x0f) * 4
+		if ihl < 20 || len(packet) < ihl {
+			return ip, false
+		}
+		ip.version = 4
+		ip.proto = int(packet[9])
+		ip.src = netip.AddrFrom4([4]byte(packet[12:16]))
+		ip.dst = netip.AddrFrom4([4]byte(packet[16:20]))
+		if binary.BigEndian.Uint16(packet[6:8])&0x1fff == 0 {
+			payload = packet[ihl:]
+		}
+	case 6:
+		if len(packet) < 40 {
+			return ip, false
+		}
+		ip.version = 6
+		ip.proto = int(packet[6])
+		ip.src = netip.AddrFrom16([16]byte(packet[8:24]))
+		ip.dst = netip.AddrFrom16([16]byte(packet[24:40]))
+		payload = packet[40:]
+	default:
+		return ip, false
+	}
+	if (ip.proto == ipProtoTCP || ip.proto == ipProtoUDP) && len(payload) >= 4 {
+		ip.hasPorts = true
+		ip.srcPort = binary.BigEndian.Uint16(payload[0:2])
+		ip.dstPort = binary.BigEndian.Uint16(payload[2:4])
+	}
+	return ip, true
+}
+
+// captureFilter returns whether a decoded packet is captured.
+type captureFilter func(ip capturedIP) bool
+
+// parseCaptureFilter parses a capture filter in a subset of the pcap-filter syntax of tcpdump: the
+// primitives [src|dst] host <ip>, [src|dst] net <cidr>, [src|dst] port <port> and the protocols
+// ip, ip6, tcp, udp, icmp and icmp6, which may qualify the primitive that follows them, combined
+// with and, or, not and parentheses. An empty filter captures every packet.
+func parseCaptureFilter(expr string) (captureFilter, error) {
+	p := &captureFilterParser{tokens: tokenizeCaptureFilter(expr)}
+	if len(p.tokens) == 0 {
+		return func(capturedIP) bool { return true }, nil
+	}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in the capture filter", p.tokens[p.pos])
+	}
+	return filter, nil
+}
+
+func tokenizeCaptureFilter(expr string) []string {
+	for _, op := range []string{"(", ")", "!"} {
+		expr = strings.ReplaceAll(expr, op, " "+op+" ")
+	}
+	return strings.Fields(expr)
+}
+
+type captureFilterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *captureFilterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *captureFilterParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("unexpected end of the capture filter")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token, nil
+}
+
+func (p *captureFilterParser) parseOr() (captureFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" || p.peek() == "||" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ip capturedIP) bool { return l(ip) || right(ip) }
+	}
+	return left, nil
+}
+
+func (p *captureFilterParser) parseAnd() (captureFilter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "and" || p.peek() == "&&" {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ip capturedIP) bool { return l(ip) && right(ip) }
+	}
+	return left, nil
+}
+
+func (p *captureFilterParser) parseNot() (captureFilter, error) {
+	switch p.peek() {
+	case "not", "!":
+		p.pos++
+		filter, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(ip capturedIP) bool { return !filter(ip) }, nil
+	case "(":
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token, err := p.next(); err != nil || token != ")" {
+			return nil, fmt.Errorf("missing ) in the capture filter")
+		}
+		return filter, nil
+	}
+	return p.parsePrimitive()
+}
+
+func (p *captureFilterParser) parsePrimitive() (captureFilter, error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	var proto captureFilter
+	switch token {
+	case "ip":
+		proto = func(ip capturedIP) bool { return ip.version == 4 }
+	case "ip6":
+		proto = func(ip capturedIP) bool { return ip.version == 6 }
+	case "tcp":
+		proto = func(ip capturedIP) bool { return ip.proto == ipProtoTCP }
+	case "udp":
+		proto = func(ip capturedIP) bool { return ip.proto == ipProtoUDP }
+	case "icmp":
+		proto = func(ip capturedIP) bool { return ip.version == 4 && ip.proto == ipProtoICMP }
+	case "icmp6":
+		proto = func(ip capturedIP) bool { return ip.version == 6 && ip.proto == ipProtoICMPv6 }
+	}
+	if proto != nil {
+		// a protocol qualifies the primitive that follows it, as in tcp port 22
+		switch p.peek() {
+		case "src", "dst", "host", "net", "port":
+			primitive, err := p.parsePrimitive()
+			if err != nil {
+				return nil, err
+			}
+			return func(ip capturedIP) bool { return proto(ip) && primitive(ip) }, nil
+		}
+		return proto, nil
+	}
+
+	src, dst := true, true
+	switch token {
+	case "src":
+		dst = false
+		if token, err = p.next(); err != nil {
+			return nil, err
+		}
+	case "dst":
+		src = false
+		if token, err = p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch token {
+	case "host":
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host %q in the capture filter: %w", value, err)
+		}
+		return prefixFilter(netip.PrefixFrom(addr, addr.BitLen()), src, dst), nil
+	case "net":
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid net %q in the capture filter: %w", value, err)
+		}
+		return prefixFilter(prefix.Masked(), src, dst), nil
+	case "port":
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q in the capture filter", value)
+		}
+		return func(ip capturedIP) bool {
+			return ip.hasPorts && ((src && ip.srcPort == uint16(port)) || (dst && ip.dstPort == uint16(port)))
+		}, nil
+	}
+
+	// like tcpdump, a bare address is a host
+	if addr, err := netip.ParseAddr(token); err == nil && src && dst {
+		return prefixFilter(netip.PrefixFrom(addr, addr.BitLen()), true, true), nil
+	}
+	return nil, fmt.Errorf("unsupported %q in the capture filter", token)
+}
+
+func prefixFilter(prefix netip.Prefix, src, dst bool) captureFilter {
+	return func(ip capturedIP) bool {
+		return (src && prefix.Contains(ip.src)) || (dst && prefix.Contains(ip.dst))
+	}
+}
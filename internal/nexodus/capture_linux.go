@@ -0,0 +1,57 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// captureInterface captures the packets of the kernel wireguard interface with a packet socket until ctx is done.
+// The interface has no link layer header, so the packets are received starting at their IP header.
+func captureInterface(ctx context.Context, iface string, session *captureSession) error {
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return fmt.Errorf("failed to find the interface %s: %w", iface, err)
+	}
+	protocol := htons(unix.ETH_P_ALL)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, int(protocol))
+	if err != nil {
+		return fmt.Errorf("failed to open a packet socket: %w", err)
+	}
+	defer unix.Close(fd)
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: protocol, Ifindex: link.Index}); err != nil {
+		return fmt.Errorf("failed to bind the packet socket to %s: %w", iface, err)
+	}
+	// wake up regularly to notice when the capture is stopped
+	timeout := unix.NsecToTimeval((250 * time.Millisecond).Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 65535)
+	for ctx.Err() == nil {
+		n, from, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return fmt.Errorf("failed to read from the packet socket: %w", err)
+		}
+		outbound := false
+		if ll, ok := from.(*unix.SockaddrLinklayer); ok {
+			outbound = ll.Pkttype == unix.PACKET_OUTGOING
+		}
+		session.offer(buf[:n], outbound)
+	}
+	return nil
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,163 @@
+package nexodus
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/tun"
+)
+
+// testIPv4Packet returns an IPv4 packet with a header of the protocol, source and destination ports.
+func testIPv4Packet(proto byte, src, dst string, srcPort, dstPort uint16) []byte {
+	packet := make([]byte, 28)
+	packet[0] = 0x45
+	binary.BigEndian.PutUint16(packet[2:4], uint16(len(packet)))
+	packet[9] = proto
+	s := netip.MustParseAddr(src).As4()
+	d := netip.MustParseAddr(dst).As4()
+	copy(packet[12:16], s[:])
+	copy(packet[16:20], d[:])
+	binary.BigEndian.PutUint16(packet[20:22], srcPort)
+	binary.BigEndian.PutUint16(packet[22:24], dstPort)
+	return packet
+}
+
+func TestCaptureFilter(t *testing.T) {
+	require := require.New(t)
+
+	dns := testIPv4Packet(ipProtoUDP, "100.64.0.1", "100.64.0.2", 40000, 53)
+	ssh := testIPv4Packet(ipProtoTCP, "100.64.0.3", "100.64.0.1", 22, 50000)
+	ping := testIPv4Packet(ipProtoICMP, "100.64.0.1", "10.0.0.5", 0, 0)
+
+	for _, tc := range []struct {
+		filter string
+		match  []bool
+	}{
+		{"", []bool{true, true, true}},
+		{"udp", []bool{true, false, false}},
+		{"tcp port 22", []bool{false, true, false}},
+		{"dst port 22", []bool{false, false, false}},
+		{"src host 100.64.0.1", []bool{true, false, true}},
+		{"100.64.0.2", []bool{true, false, false}},
+		{"net 10.0.0.0/8 or (tcp and not port 53)", []bool{false, true, true}},
+		{"ip6", []bool{false, false, false}},
+		{"!icmp && ip", []bool{true, true, false}},
+	} {
+		filter, err := parseCaptureFilter(tc.filter)
+		require.NoError(err, tc.filter)
+		for i, packet := range [][]byte{dns, ssh, ping} {
+			ip, ok := decodeCapturedIP(packet)
+			require.True(ok)
+			require.Equal(tc.match[i], filter(ip), "filter %q, packet %d", tc.filter, i)
+		}
+	}
+
+	for _, filter := range []string{"host", "port http", "net 10.0.0.1", "(tcp", "tcp udp", "vlan"} {
+		_, err := parseCaptureFilter(filter)
+		require.Error(err, filter)
+	}
+}
+
+func TestPcapngWriter(t *testing.T) {
+	require := require.New(t)
+
+	buf := bytes.NewBuffer(nil)
+	pw, err := newPcapngWriter(buf, "wg0", 20, "test")
+	require.NoError(err)
+	packet := testIPv4Packet(ipProtoUDP, "100.64.0.1", "100.64.0.2", 40000, 53)
+	require.NoError(pw.writePacket(time.Unix(1, 0), packet, true))
+
+	data := buf.Bytes()
+	var blocks []uint32
+	for len(data) > 0 {
+		require.GreaterOrEqual(len(data), 12)
+		length := binary.LittleEndian.Uint32(data[4:8])
+		require.Zero(length%4)
+		require.Equal(length, binary.LittleEndian.Uint32(data[length-4:length]))
+		blocks = append(blocks, binary.LittleEndian.Uint32(data[0:4]))
+		if binary.LittleEndian.Uint32(data[0:4]) == pcapngEnhancedPacketBlock {
+			// captured and original lengths
+			require.Equal(uint32(20), binary.LittleEndian.Uint32(data[20:24]))
+			require.Equal(uint32(len(packet)), binary.LittleEndian.Uint32(data[24:28]))
+		}
+		data = data[length:]
+	}
+	require.Equal([]uint32{pcapngSectionHeaderBlock, pcapngInterfaceDescBlock, pcapngEnhancedPacketBlock}, blocks)
+}
+
+type fakeTun struct {
+	tun.Device
+	packets chan []byte
+}
+
+func (f *fakeTun) Read(bufs [][]byte, sizes []int, offset int) (int, error) {
+	sizes[0] = copy(bufs[0][offset:], <-f.packets)
+	return 1, nil
+}
+
+func (f *fakeTun) Write(bufs [][]byte, _ int) (int, error) {
+	return len(bufs), nil
+}
+
+func TestCaptureUserspace(t *testing.T) {
+	zLogger, _ := zap.NewDevelopment()
+	require := require.New(t)
+
+	nx := &Nexodus{
+		logger:      zLogger.Sugar(),
+		tunnelIface: "go",
+		deviceCache: map[string]deviceCacheEntry{
+			"peerA": {
+				device: client.ModelsDevice{
+					PublicKey:     client.PtrString("peerA"),
+					Hostname:      client.PtrString("peer-a"),
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.2")}},
+					AllowedIps:    []string{"100.64.0.2/32", "172.16.0.0/24"},
+				},
+			},
+		},
+	}
+	nx.userspaceMode = true
+	device := &fakeTun{packets: make(chan []byte, 1)}
+	captureDevice := newCaptureTun(device, &nx.captures)
+
+	_, err := parseCaptureFilter("tcp port")
+	require.Error(err)
+	require.Error(nx.capture(context.Background(), &bytes.Buffer{}, func() {}, captureRequest{Peer: "peer-b"}))
+
+	out := bytes.NewBuffer(nil)
+	done := make(chan error, 1)
+	go func() {
+		done <- nx.capture(context.Background(), out, func() {}, captureRequest{Peer: "peer-a", Filter: "udp", Count: 1})
+	}()
+	require.Eventually(func() bool { return nx.captures.active.Load() }, time.Second, time.Millisecond)
+
+	// not from or to the peer
+	other := testIPv4Packet(ipProtoUDP, "100.64.0.1", "100.64.0.3", 40000, 53)
+	_, err = captureDevice.Write([][]byte{other}, 0)
+	require.NoError(err)
+	// to a CIDR advertised by the peer
+	routed := testIPv4Packet(ipProtoUDP, "100.64.0.1", "172.16.0.10", 40000, 53)
+	device.packets <- routed
+	bufs := [][]byte{make([]byte, 1500)}
+	n, err := captureDevice.Read(bufs, []int{0}, 0)
+	require.NoError(err)
+	require.Equal(1, n)
+
+	select {
+	case err := <-done:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the capture did not stop after one packet")
+	}
+	require.False(nx.captures.active.Load())
+	require.True(bytes.Contains(out.Bytes(), routed))
+	require.False(bytes.Contains(out.Bytes(), other))
+}
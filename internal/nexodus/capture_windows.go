@@ -0,0 +1,13 @@
+//go:build windows
+
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+func captureInterface(_ context.Context, iface string, _ *captureSession) error {
+	return fmt.Errorf("capturing the packets of %s is not supported on %s, run nexd in userspace mode or use a packet capture tool", iface, runtime.GOOS)
+}
@@ -0,0 +1,313 @@
+package nexodus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"go.uber.org/zap"
+)
+
+var errCtlApiNotFound = errors.New("not found")
+
+// ctlApiHandler returns the handler of the versioned control API that is served over HTTP on the unix socket.
+// Streaming requests end when ctx is done.
+func (nx *Nexodus) ctlApiHandler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	p := api.CtlApiPrefix
+
+	mux.HandleFunc("GET "+p+"/version", func(w http.ResponseWriter, r *http.Request) {
+		writeCtlApiResponse(w, http.StatusOK, api.VersionResponse{Version: nx.version})
+	})
+	mux.HandleFunc("GET "+p+"/status", func(w http.ResponseWriter, r *http.Request) {
+		writeCtlApiResponse(w, http.StatusOK, api.StatusResponse{
+			Status:     statusString(nx.status),
+			Message:    nx.statusMsg,
+			TunnelIPv4: nx.TunnelIP,
+			TunnelIPv6: nx.TunnelIpV6,
+		})
+	})
+	mux.HandleFunc("GET "+p+"/peers", func(w http.ResponseWriter, r *http.Request) {
+		res, err := nx.listPeers()
+		if err != nil {
+			writeCtlApiError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeCtlApiResponse(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("GET "+p+"/peers/{peer}/diagnose", func(w http.ResponseWriter, r *http.Request) {
+		res, err := nx.diagnosePeer(r.PathValue("peer"), true)
+		if err != nil {
+			writeCtlApiError(w, http.StatusNotFound, err)
+			return
+		}
+		nx.writeRedactedCtlApiResponse(w, res)
+	})
+	mux.HandleFunc("GET "+p+"/connectivity/{family}", func(w http.ResponseWriter, r *http.Request) {
+		family := r.PathValue("family")
+		if family != v4 && family != v6 {
+			writeCtlApiError(w, http.StatusBadRequest, fmt.Errorf("invalid address family %q, use v4 or v6", family))
+			return
+		}
+		writeCtlApiResponse(w, http.StatusOK, nx.connectivityProbe(family))
+	})
+	mux.HandleFunc("GET "+p+"/bugreport", func(w http.ResponseWriter, r *http.Request) {
+		res, err := nx.bugReport()
+		if err != nil {
+			writeCtlApiError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeCtlApiResponse(w, http.StatusOK, res)
+	})
+
+	mux.HandleFunc("GET "+p+"/proxy-rules", func(w http.ResponseWriter, r *http.Request) {
+		writeCtlApiResponse(w, http.StatusOK, nx.listProxyRules())
+	})
+	mux.HandleFunc("POST "+p+"/proxy-rules", func(w http.ResponseWriter, r *http.Request) {
+		var rule api.ProxyRule
+		proxyType, err := readCtlApiProxyRule(r, &rule)
+		if err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := nx.addStoredProxyRule(proxyType, rule.Rule); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeCtlApiResponse(w, http.StatusCreated, rule)
+	})
+	mux.HandleFunc("POST "+p+"/proxy-rules/remove", func(w http.ResponseWriter, r *http.Request) {
+		var rule api.ProxyRule
+		proxyType, err := readCtlApiProxyRule(r, &rule)
+		if err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := nx.removeStoredProxyRule(proxyType, rule.Rule); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeCtlApiResponse(w, http.StatusOK, rule)
+	})
+
+	mux.HandleFunc("GET "+p+"/debug", func(w http.ResponseWriter, r *http.Request) {
+		writeCtlApiResponse(w, http.StatusOK, api.DebugSetting{Enabled: nx.logLevel.Level() == zap.DebugLevel})
+	})
+	mux.HandleFunc("PUT "+p+"/debug", func(w http.ResponseWriter, r *http.Request) {
+		var setting api.DebugSetting
+		if err := json.NewDecoder(r.Body).Decode(&setting); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		if setting.Enabled {
+			nx.logLevel.SetLevel(zap.DebugLevel)
+		} else {
+			nx.logLevel.SetLevel(zap.InfoLevel)
+		}
+		writeCtlApiResponse(w, http.StatusOK, setting)
+	})
+	mux.HandleFunc("POST "+p+"/reload", func(w http.ResponseWriter, r *http.Request) {
+		if err := nx.reload(); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+p+"/state/rekey", func(w http.ResponseWriter, r *http.Request) {
+		var req api.StateRekeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := nx.rekeyState(req); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET "+p+"/exit-nodes", func(w http.ResponseWriter, r *http.Request) {
+		exitNodes := []api.ExitNode{}
+		for _, origin := range nx.listExitNodeOrigins() {
+			exitNodes = append(exitNodes, api.ExitNode{
+				PublicKey:  origin.PublicKey,
+				Endpoint:   origin.Endpoint,
+				AllowedIPs: origin.AllowedIPs,
+			})
+		}
+		writeCtlApiResponse(w, http.StatusOK, exitNodes)
+	})
+	mux.HandleFunc("PUT "+p+"/exit-node-client", func(w http.ResponseWriter, r *http.Request) {
+		var setting api.ExitNodeClientSetting
+		if err := json.NewDecoder(r.Body).Decode(&setting); err != nil {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := nx.SetExitNodeClient(setting.Enabled); err != nil {
+			writeCtlApiError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeCtlApiResponse(w, http.StatusOK, setting)
+	})
+
+	mux.HandleFunc("GET "+p+"/capture", func(w http.ResponseWriter, r *http.Request) {
+		nx.streamCapture(ctx, w, r)
+	})
+
+	mux.HandleFunc("GET "+p+"/events", func(w http.ResponseWriter, r *http.Request) {
+		nx.streamEvents(ctx, w, r)
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeCtlApiError(w, http.StatusNotFound, errCtlApiNotFound)
+	})
+	return mux
+}
+
+// streamEvents writes the events of nexd as newline delimited JSON until the client goes away or ctx is done.
+// The events may be limited to some types with one or more type query parameters.
+func (nx *Nexodus) streamEvents(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeCtlApiError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	types := r.URL.Query()["type"]
+
+	events, unsubscribe := nx.events.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			if len(types) > 0 && !slices.Contains(types, event.Type) {
+				continue
+			}
+			if err := enc.Encode(event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// streamCapture streams the decrypted packets of the tunnel in the pcapng format.
+func (nx *Nexodus) streamCapture(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeCtlApiError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	query := r.URL.Query()
+	req := captureRequest{
+		Peer:   query.Get("peer"),
+		Filter: query.Get("filter"),
+	}
+	for name, value := range map[string]*int{"snaplen": &req.SnapLen, "count": &req.Count} {
+		if query.Has(name) {
+			n, err := strconv.Atoi(query.Get(name))
+			if err != nil || n < 0 {
+				writeCtlApiError(w, http.StatusBadRequest, fmt.Errorf("invalid %s %q", name, query.Get(name)))
+				return
+			}
+			*value = n
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(r.Context(), cancel)
+	defer stop()
+
+	// the response is only started once the capture is, so that its errors are reported
+	started := false
+	out := captureResponseWriter(func(p []byte) (int, error) {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-pcapng")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		return w.Write(p)
+	})
+	if err := nx.capture(ctx, out, flusher.Flush, req); err != nil {
+		if !started {
+			writeCtlApiError(w, http.StatusBadRequest, err)
+			return
+		}
+		nx.logger.Warnf("Packet capture failed: %v", err)
+	}
+}
+
+type captureResponseWriter func(p []byte) (int, error)
+
+func (f captureResponseWriter) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func (nx *Nexodus) listProxyRules() []api.ProxyRule {
+	rules := []api.ProxyRule{}
+	nx.proxyLock.RLock()
+	defer nx.proxyLock.RUnlock()
+	for _, proxy := range nx.proxies {
+		proxy.mu.RLock()
+		for _, rule := range proxy.rules {
+			rules = append(rules, api.ProxyRule{
+				Type: rule.ruleType.String(),
+				Rule: rule.String(),
+			})
+		}
+		proxy.mu.RUnlock()
+	}
+	return rules
+}
+
+func readCtlApiProxyRule(r *http.Request, rule *api.ProxyRule) (ProxyType, error) {
+	if err := json.NewDecoder(r.Body).Decode(rule); err != nil {
+		return 0, err
+	}
+	switch rule.Type {
+	case ProxyTypeIngress.String():
+		return ProxyTypeIngress, nil
+	case ProxyTypeEgress.String():
+		return ProxyTypeEgress, nil
+	default:
+		return 0, fmt.Errorf("invalid proxy rule type %q, use ingress or egress", rule.Type)
+	}
+}
+
+// writeRedactedCtlApiResponse writes v with the secrets known to nexd redacted.
+func (nx *Nexodus) writeRedactedCtlApiResponse(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeCtlApiError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(nx.redact(string(data))))
+}
+
+func writeCtlApiResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeCtlApiError(w http.ResponseWriter, status int, err error) {
+	writeCtlApiResponse(w, status, api.CtlApiError{Error: err.Error()})
+}
@@ -0,0 +1,114 @@
+package nexodus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCtlApi(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+	logLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	nx := &Nexodus{
+		logger:   zLogger.Sugar(),
+		logLevel: &logLevel,
+		version:  "v1.2.3",
+		status:   NexdStatusRunning,
+		TunnelIP: "100.64.0.1",
+		vpc:      &client.ModelsVPC{Id: client.PtrString("vpc-id")},
+		deviceId: "local-id",
+		userspaceWG: userspaceWG{
+			userspaceMode: true,
+			proxies:       map[ProxyKey]*UsProxy{},
+		},
+	}
+	rule, err := ParseProxyRule("tcp:443:127.0.0.1:8443", ProxyTypeIngress)
+	require.NoError(err)
+	_, err = nx.UserspaceProxyAdd(rule)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(nx.ctlApiHandler(ctx))
+	defer server.Close()
+
+	do := func(method, path, body string, out any) int {
+		req, err := http.NewRequest(method, server.URL+api.CtlApiPrefix+path, strings.NewReader(body))
+		require.NoError(err)
+		resp, err := server.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		if out != nil {
+			require.NoError(json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var version api.VersionResponse
+	require.Equal(http.StatusOK, do(http.MethodGet, "/version", "", &version))
+	require.Equal("v1.2.3", version.Version)
+
+	var status api.StatusResponse
+	require.Equal(http.StatusOK, do(http.MethodGet, "/status", "", &status))
+	require.Equal("Running", status.Status)
+	require.Equal("100.64.0.1", status.TunnelIPv4)
+
+	var debug api.DebugSetting
+	require.Equal(http.StatusOK, do(http.MethodPut, "/debug", `{"enabled":true}`, nil))
+	require.Equal(http.StatusOK, do(http.MethodGet, "/debug", "", &debug))
+	require.True(debug.Enabled)
+
+	var rules []api.ProxyRule
+	require.Equal(http.StatusOK, do(http.MethodGet, "/proxy-rules", "", &rules))
+	require.Equal([]api.ProxyRule{{Type: "ingress", Rule: "tcp:443:127.0.0.1:8443"}}, rules)
+
+	var apiErr api.CtlApiError
+	require.Equal(http.StatusBadRequest, do(http.MethodPost, "/proxy-rules", `{"type":"sideways","rule":"tcp:80:127.0.0.1:80"}`, &apiErr))
+	require.Contains(apiErr.Error, "invalid proxy rule type")
+	require.Equal(http.StatusBadRequest, do(http.MethodPost, "/reload", "", &apiErr))
+	require.Equal(http.StatusNotFound, do(http.MethodGet, "/unknown", "", &apiErr))
+
+	// events are streamed as newline delimited JSON, filtered by type
+	resp, err := server.Client().Get(server.URL + api.CtlApiPrefix + "/events?type=" + api.NexdEventStatusChanged)
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal("application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	nx.publishPeerEvent(api.NexdEventPeerUp, deviceCacheEntry{})
+	nx.SetStatus(NexdStatusOfflineConfig, "offline")
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(scanner.Scan())
+	var event api.NexdEvent
+	require.NoError(json.Unmarshal(scanner.Bytes(), &event))
+	require.Equal(api.NexdEventStatusChanged, event.Type)
+	require.Equal("Degraded/OfflineConfig", event.Status)
+	require.Equal("offline", event.Message)
+	require.False(event.Time.IsZero())
+}
+
+func TestEventBus(t *testing.T) {
+	require := require.New(t)
+	var bus eventBus
+
+	events, unsubscribe := bus.subscribe()
+	for i := 0; i < eventBufferSize+10; i++ {
+		bus.publish(api.NexdEvent{Type: api.NexdEventPeerUp})
+	}
+	// a subscriber that does not keep up misses events instead of blocking nexd
+	require.Len(events, eventBufferSize)
+
+	unsubscribe()
+	bus.publish(api.NexdEvent{Type: api.NexdEventPeerDown})
+	require.Len(events, eventBufferSize)
+}
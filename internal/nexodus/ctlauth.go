@@ -0,0 +1,200 @@
+package nexodus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var errCtlForbidden = errors.New("permission denied: this command requires root or membership of the nexd control admin group")
+
+// ctlAccess is the level of access granted to a client of the control socket.
+type ctlAccess int
+
+const (
+	ctlAccessNone ctlAccess = iota
+	ctlAccessReadOnly
+	ctlAccessAdmin
+)
+
+// peerCreds are the credentials of the process on the other end of a unix socket connection.
+type peerCreds struct {
+	uid string
+	gid string
+}
+
+// ctlAuthorizer grants access to the clients of the control socket from the credentials of their process.
+// When no group is configured, every client that can open the socket is granted full access and the
+// permissions of the socket file are what keeps other users out.
+type ctlAuthorizer struct {
+	// the id of the group granted read-only access, if any
+	readGid string
+	// the id of the group granted full access, if any
+	adminGid string
+	// returns the ids of the groups a user is a member of
+	groupIds func(uid string) ([]string, error)
+}
+
+func newCtlAuthorizer(readGroup, adminGroup string) (*ctlAuthorizer, error) {
+	a := &ctlAuthorizer{
+		groupIds: func(uid string) ([]string, error) {
+			u, err := user.LookupId(uid)
+			if err != nil {
+				return nil, err
+			}
+			return u.GroupIds()
+		},
+	}
+	if readGroup == "" && adminGroup == "" {
+		return a, nil
+	}
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("control socket groups are not supported on %s", runtime.GOOS)
+	}
+	var err error
+	if a.readGid, err = lookupGid(readGroup); err != nil {
+		return nil, err
+	}
+	if a.adminGid, err = lookupGid(adminGroup); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// lookupGid returns the id of a group given by name or id.
+func lookupGid(group string) (string, error) {
+	if group == "" {
+		return "", nil
+	}
+	if g, err := user.LookupGroup(group); err == nil {
+		return g.Gid, nil
+	}
+	if _, err := strconv.ParseUint(group, 10, 32); err == nil {
+		return group, nil
+	}
+	return "", fmt.Errorf("unknown group: %s", group)
+}
+
+// enabled returns whether clients are authorized from their credentials.
+func (a *ctlAuthorizer) enabled() bool {
+	return a != nil && (a.readGid != "" || a.adminGid != "")
+}
+
+// access returns the access granted to the client of a control socket connection.
+func (a *ctlAuthorizer) access(conn net.Conn) (ctlAccess, error) {
+	if !a.enabled() {
+		return ctlAccessAdmin, nil
+	}
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return ctlAccessNone, fmt.Errorf("unexpected connection type %T", conn)
+	}
+	creds, err := peerCredentials(unixConn)
+	if err != nil {
+		return ctlAccessNone, err
+	}
+	return a.accessFor(creds), nil
+}
+
+func (a *ctlAuthorizer) accessFor(creds peerCreds) ctlAccess {
+	if creds.uid == "0" || creds.uid == strconv.Itoa(os.Geteuid()) {
+		return ctlAccessAdmin
+	}
+	gids := []string{creds.gid}
+	if groupIds, err := a.groupIds(creds.uid); err == nil {
+		gids = append(gids, groupIds...)
+	}
+	if a.adminGid != "" && slices.Contains(gids, a.adminGid) {
+		return ctlAccessAdmin
+	}
+	if a.readGid != "" && slices.Contains(gids, a.readGid) {
+		return ctlAccessReadOnly
+	}
+	return ctlAccessNone
+}
+
+type ctlAccessKey struct{}
+
+// withCtlAccess returns a context carrying the access granted to the client of the control API.
+func withCtlAccess(ctx context.Context, access ctlAccess) context.Context {
+	return context.WithValue(ctx, ctlAccessKey{}, access)
+}
+
+// ctlApiAuthorize rejects the control API requests the client is not granted access to.
+// Read-only access allows the requests that do not change nexd, except for the bug report
+// which includes the logs of nexd and the packet capture which includes the traffic of the tunnel.
+func ctlApiAuthorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access, _ := r.Context().Value(ctlAccessKey{}).(ctlAccess)
+		switch access {
+		case ctlAccessAdmin:
+		case ctlAccessReadOnly:
+			if r.Method != http.MethodGet || strings.HasSuffix(r.URL.Path, "/bugreport") || strings.HasSuffix(r.URL.Path, "/capture") {
+				writeCtlApiError(w, http.StatusForbidden, errCtlForbidden)
+				return
+			}
+		default:
+			writeCtlApiError(w, http.StatusForbidden, errCtlForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NexdCtlReadOnly exposes the methods of NexdCtl that do not change nexd to read-only clients
+// of the JSON-RPC interface.
+type NexdCtlReadOnly struct {
+	ctl *NexdCtl
+}
+
+func (ro *NexdCtlReadOnly) Status(arg string, result *string) error {
+	return ro.ctl.Status(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) Version(arg string, result *string) error {
+	return ro.ctl.Version(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) GetTunnelIPv4(arg string, result *string) error {
+	return ro.ctl.GetTunnelIPv4(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) GetTunnelIPv6(arg string, result *string) error {
+	return ro.ctl.GetTunnelIPv6(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) GetDebug(arg string, result *string) error {
+	return ro.ctl.GetDebug(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) ProxyList(arg string, result *string) error {
+	return ro.ctl.ProxyList(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) ListPeers(arg string, result *string) error {
+	return ro.ctl.ListPeers(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) ListExitNodes(arg string, result *string) error {
+	return ro.ctl.ListExitNodes(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) ConnectivityV4(arg string, result *string) error {
+	return ro.ctl.ConnectivityV4(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) ConnectivityV6(arg string, result *string) error {
+	return ro.ctl.ConnectivityV6(arg, result)
+}
+
+func (ro *NexdCtlReadOnly) Diagnose(arg string, result *string) error {
+	return ro.ctl.Diagnose(arg, result)
+}
@@ -0,0 +1,34 @@
+//go:build darwin
+
+package nexodus
+
+import (
+	"net"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the credentials of the process connected to a unix socket, using LOCAL_PEERCRED.
+func peerCredentials(conn *net.UnixConn) (peerCreds, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return peerCreds{}, err
+	}
+	var xucred *unix.Xucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		xucred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	})
+	if err != nil {
+		return peerCreds{}, err
+	}
+	if credErr != nil {
+		return peerCreds{}, credErr
+	}
+	creds := peerCreds{uid: strconv.FormatUint(uint64(xucred.Uid), 10)}
+	if xucred.Ngroups > 0 {
+		creds.gid = strconv.FormatUint(uint64(xucred.Groups[0]), 10)
+	}
+	return creds, nil
+}
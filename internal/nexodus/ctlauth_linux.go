@@ -0,0 +1,33 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"net"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the credentials of the process connected to a unix socket, using SO_PEERCRED.
+func peerCredentials(conn *net.UnixConn) (peerCreds, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return peerCreds{}, err
+	}
+	var ucred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		ucred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return peerCreds{}, err
+	}
+	if credErr != nil {
+		return peerCreds{}, credErr
+	}
+	return peerCreds{
+		uid: strconv.FormatUint(uint64(ucred.Uid), 10),
+		gid: strconv.FormatUint(uint64(ucred.Gid), 10),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package nexodus
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCtlAuthorizer(t *testing.T) {
+	require := require.New(t)
+	a := &ctlAuthorizer{
+		readGid:  "2000",
+		adminGid: "3000",
+		groupIds: func(uid string) ([]string, error) {
+			switch uid {
+			case "1001":
+				return []string{"1001", "2000"}, nil
+			case "1002":
+				return []string{"1002", "2000", "3000"}, nil
+			}
+			return []string{uid}, nil
+		},
+	}
+	require.True(a.enabled())
+	require.Equal(ctlAccessAdmin, a.accessFor(peerCreds{uid: "0", gid: "0"}))
+	require.Equal(ctlAccessAdmin, a.accessFor(peerCreds{uid: strconv.Itoa(os.Geteuid())}))
+	require.Equal(ctlAccessReadOnly, a.accessFor(peerCreds{uid: "1001", gid: "1001"}))
+	require.Equal(ctlAccessAdmin, a.accessFor(peerCreds{uid: "1002", gid: "1002"}))
+	// the primary group of the process counts too
+	require.Equal(ctlAccessReadOnly, a.accessFor(peerCreds{uid: "1003", gid: "2000"}))
+	require.Equal(ctlAccessNone, a.accessFor(peerCreds{uid: "1004", gid: "1004"}))
+
+	// without groups, the permissions of the socket file restrict access
+	var disabled *ctlAuthorizer
+	require.False(disabled.enabled())
+	access, err := disabled.access(nil)
+	require.NoError(err)
+	require.Equal(ctlAccessAdmin, access)
+}
+
+func TestCtlApiAuthorize(t *testing.T) {
+	require := require.New(t)
+	handler := ctlApiAuthorize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tc := range []struct {
+		access ctlAccess
+		method string
+		path   string
+		status int
+	}{
+		{ctlAccessAdmin, http.MethodPost, "/v1/proxy-rules", http.StatusOK},
+		{ctlAccessAdmin, http.MethodGet, "/v1/bugreport", http.StatusOK},
+		{ctlAccessReadOnly, http.MethodGet, "/v1/peers", http.StatusOK},
+		{ctlAccessReadOnly, http.MethodGet, "/v1/status", http.StatusOK},
+		{ctlAccessReadOnly, http.MethodPost, "/v1/proxy-rules", http.StatusForbidden},
+		{ctlAccessReadOnly, http.MethodPut, "/v1/debug", http.StatusForbidden},
+		{ctlAccessReadOnly, http.MethodGet, "/v1/bugreport", http.StatusForbidden},
+		{ctlAccessReadOnly, http.MethodGet, "/v1/capture", http.StatusForbidden},
+		{ctlAccessNone, http.MethodGet, "/v1/status", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req = req.WithContext(withCtlAccess(req.Context(), tc.access))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(tc.status, w.Code, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestPeerCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket peer credentials are not supported on windows")
+	}
+	require := require.New(t)
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: filepath.Join(t.TempDir(), "nexd.sock"), Net: "unix"})
+	require.NoError(err)
+	defer l.Close()
+
+	client, err := net.Dial("unix", l.Addr().String())
+	require.NoError(err)
+	defer client.Close()
+	conn, err := l.AcceptUnix()
+	require.NoError(err)
+	defer conn.Close()
+
+	creds, err := peerCredentials(conn)
+	require.NoError(err)
+	require.Equal(strconv.Itoa(os.Getuid()), creds.uid)
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package nexodus
+
+import (
+	"errors"
+	"net"
+)
+
+// peerCredentials is not supported on Windows, where access to the control socket is governed by the
+// permissions of the socket file.
+func peerCredentials(conn *net.UnixConn) (peerCreds, error) {
+	return peerCreds{}, errors.New("unix socket peer credentials are not supported on windows")
+}
@@ -0,0 +1,283 @@
+package nexodus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+)
+
+const (
+	// the number of peering configurations remembered for each peer
+	maxPeeringHistory = 10
+	// the number of STUN results remembered
+	maxStunResults = 10
+)
+
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// Diagnose reports the state of the path to a peer, identified by its public key, device id, hostname or tunnel IP.
+func (ac *NexdCtl) Diagnose(peer string, result *string) error {
+	res, err := ac.nx.diagnosePeer(peer, true)
+	if err != nil {
+		return err
+	}
+
+	diagnoseJson, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("error marshalling diagnose results: %w", err)
+	}
+
+	*result = ac.nx.redact(string(diagnoseJson))
+
+	return nil
+}
+
+// BugReport collects the state of nexd, the diagnostics of every peer and the recent logs.
+// Secrets are redacted from every file of the report.
+func (ac *NexdCtl) BugReport(_ string, result *string) error {
+	report, err := ac.nx.bugReport()
+	if err != nil {
+		return err
+	}
+	reportJson, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("error marshalling bug report: %w", err)
+	}
+
+	*result = string(reportJson)
+
+	return nil
+}
+
+func (nx *Nexodus) bugReport() (api.BugReportResponse, error) {
+	ac := &NexdCtl{nx: nx}
+	files := map[string]string{
+		"version.txt": nx.version + "\n",
+	}
+
+	var out string
+	if err := ac.Status("", &out); err != nil {
+		out = err.Error()
+	}
+	files["status.txt"] = out
+	if err := ac.ListPeers("", &out); err != nil {
+		out = err.Error()
+	}
+	files["peers.json"] = out
+
+	settingsJson, err := json.MarshalIndent(nx.diagnosticSettings(), "", "  ")
+	if err != nil {
+		return api.BugReportResponse{}, fmt.Errorf("error marshalling settings: %w", err)
+	}
+	files["settings.json"] = string(settingsJson)
+
+	var peerKeys []string
+	nx.deviceCacheIterRead(func(d deviceCacheEntry) {
+		if d.device.GetPublicKey() != nx.wireguardPubKey {
+			peerKeys = append(peerKeys, d.device.GetPublicKey())
+		}
+	})
+	for _, key := range peerKeys {
+		res, err := nx.diagnosePeer(key, false)
+		if err != nil {
+			continue
+		}
+		diagnoseJson, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return api.BugReportResponse{}, fmt.Errorf("error marshalling diagnose results: %w", err)
+		}
+		files[fmt.Sprintf("peers/%s.json", res.Peer.DeviceId)] = string(diagnoseJson)
+	}
+
+	routes, firewall := nx.hostNetworkState()
+	files["routes.txt"] = routes
+	files["firewall.txt"] = firewall
+	if nx.logBuffer != nil {
+		files["nexd.log"] = nx.logBuffer.String()
+	}
+
+	for name, content := range files {
+		files[name] = nx.redact(content)
+	}
+	return api.BugReportResponse{Files: files}, nil
+}
+
+func (nx *Nexodus) diagnosePeer(query string, includeHostState bool) (api.DiagnosePeerResponse, error) {
+	res := api.DiagnosePeerResponse{
+		NatType:          "endpoint-independent",
+		ReflexiveAddress: nx.nodeReflexiveAddressIPv4.String(),
+		RelayRequired:    nx.symmetricNat,
+		StunResults:      nx.recentStunResults(),
+	}
+	if nx.symmetricNat {
+		res.NatType = "symmetric"
+	}
+
+	found := false
+	nx.deviceCacheLock.RLock()
+	for _, d := range nx.deviceCache {
+		if d.device.GetPublicKey() == nx.wireguardPubKey || !peerMatches(d.device.GetPublicKey(), d.device.GetId(), d.device.GetHostname(), deviceTunnelIPs(d), query) {
+			continue
+		}
+		found = true
+		res.Peer = api.DiagnosePeer{
+			PublicKey:          d.device.GetPublicKey(),
+			DeviceId:           d.device.GetId(),
+			Hostname:           d.device.GetHostname(),
+			TunnelIPs:          deviceTunnelIPs(d),
+			Online:             d.device.GetOnline(),
+			Relay:              d.device.GetRelay(),
+			SymmetricNat:       d.device.GetSymmetricNat(),
+			ConfiguredEndpoint: d.endpoint,
+			AllowedIPs:         nx.wgConfig.Peers[d.device.GetPublicKey()].AllowedIPs,
+			PeeringMethod:      d.peeringMethod,
+			PeeringTime:        d.peeringTime,
+			PeeringHistory:     slices.Clone(d.peeringHistory),
+			Healthy:            d.peerHealthy,
+		}
+		for _, endpoint := range d.device.Endpoints {
+			res.Peer.EndpointCandidates = append(res.Peer.EndpointCandidates, api.EndpointCandidate{
+				Source:  endpoint.GetSource(),
+				Address: endpoint.GetAddress(),
+			})
+		}
+		break
+	}
+	nx.deviceCacheLock.RUnlock()
+	if !found {
+		return res, fmt.Errorf("no peer matches %q, use a public key, device id, hostname or tunnel ip", query)
+	}
+
+	res.Peer.HandshakeAge = "never"
+	if sessions, err := nx.DumpPeersDefault(); err == nil {
+		if session, ok := sessions[res.Peer.PublicKey]; ok {
+			res.Peer.Tx = session.Tx
+			res.Peer.Rx = session.Rx
+			if !session.LastHandshakeTime.IsZero() {
+				res.Peer.LatestHandshake = session.LastHandshakeTime
+				res.Peer.HandshakeAge = time.Since(session.LastHandshakeTime).Round(time.Second).String()
+			}
+		}
+	}
+
+	if includeHostState {
+		res.Routes, res.Firewall = nx.hostNetworkState()
+	}
+	return res, nil
+}
+
+// hostNetworkState returns the routing tables and firewall rules of the host.
+func (nx *Nexodus) hostNetworkState() (string, string) {
+	if nx.userspaceMode {
+		return "not applicable in proxy mode\n", "not applicable in proxy mode\n"
+	}
+	routes, err := routeTableDump()
+	if err != nil {
+		routes = err.Error()
+	}
+	firewall, err := firewallDump()
+	if err != nil {
+		firewall = err.Error()
+	}
+	return routes, firewall
+}
+
+// diagnosticSettings returns the settings of this nexd that do not contain secrets.
+func (nx *Nexodus) diagnosticSettings() map[string]any {
+	return map[string]any{
+		"version":                    nx.version,
+		"os":                         nx.os,
+		"hostname":                   nx.hostname,
+		"device_id":                  nx.deviceId,
+		"public_key":                 nx.wireguardPubKey,
+		"api_url":                    nx.apiURL.String(),
+		"vpc_id":                     nx.vpcId,
+		"security_group_id":          nx.securityGroupId,
+		"listen_port":                nx.listenPort,
+		"tunnel_ip":                  nx.TunnelIP,
+		"tunnel_ipv6":                nx.TunnelIpV6,
+		"local_endpoint":             nx.endpointLocalAddress,
+		"advertise_cidrs":            nx.advertiseCidrs,
+		"router_priority":            nx.routerPriority,
+		"network_router":             nx.networkRouter,
+		"network_router_disable_nat": nx.networkRouterDisableNAT,
+		"exit_node_client":           nx.exitNode.exitNodeClientEnabled,
+		"exit_node_origin":           nx.exitNode.exitNodeOriginEnabled,
+		"relay":                      nx.relay,
+		"relay_derp":                 nx.relayDerp,
+		"relay_only":                 nx.relayOnly,
+		"symmetric_nat":              nx.symmetricNat,
+		"userspace_mode":             nx.userspaceMode,
+	}
+}
+
+// redact removes the secrets known to nexd and anything that looks like a JWT from s.
+func (nx *Nexodus) redact(s string) string {
+	secrets := []string{nx.wireguardPvtKey, nx.password, nx.regKey}
+	if nx.stateStore != nil {
+		if token := nx.stateStore.State().AuthToken; token != nil {
+			secrets = append(secrets, token.AccessToken, token.RefreshToken)
+		}
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "[REDACTED]")
+		}
+	}
+	return jwtPattern.ReplaceAllString(s, "[REDACTED]")
+}
+
+func (nx *Nexodus) recordStunResult(server string, address netip.AddrPort, err error) {
+	result := api.StunResult{
+		Server: server,
+		Time:   time.Now(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Address = address.String()
+	}
+
+	nx.stunResultsLock.Lock()
+	defer nx.stunResultsLock.Unlock()
+	nx.stunResults = append(nx.stunResults, result)
+	if len(nx.stunResults) > maxStunResults {
+		nx.stunResults = nx.stunResults[len(nx.stunResults)-maxStunResults:]
+	}
+}
+
+func (nx *Nexodus) recentStunResults() []api.StunResult {
+	nx.stunResultsLock.Lock()
+	defer nx.stunResultsLock.Unlock()
+	return slices.Clone(nx.stunResults)
+}
+
+// appendPeeringAttempt adds attempt to the peering history of a peer, keeping the last maxPeeringHistory attempts.
+func appendPeeringAttempt(history []api.PeeringAttempt, attempt api.PeeringAttempt) []api.PeeringAttempt {
+	history = append(slices.Clip(history), attempt)
+	if len(history) > maxPeeringHistory {
+		history = history[len(history)-maxPeeringHistory:]
+	}
+	return history
+}
+
+func deviceTunnelIPs(d deviceCacheEntry) []string {
+	var ips []string
+	for _, ip := range d.device.Ipv4TunnelIps {
+		ips = append(ips, ip.GetAddress())
+	}
+	for _, ip := range d.device.Ipv6TunnelIps {
+		ips = append(ips, ip.GetAddress())
+	}
+	return ips
+}
+
+func peerMatches(publicKey, deviceId, hostname string, tunnelIPs []string, query string) bool {
+	return query == publicKey || query == deviceId || strings.EqualFold(query, hostname) || slices.Contains(tunnelIPs, query)
+}
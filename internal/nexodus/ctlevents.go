@@ -0,0 +1,71 @@
+package nexodus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+)
+
+// the number of events buffered for a subscriber before further events are dropped
+const eventBufferSize = 64
+
+// eventBus fans out the events of nexd to the subscribers of the control API.
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan api.NexdEvent]struct{}
+}
+
+// subscribe returns a channel receiving the published events and a function to unsubscribe.
+// Events are dropped for subscribers that do not keep up.
+func (b *eventBus) subscribe() (<-chan api.NexdEvent, func()) {
+	return b.subscribeBuffered(eventBufferSize)
+}
+
+// subscribeBuffered subscribes with a buffer of size events.
+func (b *eventBus) subscribeBuffered(size int) (<-chan api.NexdEvent, func()) {
+	ch := make(chan api.NexdEvent, size)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = map[chan api.NexdEvent]struct{}{}
+	}
+	b.subscribers[ch] = struct{}{}
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, ch)
+	}
+}
+
+func (b *eventBus) publish(event api.NexdEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func (nx *Nexodus) publishPeerEvent(eventType string, d deviceCacheEntry) {
+	nx.events.publish(api.NexdEvent{
+		Type:          eventType,
+		PublicKey:     d.device.GetPublicKey(),
+		DeviceId:      d.device.GetId(),
+		Hostname:      d.device.GetHostname(),
+		PeeringMethod: d.peeringMethod,
+	})
+}
+
+func (nx *Nexodus) publishTunnelEvent(eventType string) {
+	nx.events.publish(api.NexdEvent{
+		Type:       eventType,
+		TunnelIPv4: nx.TunnelIP,
+		TunnelIPv6: nx.TunnelIpV6,
+	})
+}
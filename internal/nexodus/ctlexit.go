@@ -33,11 +33,23 @@ func (ac *NexdCtl) DisableExitNodeClient(_ string, result *string) error {
 
 // ListExitNodes lists all exit node origins
 func (ac *NexdCtl) ListExitNodes(_ string, result *string) error {
+	exitNodeOriginsJSON, err := json.Marshal(ac.nx.listExitNodeOrigins())
+	if err != nil {
+		return fmt.Errorf("error marshalling exit node list results: %w", err)
+	}
+
+	*result = string(exitNodeOriginsJSON)
+
+	return nil
+}
+
+// listExitNodeOrigins returns the exit node origins of the VPC, including this device if it is one.
+func (nx *Nexodus) listExitNodeOrigins() []wgPeerConfig {
 	var allExitNodeOrigins []wgPeerConfig
 	isExitNode := false
 
 	// Check if the local node is an exit node
-	for _, prefix := range ac.nx.advertiseCidrs {
+	for _, prefix := range nx.advertiseCidrs {
 		if prefix == "0.0.0.0/0" {
 			isExitNode = true
 			break
@@ -47,26 +59,18 @@ func (ac *NexdCtl) ListExitNodes(_ string, result *string) error {
 	// If the local node is an exit node, create a copy and append the new instance
 	if isExitNode {
 		// Make a copy of exitNodeOrigins to a new slice
-		allExitNodeOrigins = make([]wgPeerConfig, len(ac.nx.exitNode.exitNodeOrigins))
-		copy(allExitNodeOrigins, ac.nx.exitNode.exitNodeOrigins)
+		allExitNodeOrigins = make([]wgPeerConfig, len(nx.exitNode.exitNodeOrigins))
+		copy(allExitNodeOrigins, nx.exitNode.exitNodeOrigins)
 
 		// Create a new instance of wgPeerConfig
 		newPeerConfig := wgPeerConfig{
-			PublicKey: ac.nx.wireguardPubKey,
-			Endpoint:  ac.nx.nodeReflexiveAddressIPv4.String(),
+			PublicKey: nx.wireguardPubKey,
+			Endpoint:  nx.nodeReflexiveAddressIPv4.String(),
 		}
 		// Append the local node if it is an exit node
 		allExitNodeOrigins = append(allExitNodeOrigins, newPeerConfig)
 	} else {
-		allExitNodeOrigins = ac.nx.exitNode.exitNodeOrigins
+		allExitNodeOrigins = nx.exitNode.exitNodeOrigins
 	}
-
-	exitNodeOriginsJSON, err := json.Marshal(allExitNodeOrigins)
-	if err != nil {
-		return fmt.Errorf("error marshalling exit node list results: %w", err)
-	}
-
-	*result = string(exitNodeOriginsJSON)
-
-	return nil
+	return allExitNodeOrigins
 }
@@ -3,25 +3,47 @@ package nexodus
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/nexodus-io/nexodus/internal/api"
 )
 
-type ListPeersResponse struct {
-	RelayPresent  bool                  `json:"relay-present"`
-	RelayRequired bool                  `json:"relay-required"`
-	Peers         map[string]WgSessions `json:"peers"`
+func (ac *NexdCtl) ListPeers(_ string, result *string) error {
+	response, err := ac.nx.listPeers()
+	if err != nil {
+		return err
+	}
+
+	peersJSON, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("error marshalling list of peers: %w", err)
+	}
+
+	*result = string(peersJSON)
+
+	return nil
 }
 
-func (ac *NexdCtl) ListPeers(_ string, result *string) error {
-	peers, err := ac.nx.DumpPeersDefault()
+func (nx *Nexodus) listPeers() (api.ListPeersResponse, error) {
+	sessions, err := nx.DumpPeersDefault()
 	if err != nil {
-		return fmt.Errorf("error getting list of peers: %w", err)
+		return api.ListPeersResponse{}, fmt.Errorf("error getting list of peers: %w", err)
+	}
+	response := api.ListPeersResponse{
+		Peers:         map[string]api.Peer{},
+		RelayRequired: nx.symmetricNat,
 	}
-	response := ListPeersResponse{
-		Peers:         peers,
-		RelayRequired: ac.nx.symmetricNat,
+	for key, session := range sessions {
+		response.Peers[key] = api.Peer{
+			PublicKey:       session.PublicKey,
+			Endpoint:        session.Endpoint,
+			AllowedIPs:      session.AllowedIPs,
+			LatestHandshake: session.LatestHandshake,
+			Tx:              session.Tx,
+			Rx:              session.Rx,
+		}
 	}
-	ac.nx.deviceCacheIterRead(func(d deviceCacheEntry) {
-		if d.device.GetPublicKey() == ac.nx.wireguardPubKey {
+	nx.deviceCacheIterRead(func(d deviceCacheEntry) {
+		if d.device.GetPublicKey() == nx.wireguardPubKey {
 			return
 		}
 		p, ok := response.Peers[d.device.GetPublicKey()]
@@ -34,13 +56,5 @@ func (ac *NexdCtl) ListPeers(_ string, result *string) error {
 			response.RelayPresent = true
 		}
 	})
-
-	peersJSON, err := json.Marshal(response)
-	if err != nil {
-		return fmt.Errorf("error marshalling list of peers: %w", err)
-	}
-
-	*result = string(peersJSON)
-
-	return nil
+	return response, nil
 }
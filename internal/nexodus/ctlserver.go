@@ -3,8 +3,6 @@ package nexodus
 import (
 	"fmt"
 
-	"github.com/bytedance/gopkg/util/logger"
-
 	"go.uber.org/zap"
 )
 
@@ -12,19 +10,24 @@ type NexdCtl struct {
 	nx *Nexodus
 }
 
-func (ac *NexdCtl) Status(_ string, result *string) error {
-	var statusStr string
-	switch ac.nx.status {
+// statusString returns the name of one of the NexdStatus* constants.
+func statusString(status int) string {
+	switch status {
 	case NexdStatusStarting:
-		statusStr = "Starting"
+		return "Starting"
 	case NexdStatusAuth:
-		statusStr = "WaitingForAuth"
+		return "WaitingForAuth"
 	case NexdStatusRunning:
-		statusStr = "Running"
+		return "Running"
+	case NexdStatusOfflineConfig:
+		return "Degraded/OfflineConfig"
 	default:
-		statusStr = "Unknown"
+		return "Unknown"
 	}
-	res := fmt.Sprintf("Status: %s\n", statusStr)
+}
+
+func (ac *NexdCtl) Status(_ string, result *string) error {
+	res := fmt.Sprintf("Status: %s\n", statusString(ac.nx.status))
 	if len(ac.nx.statusMsg) > 0 {
 		res += ac.nx.statusMsg
 	}
@@ -62,25 +65,28 @@ func (ac *NexdCtl) ProxyList(_ string, result *string) error {
 }
 
 func (ac *NexdCtl) proxyAdd(proxyType ProxyType, rule string, result *string) error {
+	if err := ac.nx.addStoredProxyRule(proxyType, rule); err != nil {
+		return err
+	}
+	*result = fmt.Sprintf("Added %s proxy rule: %s\n", proxyType, rule)
+	return nil
+}
 
+// addStoredProxyRule adds and starts a proxy rule that is kept in the state store across restarts.
+func (nx *Nexodus) addStoredProxyRule(proxyType ProxyType, rule string) error {
 	proxyRule, err := ParseProxyRule(rule, proxyType)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("Failed to parse %s proxy rule (%s): %v", proxyType, rule, err))
+		return fmt.Errorf("failed to parse %s proxy rule (%s): %w", proxyType, rule, err)
 	}
 	proxyRule.stored = true
 
-	proxy, err := ac.nx.UserspaceProxyAdd(proxyRule)
+	proxy, err := nx.UserspaceProxyAdd(proxyRule)
 	if err != nil {
 		return err
 	}
-	proxy.Start(ac.nx.nexCtx, ac.nx.nexWg, ac.nx.userspaceNet)
+	proxy.Start(nx.nexCtx, nx.nexWg, nx.userspaceNet)
 
-	err = ac.nx.StoreProxyRules()
-	if err != nil {
-		return err
-	}
-	*result = fmt.Sprintf("Added %s proxy rule: %s\n", proxyType, rule)
-	return nil
+	return nx.StoreProxyRules()
 }
 
 func (ac *NexdCtl) ProxyAddIngress(rule string, result *string) error {
@@ -92,23 +98,25 @@ func (ac *NexdCtl) ProxyAddEgress(rule string, result *string) error {
 }
 
 func (ac *NexdCtl) proxyRemove(proxyType ProxyType, rule string, result *string) error {
+	if err := ac.nx.removeStoredProxyRule(proxyType, rule); err != nil {
+		return err
+	}
+	*result = fmt.Sprintf("Removed %s proxy rule: %s\n", proxyType, rule)
+	return nil
+}
+
+// removeStoredProxyRule stops a proxy rule and removes it from the state store.
+func (nx *Nexodus) removeStoredProxyRule(proxyType ProxyType, rule string) error {
 	proxyRule, err := ParseProxyRule(rule, proxyType)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("Failed to parse %s proxy rule (%s): %v", proxyType, rule, err))
+		return fmt.Errorf("failed to parse %s proxy rule (%s): %w", proxyType, rule, err)
 	}
 	proxyRule.stored = true
 
-	_, err = ac.nx.UserspaceProxyRemove(proxyRule)
-	if err != nil {
+	if _, err := nx.UserspaceProxyRemove(proxyRule); err != nil {
 		return err
 	}
-	err = ac.nx.StoreProxyRules()
-	if err != nil {
-		return err
-	}
-
-	*result = fmt.Sprintf("Removed ingress proxy rule: %s\n", rule)
-	return nil
+	return nx.StoreProxyRules()
 }
 func (ac *NexdCtl) ProxyRemoveIngress(rule string, result *string) error {
 	return ac.proxyRemove(ProxyTypeIngress, rule, result)
@@ -138,3 +146,18 @@ func (ac *NexdCtl) GetDebug(_ string, result *string) error {
 	}
 	return nil
 }
+
+func (ac *NexdCtl) Reload(_ string, result *string) error {
+	if err := ac.nx.reload(); err != nil {
+		return err
+	}
+	*result = "Configuration reloaded"
+	return nil
+}
+
+func (nx *Nexodus) reload() error {
+	if nx.reloadHandler == nil {
+		return fmt.Errorf("nexd was not started with a --config file")
+	}
+	return nx.reloadHandler()
+}
@@ -3,8 +3,10 @@
 package nexodus
 
 import (
+	"bufio"
 	"context"
 	"net"
+	"net/http"
 	"net/rpc"
 	"net/rpc/jsonrpc"
 	"os"
@@ -28,6 +30,14 @@ func (nx *Nexodus) createListener() (*net.UnixListener, error) {
 		nx.logger.Error("Error creating unix socket: ", err)
 		return nil, err
 	}
+	if nx.ctlAuth.enabled() {
+		// clients are authorized from their credentials, every user may connect to the socket
+		if err := os.Chmod(socketPath, 0666); err != nil {
+			l.Close()
+			nx.logger.Error("Error setting the permissions of the unix socket: ", err)
+			return nil, err
+		}
+	}
 	return l, nil
 }
 
@@ -69,11 +79,37 @@ func (nx *Nexodus) CtlServerUnixStart(ctx context.Context, wg *sync.WaitGroup) e
 func (nx *Nexodus) CtlServerUnixRun(ctx context.Context, ctlWg *sync.WaitGroup, l *net.UnixListener) error {
 	ac := new(NexdCtl)
 	ac.nx = nx
-	err := rpc.Register(ac)
+	adminServer := rpc.NewServer()
+	err := adminServer.RegisterName("NexdCtl", ac)
 	if err != nil {
 		nx.logger.Error("Error on rpc.Register(): ", err)
 		return err
 	}
+	readOnlyServer := rpc.NewServer()
+	err = readOnlyServer.RegisterName("NexdCtl", &NexdCtlReadOnly{ctl: ac})
+	if err != nil {
+		nx.logger.Error("Error on rpc.Register(): ", err)
+		return err
+	}
+
+	// The typed control API is served over HTTP on the same socket as the
+	// legacy JSON-RPC interface, connections are told apart by their first byte.
+	httpListener := newConnListener(l.Addr())
+	defer httpListener.Close()
+	httpServer := &http.Server{
+		Handler:           ctlApiAuthorize(nx.ctlApiHandler(ctx)),
+		ReadHeaderTimeout: 10 * time.Second,
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			if sc, ok := c.(*sniffedConn); ok {
+				return withCtlAccess(ctx, sc.access)
+			}
+			return ctx
+		},
+	}
+	util.GoWithWaitGroup(ctlWg, func() {
+		_ = httpServer.Serve(httpListener)
+	})
+	defer httpServer.Close()
 
 	// This routine will exit when the listener is closed intentionally,
 	// or some error occurs.
@@ -89,7 +125,29 @@ func (nx *Nexodus) CtlServerUnixRun(ctx context.Context, ctlWg *sync.WaitGroup,
 				break
 			}
 			util.GoWithWaitGroup(ctlWg, func() {
-				jsonrpc.ServeConn(conn)
+				access, err := nx.ctlAuth.access(conn)
+				if err != nil {
+					nx.logger.Warnf("Failed to authenticate a ctl client: %v", err)
+				}
+				r := bufio.NewReader(conn)
+				first, err := r.Peek(1)
+				if err != nil {
+					conn.Close()
+					return
+				}
+				sniffed := &sniffedConn{Conn: conn, r: r, access: access}
+				if first[0] == '{' {
+					switch access {
+					case ctlAccessAdmin:
+						adminServer.ServeCodec(jsonrpc.NewServerCodec(sniffed))
+					case ctlAccessReadOnly:
+						readOnlyServer.ServeCodec(jsonrpc.NewServerCodec(sniffed))
+					default:
+						conn.Close()
+					}
+					return
+				}
+				httpListener.deliver(sniffed)
 			})
 		}
 	})
@@ -116,3 +174,59 @@ func (nx *Nexodus) CtlServerUnixRun(ctx context.Context, ctlWg *sync.WaitGroup,
 
 	return err
 }
+
+// sniffedConn is a connection whose first bytes have been peeked at.
+type sniffedConn struct {
+	net.Conn
+	r *bufio.Reader
+	// the access granted to the client
+	access ctlAccess
+}
+
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// connListener is a net.Listener accepting the connections handed to it by deliver.
+type connListener struct {
+	addr  net.Addr
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newConnListener(addr net.Addr) *connListener {
+	return &connListener{
+		addr:  addr,
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+func (l *connListener) deliver(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		conn.Close()
+	}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
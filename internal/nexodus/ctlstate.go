@@ -0,0 +1,36 @@
+package nexodus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/state/fstore"
+)
+
+// rekeyState re-encrypts the state with the key of the requested key provider. nexd must be restarted
+// with the same --state-key-provider to load it.
+func (nx *Nexodus) rekeyState(req api.StateRekeyRequest) error {
+	rekeyer, ok := nx.stateStore.(fstore.Rekeyer)
+	if !ok {
+		return fmt.Errorf("the state stored in %s cannot be re-keyed", nx.stateStore)
+	}
+	var provider fstore.KeyProvider
+	var err error
+	if req.Passphrase != "" {
+		if name, _, _ := strings.Cut(req.KeyProvider, ":"); name != "passphrase" {
+			return fmt.Errorf("a passphrase can only be used with the passphrase key provider")
+		}
+		provider = fstore.NewPassphraseKeyProvider(req.Passphrase)
+	} else {
+		provider, err = fstore.ParseKeyProvider(req.KeyProvider)
+		if err != nil {
+			return err
+		}
+	}
+	if err := rekeyer.Rekey(provider); err != nil {
+		return err
+	}
+	nx.logger.Infof("The state was re-keyed, it is now stored in %s", nx.stateStore)
+	return nil
+}
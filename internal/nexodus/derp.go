@@ -742,6 +742,14 @@ func (nr *nexRelay) DERPs() int {
 	return len(nr.activeDerp)
 }
 
+// homeDERP reports the region ID of the home DERP, 0 when there is none.
+func (nr *nexRelay) homeDERP() int {
+	nr.mu.Lock()
+	defer nr.mu.Unlock()
+
+	return nr.myDerp
+}
+
 // derpAddrFamSelector is the derphttp.AddressFamilySelector we pass
 // to derphttp.Client.SetAddressFamilySelector.
 //
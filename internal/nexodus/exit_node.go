@@ -2,6 +2,7 @@ package nexodus
 
 import (
 	"fmt"
+	"github.com/nexodus-io/nexodus/internal/api"
 	"github.com/nexodus-io/nexodus/internal/util"
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -29,6 +30,7 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 	defer nx.deviceCacheLock.RUnlock()
 
 	exitNodeFound := false
+	var exitNodeEntry deviceCacheEntry
 	for _, deviceEntry := range nx.deviceCache {
 		for _, allowedIp := range deviceEntry.device.AllowedIps {
 			if util.IsDefaultIPv4Route(allowedIp) {
@@ -47,6 +49,7 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 					PersistentKeepAlive: persistentKeepalive,
 				}
 				exitNodeFound = true
+				exitNodeEntry = deviceEntry
 				break
 			}
 		}
@@ -175,6 +178,10 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 
 	nx.logger.Info("Exit node client configuration has been enabled")
 	nx.logger.Debugf("Exit node client enabled and using the exit node server: %+v", nx.exitNode.exitNodeOrigins[0])
+	if nx.exitNode.activeExitNode != exitNodeEntry.device.GetPublicKey() {
+		nx.exitNode.activeExitNode = exitNodeEntry.device.GetPublicKey()
+		nx.publishPeerEvent(api.NexdEventExitNodeChanged, exitNodeEntry)
+	}
 
 	return nil
 }
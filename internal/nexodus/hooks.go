@@ -0,0 +1,210 @@
+package nexodus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"go.uber.org/zap"
+)
+
+// hookAllEvents is the event of the hooks that run on every event.
+const hookAllEvents = "*"
+
+// the number of events queued for the hooks before further events are dropped
+const hookQueueSize = 256
+
+var hookEvents = []string{
+	hookAllEvents,
+	api.NexdEventTunnelUp,
+	api.NexdEventTunnelDown,
+	api.NexdEventTunnelIPChanged,
+	api.NexdEventPeerAdded,
+	api.NexdEventPeerRemoved,
+	api.NexdEventPeerUp,
+	api.NexdEventPeerDown,
+	api.NexdEventPeeringMethodChanged,
+	api.NexdEventExitNodeChanged,
+	api.NexdEventStatusChanged,
+	api.NexdEventSubnetChanged,
+}
+
+// Hook is a command run or a webhook called by nexd when an event happens.
+// The event is passed as JSON on the standard input of the command, or as the body of the webhook request.
+type Hook struct {
+	// Event is the type of the events the hook runs on, or * for all events.
+	Event string
+	// Command is run with the shell of the system, if set.
+	Command string
+	// URL is sent a POST request, if set.
+	URL string
+}
+
+func (h Hook) String() string {
+	if h.URL != "" {
+		return fmt.Sprintf("%s=%s", h.Event, h.URL)
+	}
+	return fmt.Sprintf("%s=%s", h.Event, h.Command)
+}
+
+// ParseHook parses a hook command in the form event=command.
+func ParseHook(value string) (Hook, error) {
+	event, command, err := parseHookEvent(value)
+	if err != nil {
+		return Hook{}, err
+	}
+	return Hook{Event: event, Command: command}, nil
+}
+
+// ParseWebhook parses a webhook in the form event=url.
+func ParseWebhook(value string) (Hook, error) {
+	event, rawURL, err := parseHookEvent(value)
+	if err != nil {
+		return Hook{}, err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Hook{}, fmt.Errorf("invalid webhook url (%s): %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Hook{}, fmt.Errorf("invalid webhook url (%s): the scheme must be http or https", rawURL)
+	}
+	return Hook{Event: event, URL: rawURL}, nil
+}
+
+func parseHookEvent(value string) (string, string, error) {
+	event, action, found := strings.Cut(value, "=")
+	if !found || action == "" {
+		return "", "", fmt.Errorf("invalid hook (%s): expected event=action", value)
+	}
+	if !slices.Contains(hookEvents, event) {
+		return "", "", fmt.Errorf("invalid hook event (%s): use one of %s", event, strings.Join(hookEvents, ", "))
+	}
+	return event, action, nil
+}
+
+// hookRunner runs the hooks of the events of nexd, one at a time and in the order of the events.
+type hookRunner struct {
+	logger  *zap.SugaredLogger
+	hooks   []Hook
+	timeout time.Duration
+	client  *http.Client
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func newHookRunner(logger *zap.SugaredLogger, hooks []Hook, timeout time.Duration) *hookRunner {
+	if len(hooks) == 0 {
+		return nil
+	}
+	return &hookRunner{
+		logger:  logger,
+		hooks:   hooks,
+		timeout: timeout,
+		client:  &http.Client{Timeout: timeout},
+		stop:    make(chan struct{}),
+	}
+}
+
+// start runs the hooks of the events published on bus until stopped.
+func (r *hookRunner) start(bus *eventBus, wg *sync.WaitGroup) {
+	if r == nil {
+		return
+	}
+	events, unsubscribe := bus.subscribeBuffered(hookQueueSize)
+	util.GoWithWaitGroup(wg, func() {
+		defer unsubscribe()
+		for {
+			select {
+			case event := <-events:
+				r.run(event)
+			case <-r.stop:
+				// run the hooks of the events published while stopping, like tunnel-down
+				for {
+					select {
+					case event := <-events:
+						r.run(event)
+					default:
+						return
+					}
+				}
+			}
+		}
+	})
+}
+
+// shutdown stops the runner once the hooks of the events already published have run.
+func (r *hookRunner) shutdown() {
+	if r == nil {
+		return
+	}
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
+
+func (r *hookRunner) run(event api.NexdEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		r.logger.Warnf("Failed to marshal the %s event for its hooks: %v", event.Type, err)
+		return
+	}
+	for _, hook := range r.hooks {
+		if hook.Event != hookAllEvents && hook.Event != event.Type {
+			continue
+		}
+		if hook.URL != "" {
+			err = r.callWebhook(hook, data)
+		} else {
+			err = r.runCommand(hook, event, data)
+		}
+		if err != nil {
+			r.logger.Warnf("Hook (%s) failed on the %s event: %v", hook, event.Type, err)
+		} else {
+			r.logger.Debugf("Hook (%s) ran on the %s event", hook, event.Type)
+		}
+	}
+}
+
+func (r *hookRunner) runCommand(hook Hook, event api.NexdEvent, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == Windows.String() {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", hook.Command)
+	}
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(), "NEXD_EVENT="+event.Type)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (r *hookRunner) callWebhook(hook Hook, data []byte) error {
+	resp, err := r.client.Post(hook.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package nexodus
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseHook(t *testing.T) {
+	require := require.New(t)
+
+	hook, err := ParseHook("peer-up=/usr/local/bin/update-pool --add")
+	require.NoError(err)
+	require.Equal(Hook{Event: api.NexdEventPeerUp, Command: "/usr/local/bin/update-pool --add"}, hook)
+
+	hook, err = ParseWebhook("*=https://hooks.example.com/nexd?token=abc")
+	require.NoError(err)
+	require.Equal(Hook{Event: hookAllEvents, URL: "https://hooks.example.com/nexd?token=abc"}, hook)
+
+	_, err = ParseHook("peer-up")
+	require.Error(err)
+	_, err = ParseHook("peer-sideways=true")
+	require.Error(err)
+	_, err = ParseWebhook("peer-up=ftp://example.com")
+	require.Error(err)
+}
+
+func TestHookRunner(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+
+	var mu sync.Mutex
+	var webhookEvents []api.NexdEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event api.NexdEvent
+		if json.Unmarshal(body, &event) == nil {
+			mu.Lock()
+			webhookEvents = append(webhookEvents, event)
+			mu.Unlock()
+		}
+	}))
+	defer server.Close()
+
+	hooks := []Hook{{Event: hookAllEvents, URL: server.URL}}
+	output := filepath.Join(t.TempDir(), "event.json")
+	if runtime.GOOS != Windows.String() {
+		hooks = append(hooks, Hook{Event: api.NexdEventTunnelDown, Command: "cat > " + output})
+	}
+
+	var bus eventBus
+	wg := &sync.WaitGroup{}
+	runner := newHookRunner(zLogger.Sugar(), hooks, 10*time.Second)
+	runner.start(&bus, wg)
+
+	bus.publish(api.NexdEvent{Type: api.NexdEventPeerUp, PublicKey: "peer-key"})
+	bus.publish(api.NexdEvent{Type: api.NexdEventTunnelDown, TunnelIPv4: "100.64.0.1"})
+	// the hooks of the events published before the runner is stopped still run
+	runner.shutdown()
+	wg.Wait()
+
+	require.Len(webhookEvents, 2)
+	require.Equal(api.NexdEventPeerUp, webhookEvents[0].Type)
+	require.Equal("peer-key", webhookEvents[0].PublicKey)
+	require.Equal(api.NexdEventTunnelDown, webhookEvents[1].Type)
+
+	if runtime.GOOS != Windows.String() {
+		data, err := os.ReadFile(output)
+		require.NoError(err)
+		var event api.NexdEvent
+		require.NoError(json.Unmarshal(data, &event))
+		require.Equal(api.NexdEventTunnelDown, event.Type)
+		require.Equal("100.64.0.1", event.TunnelIPv4)
+	}
+
+	// no runner without hooks
+	require.Nil(newHookRunner(zLogger.Sugar(), nil, time.Second))
+}
@@ -10,12 +10,18 @@ import (
 	"github.com/nexodus-io/nexodus/internal/client"
 )
 
-func (nx *Nexodus) createOrUpdateDeviceOperation(userID string, endpoints []client.ModelsEndpoint) (client.ModelsDevice, string, error) {
+func (nx *Nexodus) createOrUpdateDeviceOperation(userID string, vpc *client.ModelsVPC, endpoints []client.ModelsEndpoint) (client.ModelsDevice, string, error) {
+	subnetId, err := nx.requestedSubnetId(context.Background(), vpc.GetId())
+	if err != nil {
+		return client.ModelsDevice{}, "", err
+	}
 	newDev := client.ModelsAddDevice{
-		VpcId:           nx.vpc.Id,
+		VpcId:           vpc.Id,
 		SecurityGroupId: client.PtrOptionalString(nx.securityGroupId),
+		SubnetId:        client.PtrOptionalString(subnetId),
 		PublicKey:       &nx.wireguardPubKey,
 		AdvertiseCidrs:  nx.advertiseCidrs,
+		RouterPriority:  client.PtrInt32(int32(nx.routerPriority)),
 		SymmetricNat:    &nx.symmetricNat,
 		Hostname:        &nx.hostname,
 		Relay:           client.PtrBool(nx.relay || nx.relayDerp),
@@ -27,7 +33,7 @@ func (nx *Nexodus) createOrUpdateDeviceOperation(userID string, endpoints []clie
 		newDev.Ipv4TunnelIps = []client.ModelsTunnelIP{
 			{
 				Address: &nx.requestedIP,
-				Cidr:    nx.vpc.Ipv4Cidr,
+				Cidr:    vpc.Ipv4Cidr,
 			},
 		}
 	}
@@ -41,6 +47,7 @@ func (nx *Nexodus) createOrUpdateDeviceOperation(userID string, endpoints []clie
 			case client.ModelsConflictsError:
 				d, resp, err = nx.client.DevicesApi.UpdateDevice(context.Background(), model.GetId()).Update(client.ModelsUpdateDevice{
 					AdvertiseCidrs:  newDev.AdvertiseCidrs,
+					RouterPriority:  newDev.RouterPriority,
 					Endpoints:       newDev.Endpoints,
 					Hostname:        newDev.Hostname,
 					Relay:           newDev.Relay,
@@ -107,7 +114,7 @@ func (nx *Nexodus) updateDeviceRelayMetadata(deviceId string) (*http.Response, e
 }
 
 func (nx *Nexodus) getDeviceRelayMetadata(deviceId string) (client.ModelsDeviceMetadata, *http.Response, error) {
-	metadata, resp, err := nx.relayMetadataInformer.Execute()
+	metadata, resp, err := nx.listRelayMetadata()
 	if err != nil {
 		return client.ModelsDeviceMetadata{}, resp, err
 	}
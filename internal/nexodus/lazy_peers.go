@@ -0,0 +1,153 @@
+package nexodus
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// how often the traffic of the peers activated on demand is checked
+const lazyPeerInterval = time.Second
+
+// lazyPeerState is the on-demand activation state of a peer when lazy peers are enabled.
+type lazyPeerState struct {
+	// the peer is configured on the wireguard interface
+	active bool
+	// the last time traffic to or from the peer was seen while active
+	activeTime time.Time
+	// routes to the tunnel IPs of the peer have been added while inactive
+	routed bool
+}
+
+// lazyPeerTracker reports the traffic of the peers that are activated on demand.
+type lazyPeerTracker interface {
+	setup() error
+	teardown() error
+	// setPeerIPs sets the tunnel IPs of the peers activated on demand
+	setPeerIPs(ips []string) error
+	// seen returns the tunnel IPs of the peers traffic was sent to or received from since the last call
+	seen() ([]string, error)
+	// handshakes returns the source IPs of the wireguard handshake initiations received since the last call
+	handshakes() ([]string, error)
+}
+
+// peerIsLazy returns whether the peer is only configured on the wireguard interface while there is traffic
+// to or from it. Relays, routers and exit nodes are always configured.
+func (nx *Nexodus) peerIsLazy(d deviceCacheEntry) bool {
+	return nx.lazyPeers && !nx.relay && !d.device.GetRelay() && len(d.device.AdvertiseCidrs) == 0
+}
+
+// peerIsLazyInactive returns whether the peer is activated on demand and currently not configured.
+func (nx *Nexodus) peerIsLazyInactive(d deviceCacheEntry) bool {
+	return nx.peerIsLazy(d) && !d.lazy.active
+}
+
+// reconcileLazyPeers activates the peers traffic has been seen for and evicts the peers that have been idle
+// for longer than the idle timeout.
+func (nx *Nexodus) reconcileLazyPeers() {
+	seen, err := nx.lazyTracker.seen()
+	if err != nil {
+		nx.logger.Warnf("Failed to read the traffic of lazy peers: %v", err)
+		return
+	}
+	handshakes, err := nx.lazyTracker.handshakes()
+	if err != nil {
+		nx.logger.Warnf("Failed to read the handshakes of lazy peers: %v", err)
+		return
+	}
+
+	nx.deviceCacheLock.Lock()
+	activated := nx.updateLazyPeers(seen, handshakes, time.Now())
+	nx.deviceCacheLock.Unlock()
+
+	if activated {
+		if err := nx.reconcileDeviceCache(); err != nil {
+			nx.logger.Warnf("Failed to configure the activated lazy peers: %v", err)
+		}
+	}
+}
+
+// updateLazyPeers updates the activation state of the lazy peers from the tunnel IPs traffic was seen for
+// and the source IPs of the handshakes received. Returns true if a peer was activated and needs to be configured.
+// assumes deviceCacheLock is held.
+func (nx *Nexodus) updateLazyPeers(seen []string, handshakes []string, now time.Time) bool {
+	activated := false
+	var peerIPs []string
+	for key, d := range nx.deviceCache {
+		if key == nx.wireguardPubKey || !nx.peerIsLazy(d) {
+			continue
+		}
+		ips := lazyPeerIPs(d)
+		peerIPs = append(peerIPs, ips...)
+		trafficSeen := slices.ContainsFunc(ips, func(ip string) bool {
+			return slices.Contains(seen, ip)
+		})
+
+		switch {
+		case d.lazy.active && trafficSeen:
+			d.lazy.activeTime = now
+		case d.lazy.active && now.Sub(d.lazy.activeTime) > nx.lazyPeerIdleTimeout:
+			nx.logger.Infof("Evicting idle lazy peer (hostname:%s pubkey:%s)", d.device.GetHostname(), key)
+			if err := nx.deletePeer(key, nx.tunnelIface); err != nil {
+				nx.logger.Warnf("Failed to evict idle lazy peer (hostname:%s pubkey:%s): %v", d.device.GetHostname(), key, err)
+				continue
+			}
+			delete(nx.wgConfig.Peers, key)
+			nx.peeringReset(&d)
+			d.lazy.active = false
+			d.lazy.routed = true
+		case !d.lazy.active && (trafficSeen || handshakeFromPeer(d, handshakes)):
+			nx.logger.Infof("Activating lazy peer (hostname:%s pubkey:%s)", d.device.GetHostname(), key)
+			d.lazy.active = true
+			d.lazy.activeTime = now
+			activated = true
+		}
+
+		if !d.lazy.active && !d.lazy.routed {
+			// route the tunnel IPs of the peer to the wireguard interface so that traffic to it is seen
+			if err := nx.handlePeerRoute(wgPeerConfig{PublicKey: key, AllowedIPs: d.device.AllowedIps}); err != nil {
+				nx.logger.Warnf("Failed to add the routes of lazy peer (hostname:%s pubkey:%s): %v", d.device.GetHostname(), key, err)
+			} else {
+				d.lazy.routed = true
+			}
+		}
+		nx.deviceCache[key] = d
+	}
+
+	slices.Sort(peerIPs)
+	if joined := strings.Join(peerIPs, ","); joined != nx.lazyPeerIPs {
+		if err := nx.lazyTracker.setPeerIPs(peerIPs); err != nil {
+			nx.logger.Warnf("Failed to track the traffic of lazy peers: %v", err)
+		} else {
+			nx.lazyPeerIPs = joined
+		}
+	}
+	return activated
+}
+
+// lazyPeerIPs returns the tunnel IPs of a peer.
+func lazyPeerIPs(d deviceCacheEntry) []string {
+	var ips []string
+	for _, ip := range d.device.Ipv4TunnelIps {
+		ips = append(ips, ip.GetAddress())
+	}
+	for _, ip := range d.device.Ipv6TunnelIps {
+		ips = append(ips, ip.GetAddress())
+	}
+	return ips
+}
+
+// handshakeFromPeer returns whether one of the handshake source IPs is an endpoint of the peer.
+func handshakeFromPeer(d deviceCacheEntry, handshakes []string) bool {
+	for _, endpoint := range d.device.Endpoints {
+		host, _, err := net.SplitHostPort(endpoint.GetAddress())
+		if err != nil {
+			continue
+		}
+		if slices.Contains(handshakes, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const lazyPeersTableName = "nexodus-lazy-peers"
+
+// nftLazyPeerTracker tracks the traffic of the lazy peers with an nftables table that adds the tunnel IPs
+// of the peers traffic is routed to or received from, and the sources of wireguard handshake initiations,
+// to dynamic sets.
+type nftLazyPeerTracker struct {
+	logger     *zap.SugaredLogger
+	iface      string
+	listenPort int
+}
+
+func newLazyPeerTracker(logger *zap.SugaredLogger, iface string, listenPort int) lazyPeerTracker {
+	return &nftLazyPeerTracker{
+		logger:     logger,
+		iface:      iface,
+		listenPort: listenPort,
+	}
+}
+
+func (t *nftLazyPeerTracker) setup() error {
+	// remove the table left behind by a previous run, if any
+	_ = t.teardown()
+
+	// a wireguard handshake initiation starts with the message type 1 followed by three reserved zero bytes
+	script := fmt.Sprintf(`table inet %[1]s {
+	set peers4 { type ipv4_addr; }
+	set peers6 { type ipv6_addr; }
+	set seen4 { type ipv4_addr; flags dynamic; }
+	set seen6 { type ipv6_addr; flags dynamic; }
+	set handshakes4 { type ipv4_addr; flags dynamic; }
+	set handshakes6 { type ipv6_addr; flags dynamic; }
+	chain output {
+		type filter hook output priority 0; policy accept;
+		oifname "%[2]s" ip daddr @peers4 add @seen4 { ip daddr }
+		oifname "%[2]s" ip6 daddr @peers6 add @seen6 { ip6 daddr }
+	}
+	chain forward {
+		type filter hook forward priority 0; policy accept;
+		oifname "%[2]s" ip daddr @peers4 add @seen4 { ip daddr }
+		oifname "%[2]s" ip6 daddr @peers6 add @seen6 { ip6 daddr }
+		iifname "%[2]s" ip saddr @peers4 add @seen4 { ip saddr }
+		iifname "%[2]s" ip6 saddr @peers6 add @seen6 { ip6 saddr }
+	}
+	chain input {
+		type filter hook input priority 0; policy accept;
+		iifname "%[2]s" ip saddr @peers4 add @seen4 { ip saddr }
+		iifname "%[2]s" ip6 saddr @peers6 add @seen6 { ip6 saddr }
+		meta nfproto ipv4 udp dport %[3]d @th,64,32 0x01000000 add @handshakes4 { ip saddr }
+		meta nfproto ipv6 udp dport %[3]d @th,64,32 0x01000000 add @handshakes6 { ip6 saddr }
+	}
+}
+`, lazyPeersTableName, t.iface, t.listenPort)
+	return t.apply(script)
+}
+
+func (t *nftLazyPeerTracker) teardown() error {
+	_, err := policyCmd(t.logger, []string{"delete", "table", tableFamily, lazyPeersTableName})
+	return err
+}
+
+func (t *nftLazyPeerTracker) setPeerIPs(ips []string) error {
+	var ipv4, ipv6 []string
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			continue
+		}
+		if parsed.To4() != nil {
+			ipv4 = append(ipv4, ip)
+		} else {
+			ipv6 = append(ipv6, ip)
+		}
+	}
+	script := fmt.Sprintf("flush set %s %s peers4\nflush set %s %s peers6\n",
+		tableFamily, lazyPeersTableName, tableFamily, lazyPeersTableName)
+	if len(ipv4) > 0 {
+		script += fmt.Sprintf("add element %s %s peers4 { %s }\n", tableFamily, lazyPeersTableName, strings.Join(ipv4, ", "))
+	}
+	if len(ipv6) > 0 {
+		script += fmt.Sprintf("add element %s %s peers6 { %s }\n", tableFamily, lazyPeersTableName, strings.Join(ipv6, ", "))
+	}
+	return t.apply(script)
+}
+
+func (t *nftLazyPeerTracker) seen() ([]string, error) {
+	return t.drainSets("seen4", "seen6")
+}
+
+func (t *nftLazyPeerTracker) handshakes() ([]string, error) {
+	return t.drainSets("handshakes4", "handshakes6")
+}
+
+// drainSets returns the elements of the sets and flushes them.
+func (t *nftLazyPeerTracker) drainSets(sets ...string) ([]string, error) {
+	var ips []string
+	for _, set := range sets {
+		output, err := policyCmd(t.logger, []string{"-j", "list", "set", tableFamily, lazyPeersTableName, set})
+		if err != nil {
+			return nil, err
+		}
+		elems, err := parseNftSetElements([]byte(output))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s set: %w", set, err)
+		}
+		if len(elems) == 0 {
+			continue
+		}
+		ips = append(ips, elems...)
+		if _, err := policyCmd(t.logger, []string{"flush", "set", tableFamily, lazyPeersTableName, set}); err != nil {
+			return nil, err
+		}
+	}
+	return ips, nil
+}
+
+// apply runs an nftables script as a single transaction.
+func (t *nftLazyPeerTracker) apply(script string) error {
+	nft := exec.Command("nft", "-f", "-")
+	nft.Stdin = strings.NewReader(script)
+	output, err := nft.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("nft script failed: %w\noutput: %s", err, output)
+	}
+	t.logger.Debugf("nft script:\n%s", script)
+	return nil
+}
+
+// parseNftSetElements returns the elements of a set listed by nft -j.
+func parseNftSetElements(data []byte) ([]string, error) {
+	var listing struct {
+		Nftables []struct {
+			Set *struct {
+				Elem []json.RawMessage `json:"elem"`
+			} `json:"set"`
+		} `json:"nftables"`
+	}
+	if err := json.Unmarshal(data, &listing); err != nil {
+		return nil, err
+	}
+	var elems []string
+	for _, item := range listing.Nftables {
+		if item.Set == nil {
+			continue
+		}
+		for _, raw := range item.Set.Elem {
+			var elem string
+			if err := json.Unmarshal(raw, &elem); err == nil {
+				elems = append(elems, elem)
+				continue
+			}
+			// elements with a timeout or counter are listed as objects
+			var obj struct {
+				Elem struct {
+					Val string `json:"val"`
+				} `json:"elem"`
+			}
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return nil, err
+			}
+			elems = append(elems, obj.Elem.Val)
+		}
+	}
+	return elems, nil
+}
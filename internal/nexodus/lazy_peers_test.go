@@ -0,0 +1,116 @@
+package nexodus
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeLazyPeerTracker struct {
+	peerIPs []string
+}
+
+func (f *fakeLazyPeerTracker) setup() error    { return nil }
+func (f *fakeLazyPeerTracker) teardown() error { return nil }
+func (f *fakeLazyPeerTracker) setPeerIPs(ips []string) error {
+	f.peerIPs = ips
+	return nil
+}
+func (f *fakeLazyPeerTracker) seen() ([]string, error)       { return nil, nil }
+func (f *fakeLazyPeerTracker) handshakes() ([]string, error) { return nil, nil }
+
+func TestLazyPeers(t *testing.T) {
+	zLogger, _ := zap.NewDevelopment()
+	require := require.New(t)
+
+	lazyPeer := func(publicKey, tunnelIP, endpoint string) deviceCacheEntry {
+		return deviceCacheEntry{
+			device: client.ModelsDevice{
+				Endpoints: []client.ModelsEndpoint{
+					{
+						Address: client.PtrString("192.168.50.2:5678"),
+						Source:  client.PtrString("local"),
+					},
+					{
+						Address: client.PtrString(endpoint),
+						Source:  client.PtrString("stun"),
+					},
+				},
+				PublicKey:     client.PtrString(publicKey),
+				Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString(tunnelIP)}},
+				AllowedIps:    []string{tunnelIP + "/32"},
+			},
+		}
+	}
+
+	tracker := &fakeLazyPeerTracker{}
+	nx := &Nexodus{
+		vpc: &client.ModelsVPC{
+			Ipv4Cidr: client.PtrString("100.64.0.0/10"),
+			Ipv6Cidr: client.PtrString("200::/64"),
+		},
+		nodeReflexiveAddressIPv4: netip.MustParseAddrPort("1.1.1.1:1234"),
+		logger:                   zLogger.Sugar(),
+		lazyPeers:                true,
+		lazyPeerIdleTimeout:      time.Minute,
+		lazyTracker:              tracker,
+		deviceCache: map[string]deviceCacheEntry{
+			"peerA": lazyPeer("peerA", "100.64.0.2", "2.2.2.2:4321"),
+			"peerB": lazyPeer("peerB", "100.64.0.3", "3.3.3.3:4321"),
+			"theRelay": {
+				device: client.ModelsDevice{
+					Endpoints: []client.ModelsEndpoint{
+						{
+							Address: client.PtrString("192.168.30.5:5678"),
+							Source:  client.PtrString("local"),
+						},
+						{
+							Address: client.PtrString("4.4.4.4:4321"),
+							Source:  client.PtrString("stun"),
+						},
+					},
+					PublicKey: client.PtrString("theRelay"),
+					Relay:     client.PtrBool(true),
+				},
+			},
+		},
+	}
+	// routes are a no-op in userspace mode
+	nx.userspaceMode = true
+	for key, d := range nx.deviceCache {
+		nx.peeringReset(&d)
+		nx.deviceCache[key] = d
+	}
+
+	// the relay is always configured, the lazy peers only once there is traffic
+	nx.buildPeersConfig()
+	require.Contains(nx.wgConfig.Peers, "theRelay")
+	require.NotContains(nx.wgConfig.Peers, "peerA")
+	require.NotContains(nx.wgConfig.Peers, "peerB")
+
+	now := time.Now()
+	require.False(nx.updateLazyPeers(nil, nil, now))
+	require.Equal([]string{"100.64.0.2", "100.64.0.3"}, tracker.peerIPs)
+	require.True(nx.deviceCache["peerA"].lazy.routed)
+
+	// traffic to the tunnel IP of a peer activates it
+	require.True(nx.updateLazyPeers([]string{"100.64.0.2"}, nil, now))
+	require.True(nx.deviceCache["peerA"].lazy.active)
+	require.False(nx.deviceCache["peerB"].lazy.active)
+	nx.buildPeersConfig()
+	require.Contains(nx.wgConfig.Peers, "peerA")
+	require.NotContains(nx.wgConfig.Peers, "peerB")
+
+	// a handshake from the endpoint of a peer activates it
+	require.True(nx.updateLazyPeers(nil, []string{"3.3.3.3"}, now))
+	require.True(nx.deviceCache["peerB"].lazy.active)
+
+	// traffic keeps an active peer active
+	later := now.Add(2 * time.Minute)
+	require.False(nx.updateLazyPeers([]string{"100.64.0.2", "100.64.0.3"}, nil, later))
+	require.Equal(later, nx.deviceCache["peerA"].lazy.activeTime)
+}
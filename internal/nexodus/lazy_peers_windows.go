@@ -0,0 +1,24 @@
+//go:build windows
+
+package nexodus
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+var errLazyPeersUnsupported = errors.New("lazy peers are not supported on windows")
+
+// unsupportedLazyPeerTracker is never used since lazy peers are rejected on windows when nexd is created.
+type unsupportedLazyPeerTracker struct{}
+
+func newLazyPeerTracker(logger *zap.SugaredLogger, iface string, listenPort int) lazyPeerTracker {
+	return unsupportedLazyPeerTracker{}
+}
+
+func (unsupportedLazyPeerTracker) setup() error                  { return errLazyPeersUnsupported }
+func (unsupportedLazyPeerTracker) teardown() error               { return errLazyPeersUnsupported }
+func (unsupportedLazyPeerTracker) setPeerIPs(ips []string) error { return errLazyPeersUnsupported }
+func (unsupportedLazyPeerTracker) seen() ([]string, error)       { return nil, errLazyPeersUnsupported }
+func (unsupportedLazyPeerTracker) handshakes() ([]string, error) { return nil, errLazyPeersUnsupported }
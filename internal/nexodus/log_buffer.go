@@ -0,0 +1,58 @@
+package nexodus
+
+import (
+	"strings"
+	"sync"
+)
+
+// LogBuffer keeps the most recent log lines of nexd in memory so they can be included in a bug report.
+// It implements zapcore.WriteSyncer so that it can be teed into the nexd logger.
+type LogBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// NewLogBuffer creates a LogBuffer that keeps the last size log lines.
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{
+		lines: make([]string, size),
+	}
+}
+
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, line := range strings.SplitAfter(string(p), "\n") {
+		if line == "" {
+			continue
+		}
+		b.lines[b.next] = line
+		b.next = (b.next + 1) % len(b.lines)
+		if b.next == 0 {
+			b.full = true
+		}
+	}
+	return len(p), nil
+}
+
+func (b *LogBuffer) Sync() error {
+	return nil
+}
+
+// String returns the buffered log lines, oldest first.
+func (b *LogBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var sb strings.Builder
+	if b.full {
+		for _, line := range b.lines[b.next:] {
+			sb.WriteString(line)
+		}
+	}
+	for _, line := range b.lines[:b.next] {
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package nexodus
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	peerLabels = []string{"public_key", "device_id", "hostname"}
+
+	peerRxBytesDesc = prometheus.NewDesc("nexd_peer_rx_bytes_total",
+		"Bytes received from the peer over the wireguard tunnel.", peerLabels, nil)
+	peerTxBytesDesc = prometheus.NewDesc("nexd_peer_tx_bytes_total",
+		"Bytes sent to the peer over the wireguard tunnel.", peerLabels, nil)
+	peerHandshakeAgeDesc = prometheus.NewDesc("nexd_peer_handshake_age_seconds",
+		"Seconds since the latest wireguard handshake with the peer.", peerLabels, nil)
+	peerHealthyDesc = prometheus.NewDesc("nexd_peer_healthy",
+		"Whether the peer is considered healthy (1) or not (0).", peerLabels, nil)
+	peerInfoDesc = prometheus.NewDesc("nexd_peer_info",
+		"Information about how the peer is configured, always 1.", append(peerLabels, "peering_method"), nil)
+	derpActiveDesc = prometheus.NewDesc("nexd_derp_active_connections",
+		"The number of active connections to DERP relays.", nil, nil)
+	derpHomeRegionDesc = prometheus.NewDesc("nexd_derp_home_region",
+		"The region id of the home DERP relay, 0 when there is none.", nil, nil)
+	proxyConnectionsDesc = prometheus.NewDesc("nexd_proxy_connections_total",
+		"Connections handled by the userspace proxy.", []string{"proxy"}, nil)
+	proxyActiveConnectionsDesc = prometheus.NewDesc("nexd_proxy_active_connections",
+		"Connections currently open through the userspace proxy.", []string{"proxy"}, nil)
+)
+
+// nexdMetrics holds the prometheus metrics of nexd. A nil *nexdMetrics ignores all observations.
+type nexdMetrics struct {
+	registry          *prometheus.Registry
+	reconcileDuration *prometheus.HistogramVec
+	apiErrors         *prometheus.CounterVec
+}
+
+func newNexdMetrics(nx *Nexodus) *nexdMetrics {
+	m := &nexdMetrics{
+		registry: prometheus.NewRegistry(),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "nexd_reconcile_duration_seconds",
+			Help:    "Duration of the reconcile loops of nexd.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"loop"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nexd_api_errors_total",
+			Help: "Failed requests to the nexodus API server.",
+		}, []string{"operation"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.reconcileDuration,
+		m.apiErrors,
+		stateCollector{nx: nx},
+	)
+	return m
+}
+
+// observeReconcile records the duration of a reconcile loop that started at start.
+func (m *nexdMetrics) observeReconcile(loop string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.reconcileDuration.WithLabelValues(loop).Observe(time.Since(start).Seconds())
+}
+
+// apiError counts a failed request to the API server.
+func (m *nexdMetrics) apiError(operation string) {
+	if m == nil {
+		return
+	}
+	m.apiErrors.WithLabelValues(operation).Inc()
+}
+
+// stateCollector reports the peer, DERP and proxy state of nexd at scrape time.
+type stateCollector struct {
+	nx *Nexodus
+}
+
+func (c stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- peerRxBytesDesc
+	ch <- peerTxBytesDesc
+	ch <- peerHandshakeAgeDesc
+	ch <- peerHealthyDesc
+	ch <- peerInfoDesc
+	ch <- derpActiveDesc
+	ch <- derpHomeRegionDesc
+	ch <- proxyConnectionsDesc
+	ch <- proxyActiveConnectionsDesc
+}
+
+func (c stateCollector) Collect(ch chan<- prometheus.Metric) {
+	nx := c.nx
+
+	sessions, err := nx.DumpPeersDefault()
+	if err != nil {
+		nx.logger.Debugf("failed to dump the wireguard peers for metrics: %v", err)
+	}
+	now := time.Now()
+	nx.deviceCacheIterRead(func(d deviceCacheEntry) {
+		publicKey := d.device.GetPublicKey()
+		if publicKey == nx.wireguardPubKey {
+			return
+		}
+		labels := []string{publicKey, d.device.GetId(), d.device.GetHostname()}
+
+		healthy := 0.0
+		if d.peerHealthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(peerHealthyDesc, prometheus.GaugeValue, healthy, labels...)
+		ch <- prometheus.MustNewConstMetric(peerInfoDesc, prometheus.GaugeValue, 1, append(labels, d.peeringMethod)...)
+
+		session, ok := sessions[publicKey]
+		if !ok {
+			return
+		}
+		ch <- prometheus.MustNewConstMetric(peerRxBytesDesc, prometheus.CounterValue, float64(session.Rx), labels...)
+		ch <- prometheus.MustNewConstMetric(peerTxBytesDesc, prometheus.CounterValue, float64(session.Tx), labels...)
+		if !session.LastHandshakeTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(peerHandshakeAgeDesc, prometheus.GaugeValue, now.Sub(session.LastHandshakeTime).Seconds(), labels...)
+		}
+	})
+
+	ch <- prometheus.MustNewConstMetric(derpActiveDesc, prometheus.GaugeValue, float64(nx.nexRelay.DERPs()))
+	ch <- prometheus.MustNewConstMetric(derpHomeRegionDesc, prometheus.GaugeValue, float64(nx.nexRelay.homeDERP()))
+
+	nx.proxyLock.RLock()
+	defer nx.proxyLock.RUnlock()
+	for key, proxy := range nx.proxies {
+		ch <- prometheus.MustNewConstMetric(proxyConnectionsDesc, prometheus.CounterValue, float64(atomic.LoadUint64(&proxy.connectionCounter)), key.String())
+		ch <- prometheus.MustNewConstMetric(proxyActiveConnectionsDesc, prometheus.GaugeValue, float64(atomic.LoadInt64(&proxy.activeConnections)), key.String())
+	}
+}
+
+// metricsServerStart serves the prometheus metrics on the --metrics-listen address until ctx is done.
+func (nx *Nexodus) metricsServerStart(ctx context.Context, wg *sync.WaitGroup) error {
+	if nx.metricsListen == "" {
+		return nil
+	}
+
+	l, err := net.Listen("tcp", nx.metricsListen)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(nx.metrics.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	util.GoWithWaitGroup(wg, func() {
+		<-ctx.Done()
+		_ = server.Close()
+	})
+	util.GoWithWaitGroup(wg, func() {
+		nx.logger.Infof("Serving metrics on http://%s/metrics", l.Addr())
+		if err := server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			nx.logger.Errorf("metrics server error: %v", err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,76 @@
+package nexodus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+	nx := &Nexodus{
+		logger:          zLogger.Sugar(),
+		wireguardPubKey: "local-key",
+		deviceCache: map[string]deviceCacheEntry{
+			"local-key": {
+				device: client.ModelsDevice{PublicKey: client.PtrString("local-key")},
+			},
+			"peer-key": {
+				device: client.ModelsDevice{
+					Id:        client.PtrString("peer-id"),
+					PublicKey: client.PtrString("peer-key"),
+					Hostname:  client.PtrString("peer"),
+				},
+				peeringMethod: peeringMethodDirectLocal,
+				peerHealth:    peerHealth{peerHealthy: true},
+			},
+		},
+		userspaceWG: userspaceWG{
+			userspaceMode: true,
+			proxies:       map[ProxyKey]*UsProxy{},
+		},
+	}
+	nx.metrics = newNexdMetrics(nx)
+
+	rule, err := ParseProxyRule("tcp:443:127.0.0.1:8443", ProxyTypeIngress)
+	require.NoError(err)
+	proxy, err := nx.UserspaceProxyAdd(rule)
+	require.NoError(err)
+	proxy.connectionCounter = 3
+	proxy.activeConnections = 1
+
+	nx.metrics.observeReconcile("devices", time.Now())
+	nx.metrics.apiError("list_devices")
+
+	expected := `
+# HELP nexd_api_errors_total Failed requests to the nexodus API server.
+# TYPE nexd_api_errors_total counter
+nexd_api_errors_total{operation="list_devices"} 1
+# HELP nexd_peer_healthy Whether the peer is considered healthy (1) or not (0).
+# TYPE nexd_peer_healthy gauge
+nexd_peer_healthy{device_id="peer-id",hostname="peer",public_key="peer-key"} 1
+# HELP nexd_peer_info Information about how the peer is configured, always 1.
+# TYPE nexd_peer_info gauge
+nexd_peer_info{device_id="peer-id",hostname="peer",peering_method="direct-local",public_key="peer-key"} 1
+# HELP nexd_proxy_active_connections Connections currently open through the userspace proxy.
+# TYPE nexd_proxy_active_connections gauge
+nexd_proxy_active_connections{proxy="ingress:tcp:443"} 1
+# HELP nexd_proxy_connections_total Connections handled by the userspace proxy.
+# TYPE nexd_proxy_connections_total counter
+nexd_proxy_connections_total{proxy="ingress:tcp:443"} 3
+`
+	require.NoError(testutil.GatherAndCompare(nx.metrics.registry, strings.NewReader(expected),
+		"nexd_api_errors_total", "nexd_peer_healthy", "nexd_peer_info", "nexd_proxy_active_connections", "nexd_proxy_connections_total"))
+	require.Equal(1, testutil.CollectAndCount(nx.metrics.reconcileDuration))
+
+	// a nil metrics ignores observations
+	var m *nexdMetrics
+	m.observeReconcile("devices", time.Now())
+	m.apiError("list_devices")
+}
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,6 +34,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/api"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/nexodus-io/nexodus/internal/stun"
 	"github.com/nexodus-io/nexodus/internal/util"
@@ -61,6 +63,8 @@ const (
 	NexdStatusAuth
 	// nexd is up and running normally
 	NexdStatusRunning
+	// the api-server was unreachable when nexd started, so it runs from the saved peer configuration
+	NexdStatusOfflineConfig
 )
 
 const (
@@ -174,6 +178,10 @@ type deviceCacheEntry struct {
 	peeringMethodIndex int
 	// The last time a new peering configuration was generated for this device
 	peeringTime time.Time
+	// the most recent peering configurations generated for this device, oldest first
+	peeringHistory []api.PeeringAttempt
+	// the on-demand activation state of the peer when lazy peers are enabled
+	lazy lazyPeerState
 }
 
 type exitNode struct {
@@ -181,19 +189,29 @@ type exitNode struct {
 	exitNodeClientEnabled bool
 	exitNodeOriginEnabled bool
 	exitNodeOrigins       []wgPeerConfig
+	// the public key of the exit node used by this device, if any
+	activeExitNode string
 }
 
 type Options struct {
 	AdvertiseCidrs          []string
 	ApiURL                  *url.URL
 	Context                 context.Context
+	CtlAdminGroup           string
+	CtlReadGroup            string
 	Derper                  *Derper
 	ExitNodeClientEnabled   bool
 	ExitNodeOriginEnabled   bool
+	HookTimeout             time.Duration
+	Hooks                   []Hook
 	InsecureSkipTlsVerify   bool
+	LazyPeerIdleTimeout     time.Duration
+	LazyPeers               bool
 	ListenPort              int
+	LogBuffer               *LogBuffer
 	LogLevel                *zap.AtomicLevel
 	Logger                  *zap.SugaredLogger
+	MetricsListen           string
 	NetworkRouter           bool
 	NetworkRouterDisableNAT bool
 	Password                string
@@ -202,6 +220,7 @@ type Options struct {
 	RelayDerp               bool
 	RelayOnly               bool
 	RequestedIP             string
+	RouterPriority          int
 	StateDir                string
 	StateStore              state.Store
 	UserProvidedLocalIP     string
@@ -210,14 +229,21 @@ type Options struct {
 	Version                 string
 	VpcId                   string
 	SecurityGroupId         string
+	Subnet                  string
 }
 type Nexodus struct {
 	advertiseCidrs          []string
+	routerPriority          int
+	cidrRouters             map[string]string // the public key of the router elected for each CIDR advertised by more than one device
 	apiURL                  *url.URL
 	insecureSkipTlsVerify   bool
+	lazyPeerIdleTimeout     time.Duration
+	lazyPeers               bool
 	listenPort              int
+	logBuffer               *LogBuffer
 	logLevel                *zap.AtomicLevel
 	logger                  *zap.SugaredLogger
+	metricsListen           string
 	networkRouter           bool
 	networkRouterDisableNAT bool
 	password                string
@@ -248,6 +274,7 @@ type Nexodus struct {
 	endpointLocalAddress     string
 	exitNode                 exitNode
 	hostname                 string
+	informerCtx              context.Context
 	informerStop             context.CancelFunc
 	ipv6Supported            bool
 	needSecGroupReconcile    bool
@@ -265,12 +292,28 @@ type Nexodus struct {
 	symmetricNat             bool
 	tunnelIface              string
 	vpc                      *client.ModelsVPC
+	vpcInformer              *client.ListInformer[client.ModelsVPC]
 	wgConfig                 wgConfig
 	wireguardPubKey          string
 	wireguardPubKeyInConfig  bool
 	wireguardPvtKey          string
 	relayMetadataInformer    *client.ListInformer[client.ModelsDeviceMetadata]
+	proxyRulesInformer       *client.ListInformer[client.ModelsProxyRule]
+	sharedVpcs               sharedVpcs
+	subnets                  subnetState
 	deviceId                 string
+	metrics                  *nexdMetrics
+	configuredProxyRules     []ProxyRule  // the proxy rules from the command line flags or the config file
+	reloadHandler            func() error // reloads the config file, set when nexd was started with --config
+	stunResults              []api.StunResult
+	stunResultsLock          sync.Mutex
+	runningPeerConfig        atomic.Pointer[state.PeerConfig] // set while running from the saved peer configuration
+	events                   eventBus
+	ctlAuth                  *ctlAuthorizer
+	hooks                    *hookRunner
+	lazyTracker              lazyPeerTracker
+	lazyPeerIPs              string // the tunnel IPs of the lazy peers last given to lazyTracker
+	captures                 captureSessions
 }
 
 type wgConfig struct {
@@ -306,6 +349,7 @@ func New(o Options) (*Nexodus, error) {
 		requestedIP:             o.RequestedIP,
 		userProvidedLocalIP:     o.UserProvidedLocalIP,
 		advertiseCidrs:          o.AdvertiseCidrs,
+		routerPriority:          o.RouterPriority,
 		relay:                   o.Relay,
 		relayDerp:               o.RelayDerp,
 		networkRouter:           o.NetworkRouter,
@@ -315,11 +359,15 @@ func New(o Options) (*Nexodus, error) {
 		relayOnly:               o.RelayOnly,
 		logger:                  o.Logger,
 		logLevel:                o.LogLevel,
+		logBuffer:               o.LogBuffer,
+		metricsListen:           o.MetricsListen,
 		version:                 o.Version,
 		regKey:                  o.RegKey,
 		username:                o.Username,
 		password:                o.Password,
 		insecureSkipTlsVerify:   o.InsecureSkipTlsVerify,
+		lazyPeers:               o.LazyPeers,
+		lazyPeerIdleTimeout:     o.LazyPeerIdleTimeout,
 		stateStore:              o.StateStore,
 		stateDir:                o.StateDir,
 		vpcId:                   o.VpcId,
@@ -328,6 +376,12 @@ func New(o Options) (*Nexodus, error) {
 		hostname:    hostname,
 		deviceCache: make(map[string]deviceCacheEntry),
 		status:      NexdStatusStarting,
+		sharedVpcs: sharedVpcs{
+			changed: make(chan struct{}, 1),
+		},
+		subnets: subnetState{
+			requested: o.Subnet,
+		},
 		userspaceWG: userspaceWG{
 			proxies: map[ProxyKey]*UsProxy{},
 		},
@@ -347,6 +401,14 @@ func New(o Options) (*Nexodus, error) {
 		},
 	}
 
+	nx.metrics = newNexdMetrics(nx)
+
+	nx.ctlAuth, err = newCtlAuthorizer(o.CtlReadGroup, o.CtlAdminGroup)
+	if err != nil {
+		return nil, err
+	}
+	nx.hooks = newHookRunner(o.Logger, o.Hooks, o.HookTimeout)
+
 	err = nx.setListenPort(o.ListenPort)
 	if err != nil {
 		return nil, err
@@ -367,6 +429,13 @@ func New(o Options) (*Nexodus, error) {
 
 	nx.tunnelIface = nx.defaultTunnelDev()
 
+	if nx.lazyPeers {
+		if runtime.GOOS != Linux.String() || nx.userspaceMode {
+			return nil, fmt.Errorf("lazy peers are only supported on linux when not running in userspace mode")
+		}
+		nx.lazyTracker = newLazyPeerTracker(nx.logger, nx.tunnelIface, nx.listenPort)
+	}
+
 	if err := nx.checkUnsupportedConfigs(); err != nil {
 		return nil, err
 	}
@@ -432,8 +501,16 @@ func (nx *Nexodus) setListenPort(requestedPort int) error {
 }
 
 func (nx *Nexodus) SetStatus(status int, msg string) {
+	if nx.status == status && nx.statusMsg == msg {
+		return
+	}
 	nx.statusMsg = msg
 	nx.status = status
+	nx.events.publish(api.NexdEvent{
+		Type:    api.NexdEventStatusChanged,
+		Status:  statusString(status),
+		Message: msg,
+	})
 }
 
 type StateTokenStore struct {
@@ -497,20 +574,21 @@ func (nx *Nexodus) migrateLegacyState(stateDir string) error {
 }
 
 func (nx *Nexodus) resetApiClient(ctx context.Context) error {
-	var err error
-	nx.client, err = client.NewClient(ctx, nx.apiURL.String(), func(msg string) {
+	c, err := client.NewClient(ctx, nx.apiURL.String(), func(msg string) {
 		nx.SetStatus(NexdStatusAuth, msg)
 	}, nx.clientOptions...)
 	if err != nil {
 		nx.logger.Warnf("client api error - retrying: %v", err)
 		return err
 	}
+	nx.client = c
 	return nil
 }
 
 func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	nx.nexCtx = ctx
 	nx.nexWg = wg
+	nx.hooks.start(&nx.events, wg)
 
 	// Block additional proxy configuration coming in via the ctl server until after
 	// initial startup is complete.
@@ -521,6 +599,10 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		return fmt.Errorf("CtlServerStart(): %w", err)
 	}
 
+	if err := nx.metricsServerStart(ctx, wg); err != nil {
+		return fmt.Errorf("metricsServerStart(): %w", err)
+	}
+
 	if runtime.GOOS != Linux.String() && runtime.GOOS != Darwin.String() {
 		nx.logger.Info("Security Groups are currently only supported on Linux and macOS")
 	} else if nx.userspaceMode {
@@ -564,22 +646,34 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	err = util.RetryOperation(ctx, retryInterval, maxRetries, func() error {
 		return nx.resetApiClient(ctx)
 	})
+	// if the api-server is unreachable, bring up the tunnel from the last peer configuration
+	// and keep trying to connect in the background
+	var peerConfig *state.PeerConfig
 	if err != nil {
-		return fmt.Errorf("client api error: %w", err)
+		if peerConfig, err = nx.startOffline(err); err != nil {
+			return fmt.Errorf("client api error: %w", err)
+		}
+	} else {
+		nx.SetStatus(NexdStatusRunning, "")
 	}
 
-	nx.SetStatus(NexdStatusRunning, "")
-
 	if err := nx.handleKeys(); err != nil {
 		return fmt.Errorf("handleKeys: %w", err)
 	}
-	userId, vpc, err := nx.fetchUserIdAndVpc(ctx)
-	if err != nil {
-		return err
+	var userId string
+	if peerConfig == nil {
+		var vpc *client.ModelsVPC
+		userId, vpc, err = nx.fetchUserIdAndVpc(ctx)
+		if err != nil {
+			// with a registration key the client is created without reaching the api-server
+			if peerConfig, err = nx.startOffline(err); err != nil {
+				return err
+			}
+		} else {
+			nx.vpc = vpc
+		}
 	}
 
-	nx.vpc = vpc
-
 	// User requested ip --request-ip takes precedent
 	if nx.userProvidedLocalIP != "" {
 		nx.endpointLocalAddress = nx.userProvidedLocalIP
@@ -606,78 +700,20 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		}
 	}
 
-	endpointSocket := net.JoinHostPort(nx.endpointLocalAddress, fmt.Sprintf("%d", nx.listenPort))
-	endpoints := []client.ModelsEndpoint{
-		{
-			Source:  client.PtrString("local"),
-			Address: &endpointSocket,
-		},
-		{
-			Source:  client.PtrString("stun:" + nx.reflexiveAddrStunSrc),
-			Address: client.PtrString(nx.nodeReflexiveAddressIPv4.String()),
-		},
-	}
-
-	var modelsDevice client.ModelsDevice
-	var deviceOperationLogMsg string
-	err = util.RetryOperation(ctx, retryInterval, maxRetries, func() error {
-		modelsDevice, deviceOperationLogMsg, err = nx.createOrUpdateDeviceOperation(userId, endpoints)
-		if err != nil {
-			nx.logger.Warnf("device join error - retrying: %v", err)
-			return err
-		}
-		return nil
-	})
-	nx.deviceId = modelsDevice.GetId()
-
-	if err != nil {
-		return fmt.Errorf("join error %w", err)
-	}
-	nx.logger.Debugf("Device: %s", util.JsonStringer(modelsDevice))
-	nx.logger.Infof("%s with UUID: [ %+v ] into vpc: [ %s (%s) ]",
-		deviceOperationLogMsg, nx.deviceId, nx.vpc.GetId(), nx.vpc.GetDescription())
-
-	// Use the device token to auth with the apiserver...
-	if modelsDevice.GetBearerToken() != "" {
-
-		key, err := wgtypes.ParseKey(nx.wireguardPvtKey)
-		if err != nil {
-			return err
-		}
-
-		sealed, err := wgcrypto.ParseSealed(modelsDevice.GetBearerToken())
-		if err != nil {
-			return err
-		}
-
-		data, err := sealed.Open(key[:])
-		if err != nil {
-			return err
-		}
-
-		//nx.stateStore.State().DeviceToken = string(data)
-		//err = nx.stateStore.Store()
-		//if err != nil {
-		//	return err
-		//}
-
-		options = append(options, client.WithBearerToken(string(data)))
-		nx.client, err = client.NewClient(ctx, nx.apiURL.String(), func(msg string) {}, options...)
+	if peerConfig == nil {
+		joinOptions, deviceId, err := nx.joinVpc(ctx, userId, nx.vpc, options)
 		if err != nil {
-			return err
+			if peerConfig, err = nx.startOffline(err); err != nil {
+				return err
+			}
+		} else {
+			options = joinOptions
+			nx.deviceId = deviceId
+			nx.startInformers(ctx)
 		}
 	}
 
-	informerCtx, informerCancel := context.WithCancel(ctx)
-	nx.informerStop = informerCancel
-
-	// event stream sharing occurs due to the informers sharing the context created in following line:
-	informerCtx = nx.client.EventsApi.Watch(informerCtx). /*.GetPublicKey()(nx.wireguardPubKey).*/ NewSharedInformerContext()
-	nx.securityGroupsInformer = nx.client.VPCApi.ListSecurityGroupsInVPC(informerCtx, nx.vpc.GetId()).Informer()
-	nx.devicesInformer = nx.client.VPCApi.ListDevicesInVPC(informerCtx, nx.vpc.GetId()).Informer()
-	nx.relayMetadataInformer = nx.client.VPCApi.ListMetadataInVPC(informerCtx, nx.vpc.GetId()).Key("relay").Informer()
-
-	if nx.relay {
+	if nx.relay && peerConfig == nil {
 		peerMap, _, err := nx.devicesInformer.Execute()
 		if err != nil {
 			return err
@@ -706,10 +742,17 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		nx.Derper.StartDerp()
 	}
 
+	if nx.lazyPeers {
+		if err := nx.lazyTracker.setup(); err != nil {
+			return fmt.Errorf("failed to set up the tracking of lazy peers: %w", err)
+		}
+	}
+
 	util.GoWithWaitGroup(wg, func() {
 		// kick it off with an immediate reconcile
 		nx.reconcileDevices(ctx, options)
 		nx.reconcileSecurityGroups(ctx)
+		nx.reconcileProxyRules(ctx, wg)
 		for _, proxy := range nx.proxies {
 			proxy.Start(ctx, wg, nx.userspaceNet)
 		}
@@ -718,11 +761,29 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 				nx.logger.Errorf("failed to enable this device as an exit-node client: %v", err)
 			}
 		}
+		// keep retrying to join the api-server from the loop while running from the saved peer
+		// configuration, so that the peers are still polled and probed in the meantime
+		var reconnectTickerC <-chan time.Time
+		// a reconnect attempt retries with a backoff, it runs in the background and
+		// reports back on reconnectedC so that it does not block the loop
+		reconnectedC := make(chan reconnection, 1)
+		reconnecting := false
+		if peerConfig != nil {
+			reconnectTicker := time.NewTicker(retryInterval)
+			defer reconnectTicker.Stop()
+			reconnectTickerC = reconnectTicker.C
+		}
 		stunTicker := time.NewTicker(time.Second * 20)
 		secGroupTicker := time.NewTicker(time.Second * 20)
 		defer stunTicker.Stop()
 		pollTicker := time.NewTicker(pollInterval)
 		defer pollTicker.Stop()
+		var lazyPeerTickerC <-chan time.Time
+		if nx.lazyPeers {
+			lazyPeerTicker := time.NewTicker(lazyPeerInterval)
+			defer lazyPeerTicker.Stop()
+			lazyPeerTickerC = lazyPeerTicker.C
+		}
 		for {
 			select {
 			case <-ctx.Done():
@@ -733,12 +794,39 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 						nx.logger.Debug(err)
 					}
 				}
-			case <-nx.relayMetadataInformer.Changed():
+			case <-reconnectTickerC:
+				if reconnecting {
+					continue
+				}
+				reconnecting = true
+				joinOptions := options
+				go func() {
+					reconnectedC <- nx.reconnect(ctx, joinOptions)
+				}()
+			case r := <-reconnectedC:
+				reconnecting = false
+				if r.err != nil {
+					nx.logger.Warnf("Failed to reconnect to the api-server: %v", r.err)
+					continue
+				}
+				nx.reconnected(ctx, r)
+				options = r.options
+				reconnectTickerC = nil
+				nx.reconcileDevices(ctx, options)
+				nx.reconcileSecurityGroups(ctx)
+				nx.reconcileProxyRules(ctx, wg)
+			case <-informerChanged(nx.relayMetadataInformer):
+				nx.reconcileDevices(ctx, options)
+			case <-informerChanged(nx.devicesInformer):
 				nx.reconcileDevices(ctx, options)
-			case <-nx.devicesInformer.Changed():
+			case <-nx.sharedDevicesChanged():
 				nx.reconcileDevices(ctx, options)
-			case <-nx.securityGroupsInformer.Changed():
+			case <-informerChanged(nx.vpcInformer):
+				nx.reconcileDevices(ctx, options)
+			case <-informerChanged(nx.securityGroupsInformer):
 				nx.reconcileSecurityGroups(ctx)
+			case <-nx.proxyRulesChanged():
+				nx.reconcileProxyRules(ctx, wg)
 			case <-pollTicker.C:
 				// This does not actually poll the API for changes. Peer configuration changes will only
 				// be processed when they come in on the informer. This periodic check is needed to
@@ -746,6 +834,8 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 				nx.reconcileDevices(ctx, options)
 			case <-secGroupTicker.C:
 				nx.reconcileSecurityGroups(ctx)
+			case <-lazyPeerTickerC:
+				nx.reconcileLazyPeers()
 			}
 			if nx.needSecGroupReconcile {
 				// device reconcile noticed that the security group Id changed
@@ -758,6 +848,92 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	return nil
 }
 
+// joinVpc registers this device in the VPC. It returns the client options with the device token and
+// the ID of the device, the caller starts the informers that watch the VPC for changes.
+func (nx *Nexodus) joinVpc(ctx context.Context, userId string, vpc *client.ModelsVPC, options []client.Option) ([]client.Option, string, error) {
+	endpointSocket := net.JoinHostPort(nx.endpointLocalAddress, fmt.Sprintf("%d", nx.listenPort))
+	endpoints := []client.ModelsEndpoint{
+		{
+			Source:  client.PtrString("local"),
+			Address: &endpointSocket,
+		},
+		{
+			Source:  client.PtrString("stun:" + nx.reflexiveAddrStunSrc),
+			Address: client.PtrString(nx.nodeReflexiveAddressIPv4.String()),
+		},
+	}
+
+	var modelsDevice client.ModelsDevice
+	var deviceOperationLogMsg string
+	var err error
+	err = util.RetryOperation(ctx, retryInterval, maxRetries, func() error {
+		modelsDevice, deviceOperationLogMsg, err = nx.createOrUpdateDeviceOperation(userId, vpc, endpoints)
+		if err != nil {
+			nx.logger.Warnf("device join error - retrying: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("join error %w", err)
+	}
+	nx.logger.Debugf("Device: %s", util.JsonStringer(modelsDevice))
+	nx.logger.Infof("%s with UUID: [ %+v ] into vpc: [ %s (%s) ]",
+		deviceOperationLogMsg, modelsDevice.GetId(), vpc.GetId(), vpc.GetDescription())
+
+	// Use the device token to auth with the apiserver...
+	if modelsDevice.GetBearerToken() != "" {
+
+		key, err := wgtypes.ParseKey(nx.wireguardPvtKey)
+		if err != nil {
+			return nil, "", err
+		}
+
+		sealed, err := wgcrypto.ParseSealed(modelsDevice.GetBearerToken())
+		if err != nil {
+			return nil, "", err
+		}
+
+		data, err := sealed.Open(key[:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		//nx.stateStore.State().DeviceToken = string(data)
+		//err = nx.stateStore.Store()
+		//if err != nil {
+		//	return err
+		//}
+
+		options = append(options, client.WithBearerToken(string(data)))
+		c, err := client.NewClient(ctx, nx.apiURL.String(), func(msg string) {}, options...)
+		if err != nil {
+			return nil, "", err
+		}
+		nx.client = c
+	}
+
+	return options, modelsDevice.GetId(), nil
+}
+
+// startInformers starts watching the VPC and its devices, security groups and relay metadata.
+func (nx *Nexodus) startInformers(ctx context.Context) {
+	informerCtx, informerCancel := context.WithCancel(ctx)
+	nx.informerStop = informerCancel
+	nx.informerCtx = informerCtx
+	nx.resetSharedVpcs()
+
+	// event stream sharing occurs due to the informers sharing the context created in following line:
+	informerCtx = nx.client.EventsApi.Watch(informerCtx). /*.GetPublicKey()(nx.wireguardPubKey).*/ NewSharedInformerContext()
+	nx.securityGroupsInformer = nx.client.VPCApi.ListSecurityGroupsInVPC(informerCtx, nx.vpc.GetId()).Informer()
+	nx.devicesInformer = nx.client.VPCApi.ListDevicesInVPC(informerCtx, nx.vpc.GetId()).Informer()
+	nx.relayMetadataInformer = nx.client.VPCApi.ListMetadataInVPC(informerCtx, nx.vpc.GetId()).Key("relay").Informer()
+	nx.vpcInformer = nx.client.VPCApi.GetVPC(informerCtx, nx.vpc.GetId()).Informer()
+	if nx.userspaceMode {
+		nx.proxyRulesInformer = nx.client.DevicesApi.ListDeviceProxyRules(informerCtx, nx.deviceId).Informer()
+	}
+}
+
 type NexodusClaims struct {
 	jwt.RegisteredClaims
 	Scope          string    `json:"scope,omitempty"`
@@ -854,6 +1030,9 @@ func (nx *Nexodus) fetchUserIdAndVpcFromAPI(ctx context.Context) (string, *clien
 
 func (nx *Nexodus) Stop() {
 	nx.logger.Info("Stopping nexd")
+	nx.publishTunnelEvent(api.NexdEventTunnelDown)
+	defer nx.hooks.shutdown()
+
 	for _, proxy := range nx.proxies {
 		proxy.Stop()
 	}
@@ -872,6 +1051,12 @@ func (nx *Nexodus) Stop() {
 		}
 	}
 
+	if nx.lazyPeers {
+		if err := nx.lazyTracker.teardown(); err != nil {
+			nx.logger.Errorf("failed to remove the tracking of lazy peers: %v", err)
+		}
+	}
+
 	if nx.Derper != nil {
 		nx.logger.Info("Stopping Derp Server")
 		nx.Derper.StopDerper()
@@ -884,6 +1069,7 @@ func (nx *Nexodus) Stop() {
 
 // reconcileSecurityGroups will check the security group and update it if necessary.
 func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
+	defer nx.metrics.observeReconcile("security_groups", time.Now())
 	if runtime.GOOS != Linux.String() && runtime.GOOS != Darwin.String() || nx.userspaceMode {
 		return
 	}
@@ -909,7 +1095,7 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 	}
 
 	// if the security group ID is not nil, lookup the ID and check for any changes
-	securityGroups, httpResp, err := nx.securityGroupsInformer.Execute()
+	securityGroups, httpResp, err := nx.listSecurityGroups()
 	if err != nil {
 		// if the group ID returns a 404, clear the current rules
 		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
@@ -919,6 +1105,7 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 			}
 			return
 		}
+		nx.metrics.apiError("list_security_groups")
 		nx.logger.Errorf("Error retrieving the security groups: %v", err)
 		return
 	}
@@ -952,16 +1139,38 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 	// apply the new security group rules
 	if err := nx.processSecurityGroupRules(); err != nil {
 		nx.logger.Error(err)
+		return
 	}
+	nx.savePeerConfig()
 }
 
 func (nx *Nexodus) reconcileDevices(ctx context.Context, options []client.Option) {
+	defer nx.metrics.observeReconcile("devices", time.Now())
 	var err error
+	if nx.offlineConfig() != nil {
+		if err = nx.reconcileDeviceCache(); err != nil {
+			nx.logger.Errorf("Failed to apply the saved peer configuration: %v", err)
+		}
+		return
+	}
+	if err = nx.followVpcMove(ctx); err != nil {
+		nx.logger.Warnf("Failed to follow this device to its new VPC: %v", err)
+	}
+	if err = nx.refreshVpc(); err != nil {
+		nx.logger.Warnf("Failed to refresh the VPC: %v", err)
+	}
+	if err = nx.refreshSubnet(ctx); err != nil {
+		nx.logger.Warnf("Failed to refresh the subnet of this device: %v", err)
+	}
+	if err = nx.reconcileSharedVpcs(ctx); err != nil {
+		nx.logger.Warnf("Failed to watch the VPCs this device is shared into: %v", err)
+	}
 	if err = nx.reconcileDeviceCache(); err == nil {
 		if !nx.deviceReconciled {
 			nx.deviceReconciled = true
 			nx.logger.Info("Nexodus agent has reconciled state with API server")
 		}
+		nx.savePeerConfig()
 		return
 	}
 
@@ -972,6 +1181,7 @@ func (nx *Nexodus) reconcileDevices(ctx context.Context, options []client.Option
 		return
 	}
 
+	nx.metrics.apiError("list_devices")
 	nx.logger.Errorf("Failed to reconcile state with the nexodus API server: %v", err)
 	nx.deviceReconciled = false
 
@@ -1003,30 +1213,100 @@ func (nx *Nexodus) reconcileDevices(ctx context.Context, options []client.Option
 	}
 
 	nx.client = c
-	informerCtx, informerCancel := context.WithCancel(ctx)
-	nx.informerStop = informerCancel
-
-	informerCtx = nx.client.EventsApi.Watch(informerCtx).NewSharedInformerContext()
-	nx.securityGroupsInformer = nx.client.VPCApi.ListSecurityGroupsInVPC(informerCtx, nx.vpc.GetId()).Informer()
-	nx.devicesInformer = nx.client.VPCApi.ListDevicesInVPC(informerCtx, nx.vpc.GetId()).Informer()
-	nx.relayMetadataInformer = nx.client.VPCApi.ListMetadataInVPC(informerCtx, nx.vpc.GetId()).Key("relay").Informer()
+	nx.startInformers(ctx)
 
 	nx.SetStatus(NexdStatusRunning, "")
 	nx.logger.Infoln("Nexodus agent has re-established a connection to the api-server")
 }
 
+// followVpcMove watches the new VPC of this device when it was moved to another VPC. The move is
+// noticed when the device is deleted from the devices of its VPC, the peers of the old VPC are then
+// removed and the tunnel is re-addressed by the next reconcile of the devices of the new VPC.
+func (nx *Nexodus) followVpcMove(ctx context.Context) error {
+	if nx.deviceId == "" {
+		return nil
+	}
+	if _, cached := nx.deviceCacheLookup(nx.wireguardPubKey); !cached {
+		return nil
+	}
+	peerMap, _, err := nx.devicesInformer.Execute()
+	if err != nil {
+		return err
+	}
+	if _, listed := peerMap[nx.deviceId]; listed {
+		return nil
+	}
+
+	device, _, err := nx.client.DevicesApi.GetDevice(ctx, nx.deviceId).Execute()
+	if err != nil {
+		return err
+	}
+	if device.GetVpcId() == nx.vpc.GetId() {
+		// the device was deleted, not moved
+		return nil
+	}
+	vpc, _, err := nx.client.VPCApi.GetVPC(ctx, device.GetVpcId()).Execute()
+	if err != nil {
+		return err
+	}
+
+	nx.logger.Infof("This device was moved from VPC %s to VPC %s", nx.vpc.GetId(), vpc.GetId())
+	if nx.informerStop != nil {
+		nx.informerStop()
+		nx.informerStop = nil
+	}
+	nx.vpc = vpc
+	nx.vpcId = vpc.GetId()
+	nx.securityGroupId = device.GetSecurityGroupId()
+	nx.startInformers(ctx)
+	return nil
+}
+
+// vpcCidrs returns the primary and secondary CIDRs of the VPC of this device.
+func (nx *Nexodus) vpcCidrs() []string {
+	return append([]string{nx.vpc.GetIpv4Cidr(), nx.vpc.GetIpv6Cidr()}, nx.vpc.GetSecondaryCidrs()...)
+}
+
+// refreshVpc applies the changes to the VPC of this device, such as its secondary CIDRs, once the watch
+// of the VPC sees a new revision of it.
+func (nx *Nexodus) refreshVpc() error {
+	if nx.offlineConfig() != nil || nx.vpcInformer == nil {
+		return nil
+	}
+	vpcs, _, err := nx.vpcInformer.Execute()
+	if err != nil {
+		return err
+	}
+	vpc, ok := vpcs[nx.vpc.GetId()]
+	if !ok || vpc.GetRevision() == nx.vpc.GetRevision() {
+		return nil
+	}
+	cidrs := append([]string{vpc.GetIpv4Cidr(), vpc.GetIpv6Cidr()}, vpc.GetSecondaryCidrs()...)
+	if !slices.Equal(cidrs, nx.vpcCidrs()) {
+		nx.logger.Infof("The CIDRs of VPC %s changed to %v", vpc.GetId(), cidrs)
+	}
+	nx.vpc = &vpc
+	return nil
+}
+
 func (nx *Nexodus) reconcileStun(deviceID string) error {
 	if nx.symmetricNat {
 		return nil
 	}
+	defer nx.metrics.observeReconcile("stun", time.Now())
 
 	nx.logger.Debug("sending stun request")
 	stunServer1 := stun.NextServer()
 	reflexiveIP, err := stun.Request(nx.logger, stunServer1, nx.listenPort)
+	nx.recordStunResult(stunServer1, reflexiveIP, err)
 	if err != nil {
 		return fmt.Errorf("stun request error: %w", err)
 	}
 
+	// the endpoints are updated once nexd has reconnected to the api-server
+	if nx.offlineConfig() != nil {
+		return nil
+	}
 	if nx.nodeReflexiveAddressIPv4 != reflexiveIP {
 		nx.logger.Infof("detected a NAT binding changed for this device %s from %s to %s, updating peers", deviceID, nx.nodeReflexiveAddressIPv4, reflexiveIP)
 
@@ -1043,6 +1323,7 @@ func (nx *Nexodus) reconcileStun(deviceID string) error {
 			},
 		}).Execute()
 		if err != nil {
+			nx.metrics.apiError("update_device")
 			return fmt.Errorf("failed to update this device's new NAT binding, likely still reconnecting to the api-server, retrying in 20s: %w", err)
 		} else {
 			nx.logger.Debugf("update device response %+v", res)
@@ -1130,12 +1411,17 @@ func (nx *Nexodus) addToDeviceCache(p client.ModelsDevice) {
 		device:      p,
 		lastUpdated: time.Now(),
 	}
+	if existing, ok := nx.deviceCache[p.GetPublicKey()]; ok {
+		// the peer stays configured if it was active, but the routes to its tunnel IPs may have changed
+		d.lazy = existing.lazy
+		d.lazy.routed = false
+	}
 	nx.peeringReset(&d)
 	nx.deviceCache[p.GetPublicKey()] = d
 }
 
 func (nx *Nexodus) reconcileDeviceCache() error {
-	peerMap, resp, err := nx.devicesInformer.Execute()
+	peerMap, resp, err := nx.listDevices()
 	if err != nil {
 		if resp != nil {
 			return fmt.Errorf("error: %w header: %v", err, resp.Header)
@@ -1163,16 +1449,34 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 	for _, p := range peerMap {
 		// Update the cache if the device is new or has changed
 		existing, ok := nx.deviceCache[p.GetPublicKey()]
+		if !ok && p.GetPublicKey() != nx.wireguardPubKey {
+			nx.events.publish(api.NexdEvent{
+				Type:      api.NexdEventPeerAdded,
+				PublicKey: p.GetPublicKey(),
+				DeviceId:  p.GetId(),
+				Hostname:  p.GetHostname(),
+			})
+		}
 		if !ok || deviceUpdated(existing.device, p) {
 			if p.GetPublicKey() == nx.wireguardPubKey {
 				newLocalConfig = true
 				if nx.securityGroup == nil || !reflect.DeepEqual(p.SecurityGroupId, nx.securityGroup.Id) {
 					nx.needSecGroupReconcile = true
 				}
+				for _, cidr := range p.AdvertiseCidrs {
+					if !slices.Contains(p.ApprovedCidrs, cidr) {
+						nx.logger.Warnf("Advertised CIDR [ %s ] has not been approved by an organization owner, peers will not route it to this device", cidr)
+					}
+				}
 			}
 			nx.addToDeviceCache(p)
 			existing = nx.deviceCache[p.GetPublicKey()]
 			delete(peerStats, p.GetPublicKey())
+		} else if existing.device.GetOnline() != p.GetOnline() || existing.device.GetRouterPriority() != p.GetRouterPriority() {
+			// these only affect which router HA group member is elected, so don't reset the peering
+			existing.device.Online = p.Online
+			existing.device.RouterPriority = p.RouterPriority
+			nx.deviceCache[p.GetPublicKey()] = existing
 		}
 
 		// Store the relay IP for easy reference later
@@ -1191,7 +1495,7 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 		// Keep track of peer connection stats for connection health tracking
 		curStats, ok := peerStats[p.GetPublicKey()]
 		if !ok {
-			if nx.wireguardPubKey != p.GetPublicKey() && existing.peeringMethod != peeringMethodViaRelay {
+			if nx.wireguardPubKey != p.GetPublicKey() && existing.peeringMethod != peeringMethodViaRelay && !nx.peerIsLazyInactive(existing) {
 				nx.logger.Debugf("peer (hostname:%s pubkey:%s) has no stats", p.GetHostname(), p.GetPublicKey())
 			}
 			// This won't be available early because the peer hasn't been configured yet
@@ -1209,10 +1513,16 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 		existing.lastHandshake = curStats.LatestHandshake
 		existing.lastRefresh = now
 		existing.endpoint = curStats.Endpoint
+		wasHealthy := existing.peerHealthy
 		existing.peerHealthy = nx.peerIsHealthy(existing)
 		if existing.peerHealthy {
 			existing.peerHealthyTime = now
 		}
+		if existing.peerHealthy && !wasHealthy {
+			nx.publishPeerEvent(api.NexdEventPeerUp, existing)
+		} else if !existing.peerHealthy && wasHealthy {
+			nx.publishPeerEvent(api.NexdEventPeerDown, existing)
+		}
 		nx.deviceCache[p.GetPublicKey()] = existing
 	}
 
@@ -1265,6 +1575,7 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 func deviceUpdated(d1, d2 client.ModelsDevice) bool {
 	return !reflect.DeepEqual(d1.AllowedIps, d2.AllowedIps) ||
 		!reflect.DeepEqual(d1.AdvertiseCidrs, d2.AdvertiseCidrs) ||
+		!reflect.DeepEqual(d1.ApprovedCidrs, d2.ApprovedCidrs) ||
 		!reflect.DeepEqual(d1.Endpoints, d2.Endpoints) ||
 		d1.GetRelay() != d2.GetRelay() ||
 		d1.GetSymmetricNat() != d2.GetSymmetricNat() ||
@@ -1289,6 +1600,7 @@ func (nx *Nexodus) symmetricNatDisco(ctx context.Context) error {
 		stunServer1 := stun.NextServer()
 		stunServer2 := stun.NextServer()
 		stunAddr1, err := stun.Request(nx.logger, stunServer1, nx.listenPort)
+		nx.recordStunResult(stunServer1, stunAddr1, err)
 		if err != nil {
 			return err
 		} else {
@@ -1297,6 +1609,7 @@ func (nx *Nexodus) symmetricNatDisco(ctx context.Context) error {
 
 		isSymmetric := false
 		stunAddr2, err := stun.Request(nx.logger, stunServer2, nx.listenPort)
+		nx.recordStunResult(stunServer2, stunAddr2, err)
 		if err != nil {
 			return err
 		} else {
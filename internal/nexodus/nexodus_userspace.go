@@ -36,7 +36,8 @@ func (nx *Nexodus) setupInterfaceUS() error {
 		nx.logger.Errorf("Failed to create userspace tunnel device: %w", err)
 		return err
 	}
-	nx.userspaceTun = tun
+	// tap the decrypted packets for nexctl nexd capture
+	nx.userspaceTun = newCaptureTun(tun, &nx.captures)
 	nx.userspaceNet = tnet
 	logger := &device.Logger{
 		Verbosef: device.DiscardLogf,
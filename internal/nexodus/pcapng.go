@@ -0,0 +1,101 @@
+package nexodus
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+const (
+	pcapngSectionHeaderBlock  = 0x0a0d0d0a
+	pcapngInterfaceDescBlock  = 0x00000001
+	pcapngEnhancedPacketBlock = 0x00000006
+	pcapngByteOrderMagic      = 0x1a2b3c4d
+	pcapngLinkTypeRaw         = 101
+	pcapngOptEndOfOpt         = 0
+	pcapngOptComment          = 1
+	pcapngOptIfName           = 2
+	pcapngOptEpbFlags         = 2
+	pcapngEpbFlagInbound      = 0x1
+	pcapngEpbFlagOutbound     = 0x2
+	pcapngDefaultSnapLen      = 65535
+)
+
+// pcapngWriter writes the packets of a single interface carrying raw IP packets in the pcapng format.
+type pcapngWriter struct {
+	w       io.Writer
+	snapLen int
+}
+
+// newPcapngWriter writes the section header and the description of the interface.
+func newPcapngWriter(w io.Writer, ifName string, snapLen int, comment string) (*pcapngWriter, error) {
+	shb := binary.LittleEndian.AppendUint32(nil, pcapngByteOrderMagic)
+	shb = binary.LittleEndian.AppendUint16(shb, 1)
+	shb = binary.LittleEndian.AppendUint16(shb, 0)
+	// the length of the section is not known
+	shb = binary.LittleEndian.AppendUint64(shb, 0xffffffffffffffff)
+	if comment != "" {
+		shb = appendPcapngOption(shb, pcapngOptComment, []byte(comment))
+	}
+	shb = appendPcapngOption(shb, pcapngOptEndOfOpt, nil)
+	if err := writePcapngBlock(w, pcapngSectionHeaderBlock, shb); err != nil {
+		return nil, err
+	}
+
+	idb := binary.LittleEndian.AppendUint16(nil, pcapngLinkTypeRaw)
+	idb = binary.LittleEndian.AppendUint16(idb, 0)
+	idb = binary.LittleEndian.AppendUint32(idb, uint32(snapLen))
+	idb = appendPcapngOption(idb, pcapngOptIfName, []byte(ifName))
+	idb = appendPcapngOption(idb, pcapngOptEndOfOpt, nil)
+	if err := writePcapngBlock(w, pcapngInterfaceDescBlock, idb); err != nil {
+		return nil, err
+	}
+	return &pcapngWriter{w: w, snapLen: snapLen}, nil
+}
+
+// writePacket writes an IP packet, truncated to the snap length.
+func (p *pcapngWriter) writePacket(t time.Time, packet []byte, outbound bool) error {
+	captured := packet
+	if len(captured) > p.snapLen {
+		captured = captured[:p.snapLen]
+	}
+	ts := uint64(t.UnixMicro())
+	epb := binary.LittleEndian.AppendUint32(nil, 0)
+	epb = binary.LittleEndian.AppendUint32(epb, uint32(ts>>32))
+	epb = binary.LittleEndian.AppendUint32(epb, uint32(ts))
+	epb = binary.LittleEndian.AppendUint32(epb, uint32(len(captured)))
+	epb = binary.LittleEndian.AppendUint32(epb, uint32(len(packet)))
+	epb = append(epb, captured...)
+	epb = appendPcapngPadding(epb)
+	flags := uint32(pcapngEpbFlagInbound)
+	if outbound {
+		flags = pcapngEpbFlagOutbound
+	}
+	epb = appendPcapngOption(epb, pcapngOptEpbFlags, binary.LittleEndian.AppendUint32(nil, flags))
+	epb = appendPcapngOption(epb, pcapngOptEndOfOpt, nil)
+	return writePcapngBlock(p.w, pcapngEnhancedPacketBlock, epb)
+}
+
+func writePcapngBlock(w io.Writer, blockType uint32, body []byte) error {
+	length := uint32(len(body) + 12)
+	block := binary.LittleEndian.AppendUint32(nil, blockType)
+	block = binary.LittleEndian.AppendUint32(block, length)
+	block = append(block, body...)
+	block = binary.LittleEndian.AppendUint32(block, length)
+	_, err := w.Write(block)
+	return err
+}
+
+func appendPcapngOption(b []byte, code uint16, value []byte) []byte {
+	b = binary.LittleEndian.AppendUint16(b, code)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(value)))
+	b = append(b, value...)
+	return appendPcapngPadding(b)
+}
+
+func appendPcapngPadding(b []byte) []byte {
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
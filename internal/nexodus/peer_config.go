@@ -0,0 +1,215 @@
+package nexodus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/state"
+)
+
+var errOfflineConfig = errors.New("the api-server is unreachable, nexd is running from the saved peer configuration")
+
+// offlineConfig returns the saved peer configuration nexd is running from, or nil when it is connected to the api-server.
+func (nx *Nexodus) offlineConfig() *state.PeerConfig {
+	return nx.runningPeerConfig.Load()
+}
+
+// loadPeerConfig returns the saved peer configuration if it can be used to bring up the tunnel of this device.
+func (nx *Nexodus) loadPeerConfig() *state.PeerConfig {
+	if nx.stateStore == nil {
+		return nil
+	}
+	s := nx.stateStore.State()
+	pc := s.PeerConfig
+	if pc == nil || pc.ApiURL != nx.apiURL.String() || (nx.vpcId != "" && pc.Vpc.GetId() != nx.vpcId) {
+		return nil
+	}
+	for _, d := range pc.Devices {
+		if d.GetId() == pc.DeviceId && d.GetPublicKey() == s.PublicKey {
+			return pc
+		}
+	}
+	return nil
+}
+
+// startOffline brings up the tunnel from the saved peer configuration when the api-server could not
+// be reached, it returns err when there is no peer configuration to start from.
+func (nx *Nexodus) startOffline(err error) (*state.PeerConfig, error) {
+	pc := nx.loadPeerConfig()
+	if pc == nil {
+		return nil, err
+	}
+	nx.logger.Warnf("Unable to reach the api-server, starting from the peer configuration saved at %s: %v", pc.Time.Format(time.RFC3339), err)
+	nx.startFromPeerConfig(pc)
+	return pc, nil
+}
+
+// startFromPeerConfig configures nexd to run from the saved peer configuration until it reconnects to the api-server.
+func (nx *Nexodus) startFromPeerConfig(pc *state.PeerConfig) {
+	vpc := pc.Vpc
+	nx.vpc = &vpc
+	nx.deviceId = pc.DeviceId
+	nx.runningPeerConfig.Store(pc)
+	nx.SetStatus(NexdStatusOfflineConfig, fmt.Sprintf("The api-server is unreachable, running from the peer configuration saved at %s\n", pc.Time.Format(time.RFC3339)))
+}
+
+// savePeerConfig stores the reconciled device cache, security group and tunnel IPs so that
+// the tunnel can be brought up from them if the api-server is unreachable when nexd starts.
+func (nx *Nexodus) savePeerConfig() {
+	if nx.stateStore == nil || nx.vpc == nil || nx.offlineConfig() != nil {
+		return
+	}
+	pc := nx.peerConfigSnapshot()
+
+	s := nx.stateStore.State()
+	if s.PeerConfig != nil {
+		previous := *s.PeerConfig
+		previous.Time = time.Time{}
+		if peerConfigEqual(previous, pc) {
+			return
+		}
+	}
+	pc.Time = time.Now()
+	s.PeerConfig = &pc
+	if err := nx.stateStore.Store(); err != nil {
+		nx.logger.Warnf("Failed to store the peer configuration: %v", err)
+	}
+}
+
+func (nx *Nexodus) peerConfigSnapshot() state.PeerConfig {
+	pc := state.PeerConfig{
+		ApiURL:     nx.apiURL.String(),
+		DeviceId:   nx.deviceId,
+		TunnelIP:   nx.TunnelIP,
+		TunnelIpV6: nx.TunnelIpV6,
+		Vpc:        *nx.vpc,
+	}
+	if nx.securityGroup != nil {
+		securityGroup := *nx.securityGroup
+		pc.SecurityGroup = &securityGroup
+	}
+
+	nx.deviceCacheLock.RLock()
+	for _, d := range nx.deviceCache {
+		device := d.device
+		device.BearerToken = nil
+		pc.Devices = append(pc.Devices, device)
+		if d.device.GetRelay() && d.metadata.DeviceId != nil {
+			if pc.RelayMetadata == nil {
+				pc.RelayMetadata = map[string]client.ModelsDeviceMetadata{}
+			}
+			pc.RelayMetadata[d.device.GetId()+"/relay"] = d.metadata
+		}
+	}
+	nx.deviceCacheLock.RUnlock()
+
+	sort.Slice(pc.Devices, func(i, j int) bool {
+		return pc.Devices[i].GetId() < pc.Devices[j].GetId()
+	})
+	return pc
+}
+
+func peerConfigEqual(a, b state.PeerConfig) bool {
+	aJson, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJson, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aJson) == string(bJson)
+}
+
+// reconnection is the result of an attempt to join the api-server while nexd runs from the saved peer configuration.
+type reconnection struct {
+	options  []client.Option
+	vpc      *client.ModelsVPC
+	deviceId string
+	err      error
+}
+
+// reconnect tries once to join the api-server while nexd runs from the saved peer configuration.
+// It runs outside the main loop, so the VPC, device ID and informers read by the loop are only
+// updated by reconnected.
+func (nx *Nexodus) reconnect(ctx context.Context, options []client.Option) reconnection {
+	if err := nx.resetApiClient(ctx); err != nil {
+		nx.metrics.apiError("connect")
+		return reconnection{err: err}
+	}
+	userId, vpc, err := nx.fetchUserIdAndVpc(ctx)
+	if err != nil {
+		nx.metrics.apiError("get_vpc")
+		return reconnection{err: err}
+	}
+	joinOptions, deviceId, err := nx.joinVpc(ctx, userId, vpc, options)
+	if err != nil {
+		nx.metrics.apiError("join")
+		return reconnection{err: err}
+	}
+	return reconnection{options: joinOptions, vpc: vpc, deviceId: deviceId}
+}
+
+// reconnected leaves the saved peer configuration once reconnect has joined the api-server.
+func (nx *Nexodus) reconnected(ctx context.Context, r reconnection) {
+	nx.vpc = r.vpc
+	nx.deviceId = r.deviceId
+	nx.startInformers(ctx)
+	nx.runningPeerConfig.Store(nil)
+	nx.SetStatus(NexdStatusRunning, "")
+	nx.logger.Info("Nexodus agent has connected to the api-server, leaving the saved peer configuration")
+}
+
+// informerChanged returns the change notifications of an informer, or nil while nexd runs from the
+// saved peer configuration and the informers of the VPC are not started.
+func informerChanged[T any](informer *client.ListInformer[T]) <-chan struct{} {
+	if informer == nil {
+		return nil
+	}
+	return informer.Changed()
+}
+
+// listDevices returns the devices of the VPC and of the VPCs this device is shared into, from the
+// saved peer configuration when nexd is offline.
+func (nx *Nexodus) listDevices() (map[string]client.ModelsDevice, *http.Response, error) {
+	if pc := nx.offlineConfig(); pc != nil {
+		devices := map[string]client.ModelsDevice{}
+		for _, d := range pc.Devices {
+			devices[d.GetId()] = d
+		}
+		return devices, nil, nil
+	}
+	devices, resp, err := nx.devicesInformer.Execute()
+	if err != nil {
+		return devices, resp, err
+	}
+	devices = nx.topologyPeers(devices)
+	devices, err = nx.listSharedDevices(devices)
+	return devices, resp, err
+}
+
+// listSecurityGroups returns the security groups of the VPC, from the saved peer configuration when nexd is offline.
+func (nx *Nexodus) listSecurityGroups() (map[string]client.ModelsSecurityGroup, *http.Response, error) {
+	if pc := nx.offlineConfig(); pc != nil {
+		securityGroups := map[string]client.ModelsSecurityGroup{}
+		if pc.SecurityGroup != nil {
+			securityGroups[pc.SecurityGroup.GetId()] = *pc.SecurityGroup
+		}
+		return securityGroups, nil, nil
+	}
+	return nx.securityGroupsInformer.Execute()
+}
+
+// listRelayMetadata returns the relay metadata of the VPC, from the saved peer configuration when nexd is offline.
+func (nx *Nexodus) listRelayMetadata() (map[string]client.ModelsDeviceMetadata, *http.Response, error) {
+	if pc := nx.offlineConfig(); pc != nil {
+		return pc.RelayMetadata, nil, nil
+	}
+	return nx.relayMetadataInformer.Execute()
+}
@@ -0,0 +1,96 @@
+package nexodus
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/state/fstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPeerConfig(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+	file := filepath.Join(t.TempDir(), "state.json")
+	store := fstore.New(file)
+	require.NoError(store.Load())
+	store.State().PublicKey = "local-key"
+
+	apiURL, err := url.Parse("https://api.example.com")
+	require.NoError(err)
+	nx := &Nexodus{
+		logger:          zLogger.Sugar(),
+		apiURL:          apiURL,
+		stateStore:      store,
+		deviceId:        "local-id",
+		wireguardPubKey: "local-key",
+		TunnelIP:        "100.64.0.1",
+		vpc:             &client.ModelsVPC{Id: client.PtrString("vpc-id")},
+		securityGroup:   &client.ModelsSecurityGroup{Id: client.PtrString("sg-id")},
+		deviceCache: map[string]deviceCacheEntry{
+			"local-key": {
+				device: client.ModelsDevice{
+					Id:          client.PtrString("local-id"),
+					PublicKey:   client.PtrString("local-key"),
+					BearerToken: client.PtrString("secret"),
+				},
+			},
+			"relay-key": {
+				device: client.ModelsDevice{
+					Id:        client.PtrString("relay-id"),
+					PublicKey: client.PtrString("relay-key"),
+					Relay:     client.PtrBool(true),
+				},
+				metadata: client.ModelsDeviceMetadata{
+					DeviceId: client.PtrString("relay-id"),
+					Key:      client.PtrString("relay"),
+				},
+			},
+		},
+	}
+	nx.savePeerConfig()
+
+	// reload the state the way nexd does when it starts
+	restarted := fstore.New(file)
+	require.NoError(restarted.Load())
+	nx.stateStore = restarted
+	pc := nx.loadPeerConfig()
+	require.NotNil(pc)
+	require.False(pc.Time.IsZero())
+	require.Equal("local-id", pc.DeviceId)
+	require.Equal("100.64.0.1", pc.TunnelIP)
+	require.Equal("vpc-id", pc.Vpc.GetId())
+	require.Equal("sg-id", pc.SecurityGroup.GetId())
+	require.Len(pc.Devices, 2)
+	for _, d := range pc.Devices {
+		require.Nil(d.BearerToken)
+	}
+
+	// unchanged peer configurations are not stored again
+	saved := pc.Time
+	nx.savePeerConfig()
+	require.Equal(saved, nx.stateStore.State().PeerConfig.Time)
+
+	// while offline, the VPC is listed from the saved peer configuration
+	nx.startFromPeerConfig(pc)
+	require.Equal(NexdStatusOfflineConfig, nx.status)
+	devices, _, err := nx.listDevices()
+	require.NoError(err)
+	require.Contains(devices, "relay-id")
+	metadata, _, err := nx.getDeviceRelayMetadata("relay-id")
+	require.NoError(err)
+	require.Equal("relay", metadata.GetKey())
+	securityGroups, _, err := nx.listSecurityGroups()
+	require.NoError(err)
+	require.Contains(securityGroups, "sg-id")
+	require.ErrorIs(nx.SetAdvertiseCidrs([]string{"10.0.0.0/24"}), errOfflineConfig)
+
+	// a peer configuration saved for another service is not used
+	other, err := url.Parse("https://other.example.com")
+	require.NoError(err)
+	nx.apiURL = other
+	require.Nil(nx.loadPeerConfig())
+}
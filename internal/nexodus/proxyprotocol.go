@@ -0,0 +1,331 @@
+package nexodus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProxyProtocolVersion identifies the version of the HAProxy PROXY protocol
+// header that a proxy rule emits when it connects to its destination.
+//
+// See https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt
+type ProxyProtocolVersion int
+
+const (
+	proxyProtocolNone ProxyProtocolVersion = iota
+	proxyProtocolV1
+	proxyProtocolV2
+)
+
+func (v ProxyProtocolVersion) String() string {
+	switch v {
+	case proxyProtocolNone:
+		return "none"
+	case proxyProtocolV1:
+		return "v1"
+	case proxyProtocolV2:
+		return "v2"
+	default:
+		panic(fmt.Sprintf("Invalid proxy protocol version: %d", v))
+	}
+}
+
+const (
+	// proxy rule options, appended to a rule as /option
+	proxyOptionSendProxyV1 = "send-proxy-v1"
+	proxyOptionSendProxyV2 = "send-proxy-v2"
+	proxyOptionAcceptProxy = "accept-proxy"
+
+	// the longest possible v1 header, including the CRLF
+	proxyProtocolV1MaxLen = 107
+	// the fixed-size portion of a v2 header
+	proxyProtocolV2HeaderLen = 16
+	// how long we wait for a client to send its PROXY header
+	proxyProtocolReadTimeout = 5 * time.Second
+)
+
+var (
+	proxyProtocolV1Prefix   = []byte("PROXY ")
+	proxyProtocolV2Sig      = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+	errProxyHeaderMissing   = errors.New("connection did not start with a PROXY protocol header")
+	errProxyHeaderMalformed = errors.New("malformed PROXY protocol header")
+)
+
+// proxyAddrs holds the original source and destination of a proxied connection.
+// Either address may be invalid when it is not known, such as for v2 LOCAL
+// connections or v1 UNKNOWN connections.
+type proxyAddrs struct {
+	src netip.AddrPort
+	dst netip.AddrPort
+}
+
+func proxyAddrsFromConn(conn net.Conn) proxyAddrs {
+	return proxyAddrs{
+		src: addrPortFromNetAddr(conn.RemoteAddr()),
+		dst: addrPortFromNetAddr(conn.LocalAddr()),
+	}
+}
+
+func addrPortFromNetAddr(addr net.Addr) netip.AddrPort {
+	if addr == nil {
+		return netip.AddrPort{}
+	}
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		return tcpAddr.AddrPort()
+	}
+	addrPort, err := netip.ParseAddrPort(addr.String())
+	if err != nil {
+		return netip.AddrPort{}
+	}
+	return addrPort
+}
+
+// known returns true if both addresses are valid and of the same address family,
+// which is required to encode them in a PROXY header.
+func (a proxyAddrs) known() bool {
+	if !a.src.IsValid() || !a.dst.IsValid() {
+		return false
+	}
+	return a.src.Addr().Unmap().Is4() == a.dst.Addr().Unmap().Is4()
+}
+
+func (a proxyAddrs) is4() bool {
+	return a.src.Addr().Unmap().Is4()
+}
+
+// writeProxyHeader writes a PROXY protocol header of the given version to w
+// describing a connection from addrs.src to addrs.dst.
+func writeProxyHeader(w io.Writer, version ProxyProtocolVersion, addrs proxyAddrs) error {
+	var header []byte
+	switch version {
+	case proxyProtocolNone:
+		return nil
+	case proxyProtocolV1:
+		header = encodeProxyHeaderV1(addrs)
+	case proxyProtocolV2:
+		header = encodeProxyHeaderV2(addrs)
+	default:
+		return fmt.Errorf("unexpected proxy protocol version: %v", version)
+	}
+	_, err := w.Write(header)
+	return err
+}
+
+func encodeProxyHeaderV1(addrs proxyAddrs) []byte {
+	if !addrs.known() {
+		return []byte("PROXY UNKNOWN\r\n")
+	}
+	family := "TCP6"
+	src, dst := addrs.src.Addr(), addrs.dst.Addr()
+	if addrs.is4() {
+		family = "TCP4"
+		src, dst = src.Unmap(), dst.Unmap()
+	}
+	return []byte(fmt.Sprintf("PROXY %s %s %s %d %d\r\n", family, src, dst, addrs.src.Port(), addrs.dst.Port()))
+}
+
+func encodeProxyHeaderV2(addrs proxyAddrs) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, proxyProtocolV2HeaderLen+36))
+	buf.Write(proxyProtocolV2Sig)
+	if !addrs.known() {
+		// version 2, LOCAL command, unspecified family and no addresses
+		buf.Write([]byte{0x20, 0x00, 0x00, 0x00})
+		return buf.Bytes()
+	}
+
+	// version 2, PROXY command
+	buf.WriteByte(0x21)
+	if addrs.is4() {
+		// AF_INET, STREAM
+		buf.WriteByte(0x11)
+		_ = binary.Write(buf, binary.BigEndian, uint16(12))
+		src, dst := addrs.src.Addr().Unmap().As4(), addrs.dst.Addr().Unmap().As4()
+		buf.Write(src[:])
+		buf.Write(dst[:])
+	} else {
+		// AF_INET6, STREAM
+		buf.WriteByte(0x21)
+		_ = binary.Write(buf, binary.BigEndian, uint16(36))
+		src, dst := addrs.src.Addr().As16(), addrs.dst.Addr().As16()
+		buf.Write(src[:])
+		buf.Write(dst[:])
+	}
+	_ = binary.Write(buf, binary.BigEndian, addrs.src.Port())
+	_ = binary.Write(buf, binary.BigEndian, addrs.dst.Port())
+	return buf.Bytes()
+}
+
+// readProxyHeader consumes a v1 or v2 PROXY protocol header from r. The header
+// is mandatory; a connection that does not start with one is rejected.
+func readProxyHeader(r *bufio.Reader) (proxyAddrs, error) {
+	peek, err := r.Peek(len(proxyProtocolV1Prefix))
+	if err != nil {
+		return proxyAddrs{}, err
+	}
+	if bytes.Equal(peek, proxyProtocolV1Prefix) {
+		return readProxyHeaderV1(r)
+	}
+	peek, err = r.Peek(len(proxyProtocolV2Sig))
+	if err != nil {
+		return proxyAddrs{}, err
+	}
+	if bytes.Equal(peek, proxyProtocolV2Sig) {
+		return readProxyHeaderV2(r)
+	}
+	return proxyAddrs{}, errProxyHeaderMissing
+}
+
+func readProxyHeaderV1(r *bufio.Reader) (proxyAddrs, error) {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return proxyAddrs{}, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= proxyProtocolV1MaxLen {
+			return proxyAddrs{}, fmt.Errorf("%w: v1 header too long", errProxyHeaderMalformed)
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return proxyAddrs{}, fmt.Errorf("%w: v1 header not terminated by CRLF", errProxyHeaderMalformed)
+	}
+
+	fields := strings.Split(string(line[:len(line)-2]), " ")
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return proxyAddrs{}, nil
+	}
+	if len(fields) != 6 {
+		return proxyAddrs{}, fmt.Errorf("%w: v1 header has %d fields", errProxyHeaderMalformed, len(fields))
+	}
+	if fields[1] != "TCP4" && fields[1] != "TCP6" {
+		return proxyAddrs{}, fmt.Errorf("%w: unsupported v1 protocol %q", errProxyHeaderMalformed, fields[1])
+	}
+
+	parse := func(ip, port string) (netip.AddrPort, error) {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return netip.AddrPort{}, fmt.Errorf("%w: %w", errProxyHeaderMalformed, err)
+		}
+		if addr.Is4() != (fields[1] == "TCP4") {
+			return netip.AddrPort{}, fmt.Errorf("%w: address %s does not match %s", errProxyHeaderMalformed, addr, fields[1])
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return netip.AddrPort{}, fmt.Errorf("%w: %w", errProxyHeaderMalformed, err)
+		}
+		return netip.AddrPortFrom(addr, uint16(p)), nil
+	}
+	src, err := parse(fields[2], fields[4])
+	if err != nil {
+		return proxyAddrs{}, err
+	}
+	dst, err := parse(fields[3], fields[5])
+	if err != nil {
+		return proxyAddrs{}, err
+	}
+	return proxyAddrs{src: src, dst: dst}, nil
+}
+
+func readProxyHeaderV2(r *bufio.Reader) (proxyAddrs, error) {
+	header := make([]byte, proxyProtocolV2HeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return proxyAddrs{}, err
+	}
+	if header[12]>>4 != 0x2 {
+		return proxyAddrs{}, fmt.Errorf("%w: unsupported v2 version %d", errProxyHeaderMalformed, header[12]>>4)
+	}
+	command := header[12] & 0x0F
+	family := header[13]
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return proxyAddrs{}, err
+	}
+
+	switch command {
+	case 0x0:
+		// LOCAL: the connection was established on purpose by the proxy
+		// without being relayed, so there is no original address.
+		return proxyAddrs{}, nil
+	case 0x1:
+	default:
+		return proxyAddrs{}, fmt.Errorf("%w: unsupported v2 command %d", errProxyHeaderMalformed, command)
+	}
+
+	switch family {
+	case 0x11:
+		if len(payload) < 12 {
+			return proxyAddrs{}, fmt.Errorf("%w: v2 TCP4 address block too short", errProxyHeaderMalformed)
+		}
+		src := netip.AddrFrom4([4]byte(payload[0:4]))
+		dst := netip.AddrFrom4([4]byte(payload[4:8]))
+		return proxyAddrs{
+			src: netip.AddrPortFrom(src, binary.BigEndian.Uint16(payload[8:10])),
+			dst: netip.AddrPortFrom(dst, binary.BigEndian.Uint16(payload[10:12])),
+		}, nil
+	case 0x21:
+		if len(payload) < 36 {
+			return proxyAddrs{}, fmt.Errorf("%w: v2 TCP6 address block too short", errProxyHeaderMalformed)
+		}
+		src := netip.AddrFrom16([16]byte(payload[0:16]))
+		dst := netip.AddrFrom16([16]byte(payload[16:32]))
+		return proxyAddrs{
+			src: netip.AddrPortFrom(src, binary.BigEndian.Uint16(payload[32:34])),
+			dst: netip.AddrPortFrom(dst, binary.BigEndian.Uint16(payload[34:36])),
+		}, nil
+	default:
+		// Any TLVs or unsupported address families are skipped, the
+		// connection is treated as if the original address were unknown.
+		return proxyAddrs{}, nil
+	}
+}
+
+// proxyProtocolConn is a net.Conn that has had its PROXY protocol header
+// consumed. Reads are served from the buffered reader used to parse the header
+// so that no payload bytes are lost.
+type proxyProtocolConn struct {
+	net.Conn
+	reader *bufio.Reader
+	addrs  proxyAddrs
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// acceptProxyHeader reads the PROXY protocol header sent by a fronting load
+// balancer on conn. The returned connection must be used for all further reads.
+func acceptProxyHeader(conn net.Conn) (*proxyProtocolConn, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(proxyProtocolReadTimeout)); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReaderSize(conn, proxyProtocolV1MaxLen)
+	addrs, err := readProxyHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	if !addrs.known() {
+		// Fall back to the address of the load balancer itself.
+		addrs = proxyAddrsFromConn(conn)
+	}
+	return &proxyProtocolConn{
+		Conn:   conn,
+		reader: reader,
+		addrs:  addrs,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package nexodus
+
+import (
+	"bufio"
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyHeaderRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  ProxyProtocolVersion
+		addrs    proxyAddrs
+		expected string
+	}{
+		{
+			name:    "v1 IPv4",
+			version: proxyProtocolV1,
+			addrs: proxyAddrs{
+				src: netip.MustParseAddrPort("100.64.0.1:53122"),
+				dst: netip.MustParseAddrPort("100.64.0.2:443"),
+			},
+			expected: "PROXY TCP4 100.64.0.1 100.64.0.2 53122 443\r\n",
+		},
+		{
+			name:    "v1 IPv6",
+			version: proxyProtocolV1,
+			addrs: proxyAddrs{
+				src: netip.MustParseAddrPort("[200::1]:53122"),
+				dst: netip.MustParseAddrPort("[200::2]:443"),
+			},
+			expected: "PROXY TCP6 200::1 200::2 53122 443\r\n",
+		},
+		{
+			name:     "v1 unknown",
+			version:  proxyProtocolV1,
+			addrs:    proxyAddrs{},
+			expected: "PROXY UNKNOWN\r\n",
+		},
+		{
+			name:    "v2 IPv4",
+			version: proxyProtocolV2,
+			addrs: proxyAddrs{
+				src: netip.MustParseAddrPort("100.64.0.1:53122"),
+				dst: netip.MustParseAddrPort("100.64.0.2:443"),
+			},
+		},
+		{
+			name:    "v2 IPv6",
+			version: proxyProtocolV2,
+			addrs: proxyAddrs{
+				src: netip.MustParseAddrPort("[200::1]:53122"),
+				dst: netip.MustParseAddrPort("[200::2]:443"),
+			},
+		},
+		{
+			name:    "v2 local",
+			version: proxyProtocolV2,
+			addrs:   proxyAddrs{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			buf := &bytes.Buffer{}
+			require.NoError(writeProxyHeader(buf, tc.version, tc.addrs))
+			if tc.expected != "" {
+				require.Equal(tc.expected, buf.String())
+			}
+
+			// the payload following the header must be left untouched
+			buf.WriteString("GET / HTTP/1.1\r\n")
+			reader := bufio.NewReader(buf)
+			addrs, err := readProxyHeader(reader)
+			require.NoError(err)
+			require.Equal(tc.addrs, addrs)
+
+			rest, err := reader.ReadString('\n')
+			require.NoError(err)
+			require.Equal("GET / HTTP/1.1\r\n", rest)
+		})
+	}
+}
+
+func TestReadProxyHeaderErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing header", input: "GET / HTTP/1.1\r\n"},
+		{name: "v1 no CRLF", input: "PROXY TCP4 1.1.1.1 2.2.2.2 1 2\n"},
+		{name: "v1 bad address", input: "PROXY TCP4 1.1.1 2.2.2.2 1 2\r\n"},
+		{name: "v1 family mismatch", input: "PROXY TCP4 200::1 200::2 1 2\r\n"},
+		{name: "v1 bad port", input: "PROXY TCP4 1.1.1.1 2.2.2.2 1 70000\r\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readProxyHeader(bufio.NewReader(bytes.NewBufferString(tc.input)))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseProxyRuleOptions(t *testing.T) {
+	require := require.New(t)
+
+	rule, err := ParseProxyRule("tcp:443:[200::2]:8443/accept-proxy/send-proxy-v2", ProxyTypeEgress)
+	require.NoError(err)
+	require.True(rule.acceptProxy)
+	require.Equal(proxyProtocolV2, rule.sendProxy)
+	require.Equal(HostPort{host: "200::2", port: 8443}, rule.dest)
+	require.Equal("tcp:443:[200::2]:8443/accept-proxy/send-proxy-v2", rule.String())
+
+	rule, err = ParseProxyRule("tcp:80:127.0.0.1:8080/send-proxy-v1", ProxyTypeIngress)
+	require.NoError(err)
+	require.False(rule.acceptProxy)
+	require.Equal(proxyProtocolV1, rule.sendProxy)
+
+	_, err = ParseProxyRule("udp:53:127.0.0.1:53/send-proxy-v2", ProxyTypeIngress)
+	require.Error(err)
+
+	_, err = ParseProxyRule("tcp:80:127.0.0.1:8080/bogus", ProxyTypeIngress)
+	require.Error(err)
+}
@@ -55,8 +55,14 @@ func (rule ProxyKey) String() string {
 
 type ProxyRule struct {
 	ProxyKey
-	dest   HostPort
-	stored bool
+	dest HostPort
+	// the PROXY protocol header version to send to dest, if any
+	sendProxy ProxyProtocolVersion
+	// expect a PROXY protocol header from clients connecting to the listener
+	acceptProxy bool
+	stored      bool
+	// the id of the API proxy rule this rule was created from, if any
+	apiRuleId string
 }
 
 type HostPort struct {
@@ -70,8 +76,22 @@ func (hp HostPort) String() string {
 }
 
 func (rule ProxyRule) String() string {
-	// protocol:port:destination_ip:destination_port
-	return fmt.Sprintf("%s:%d:%s", rule.protocol, rule.listenPort, rule.dest)
+	// protocol:port:destination_ip:destination_port[/option...]
+	return fmt.Sprintf("%s:%d:%s%s", rule.protocol, rule.listenPort, rule.dest, rule.optionsString())
+}
+
+func (rule ProxyRule) optionsString() string {
+	options := ""
+	if rule.acceptProxy {
+		options += "/" + proxyOptionAcceptProxy
+	}
+	switch rule.sendProxy {
+	case proxyProtocolV1:
+		options += "/" + proxyOptionSendProxyV1
+	case proxyProtocolV2:
+		options += "/" + proxyOptionSendProxyV2
+	}
+	return options
 }
 
 func (rule ProxyRule) AsFlag() string {
@@ -90,8 +110,9 @@ func parsePort(portStr string) (int, error) {
 }
 
 func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err error) {
-	// protocol:port:destination_ip:destination_port
-	parts := strings.Split(rule, ":")
+	// protocol:port:destination_ip:destination_port[/option...]
+	options := strings.Split(rule, "/")
+	parts := strings.Split(options[0], ":")
 	if len(parts) < 4 {
 		return emptyRule, fmt.Errorf("invalid proxy rule format, must specify 4 colon-separated values (%s)", rule)
 	}
@@ -122,7 +143,7 @@ func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err e
 		return emptyRule, err
 	}
 
-	return ProxyRule{
+	parsedRule := ProxyRule{
 		ProxyKey: ProxyKey{
 			ruleType:   ruleType,
 			protocol:   protocol,
@@ -132,5 +153,23 @@ func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err e
 			host: destHost,
 			port: destPort,
 		},
-	}, nil
+	}
+
+	for _, option := range options[1:] {
+		switch strings.ToLower(option) {
+		case proxyOptionSendProxyV1:
+			parsedRule.sendProxy = proxyProtocolV1
+		case proxyOptionSendProxyV2:
+			parsedRule.sendProxy = proxyProtocolV2
+		case proxyOptionAcceptProxy:
+			parsedRule.acceptProxy = true
+		default:
+			return emptyRule, fmt.Errorf("invalid proxy rule option (%s)", option)
+		}
+		if protocol != proxyProtocolTCP {
+			return emptyRule, fmt.Errorf("proxy rule option (%s) is only supported for tcp rules", option)
+		}
+	}
+
+	return parsedRule, nil
 }
@@ -0,0 +1,121 @@
+package nexodus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/client"
+)
+
+// SetReloadHandler sets the function that reloads the nexd configuration file when a reload
+// is requested with nexctl.
+func (nx *Nexodus) SetReloadHandler(handler func() error) {
+	nx.reloadHandler = handler
+}
+
+// SetConfiguredProxyRules replaces the proxy rules that came from the command line flags or the
+// config file. Proxies for new rules are started if nexd is already running. Rules added with
+// nexctl or through the API are left untouched.
+func (nx *Nexodus) SetConfiguredProxyRules(rules []ProxyRule) error {
+	for _, rule := range nx.configuredProxyRules {
+		if slices.Contains(rules, rule) {
+			continue
+		}
+		if _, err := nx.UserspaceProxyRemove(rule); err != nil {
+			return err
+		}
+		nx.logger.Infof("Removed %s proxy rule: %s", rule.ruleType, rule)
+	}
+
+	for _, rule := range rules {
+		if slices.Contains(nx.configuredProxyRules, rule) {
+			continue
+		}
+		proxy, err := nx.UserspaceProxyAdd(rule)
+		if err != nil {
+			if errors.Is(err, ProxyExistsError) {
+				continue
+			}
+			return fmt.Errorf("failed to add %s proxy rule (%s): %w", rule.ruleType, rule, err)
+		}
+		if nx.nexCtx != nil {
+			proxy.Start(nx.nexCtx, nx.nexWg, nx.userspaceNet)
+			nx.logger.Infof("Added %s proxy rule: %s", rule.ruleType, rule)
+		}
+	}
+
+	nx.configuredProxyRules = rules
+	return nil
+}
+
+// SetAdvertiseCidrs changes the CIDRs advertised by this device without restarting the tunnel.
+func (nx *Nexodus) SetAdvertiseCidrs(cidrs []string) error {
+	if slices.Equal(nx.advertiseCidrs, cidrs) {
+		return nil
+	}
+	if cidrs == nil {
+		// the api only updates the fields that are present, an empty list stops advertising CIDRs
+		cidrs = []string{}
+	}
+	if nx.offlineConfig() != nil {
+		return errOfflineConfig
+	}
+
+	_, _, err := nx.client.DevicesApi.UpdateDevice(context.Background(), nx.deviceId).Update(client.ModelsUpdateDevice{
+		AdvertiseCidrs: cidrs,
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to update the advertised CIDRs of this device: %w", err)
+	}
+
+	nx.deviceCacheLock.Lock()
+	nx.advertiseCidrs = cidrs
+	nx.deviceCacheLock.Unlock()
+	nx.logger.Infof("Advertising CIDRs: %v", cidrs)
+
+	if nx.networkRouter {
+		if err := nx.setupNetworkRouterNode(); err != nil {
+			return fmt.Errorf("failed to setup this device as a network router node: %w", err)
+		}
+	}
+
+	return nx.reconcileDeviceCache()
+}
+
+// SetExitNodeOrigin enables or disables this device as an exit node origin.
+func (nx *Nexodus) SetExitNodeOrigin(enabled bool) error {
+	if nx.exitNode.exitNodeOriginEnabled == enabled {
+		return nil
+	}
+	if enabled {
+		if err := nx.exitNodeOriginSetup(); err != nil {
+			return fmt.Errorf("failed to setup this device as an exit-node: %w", err)
+		}
+	} else if err := nx.exitNodeOriginTeardown(); err != nil {
+		return fmt.Errorf("failed to disable this device as an exit-node: %w", err)
+	}
+	nx.exitNode.exitNodeOriginEnabled = enabled
+	return nil
+}
+
+// SetExitNodeClient enables or disables the use of an exit node by this device.
+func (nx *Nexodus) SetExitNodeClient(enabled bool) error {
+	if nx.exitNode.exitNodeClientEnabled == enabled {
+		return nil
+	}
+	if enabled {
+		return nx.ExitNodeClientSetup()
+	}
+	if err := nx.exitNodeClientTeardown(); err != nil {
+		return err
+	}
+	nx.exitNode.exitNodeClientEnabled = false
+	if nx.exitNode.activeExitNode != "" {
+		nx.exitNode.activeExitNode = ""
+		nx.publishPeerEvent(api.NexdEventExitNodeChanged, deviceCacheEntry{})
+	}
+	return nx.reconcileDeviceCache()
+}
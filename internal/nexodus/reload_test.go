@@ -0,0 +1,45 @@
+package nexodus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSetConfiguredProxyRules(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+	nx := &Nexodus{
+		logger: zLogger.Sugar(),
+		userspaceWG: userspaceWG{
+			proxies: map[ProxyKey]*UsProxy{},
+		},
+	}
+
+	parse := func(rule string, proxyType ProxyType) ProxyRule {
+		r, err := ParseProxyRule(rule, proxyType)
+		require.NoError(err)
+		return r
+	}
+	web := parse("tcp:443:127.0.0.1:8443", ProxyTypeIngress)
+	dns := parse("udp:53:10.0.0.1:53", ProxyTypeEgress)
+	stored := parse("tcp:8080:127.0.0.1:80", ProxyTypeIngress)
+	stored.stored = true
+
+	_, err := nx.UserspaceProxyAdd(stored)
+	require.NoError(err)
+
+	require.NoError(nx.SetConfiguredProxyRules([]ProxyRule{web, dns}))
+	require.Len(nx.proxies, 3)
+
+	// rules dropped from the config are removed, stored rules are left alone
+	require.NoError(nx.SetConfiguredProxyRules([]ProxyRule{dns}))
+	require.Len(nx.proxies, 2)
+	require.Contains(nx.proxies, dns.ProxyKey)
+	require.Contains(nx.proxies, stored.ProxyKey)
+
+	require.NoError(nx.SetConfiguredProxyRules(nil))
+	require.Len(nx.proxies, 1)
+	require.Contains(nx.proxies, stored.ProxyKey)
+}
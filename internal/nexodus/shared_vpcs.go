@@ -0,0 +1,144 @@
+package nexodus
+
+import (
+	"context"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+)
+
+// sharedVpcs tracks the VPCs of other organizations this device is shared into.
+type sharedVpcs struct {
+	// the revision of this device when the shares were last listed, sharing the device or
+	// revoking a share bumps it.
+	revision int32
+	vpcs     map[string]*sharedVpc
+	changed  chan struct{}
+}
+
+// sharedVpc watches the devices of a VPC this device is shared into.
+type sharedVpc struct {
+	devicesInformer *client.ListInformer[client.ModelsDevice]
+	stop            context.CancelFunc
+}
+
+// resetSharedVpcs stops watching the VPCs this device is shared into, they are listed
+// again by the next reconcile.
+func (nx *Nexodus) resetSharedVpcs() {
+	for _, vpc := range nx.sharedVpcs.vpcs {
+		vpc.stop()
+	}
+	nx.sharedVpcs.vpcs = nil
+	nx.sharedVpcs.revision = 0
+}
+
+// sharedDevicesChanged returns a channel signaled when the devices of a VPC this device
+// is shared into change.
+func (nx *Nexodus) sharedDevicesChanged() <-chan struct{} {
+	return nx.sharedVpcs.changed
+}
+
+// reconcileSharedVpcs starts watching the devices of the VPCs this device was shared into
+// and stops watching the ones it is no longer shared into.
+func (nx *Nexodus) reconcileSharedVpcs(ctx context.Context) error {
+	if nx.deviceId == "" || nx.informerCtx == nil || nx.offlineConfig() != nil {
+		return nil
+	}
+	defer nx.metrics.observeReconcile("shared_vpcs", time.Now())
+
+	peerMap, _, err := nx.devicesInformer.Execute()
+	if err != nil {
+		return err
+	}
+	self, ok := peerMap[nx.deviceId]
+	if !ok || self.GetRevision() == nx.sharedVpcs.revision {
+		return nil
+	}
+	shares, _, err := nx.client.DevicesApi.ListDeviceShares(ctx, nx.deviceId).Execute()
+	if err != nil {
+		nx.metrics.apiError("list_device_shares")
+		return err
+	}
+
+	vpcs := map[string]*sharedVpc{}
+	for _, share := range shares {
+		vpcId := share.GetVpcId()
+		if vpc, ok := nx.sharedVpcs.vpcs[vpcId]; ok {
+			vpcs[vpcId] = vpc
+			continue
+		}
+		nx.logger.Infof("This device is shared into VPC %s", vpcId)
+		// each VPC gets its own watch, so that it can be stopped when the share is revoked
+		informerCtx, stop := context.WithCancel(nx.informerCtx)
+		vpc := &sharedVpc{
+			devicesInformer: nx.client.VPCApi.ListDevicesInVPC(informerCtx, vpcId).Informer(),
+			stop:            stop,
+		}
+		go func() {
+			for {
+				select {
+				case <-informerCtx.Done():
+					return
+				case <-vpc.devicesInformer.Changed():
+					select {
+					case nx.sharedVpcs.changed <- struct{}{}:
+					default: // so we don't block if a signal is pending.
+					}
+				}
+			}
+		}()
+		vpcs[vpcId] = vpc
+	}
+	for vpcId, vpc := range nx.sharedVpcs.vpcs {
+		if _, ok := vpcs[vpcId]; !ok {
+			nx.logger.Infof("This device is no longer shared into VPC %s", vpcId)
+			vpc.stop()
+		}
+	}
+	nx.sharedVpcs.vpcs = vpcs
+	nx.sharedVpcs.revision = self.GetRevision()
+	return nil
+}
+
+// listSharedDevices adds the devices of the VPCs this device is shared into to the devices
+// of its VPC. Only their tunnel IPs are routed: the routes they advertise and the relays of
+// their VPC only apply to their own VPC.
+func (nx *Nexodus) listSharedDevices(devices map[string]client.ModelsDevice) (map[string]client.ModelsDevice, error) {
+	if len(nx.sharedVpcs.vpcs) == 0 {
+		return devices, nil
+	}
+	merged := make(map[string]client.ModelsDevice, len(devices))
+	for id, d := range devices {
+		merged[id] = d
+	}
+	for _, vpc := range nx.sharedVpcs.vpcs {
+		shared, _, err := vpc.devicesInformer.Execute()
+		if err != nil {
+			return nil, err
+		}
+		for id, d := range shared {
+			if _, ok := merged[id]; ok {
+				continue
+			}
+			merged[id] = sharedVpcDevice(d)
+		}
+	}
+	return merged, nil
+}
+
+func sharedVpcDevice(d client.ModelsDevice) client.ModelsDevice {
+	d.AdvertiseCidrs = nil
+	d.ApprovedCidrs = nil
+	d.RejectedCidrs = nil
+	d.Relay = client.PtrBool(false)
+	d.Hub = client.PtrBool(false)
+	d.PeeringGroups = nil
+	d.AllowedIps = nil
+	for _, ip := range d.Ipv4TunnelIps {
+		d.AllowedIps = append(d.AllowedIps, ip.GetAddress()+"/32")
+	}
+	for _, ip := range d.Ipv6TunnelIps {
+		d.AllowedIps = append(d.AllowedIps, ip.GetAddress()+"/128")
+	}
+	return d
+}
@@ -0,0 +1,92 @@
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/api"
+	"github.com/nexodus-io/nexodus/internal/client"
+)
+
+// subnetState tracks the subnet of the VPC this device joined.
+type subnetState struct {
+	// requested is the name or ID of the subnet passed with --subnet
+	requested string
+	// subnet is nil while the device is not in a subnet
+	subnet *client.ModelsSubnet
+	// deviceRevision is the revision of the device the subnet was last fetched at
+	deviceRevision int32
+}
+
+// requestedSubnetId resolves the subnet passed with --subnet to its ID in the VPC this device joins.
+func (nx *Nexodus) requestedSubnetId(ctx context.Context, vpcId string) (string, error) {
+	requested := nx.subnets.requested
+	if requested == "" {
+		return "", nil
+	}
+	if _, err := uuid.Parse(requested); err == nil {
+		return requested, nil
+	}
+	subnets, _, err := nx.client.VPCApi.ListSubnets(ctx, vpcId).Execute()
+	if err != nil {
+		return "", fmt.Errorf("could not list the subnets of the VPC: %w", err)
+	}
+	for _, subnet := range subnets {
+		if subnet.GetName() == requested {
+			return subnet.GetId(), nil
+		}
+	}
+	return "", fmt.Errorf("subnet %s not found in VPC %s", requested, vpcId)
+}
+
+// refreshSubnet gets the subnet of this device again when the device changed, which happens when
+// the settings of the subnet are updated, and publishes a subnet-changed event when they differ.
+func (nx *Nexodus) refreshSubnet(ctx context.Context) error {
+	if nx.deviceId == "" || nx.offlineConfig() != nil {
+		return nil
+	}
+	peerMap, _, err := nx.devicesInformer.Execute()
+	if err != nil {
+		return err
+	}
+	device, found := peerMap[nx.deviceId]
+	if !found || device.GetRevision() == nx.subnets.deviceRevision {
+		return nil
+	}
+
+	var subnet *client.ModelsSubnet
+	if device.GetSubnetId() != "" {
+		subnet, _, err = nx.client.VPCApi.GetSubnet(ctx, device.GetVpcId(), device.GetSubnetId()).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	nx.subnets.deviceRevision = device.GetRevision()
+	if reflect.DeepEqual(subnet, nx.subnets.subnet) {
+		return nil
+	}
+
+	nx.deviceCacheLock.Lock()
+	nx.subnets.subnet = subnet
+	nx.deviceCacheLock.Unlock()
+	if subnet == nil {
+		nx.logger.Info("This device is no longer in a subnet")
+	} else {
+		nx.logger.Infof("Using the settings of subnet %s", subnet.GetName())
+	}
+	nx.events.publish(api.NexdEvent{
+		Type:             api.NexdEventSubnetChanged,
+		Subnet:           subnet.GetName(),
+		DnsServers:       subnet.GetDnsServers(),
+		DnsSearchDomains: subnet.GetDnsSearchDomains(),
+	})
+	return nil
+}
+
+// subnetRelayDeviceId returns the ID of the relay the subnet of this device prefers, if any.
+// assumes deviceCacheLock is held.
+func (nx *Nexodus) subnetRelayDeviceId() string {
+	return nx.subnets.subnet.GetRelayDeviceId()
+}
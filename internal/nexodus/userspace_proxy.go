@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/nexodus-io/nexodus/internal/state"
 	"io"
 	"net"
@@ -28,6 +29,7 @@ type UsProxy struct {
 	mu                sync.RWMutex
 	rules             []ProxyRule
 	connectionCounter uint64
+	activeConnections int64
 	userspaceNet      *netstack.Net
 	proxyCtx          context.Context
 	proxyCancel       context.CancelFunc
@@ -58,15 +60,19 @@ func (nx *Nexodus) UserspaceProxyAdd(newRule ProxyRule) (*UsProxy, error) {
 		nx.proxies[newRule.ProxyKey] = proxy
 	}
 
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+
 	for _, rule := range proxy.rules {
 		if rule == newRule {
 			return proxy, ProxyExistsError
 		}
+		// All rules sharing a listener must agree on whether clients send a PROXY header.
+		if rule.acceptProxy != newRule.acceptProxy {
+			return nil, fmt.Errorf("%s proxy rule %s conflicts with existing rule %s: %s must be set on all rules for the same port", newRule.ruleType, newRule, rule, proxyOptionAcceptProxy)
+		}
 	}
 
-	proxy.mu.Lock()
-	defer proxy.mu.Unlock()
-
 	proxy.rules = append(proxy.rules, newRule)
 	return proxy, nil
 }
@@ -157,6 +163,104 @@ func (nx *Nexodus) StoreProxyRules() error {
 	return nx.stateStore.Store()
 }
 
+// proxyRulesChanged returns the change channel of the device proxy rules informer, or
+// nil when nexd is not running in proxy mode so that selecting on it never fires.
+func (nx *Nexodus) proxyRulesChanged() <-chan struct{} {
+	if nx.proxyRulesInformer == nil {
+		return nil
+	}
+	return nx.proxyRulesInformer.Changed()
+}
+
+// proxyRuleFromModel converts a proxy rule published through the API into a local ProxyRule.
+func proxyRuleFromModel(item client.ModelsProxyRule) (ProxyRule, error) {
+	var ruleType ProxyType
+	switch item.GetType() {
+	case "ingress":
+		ruleType = ProxyTypeIngress
+	case "egress":
+		ruleType = ProxyTypeEgress
+	default:
+		return ProxyRule{}, fmt.Errorf("invalid proxy rule type (%s)", item.GetType())
+	}
+
+	ruleStr := fmt.Sprintf("%s:%d:%s", item.GetProtocol(), item.GetListenPort(),
+		net.JoinHostPort(item.GetDestinationHost(), strconv.Itoa(int(item.GetDestinationPort()))))
+	if item.GetAcceptProxyProtocol() {
+		ruleStr += "/" + proxyOptionAcceptProxy
+	}
+	if item.GetSendProxyProtocol() != "" {
+		ruleStr += "/send-proxy-" + item.GetSendProxyProtocol()
+	}
+
+	rule, err := ParseProxyRule(ruleStr, ruleType)
+	if err != nil {
+		return ProxyRule{}, err
+	}
+	rule.apiRuleId = item.GetId()
+	return rule, nil
+}
+
+// reconcileProxyRules applies the proxy rules published for this device through the API.
+// Rules added locally with nexctl or the command line are left untouched.
+func (nx *Nexodus) reconcileProxyRules(ctx context.Context, wg *sync.WaitGroup) {
+	if nx.proxyRulesInformer == nil {
+		return
+	}
+	defer nx.metrics.observeReconcile("proxy_rules", time.Now())
+
+	items, _, err := nx.proxyRulesInformer.Execute()
+	if err != nil {
+		nx.metrics.apiError("list_proxy_rules")
+		nx.logger.Errorf("Error retrieving the device proxy rules: %v", err)
+		return
+	}
+
+	desired := map[string]ProxyRule{}
+	for id, item := range items {
+		rule, err := proxyRuleFromModel(item)
+		if err != nil {
+			nx.logger.Warnf("Ignoring invalid proxy rule %s: %v", id, err)
+			continue
+		}
+		desired[id] = rule
+	}
+
+	current := map[string]ProxyRule{}
+	nx.proxyLock.RLock()
+	for _, proxy := range nx.proxies {
+		proxy.mu.RLock()
+		for _, rule := range proxy.rules {
+			if rule.apiRuleId != "" {
+				current[rule.apiRuleId] = rule
+			}
+		}
+		proxy.mu.RUnlock()
+	}
+	nx.proxyLock.RUnlock()
+
+	for id, rule := range current {
+		if desiredRule, found := desired[id]; found && desiredRule == rule {
+			continue
+		}
+		if _, err := nx.UserspaceProxyRemove(rule); err != nil {
+			nx.logger.Errorf("Failed to remove %s proxy rule (%s): %v", rule.ruleType, rule, err)
+		}
+	}
+
+	for id, rule := range desired {
+		if currentRule, found := current[id]; found && currentRule == rule {
+			continue
+		}
+		proxy, err := nx.UserspaceProxyAdd(rule)
+		if err != nil {
+			nx.logger.Errorf("Failed to add %s proxy rule (%s): %v", rule.ruleType, rule, err)
+			continue
+		}
+		proxy.Start(ctx, wg, nx.userspaceNet)
+	}
+}
+
 func (proxy *UsProxy) Start(ctx context.Context, wg *sync.WaitGroup, net *netstack.Net) {
 	proxy.mu.Lock()
 	defer proxy.mu.Unlock()
@@ -187,6 +291,10 @@ func (proxy *UsProxy) Start(ctx context.Context, wg *sync.WaitGroup, net *netsta
 }
 
 func (proxy *UsProxy) Stop() {
+	if proxy.proxyCancel == nil {
+		// the proxy was never started
+		return
+	}
 	proxy.proxyCancel()
 	proxy.wg.Wait()
 }
@@ -292,6 +400,9 @@ func (proxy *UsProxy) runUDP(ctx context.Context, proxyWg *sync.WaitGroup) error
 
 	buffer := make([]byte, udpMaxPayloadSize)
 	proxyConns := make(map[string]*udpProxyConn)
+	defer func() {
+		atomic.AddInt64(&proxy.activeConnections, -int64(len(proxyConns)))
+	}()
 	var clientAddr *net.UDPAddr
 	var n int
 	// a channel for being notified when a proxy connection is to be closed
@@ -317,6 +428,7 @@ func (proxy *UsProxy) runUDP(ctx context.Context, proxyWg *sync.WaitGroup) error
 				_ = proxyConn.proxyConn.Close()
 			}
 			delete(proxyConns, clientAddrStr)
+			atomic.AddInt64(&proxy.activeConnections, -1)
 		default:
 			// read a packet from the originator sent to the proxy
 			if err = udpProxy.setReadDeadline(); err != nil {
@@ -348,6 +460,7 @@ func (proxy *UsProxy) runUDP(ctx context.Context, proxyWg *sync.WaitGroup) error
 					continue
 				}
 				proxyConns[clientAddr.String()] = proxyConn
+				atomic.AddInt64(&proxy.activeConnections, 1)
 			}
 
 			// forward the original packet to the destination
@@ -414,13 +527,25 @@ func (proxyConn *udpProxyConn) writeBackToOriginator(buffer []byte, n int) error
 }
 
 func (proxy *UsProxy) NextDest() HostPort {
+	return proxy.nextRule().dest
+}
+
+func (proxy *UsProxy) nextRule() ProxyRule {
 	proxy.mu.RLock()
 	defer proxy.mu.RUnlock()
 
 	counter := atomic.AddUint64(&proxy.connectionCounter, 1)
 
 	index := counter % uint64(len(proxy.rules))
-	return proxy.rules[index].dest
+	return proxy.rules[index]
+}
+
+// acceptsProxyHeader returns true if clients of this proxy's listener are
+// expected to send a PROXY protocol header.
+func (proxy *UsProxy) acceptsProxyHeader() bool {
+	proxy.mu.RLock()
+	defer proxy.mu.RUnlock()
+	return len(proxy.rules) > 0 && proxy.rules[0].acceptProxy
 }
 
 func (proxy *UsProxy) createUDPProxyConn(ctx context.Context, proxyWg *sync.WaitGroup, proxyConn *udpProxyConn) error {
@@ -572,12 +697,27 @@ func (proxy *UsProxy) runTCP(ctx context.Context, proxyWg *sync.WaitGroup) error
 
 func (proxy *UsProxy) handleTCPConnection(ctx context.Context, proxyWg *sync.WaitGroup, inConn net.Conn) error {
 	defer util.IgnoreError(inConn.Close)
+	atomic.AddInt64(&proxy.activeConnections, 1)
+	defer atomic.AddInt64(&proxy.activeConnections, -1)
+
+	// The original addresses of the connection, passed on to the destination
+	// if the rule sends a PROXY protocol header.
+	addrs := proxyAddrsFromConn(inConn)
+	if proxy.acceptsProxyHeader() {
+		ppConn, err := acceptProxyHeader(inConn)
+		if err != nil {
+			return fmt.Errorf("failed to read PROXY protocol header: %w", err)
+		}
+		inConn = ppConn
+		addrs = ppConn.addrs
+	}
 
-	dest := proxy.NextDest()
+	rule := proxy.nextRule()
+	dest := rule.dest
 	logger := proxy.logger.With("dest", dest)
 
 	proxyDest := net.JoinHostPort(dest.host, fmt.Sprintf("%d", dest.port))
-	logger.Debugf("Handling connection from %s, proxying to %s", inConn.RemoteAddr().String(), proxyDest)
+	logger.Debugf("Handling connection from %s, proxying to %s", addrs.src, proxyDest)
 
 	var outConn net.Conn
 	var err error
@@ -592,6 +732,10 @@ func (proxy *UsProxy) handleTCPConnection(ctx context.Context, proxyWg *sync.Wai
 	}
 	defer util.IgnoreError(outConn.Close)
 
+	if err = writeProxyHeader(outConn, rule.sendProxy, addrs); err != nil {
+		return fmt.Errorf("failed to write PROXY protocol header: %w", err)
+	}
+
 	util.GoWithWaitGroup(proxyWg, func() {
 		_, err := io.Copy(inConn, outConn)
 		if err != nil {
@@ -146,3 +146,13 @@ func isElevated() (bool, error) {
 	}
 	return true, nil
 }
+
+// routeTableDump returns the routing tables of the host
+func routeTableDump() (string, error) {
+	return RunCommand("netstat", "-r", "-n")
+}
+
+// firewallDump returns the pf rules of the host
+func firewallDump() (string, error) {
+	return RunCommand("pfctl", "-s", "rules")
+}
@@ -238,3 +238,21 @@ func isElevated() (bool, error) {
 	}
 	return true, nil
 }
+
+// routeTableDump returns the IPv4 and IPv6 routing tables of the host
+func routeTableDump() (string, error) {
+	v4, err := RunCommand("ip", "-4", "route", "show", "table", "all")
+	if err != nil {
+		return "", err
+	}
+	v6, err := RunCommand("ip", "-6", "route", "show", "table", "all")
+	if err != nil {
+		return v4, err
+	}
+	return v4 + v6, nil
+}
+
+// firewallDump returns the nftables ruleset of the host
+func firewallDump() (string, error) {
+	return RunCommand("nft", "list", "ruleset")
+}
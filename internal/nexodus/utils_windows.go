@@ -105,3 +105,13 @@ func isElevated() (bool, error) {
 	}
 	return true, nil
 }
+
+// routeTableDump returns the routing tables of the host
+func routeTableDump() (string, error) {
+	return RunCommand("route", "print")
+}
+
+// firewallDump not currently implemented for windows
+func firewallDump() (string, error) {
+	return "", fmt.Errorf("method currently unsupported for windows")
+}
@@ -3,6 +3,7 @@ package nexodus
 import (
 	"errors"
 	"fmt"
+	"github.com/nexodus-io/nexodus/internal/api"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"net"
 )
@@ -25,6 +26,7 @@ func (nx *Nexodus) DeployWireguardConfig(updatedPeers map[string]client.ModelsDe
 		if err := nx.setupInterface(); err != nil {
 			return err
 		}
+		nx.publishTunnelEvent(api.NexdEventTunnelUp)
 	}
 
 	// keep track of the last error that occurred during config setup which can be returned at the end
@@ -2,11 +2,13 @@ package nexodus
 
 import (
 	"fmt"
+	"github.com/nexodus-io/nexodus/internal/api"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"net"
 	"net/netip"
 	"reflect"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -118,6 +120,9 @@ func (nx *Nexodus) peeringReset(d *deviceCacheEntry) {
 	nx.logger.Debugf("Resetting peer configuration - Peer AllowedIps [ %s ] Peer Public Key [ %s ]",
 		strings.Join(d.device.AllowedIps, ", "), d.device.GetPublicKey())
 
+	if d.peerHealthy {
+		nx.publishPeerEvent(api.NexdEventPeerDown, *d)
+	}
 	d.peeringMethod = peeringMethodNone
 	// By setting the peering method index to -1, we will consider all other
 	// methods that may be available.
@@ -162,10 +167,7 @@ func (nx *Nexodus) shouldResetPeering(d *deviceCacheEntry, reflexiveIP4 string,
 func (nx *Nexodus) rebuildPeerConfig(d *deviceCacheEntry, healthyRelay bool, wgRelayAvailable bool) (wgPeerConfig, string, int) {
 	localIP, reflexiveIP4 := nx.extractLocalAndReflexiveIP(d.device)
 	peerPort := nx.extractPeerPort(localIP)
-	relayAllowedIP := []string{
-		nx.vpc.GetIpv4Cidr(),
-		nx.vpc.GetIpv6Cidr(),
-	}
+	relayAllowedIP := nx.vpcCidrs()
 
 	tryNextMethod := nx.peeringFailed(*d, healthyRelay)
 	if tryNextMethod {
@@ -197,7 +199,10 @@ func (nx *Nexodus) rebuildPeerConfig(d *deviceCacheEntry, healthyRelay bool, wgR
 			// We are already set up to use a relay for this peer
 			break
 		}
-		peer = method.buildPeerConfig(nx, d.device, relayAllowedIP, localIP, peerPort, reflexiveIP4)
+		// only route the advertised CIDRs this peer has been elected the router for
+		device := d.device
+		device.AdvertiseCidrs = nx.routedAdvertiseCidrs(d.device)
+		peer = method.buildPeerConfig(nx, device, relayAllowedIP, localIP, peerPort, reflexiveIP4)
 		chosenMethod = method.name
 		chosenMethodIndex = i
 		break
@@ -227,15 +232,27 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 	relayAvailable := false
 	isDerpRelay := false
 	var relayDevice deviceCacheEntry
+	// prefer a healthy relay, then the relay of the subnet of this device
+	preferredRelay := nx.subnetRelayDeviceId()
+	betterRelay := func(d deviceCacheEntry) bool {
+		if !relayAvailable {
+			return true
+		}
+		if d.peerHealthy != healthyRelay {
+			return d.peerHealthy
+		}
+		return d.device.GetId() == preferredRelay
+	}
 	for _, d := range nx.deviceCache {
-		if d.device.GetRelay() {
-			relayAvailable = true
-			relayDevice = d
-			isDerpRelay = nx.derpRelay(d)
-			if d.peerHealthy {
-				healthyRelay = true
-				break
-			}
+		if !d.device.GetRelay() || !betterRelay(d) {
+			continue
+		}
+		relayAvailable = true
+		relayDevice = d
+		isDerpRelay = nx.derpRelay(d)
+		healthyRelay = d.peerHealthy
+		if healthyRelay && (preferredRelay == "" || d.device.GetId() == preferredRelay) {
+			break
 		}
 	}
 
@@ -308,6 +325,8 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 
 	}
 
+	nx.cidrRouters = nx.electCidrRouters()
+
 	now := time.Now()
 	wgRelayAvailable := relayAvailable && !isDerpRelay
 	for _, dIter := range nx.deviceCache {
@@ -316,6 +335,10 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 		if d.device.GetPublicKey() == nx.wireguardPubKey {
 			continue
 		}
+		// lazy peers are only configured once traffic to or from them is seen
+		if nx.peerIsLazyInactive(d) {
+			continue
+		}
 
 		peerConfig, chosenMethod, chosenMethodIndex := nx.rebuildPeerConfig(&d, healthyRelay, wgRelayAvailable)
 		if len(peerConfig.AllowedIPsForRelay) > 0 {
@@ -338,8 +361,16 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 			nx.wgConfig.Peers[d.device.GetPublicKey()] = peerConfig
 		}
 		d.peeringMethodIndex = chosenMethodIndex
-		d.peeringMethod = chosenMethod
+		if d.peeringMethod != chosenMethod {
+			d.peeringMethod = chosenMethod
+			nx.publishPeerEvent(api.NexdEventPeeringMethodChanged, d)
+		}
 		d.peeringTime = now
+		d.peeringHistory = appendPeeringAttempt(d.peeringHistory, api.PeeringAttempt{
+			Method:   chosenMethod,
+			Endpoint: peerConfig.Endpoint,
+			Time:     now,
+		})
 		nx.deviceCache[d.device.GetPublicKey()] = d
 		nx.logPeerInfo(d.device, peerConfig.Endpoint, chosenMethod)
 	}
@@ -347,13 +378,136 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 	if healthyRelay && len(allowedIPsForRelay) > 0 {
 		// Add child prefix CIDRs to the relay for peers that we can only reach via the relay
 		relayConfig := nx.wgConfig.Peers[relayDevice.device.GetPublicKey()]
-		relayConfig.AllowedIPs = append([]string{nx.vpc.GetIpv4Cidr(), nx.vpc.GetIpv4Cidr()}, allowedIPsForRelay...)
+		relayConfig.AllowedIPs = append(nx.vpcCidrs(), allowedIPsForRelay...)
 		nx.wgConfig.Peers[relayDevice.device.GetPublicKey()] = relayConfig
 	}
 
 	return updatedPeers
 }
 
+// topologyPeers drops the devices of the VPC this device may not peer with given the topology of the VPC.
+// The apiserver only serves a device its peers, this also keeps the peers that are configured on demand and
+// the devices served before the topology changed from being peered with. The devices of the VPCs this device
+// is shared into are served limited to its peers by their apiserver, as their topology is not known here.
+func (nx *Nexodus) topologyPeers(devices map[string]client.ModelsDevice) map[string]client.ModelsDevice {
+	topology := nx.vpc.GetTopology()
+	if topology == "" || topology == vpcTopologyFullMesh {
+		return devices
+	}
+	self, ok := devices[nx.deviceId]
+	if !ok {
+		return devices
+	}
+	peers := make(map[string]client.ModelsDevice, len(devices))
+	for id, d := range devices {
+		if id == nx.deviceId || peeringAllowed(topology, self, d) {
+			peers[id] = d
+		}
+	}
+	return peers
+}
+
+const (
+	vpcTopologyFullMesh      = "full-mesh"
+	vpcTopologyPeeringGroups = "peering-groups"
+)
+
+// peeringAllowed returns whether two devices of a VPC with the given topology may peer, as the apiserver
+// decides it: the relays and hubs peer with every device, and with the peering-groups topology the devices
+// sharing a peering group peer with each other.
+func peeringAllowed(topology string, a, b client.ModelsDevice) bool {
+	if topology == "" || topology == vpcTopologyFullMesh {
+		return true
+	}
+	if a.GetRelay() || b.GetRelay() || a.GetHub() || b.GetHub() {
+		return true
+	}
+	if topology == vpcTopologyPeeringGroups {
+		for _, group := range a.GetPeeringGroups() {
+			if slices.Contains(b.GetPeeringGroups(), group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// electCidrRouters elects a router for every CIDR advertised by more than one peer. These peers
+// form a router HA group: online routers are preferred, then routers we are healthily peered with,
+// then the highest router priority. Remaining ties are broken by public key. The health of the
+// peerings is only known locally, so peers agree on the same router as long as they see the same
+// routers healthy, and otherwise each peer routes around the routers it cannot reach. Returns a map
+// of CIDRs to the public key of the elected router.
+// assumes deviceCacheLock is held.
+func (nx *Nexodus) electCidrRouters() map[string]string {
+	groups := map[string][]deviceCacheEntry{}
+	for _, d := range nx.deviceCache {
+		if d.device.GetPublicKey() == nx.wireguardPubKey {
+			continue
+		}
+		for _, cidr := range d.device.AdvertiseCidrs {
+			if !slices.Contains(d.device.ApprovedCidrs, cidr) {
+				continue
+			}
+			groups[cidr] = append(groups[cidr], d)
+		}
+	}
+
+	routers := map[string]string{}
+	for cidr, group := range groups {
+		if slices.Contains(nx.advertiseCidrs, cidr) {
+			// we are a router for this CIDR ourselves, so don't route it to any peer
+			routers[cidr] = nx.wireguardPubKey
+			continue
+		}
+		if len(group) < 2 {
+			continue
+		}
+		best := group[0]
+		for _, d := range group[1:] {
+			if betterCidrRouter(d, best) {
+				best = d
+			}
+		}
+		if prev, ok := nx.cidrRouters[cidr]; ok && prev != best.device.GetPublicKey() {
+			nx.logger.Infof("Failing over advertised CIDR [ %s ] to router [ %s ] hostname [ %s ]", cidr, best.device.GetPublicKey(), best.device.GetHostname())
+		}
+		routers[cidr] = best.device.GetPublicKey()
+	}
+	return routers
+}
+
+// betterCidrRouter returns true if router a should be preferred over router b.
+func betterCidrRouter(a, b deviceCacheEntry) bool {
+	if a.device.GetOnline() != b.device.GetOnline() {
+		return a.device.GetOnline()
+	}
+	if a.peerHealthy != b.peerHealthy {
+		return a.peerHealthy
+	}
+	if a.device.GetRouterPriority() != b.device.GetRouterPriority() {
+		return a.device.GetRouterPriority() > b.device.GetRouterPriority()
+	}
+	return a.device.GetPublicKey() < b.device.GetPublicKey()
+}
+
+// routedAdvertiseCidrs returns the advertised CIDRs of the device that should be routed to it,
+// leaving out the CIDRs that have not been approved and the CIDRs another router of its HA group
+// has been elected for.
+func (nx *Nexodus) routedAdvertiseCidrs(device client.ModelsDevice) []string {
+	var cidrs []string
+	for _, cidr := range device.AdvertiseCidrs {
+		if !slices.Contains(device.ApprovedCidrs, cidr) {
+			continue
+		}
+		if router, ok := nx.cidrRouters[cidr]; ok && router != device.GetPublicKey() {
+			continue
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs
+}
+
 func (nx *Nexodus) peeringFailed(d deviceCacheEntry, healthyRelay bool) bool {
 	if d.peerHealthy {
 		return false
@@ -539,8 +693,13 @@ func (nx *Nexodus) buildLocalConfig() {
 			}
 		}
 	}
+	tunnelIPChanged := nx.TunnelIP != "" && (nx.TunnelIP != d.device.Ipv4TunnelIps[0].GetAddress() ||
+		nx.TunnelIpV6 != d.device.Ipv6TunnelIps[0].GetAddress())
 	nx.TunnelIP = d.device.Ipv4TunnelIps[0].GetAddress()
 	nx.TunnelIpV6 = d.device.Ipv6TunnelIps[0].GetAddress()
+	if tunnelIPChanged {
+		nx.publishTunnelEvent(api.NexdEventTunnelIPChanged)
+	}
 	localInterface = wgLocalConfig{
 		nx.wireguardPvtKey,
 		nx.listenPort,
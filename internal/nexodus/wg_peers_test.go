@@ -312,6 +312,9 @@ func TestBuildPeersConfig(t *testing.T) {
 					AdvertiseCidrs: []string{
 						"192.168.50.0/24",
 					},
+					ApprovedCidrs: []string{
+						"192.168.50.0/24",
+					},
 				},
 			},
 			"peerViaRelayWithAdvertiseCidrs": {
@@ -331,6 +334,9 @@ func TestBuildPeersConfig(t *testing.T) {
 					AdvertiseCidrs: []string{
 						"192.168.40.0/24",
 					},
+					ApprovedCidrs: []string{
+						"192.168.40.0/24",
+					},
 				},
 			},
 			"theRelay": {
@@ -374,3 +380,155 @@ func TestBuildPeersConfig(t *testing.T) {
 	require.NotContains(nx.wgConfig.Peers, "peerViaRelayWithAdvertiseCidrs")
 	require.Contains(nx.wgConfig.Peers["theRelay"].AllowedIPs, "192.168.40.0/24")
 }
+
+func TestBuildPeersConfigSubnetRelay(t *testing.T) {
+	zLogger, _ := zap.NewDevelopment()
+	testLogger := zLogger.Sugar()
+	require := require.New(t)
+
+	relay := func(id string, healthy bool) deviceCacheEntry {
+		d := deviceCacheEntry{
+			device: client.ModelsDevice{
+				Id: client.PtrString(id),
+				Endpoints: []client.ModelsEndpoint{
+					{
+						Address: client.PtrString("3.3.3.3:4321"),
+						Source:  client.PtrString("stun"),
+					},
+				},
+				PublicKey: client.PtrString(id),
+				Relay:     client.PtrBool(true),
+			},
+		}
+		d.peerHealthy = healthy
+		return d
+	}
+	peerViaRelay := deviceCacheEntry{
+		device: client.ModelsDevice{
+			Endpoints: []client.ModelsEndpoint{
+				{
+					Address: client.PtrString("2.2.2.2:4321"),
+					Source:  client.PtrString("stun"),
+				},
+			},
+			PublicKey:      client.PtrString("peerViaRelay"),
+			SymmetricNat:   client.PtrBool(true),
+			AdvertiseCidrs: []string{"192.168.40.0/24"},
+			ApprovedCidrs:  []string{"192.168.40.0/24"},
+		},
+	}
+	peerViaRelay.peerHealthy = true
+
+	// the devices of the subnet route through its relay while it is healthy, whatever the order of the device cache
+	for _, preferredHealthy := range []bool{true, false} {
+		for i := 0; i < 10; i++ {
+			nx := &Nexodus{
+				vpc: &client.ModelsVPC{
+					Ipv4Cidr: client.PtrString("100.64.0.0/10"),
+				},
+				nodeReflexiveAddressIPv4: netip.MustParseAddrPort("1.1.1.1:1234"),
+				logger:                   testLogger,
+				deviceCache: map[string]deviceCacheEntry{
+					"relayA":       relay("relayA", true),
+					"relayB":       relay("relayB", preferredHealthy),
+					"relayC":       relay("relayC", true),
+					"peerViaRelay": peerViaRelay,
+				},
+				subnets: subnetState{
+					subnet: &client.ModelsSubnet{RelayDeviceId: client.PtrString("relayB")},
+				},
+			}
+			nx.buildPeersConfig()
+			if preferredHealthy {
+				require.Contains(nx.wgConfig.Peers["relayB"].AllowedIPs, "192.168.40.0/24")
+			} else {
+				require.NotContains(nx.wgConfig.Peers["relayB"].AllowedIPs, "192.168.40.0/24")
+			}
+		}
+	}
+}
+
+func TestElectCidrRouters(t *testing.T) {
+	zLogger, _ := zap.NewDevelopment()
+	require := require.New(t)
+
+	router := func(publicKey string, online bool, priority int32, cidrs ...string) deviceCacheEntry {
+		d := deviceCacheEntry{
+			device: client.ModelsDevice{
+				PublicKey:      client.PtrString(publicKey),
+				Online:         client.PtrBool(online),
+				RouterPriority: client.PtrInt32(priority),
+				AdvertiseCidrs: cidrs,
+				ApprovedCidrs:  cidrs,
+			},
+		}
+		d.peerHealthy = true
+		return d
+	}
+
+	nx := &Nexodus{
+		logger:          zLogger.Sugar(),
+		wireguardPubKey: "self",
+		advertiseCidrs:  []string{"10.30.0.0/16"},
+		deviceCache: map[string]deviceCacheEntry{
+			"primary": router("primary", true, 100, "10.10.0.0/16", "10.30.0.0/16"),
+			"backup":  router("backup", true, 50, "10.10.0.0/16"),
+			"single":  router("single", true, 0, "10.20.0.0/16"),
+		},
+	}
+
+	nx.cidrRouters = nx.electCidrRouters()
+	require.Equal("primary", nx.cidrRouters["10.10.0.0/16"])
+	require.NotContains(nx.cidrRouters, "10.20.0.0/16")
+	// we advertise this CIDR ourselves, so it is not routed to any peer
+	require.Equal("self", nx.cidrRouters["10.30.0.0/16"])
+	require.Equal([]string{"10.10.0.0/16"}, nx.routedAdvertiseCidrs(nx.deviceCache["primary"].device))
+	require.Empty(nx.routedAdvertiseCidrs(nx.deviceCache["backup"].device))
+	require.Equal([]string{"10.20.0.0/16"}, nx.routedAdvertiseCidrs(nx.deviceCache["single"].device))
+
+	// the online tracker marks the primary offline, so the backup takes over
+	nx.deviceCache["primary"] = router("primary", false, 100, "10.10.0.0/16", "10.30.0.0/16")
+	nx.cidrRouters = nx.electCidrRouters()
+	require.Equal("backup", nx.cidrRouters["10.10.0.0/16"])
+	require.Empty(nx.routedAdvertiseCidrs(nx.deviceCache["primary"].device))
+	require.Equal([]string{"10.10.0.0/16"}, nx.routedAdvertiseCidrs(nx.deviceCache["backup"].device))
+
+	// routes that have not been approved are not routed to the device
+	pending := nx.deviceCache["single"]
+	pending.device.AdvertiseCidrs = append(pending.device.AdvertiseCidrs, "10.40.0.0/16")
+	require.Equal([]string{"10.20.0.0/16"}, nx.routedAdvertiseCidrs(pending.device))
+}
+
+func TestTopologyPeers(t *testing.T) {
+	require := require.New(t)
+	device := func(id string, hub bool, groups ...string) client.ModelsDevice {
+		return client.ModelsDevice{Id: client.PtrString(id), Hub: client.PtrBool(hub), PeeringGroups: groups}
+	}
+	devices := map[string]client.ModelsDevice{
+		"self":  device("self", false, "a"),
+		"hub":   device("hub", true),
+		"group": device("group", false, "a", "b"),
+		"other": device("other", false, "b"),
+	}
+	peers := func(topology string) []string {
+		nx := &Nexodus{
+			deviceId: "self",
+			vpc:      &client.ModelsVPC{Topology: client.PtrString(topology)},
+		}
+		var ids []string
+		for id := range nx.topologyPeers(devices) {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	require.ElementsMatch([]string{"self", "hub", "group", "other"}, peers("full-mesh"))
+	require.ElementsMatch([]string{"self", "hub"}, peers("hub-and-spoke"))
+	require.ElementsMatch([]string{"self", "hub", "group"}, peers("peering-groups"))
+
+	// a relay peers with every device
+	relay := devices["other"]
+	relay.Relay = client.PtrBool(true)
+	devices["other"] = relay
+	require.ElementsMatch([]string{"self", "hub", "other"}, peers("hub-and-spoke"))
+}
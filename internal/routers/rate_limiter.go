@@ -0,0 +1,308 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	csmap "github.com/mhmtszr/concurrent-swiss-map"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// The classes of rate limited requests
+const (
+	RateLimitReads   = "reads"
+	RateLimitWrites  = "writes"
+	RateLimitWatches = "watches"
+	RateLimitLogins  = "logins"
+)
+
+const rateLimitPrefix = "ratelimit:"
+
+// rateLimitPeriod is the time it takes an empty token bucket to refill.
+const rateLimitPeriod = time.Minute
+
+// redisRetryBackoff is how long the requests are rate limited in memory after Redis fails before Redis
+// is tried again, so that the requests don't each wait for Redis to time out while it is unavailable.
+const redisRetryBackoff = 10 * time.Second
+
+// RateLimits are the number of requests per minute a client can make in each class of requests. A client
+// can burst up to a minute of requests at once. A rate limit of 0 is unlimited.
+type RateLimits struct {
+	Reads   int64
+	Writes  int64
+	Watches int64
+	Logins  int64
+}
+
+// Limit returns the rate limit of a class of requests.
+func (r RateLimits) Limit(class string) int64 {
+	switch class {
+	case RateLimitReads:
+		return r.Reads
+	case RateLimitWrites:
+		return r.Writes
+	case RateLimitWatches:
+		return r.Watches
+	case RateLimitLogins:
+		return r.Logins
+	}
+	return 0
+}
+
+// takeTokenScript takes a token from the bucket at KEYS[1], holding up to ARGV[1] tokens refilled over
+// ARGV[2] milliseconds, at time ARGV[3] in milliseconds. It returns whether a token was taken and the
+// tokens left in the bucket. A bucket left alone for the refill period is full, so it expires then.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+	updated = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * capacity / period)
+local taken = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	taken = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], period)
+return {taken, tostring(tokens)}
+`)
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter rate limits the requests of each client with token buckets. The buckets are kept in Redis
+// so that all the apiserver replicas share them, and in memory while Redis is unavailable.
+type RateLimiter struct {
+	logger    *zap.SugaredLogger
+	limits    RateLimits
+	redis     *redis.Client
+	buckets   *csmap.CsMap[string, memoryBucket]
+	lastSweep atomic.Int64
+	// redisRetryAt is when Redis is tried again in unix milliseconds after it failed, or 0 while it works
+	redisRetryAt atomic.Int64
+	// trustedProxies are the proxies the source address of a request is taken from the X-Forwarded-For header of
+	trustedProxies []netip.Prefix
+}
+
+// NewRateLimiter returns a rate limiter. The clients without a token are identified by the peer address of
+// the connection, or by the X-Forwarded-For header when the peer is one of trustedProxies.
+func NewRateLimiter(logger *zap.SugaredLogger, client *redis.Client, limits RateLimits, trustedProxies []netip.Prefix) *RateLimiter {
+	return &RateLimiter{
+		logger:         logger,
+		limits:         limits,
+		redis:          client,
+		trustedProxies: trustedProxies,
+		buckets: csmap.Create[string, memoryBucket](
+			csmap.WithShardCount[string, memoryBucket](2*uint64(runtime.GOMAXPROCS(-1))),
+			csmap.WithSize[string, memoryBucket](1000),
+		),
+	}
+}
+
+// Limit returns a middleware rate limiting each client in the class of requests returned by classify.
+// It sets the RateLimit-* headers on the responses, and fails the requests over the limit with an HTTP 429.
+func (l *RateLimiter) Limit(classify func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class := classify(c)
+		limit := l.limits.Limit(class)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		key := rateLimitPrefix + class + ":" + rateLimitClient(c, l.trustedProxies)
+		tokens, taken := l.take(c.Request.Context(), key, limit, time.Now())
+
+		perToken := rateLimitPeriod.Seconds() / float64(limit)
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit, int64(rateLimitPeriod.Seconds())))
+		c.Header("RateLimit-Limit", strconv.FormatInt(limit, 10))
+		c.Header("RateLimit-Remaining", strconv.FormatInt(int64(tokens), 10))
+		c.Header("RateLimit-Reset", strconv.FormatInt(int64(math.Ceil((float64(limit)-tokens)*perToken)), 10))
+		if !taken {
+			c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil((1-tokens)*perToken)), 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.NewBaseError("rate limit exceeded"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// LimitClass returns a middleware rate limiting each client in a class of requests.
+func (l *RateLimiter) LimitClass(class string) gin.HandlerFunc {
+	return l.Limit(func(*gin.Context) string {
+		return class
+	})
+}
+
+// apiRateLimitClass classifies the requests to the /api routes.
+func apiRateLimitClass(c *gin.Context) string {
+	switch {
+	case c.Request.Method == http.MethodPost && strings.HasSuffix(c.FullPath(), "/events"):
+		return RateLimitWatches
+	case c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead:
+		return RateLimitReads
+	}
+	return RateLimitWrites
+}
+
+// rateLimitClient identifies the client making a request: the device or the registration key of a nexodus
+// token, the subject of any other validated token, or else the source address of the request.
+func rateLimitClient(c *gin.Context, trustedProxies []netip.Prefix) string {
+	claims := c.GetStringMap("_nexodus.Claims")
+	id, _ := claims["jti"].(string)
+	scope, _ := claims["scope"].(string)
+	switch {
+	case scope == "device-token" && id != "":
+		return "device:" + id
+	case scope == "reg-token" && id != "":
+		return "reg-key:" + id
+	}
+	if subject, _ := claims["sub"].(string); subject != "" {
+		return "sub:" + subject
+	}
+	return "ip:" + rateLimitAddress(c, trustedProxies)
+}
+
+// rateLimitAddress returns the source address of a request: the peer address of the connection, or when
+// the peer is a trusted proxy, the last address of the X-Forwarded-For header that is not a trusted proxy.
+// The header is not trusted otherwise, since any client can set it.
+func rateLimitAddress(c *gin.Context, trustedProxies []netip.Prefix) string {
+	remote := c.RemoteIP()
+	addr, err := netip.ParseAddr(remote)
+	if err != nil || !containsAddr(trustedProxies, addr.Unmap()) {
+		return remote
+	}
+	forwarded := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			break
+		}
+		addr = hop.Unmap()
+		if !containsAddr(trustedProxies, addr) {
+			break
+		}
+	}
+	return addr.String()
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// take takes a token from a bucket holding up to limit tokens. It returns the tokens left in the
+// bucket, and whether a token was taken.
+func (l *RateLimiter) take(ctx context.Context, key string, limit int64, now time.Time) (float64, bool) {
+	if l.redis != nil && l.useRedis(now) {
+		tokens, taken, err := l.takeRedis(ctx, key, limit, now)
+		if err == nil {
+			if retryAt := l.redisRetryAt.Load(); retryAt != 0 && l.redisRetryAt.CompareAndSwap(retryAt, 0) {
+				l.logger.Info("rate limiting with redis again")
+			}
+			return tokens, taken
+		}
+		// warn once when redis becomes unavailable rather than on every request
+		if l.redisRetryAt.Swap(now.Add(redisRetryBackoff).UnixMilli()) == 0 {
+			l.logger.Warnf("failed to rate limit with redis, falling back to memory: %s", err)
+		}
+	}
+	return l.takeMemory(key, limit, now)
+}
+
+// useRedis returns whether to rate limit a request with Redis. Once Redis failed, a single request tries it
+// again after the backoff while the others keep being rate limited in memory.
+func (l *RateLimiter) useRedis(now time.Time) bool {
+	retryAt := l.redisRetryAt.Load()
+	if retryAt == 0 {
+		return true
+	}
+	return now.UnixMilli() >= retryAt && l.redisRetryAt.CompareAndSwap(retryAt, now.Add(redisRetryBackoff).UnixMilli())
+}
+
+func (l *RateLimiter) takeRedis(ctx context.Context, key string, limit int64, now time.Time) (float64, bool, error) {
+	result, err := takeTokenScript.Run(ctx, l.redis, []string{key}, limit, rateLimitPeriod.Milliseconds(), now.UnixMilli()).Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	if len(result) != 2 {
+		return 0, false, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+	taken, ok := result[0].(int64)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+	remaining, ok := result[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+	tokens, err := strconv.ParseFloat(remaining, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return tokens, taken == 1, nil
+}
+
+func (l *RateLimiter) takeMemory(key string, limit int64, now time.Time) (float64, bool) {
+	capacity := float64(limit)
+	var tokens float64
+	var taken bool
+	l.buckets.SetIf(key, func(bucket memoryBucket, found bool) (memoryBucket, bool) {
+		tokens = capacity
+		if found {
+			elapsed := math.Max(0, now.Sub(bucket.updated).Seconds())
+			tokens = math.Min(capacity, bucket.tokens+elapsed*capacity/rateLimitPeriod.Seconds())
+		}
+		if tokens >= 1 {
+			tokens--
+			taken = true
+		}
+		return memoryBucket{tokens: tokens, updated: now}, true
+	})
+	l.sweep(now)
+	return tokens, taken
+}
+
+// sweep deletes the buckets left alone for the refill period once per period, since they are full.
+func (l *RateLimiter) sweep(now time.Time) {
+	last := l.lastSweep.Load()
+	if now.Sub(time.UnixMilli(last)) < rateLimitPeriod || !l.lastSweep.CompareAndSwap(last, now.UnixMilli()) {
+		return
+	}
+	var idle []string
+	l.buckets.Range(func(key string, bucket memoryBucket) (stop bool) {
+		if now.Sub(bucket.updated) >= rateLimitPeriod {
+			idle = append(idle, key)
+		}
+		return false
+	})
+	for _, key := range idle {
+		l.buckets.DeleteIf(key, func(bucket memoryBucket) bool {
+			return now.Sub(bucket.updated) >= rateLimitPeriod
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimiterTakeMemory(t *testing.T) {
+	require := require.New(t)
+	limiter := NewRateLimiter(zap.NewNop().Sugar(), nil, RateLimits{}, nil)
+
+	now := time.Now()
+	tokens, taken := limiter.takeMemory("a", 2, now)
+	require.True(taken)
+	require.Equal(1.0, tokens)
+	_, taken = limiter.takeMemory("a", 2, now)
+	require.True(taken)
+	_, taken = limiter.takeMemory("a", 2, now)
+	require.False(taken)
+
+	// the buckets are separate
+	_, taken = limiter.takeMemory("b", 2, now)
+	require.True(taken)
+
+	// a bucket of 2 tokens refills one every 30 seconds
+	_, taken = limiter.takeMemory("a", 2, now.Add(29*time.Second))
+	require.False(taken)
+	_, taken = limiter.takeMemory("a", 2, now.Add(60*time.Second))
+	require.True(taken)
+
+	// idle buckets are full, so they are swept
+	limiter.takeMemory("c", 2, now.Add(3*time.Minute))
+	require.False(limiter.buckets.Has("a"))
+	require.False(limiter.buckets.Has("b"))
+	require.True(limiter.buckets.Has("c"))
+}
+
+func TestRateLimiterTakeRedis(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := redis.NewClient(&redis.Options{
+		Addr:             "localhost:6379",
+		DisableIndentity: true,
+	})
+	defer client.Close()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis is unavailable: %s", err)
+	}
+	limiter := NewRateLimiter(zap.NewNop().Sugar(), client, RateLimits{}, nil)
+
+	key := rateLimitPrefix + "test:" + t.Name()
+	require.NoError(client.Del(ctx, key).Err())
+	defer client.Del(ctx, key)
+
+	now := time.Now()
+	tokens, taken, err := limiter.takeRedis(ctx, key, 2, now)
+	require.NoError(err)
+	require.True(taken)
+	require.Equal(1.0, tokens)
+	_, taken, err = limiter.takeRedis(ctx, key, 2, now)
+	require.NoError(err)
+	require.True(taken)
+	tokens, taken, err = limiter.takeRedis(ctx, key, 2, now)
+	require.NoError(err)
+	require.False(taken)
+	require.Equal(0.0, tokens)
+
+	// a bucket of 2 tokens refills one every 30 seconds
+	_, taken, err = limiter.takeRedis(ctx, key, 2, now.Add(29*time.Second))
+	require.NoError(err)
+	require.False(taken)
+	_, taken, err = limiter.takeRedis(ctx, key, 2, now.Add(60*time.Second))
+	require.NoError(err)
+	require.True(taken)
+
+	// the bucket expires once it is full
+	ttl, err := client.PTTL(ctx, key).Result()
+	require.NoError(err)
+	require.Greater(ttl, time.Duration(0))
+	require.LessOrEqual(ttl, rateLimitPeriod)
+}
+
+func TestRateLimiterRedisBackoff(t *testing.T) {
+	require := require.New(t)
+
+	client := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+	core, logs := observer.New(zapcore.WarnLevel)
+	limiter := NewRateLimiter(zap.New(core).Sugar(), client, RateLimits{}, nil)
+
+	now := time.Now()
+	_, taken := limiter.take(context.Background(), "a", 2, now)
+	require.True(taken)
+	require.Equal(1, logs.Len())
+	require.False(limiter.useRedis(now.Add(redisRetryBackoff - time.Second)))
+
+	// redis is tried again after the backoff, without warning again
+	require.True(limiter.useRedis(now.Add(redisRetryBackoff)))
+	require.False(limiter.useRedis(now.Add(redisRetryBackoff)))
+	_, taken = limiter.take(context.Background(), "a", 2, now.Add(2*redisRetryBackoff))
+	require.True(taken)
+	require.Equal(1, logs.Len())
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	require := require.New(t)
+	gin.SetMode(gin.TestMode)
+
+	// redis is unavailable, so the limiter falls back to memory
+	client := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+	limiter := NewRateLimiter(zap.NewNop().Sugar(), client, RateLimits{Reads: 2, Writes: 1}, nil)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if subject := c.GetHeader("X-Subject"); subject != "" {
+			c.Set("_nexodus.Claims", map[string]interface{}{"sub": subject})
+		}
+	})
+	r.Use(limiter.Limit(apiRateLimitClass))
+	r.GET("/api/vpcs", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	r.POST("/api/vpcs", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	r.POST("/api/events", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(method, path, subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Subject", subject)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		return res
+	}
+
+	res := serve(http.MethodGet, "/api/vpcs", "alice")
+	require.Equal(http.StatusOK, res.Code)
+	require.Equal("2;w=60", res.Header().Get("RateLimit-Policy"))
+	require.Equal("2", res.Header().Get("RateLimit-Limit"))
+	require.Equal("1", res.Header().Get("RateLimit-Remaining"))
+	require.Equal("30", res.Header().Get("RateLimit-Reset"))
+
+	res = serve(http.MethodGet, "/api/vpcs", "alice")
+	require.Equal(http.StatusOK, res.Code)
+	require.Equal("0", res.Header().Get("RateLimit-Remaining"))
+
+	res = serve(http.MethodGet, "/api/vpcs", "alice")
+	require.Equal(http.StatusTooManyRequests, res.Code)
+	require.Equal("30", res.Header().Get("Retry-After"))
+	require.JSONEq(`{"error":"rate limit exceeded"}`, res.Body.String())
+
+	// the classes and the clients are limited separately
+	require.Equal(http.StatusCreated, serve(http.MethodPost, "/api/vpcs", "alice").Code)
+	require.Equal(http.StatusTooManyRequests, serve(http.MethodPost, "/api/vpcs", "alice").Code)
+	require.Equal(http.StatusOK, serve(http.MethodGet, "/api/vpcs", "bob").Code)
+	require.Equal(http.StatusOK, serve(http.MethodGet, "/api/vpcs", "").Code)
+
+	// watches are unlimited
+	for i := 0; i < 3; i++ {
+		res = serve(http.MethodPost, "/api/events", "alice")
+		require.Equal(http.StatusOK, res.Code)
+		require.Empty(res.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitClient(t *testing.T) {
+	require := require.New(t)
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/vpcs", nil)
+	c.Request.RemoteAddr = "192.0.2.1:1234"
+	require.Equal("ip:192.0.2.1", rateLimitClient(c, nil))
+
+	// the X-Forwarded-For header is only trusted from a trusted proxy
+	c.Request.Header.Set("X-Forwarded-For", "198.51.100.7, 203.0.113.9")
+	require.Equal("ip:192.0.2.1", rateLimitClient(c, nil))
+	require.Equal("ip:192.0.2.1", rateLimitClient(c, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}))
+	proxies := []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24"), netip.MustParsePrefix("203.0.113.9/32")}
+	require.Equal("ip:198.51.100.7", rateLimitClient(c, proxies))
+
+	c.Set("_nexodus.Claims", map[string]interface{}{"sub": "alice"})
+	require.Equal("sub:alice", rateLimitClient(c, nil))
+
+	c.Set("_nexodus.Claims", map[string]interface{}{"sub": "alice", "scope": "device-token", "jti": "d1"})
+	require.Equal("device:d1", rateLimitClient(c, nil))
+
+	c.Set("_nexodus.Claims", map[string]interface{}{"sub": "alice", "scope": "reg-token", "jti": "k1"})
+	require.Equal("reg-key:k1", rateLimitClient(c, nil))
+}
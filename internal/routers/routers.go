@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap/zapcore"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"strings"
 	"time"
@@ -42,6 +43,8 @@ type APIRouterOptions struct {
 	DeviceFlow      *agent.OidcAgent
 	Store           storage.Store
 	SessionStore    session.ManagerStore
+	RateLimits      RateLimits
+	TrustedProxies  []netip.Prefix
 }
 
 func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error) {
@@ -76,9 +79,12 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 
 	r.GET("/openapi/*any", ginSwagger.WrapHandler(swaggerFiles.Handler), loggerMiddleware)
 
+	rateLimiter := NewRateLimiter(o.Logger, o.Api.Redis, o.RateLimits, o.TrustedProxies)
+	loginRateLimit := rateLimiter.LimitClass(RateLimitLogins)
+
 	deviceGroup := r.Group("/device", loggerMiddleware)
 	{
-		deviceGroup.POST("/login/start", o.DeviceFlow.DeviceStart)
+		deviceGroup.POST("/login/start", loginRateLimit, o.DeviceFlow.DeviceStart)
 		deviceGroup.GET("/certs", o.Api.Certs)
 	}
 	webGroup := r.Group("/web", loggerMiddleware)
@@ -87,13 +93,13 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		webGroup.Use(ginsession.New(
 			session.SetCookieName(handlers.SESSION_ID_COOKIE_NAME),
 			session.SetStore(o.SessionStore)))
-		webGroup.GET("/login/start", o.BrowserFlow.LoginStart)
-		webGroup.GET("/login/end", o.BrowserFlow.LoginEnd)
+		webGroup.GET("/login/start", loginRateLimit, o.BrowserFlow.LoginStart)
+		webGroup.GET("/login/end", loginRateLimit, o.BrowserFlow.LoginEnd)
 		webGroup.GET("/user_info", o.BrowserFlow.UserInfo)
 		webGroup.GET("/claims", o.BrowserFlow.Claims)
 		webGroup.GET("/logout", o.BrowserFlow.Logout)
 		// web.GET("/check_auth", o.BrowserFlow.CheckAuth)
-		webGroup.POST("/refresh", o.BrowserFlow.Refresh)
+		webGroup.POST("/refresh", loginRateLimit, o.BrowserFlow.Refresh)
 	}
 	apiGroup := r.Group("/api", loggerMiddleware)
 	{
@@ -109,6 +115,7 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		}
 
 		apiGroup.Use(validateJWT)
+		apiGroup.Use(rateLimiter.Limit(apiRateLimitClass))
 
 		// Feature Flags
 		apiGroup.GET("fflags", api.ListFeatureFlags)
@@ -125,6 +132,7 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.POST("/organizations", api.CreateOrganization)
 		apiGroup.GET("/organizations/:id", api.GetOrganizations)
 		apiGroup.DELETE("/organizations/:id", api.DeleteOrganization)
+		apiGroup.GET("/organizations/:id/usage", api.GetOrganizationUsage)
 
 		apiGroup.GET("/organizations/:id/users", api.ListOrganizationUsers)
 		apiGroup.GET("/organizations/:id/users/:uid", api.GetOrganizationUser)
@@ -158,6 +166,24 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.GET("/vpcs/:id/devices", api.ListDevicesInVPC)
 		apiGroup.GET("/vpcs/:id/metadata", api.ListMetadataInVPC)
 		apiGroup.GET("/vpcs/:id/security-groups", api.ListSecurityGroupsInVPC)
+		apiGroup.GET("/vpcs/:id/ip-reservations", api.ListIPReservations)
+		apiGroup.POST("/vpcs/:id/ip-reservations", api.CreateIPReservation)
+		apiGroup.DELETE("/vpcs/:id/ip-reservations/:reservation_id", api.DeleteIPReservation)
+		apiGroup.GET("/vpcs/:id/excluded-ranges", api.ListExcludedRanges)
+		apiGroup.POST("/vpcs/:id/excluded-ranges", api.CreateExcludedRange)
+		apiGroup.DELETE("/vpcs/:id/excluded-ranges/:range_id", api.DeleteExcludedRange)
+		apiGroup.GET("/vpcs/:id/subnets", api.ListSubnets)
+		apiGroup.GET("/vpcs/:id/subnets/:subnet_id", api.GetSubnet)
+		apiGroup.POST("/vpcs/:id/subnets", api.CreateSubnet)
+		apiGroup.PATCH("/vpcs/:id/subnets/:subnet_id", api.UpdateSubnet)
+		apiGroup.DELETE("/vpcs/:id/subnets/:subnet_id", api.DeleteSubnet)
+
+		// VPC Peerings
+		apiGroup.GET("/vpc-peerings", api.ListVpcPeerings)
+		apiGroup.GET("/vpc-peerings/:id", api.GetVpcPeering)
+		apiGroup.POST("/vpc-peerings", api.CreateVpcPeering)
+		apiGroup.POST("/vpc-peerings/:id/accept", api.AcceptVpcPeering)
+		apiGroup.DELETE("/vpc-peerings/:id", api.DeleteVpcPeering)
 
 		// Devices
 		apiGroup.GET("/devices", api.ListDevices)
@@ -173,6 +199,22 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.DELETE("/devices/:id/metadata/:key", api.DeleteDeviceMetadataKey)
 		apiGroup.DELETE("/devices/:id/metadata", api.DeleteDeviceMetadata)
 
+		// Device Proxy Rules
+		apiGroup.GET("/devices/:id/proxy-rules", api.ListDeviceProxyRules)
+		apiGroup.POST("/devices/:id/proxy-rules", api.CreateDeviceProxyRule)
+		apiGroup.GET("/devices/:id/proxy-rules/:rule_id", api.GetDeviceProxyRule)
+		apiGroup.PATCH("/devices/:id/proxy-rules/:rule_id", api.UpdateDeviceProxyRule)
+		apiGroup.DELETE("/devices/:id/proxy-rules/:rule_id", api.DeleteDeviceProxyRule)
+
+		// Device Shares
+		apiGroup.GET("/devices/:id/shares", api.ListDeviceShares)
+		apiGroup.POST("/devices/:id/shares", api.CreateDeviceShare)
+		apiGroup.DELETE("/devices/:id/shares/:vpc_id", api.DeleteDeviceShare)
+
+		// Device Routes
+		apiGroup.POST("/devices/:id/routes/approve", api.ApproveDeviceRoutes)
+		apiGroup.POST("/devices/:id/routes/reject", api.RejectDeviceRoutes)
+
 		// Security Groups
 		apiGroup.GET("/security-groups", api.ListSecurityGroups)
 		apiGroup.GET("/security-groups/:id", api.GetSecurityGroup)
@@ -201,6 +243,10 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 	privateGroup := r.Group("/private")
 	{
 		privateGroup.GET("/gc", o.Api.GarbageCollect, loggerMiddleware)
+		privateGroup.GET("/ipam/verify", o.Api.VerifyIPAM, loggerMiddleware)
+		privateGroup.POST("/ipam/repair", o.Api.RepairIPAM, loggerMiddleware)
+		privateGroup.GET("/organizations/:id/quota", o.Api.GetOrganizationQuota, loggerMiddleware)
+		privateGroup.PUT("/organizations/:id/quota", o.Api.UpdateOrganizationQuota, loggerMiddleware)
 		privateGroup.GET("/ready", o.Api.Ready)
 		privateGroup.GET("/live", o.Api.Live)
 	}
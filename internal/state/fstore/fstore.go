@@ -2,31 +2,69 @@ package fstore
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"github.com/natefinch/atomic"
 	"github.com/nexodus-io/nexodus/internal/state"
-	"github.com/nexodus-io/nexodus/internal/util"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
 )
 
 type store struct {
-	File  string
-	state *state.State
-	mu    sync.RWMutex
+	File     string
+	state    *state.State
+	provider KeyProvider
+	mu       sync.RWMutex
 }
 
 var _ state.Store = &store{}
+var _ Rekeyer = &store{}
+
+// Rekeyer is implemented by the stores that can encrypt the state with a different key.
+type Rekeyer interface {
+	// Rekey stores the state encrypted with the key of the provider, or in plain text when it is nil.
+	Rekey(provider KeyProvider) error
+}
+
+// encryptedState is the content of the file when the state is encrypted.
+type encryptedState struct {
+	Encrypted *envelope `json:"encrypted"`
+}
+
+type envelope struct {
+	Provider string `json:"provider"`
+	Cipher   string `json:"cipher"`
+	Salt     []byte `json:"salt"`
+	Nonce    []byte `json:"nonce"`
+	Data     []byte `json:"data"`
+}
+
+const cipherAES256GCM = "aes-256-gcm"
 
 func New(file string) state.Store {
+	return NewWithKeyProvider(file, nil)
+}
+
+// NewWithKeyProvider returns a store that encrypts the state with the key of the provider.
+// State stored in plain text is encrypted the first time it is loaded.
+func NewWithKeyProvider(file string, provider KeyProvider) state.Store {
 	return &store{
-		File: file,
+		File:     file,
+		provider: provider,
 	}
 }
 
 func (fs *store) String() string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if fs.provider != nil {
+		return fmt.Sprintf("file '%s' encrypted with %s", fs.File, fs.provider)
+	}
 	return fmt.Sprintf("file '%s'", fs.File)
 }
 
@@ -46,12 +84,25 @@ func (fs *store) Load() error {
 		fs.mu.Unlock()
 		return nil
 	}
-	f, err := os.Open(fs.File)
+	data, err := os.ReadFile(fs.File)
 	if err != nil {
 		return err
 	}
-	defer util.IgnoreError(f.Close)
-	err = json.NewDecoder(f).Decode(&state)
+	fs.mu.RLock()
+	provider := fs.provider
+	fs.mu.RUnlock()
+
+	var encrypted encryptedState
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return err
+	}
+	if encrypted.Encrypted != nil {
+		data, err = decrypt(encrypted.Encrypted, provider)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt the state in %s: %w", fs.File, err)
+		}
+	}
+	err = json.Unmarshal(data, &state)
 	if err != nil {
 		return err
 	}
@@ -59,6 +110,11 @@ func (fs *store) Load() error {
 	fs.mu.Lock()
 	fs.state = &state
 	fs.mu.Unlock()
+
+	if encrypted.Encrypted == nil && provider != nil {
+		// migrate the state stored before encryption was enabled
+		return fs.Store()
+	}
 	return nil
 }
 
@@ -80,9 +136,100 @@ func (fs *store) Store() error {
 	if err != nil {
 		return err
 	}
+	fs.mu.RLock()
+	provider := fs.provider
+	fs.mu.RUnlock()
+	if provider != nil {
+		sealed, err := encrypt(buf.Bytes(), provider)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt the state: %w", err)
+		}
+		buf.Reset()
+		if err := enc.Encode(encryptedState{Encrypted: sealed}); err != nil {
+			return err
+		}
+	}
 	return atomic.WriteFile(fs.File, buf)
 }
 
+// Rekey stores the state encrypted with the key of the provider.
+func (fs *store) Rekey(provider KeyProvider) error {
+	if fs.State() == nil {
+		if err := fs.Load(); err != nil {
+			return err
+		}
+	}
+	fs.mu.Lock()
+	previous := fs.provider
+	fs.provider = provider
+	fs.mu.Unlock()
+	if err := fs.Store(); err != nil {
+		fs.mu.Lock()
+		fs.provider = previous
+		fs.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func encrypt(plaintext []byte, provider KeyProvider) (*envelope, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(provider, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return &envelope{
+		Provider: provider.Name(),
+		Cipher:   cipherAES256GCM,
+		Salt:     salt,
+		Nonce:    nonce,
+		Data:     aead.Seal(nil, nonce, plaintext, []byte(provider.Name())),
+	}, nil
+}
+
+func decrypt(sealed *envelope, provider KeyProvider) ([]byte, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("the state is encrypted with the %s key provider, but no key provider is configured", sealed.Provider)
+	}
+	if sealed.Provider != provider.Name() {
+		return nil, fmt.Errorf("the state is encrypted with the %s key provider, not %s", sealed.Provider, provider.Name())
+	}
+	if sealed.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported cipher %q", sealed.Cipher)
+	}
+	aead, err := newAEAD(provider, sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Data, []byte(sealed.Provider))
+	if err != nil {
+		return nil, fmt.Errorf("wrong key: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(provider KeyProvider, salt []byte) (cipher.AEAD, error) {
+	key, err := provider.Key(salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
 func (fs *store) Close() error {
 	return nil
 }
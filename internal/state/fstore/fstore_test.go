@@ -0,0 +1,79 @@
+package fstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "state.json")
+
+	// state stored before encryption was enabled
+	plain := New(file)
+	require.NoError(plain.Load())
+	plain.State().PrivateKey = "private-key"
+	require.NoError(plain.Store())
+	data, err := os.ReadFile(file)
+	require.NoError(err)
+	require.Contains(string(data), "private-key")
+
+	// it is encrypted when it is loaded with a key provider
+	keyFile, err := ParseKeyProvider("key-file:" + filepath.Join(dir, "state.key"))
+	require.NoError(err)
+	encrypted := NewWithKeyProvider(file, keyFile)
+	require.NoError(encrypted.Load())
+	require.Equal("private-key", encrypted.State().PrivateKey)
+	data, err = os.ReadFile(file)
+	require.NoError(err)
+	require.NotContains(string(data), "private-key")
+
+	// it can only be loaded with the same key
+	require.ErrorContains(New(file).Load(), "no key provider is configured")
+	require.ErrorContains(NewWithKeyProvider(file, NewPassphraseKeyProvider("secret")).Load(), "key-file key provider")
+	otherKeyFile, err := ParseKeyProvider("key-file:" + filepath.Join(dir, "other.key"))
+	require.NoError(err)
+	require.ErrorContains(NewWithKeyProvider(file, otherKeyFile).Load(), "wrong key")
+
+	reloaded := NewWithKeyProvider(file, keyFile)
+	require.NoError(reloaded.Load())
+	require.Equal("private-key", reloaded.State().PrivateKey)
+
+	// re-key with a passphrase, then back to plain text
+	passphrase := NewPassphraseKeyProvider("secret")
+	require.NoError(reloaded.(Rekeyer).Rekey(passphrase))
+	require.ErrorContains(NewWithKeyProvider(file, NewPassphraseKeyProvider("wrong")).Load(), "wrong key")
+	rekeyed := NewWithKeyProvider(file, passphrase)
+	require.NoError(rekeyed.Load())
+	require.Equal("private-key", rekeyed.State().PrivateKey)
+
+	require.NoError(rekeyed.(Rekeyer).Rekey(nil))
+	plain = New(file)
+	require.NoError(plain.Load())
+	require.Equal("private-key", plain.State().PrivateKey)
+}
+
+func TestParseKeyProvider(t *testing.T) {
+	require := require.New(t)
+
+	provider, err := ParseKeyProvider("none")
+	require.NoError(err)
+	require.Nil(provider)
+
+	provider, err = ParseKeyProvider("systemd-creds")
+	require.NoError(err)
+	require.Equal("systemd credential 'nexd-state-key'", provider.String())
+
+	t.Setenv(PassphraseEnv, "")
+	_, err = ParseKeyProvider("passphrase")
+	require.ErrorContains(err, PassphraseEnv)
+
+	_, err = ParseKeyProvider("key-file")
+	require.Error(err)
+	_, err = ParseKeyProvider("tpm")
+	require.ErrorContains(err, "unknown state key provider")
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package fstore
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func readKeyringKey(_ string) ([]byte, error) {
+	return nil, fmt.Errorf("the kernel keyring is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,197 @@
+package fstore
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PassphraseEnv is the environment variable the passphrase key provider reads the passphrase from.
+	PassphraseEnv = "NEXD_STATE_PASSPHRASE"
+	// DefaultKeyName is the name of the key in the kernel keyring and of the systemd credential by default.
+	DefaultKeyName = "nexd-state-key"
+
+	keySize = 32
+)
+
+// KeyProvider provides the key encrypting the state.
+type KeyProvider interface {
+	fmt.Stringer
+	// Name is the name of the provider recorded with the encrypted state.
+	Name() string
+	// Key returns the 256-bit key encrypting the state, derived from the secret of the provider and the salt.
+	Key(salt []byte) ([]byte, error)
+}
+
+// ParseKeyProvider returns the key provider for a spec of the form name[:argument]:
+//
+//	none                       the state is not encrypted
+//	passphrase                 a passphrase read from the NEXD_STATE_PASSPHRASE environment variable
+//	passphrase-file:<path>     a passphrase read from a file
+//	key-file:<path>            a random key read from a file, which is generated if it does not exist
+//	systemd-creds[:<name>]     a key read from a systemd credential, nexd-state-key by default
+//	keyring[:<description>]    a key read from the kernel keyring of the user, nexd-state-key by default
+//
+// It returns a nil provider for an empty spec or none.
+func ParseKeyProvider(spec string) (KeyProvider, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "passphrase":
+		passphrase := os.Getenv(PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the %s environment variable is not set", PassphraseEnv)
+		}
+		return NewPassphraseKeyProvider(passphrase), nil
+	case "passphrase-file":
+		if arg == "" {
+			return nil, fmt.Errorf("the passphrase-file key provider requires a path")
+		}
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the passphrase file: %w", err)
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return nil, fmt.Errorf("the passphrase file %s is empty", arg)
+		}
+		return NewPassphraseKeyProvider(passphrase), nil
+	case "key-file":
+		if arg == "" {
+			return nil, fmt.Errorf("the key-file key provider requires a path")
+		}
+		return &keyFileProvider{file: arg}, nil
+	case "systemd-creds":
+		if arg == "" {
+			arg = DefaultKeyName
+		}
+		return &systemdCredsProvider{name: arg}, nil
+	case "keyring":
+		if arg == "" {
+			arg = DefaultKeyName
+		}
+		return &keyringProvider{description: arg}, nil
+	default:
+		return nil, fmt.Errorf("unknown state key provider %q, use none, passphrase, passphrase-file, key-file, systemd-creds or keyring", name)
+	}
+}
+
+// NewPassphraseKeyProvider returns a key provider deriving the key from a passphrase.
+func NewPassphraseKeyProvider(passphrase string) KeyProvider {
+	return &passphraseProvider{passphrase: passphrase}
+}
+
+type passphraseProvider struct {
+	passphrase string
+}
+
+func (p *passphraseProvider) String() string { return "passphrase" }
+func (p *passphraseProvider) Name() string   { return "passphrase" }
+
+func (p *passphraseProvider) Key(salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(p.passphrase), salt, 1<<15, 8, 1, keySize)
+}
+
+// keyFileProvider reads a random secret from a file readable only by root, like the state itself,
+// so it protects the state when it is copied out of the state directory or backed up on its own.
+type keyFileProvider struct {
+	file string
+}
+
+func (p *keyFileProvider) String() string { return fmt.Sprintf("key file '%s'", p.file) }
+func (p *keyFileProvider) Name() string   { return "key-file" }
+
+func (p *keyFileProvider) Key(salt []byte) ([]byte, error) {
+	secret, err := os.ReadFile(p.file)
+	if errors.Is(err, os.ErrNotExist) {
+		secret, err = p.generate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key file: %w", err)
+	}
+	return deriveKey(secret, salt)
+}
+
+func (p *keyFileProvider) generate() ([]byte, error) {
+	secret := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.file), 0700); err != nil {
+		return nil, err
+	}
+	// O_EXCL so that a key generated concurrently is never overwritten
+	f, err := os.OpenFile(p.file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return os.ReadFile(p.file)
+		}
+		return nil, err
+	}
+	if _, err := f.Write(secret); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return secret, f.Close()
+}
+
+// systemdCredsProvider reads the secret from a credential passed to the nexd service with
+// LoadCredentialEncrypted=, which systemd decrypts into $CREDENTIALS_DIRECTORY.
+type systemdCredsProvider struct {
+	name string
+}
+
+func (p *systemdCredsProvider) String() string { return fmt.Sprintf("systemd credential '%s'", p.name) }
+func (p *systemdCredsProvider) Name() string   { return "systemd-creds" }
+
+func (p *systemdCredsProvider) Key(salt []byte) ([]byte, error) {
+	dir := os.Getenv("CREDENTIALS_DIRECTORY")
+	if dir == "" {
+		return nil, fmt.Errorf("CREDENTIALS_DIRECTORY is not set, nexd must be started by systemd with the %s credential", p.name)
+	}
+	secret, err := os.ReadFile(filepath.Join(dir, p.name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the systemd credential: %w", err)
+	}
+	return deriveKey(secret, salt)
+}
+
+// keyringProvider reads the secret from a user key of the kernel keyring, which is never written to disk.
+type keyringProvider struct {
+	description string
+}
+
+func (p *keyringProvider) String() string {
+	return fmt.Sprintf("kernel keyring key '%s'", p.description)
+}
+func (p *keyringProvider) Name() string { return "keyring" }
+
+func (p *keyringProvider) Key(salt []byte) ([]byte, error) {
+	secret, err := readKeyringKey(p.description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the kernel keyring key: %w", err)
+	}
+	return deriveKey(secret, salt)
+}
+
+// deriveKey derives the key encrypting the state from a random secret.
+func deriveKey(secret, salt []byte) ([]byte, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("the key is empty")
+	}
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte("nexd state")), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
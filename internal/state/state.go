@@ -3,7 +3,9 @@ package state
 import (
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/nexodus-io/nexodus/internal/client"
 	"golang.org/x/oauth2"
 )
 
@@ -13,6 +15,7 @@ type State struct {
 	PrivateKey       string           `json:"private-key"`
 	ProxyRulesConfig ProxyRulesConfig `json:"proxy-rules-config"`
 	Port             int              `json:"port"`
+	PeerConfig       *PeerConfig      `json:"peer-config,omitempty"`
 }
 
 type ProxyRulesConfig struct {
@@ -20,6 +23,20 @@ type ProxyRulesConfig struct {
 	Ingress []string `json:"ingress"`
 }
 
+// PeerConfig is the last peer configuration reconciled with the api-server. nexd
+// brings up the tunnel from it when the api-server is unreachable at startup.
+type PeerConfig struct {
+	ApiURL        string                                 `json:"api-url"`
+	DeviceId      string                                 `json:"device-id"`
+	TunnelIP      string                                 `json:"tunnel-ip"`
+	TunnelIpV6    string                                 `json:"tunnel-ipv6"`
+	Vpc           client.ModelsVPC                       `json:"vpc"`
+	Devices       []client.ModelsDevice                  `json:"devices"`
+	RelayMetadata map[string]client.ModelsDeviceMetadata `json:"relay-metadata,omitempty"`
+	SecurityGroup *client.ModelsSecurityGroup            `json:"security-group,omitempty"`
+	Time          time.Time                              `json:"time"`
+}
+
 type Store interface {
 	fmt.Stringer
 	io.Closer